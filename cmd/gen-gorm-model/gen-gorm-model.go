@@ -186,6 +186,56 @@ func GenerateMiniBlogModels(g *gen.Generator) {
 			return tag
 		}),
 	)
+	g.GenerateModelAs(
+		"user_totp",
+		"UserTOTPM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("userID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_user_totp_userID")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"recovery_code",
+		"RecoveryCodeM",
+		gen.FieldIgnore("placeholder"),
+	)
+	g.GenerateModelAs(
+		"password_history",
+		"PasswordHistoryM",
+		gen.FieldIgnore("placeholder"),
+	)
+	g.GenerateModelAs(
+		"user_identity",
+		"UserIdentityM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("provider", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_user_identity_provider_subject,priority:1")
+			return tag
+		}),
+		gen.FieldGORMTag("subject", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_user_identity_provider_subject,priority:2")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"organization",
+		"OrganizationM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldGORMTag("orgID", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_organization_orgID")
+			return tag
+		}),
+		gen.FieldGORMTag("slug", func(tag field.GormTag) field.GormTag {
+			tag.Set("uniqueIndex", "idx_organization_slug")
+			return tag
+		}),
+	)
+	g.GenerateModelAs(
+		"audit_event",
+		"AuditEventM",
+		gen.FieldIgnore("placeholder"),
+	)
 	g.GenerateModelAs(
 		"casbin_rule",
 		"CasbinRuleM",
@@ -0,0 +1,191 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/cmd/mb-apiserver/app/options"
+	"github.com/TobyIcetea/miniblog/internal/apiserver"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/migration"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+// newMigrateCommand 创建数据库迁移子命令，数据库连接使用与 apiserver 相同的配置.
+func newMigrateCommand(opts *options.ServerOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Manage database schema migrations",
+		Long: `Manage the database schema with the versioned migrations built into mb-apiserver.
+
+Migrations are applied in version order and recorded in the schema_migrations table.
+A lock ensures that only one process runs migrations at a time.`,
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "up [N]",
+			Short: "Apply all or N pending migrations",
+			Args:  cobra.MaximumNArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				steps, err := parseSteps(args, 0)
+				if err != nil {
+					return err
+				}
+				return withMigrator(opts, func(m *migration.Migrator) error {
+					done, err := m.Up(cmd.Context(), steps)
+					printMigrations(cmd, "Applied", done)
+					return err
+				})
+			},
+		},
+		&cobra.Command{
+			Use:   "down [N]",
+			Short: "Revert the last N applied migrations (default 1)",
+			Args:  cobra.MaximumNArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				steps, err := parseSteps(args, 1)
+				if err != nil {
+					return err
+				}
+				return withMigrator(opts, func(m *migration.Migrator) error {
+					done, err := m.Down(cmd.Context(), steps)
+					printMigrations(cmd, "Reverted", done)
+					return err
+				})
+			},
+		},
+		&cobra.Command{
+			Use:   "status",
+			Short: "Show the status of all migrations",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return withMigrator(opts, func(m *migration.Migrator) error {
+					statuses, err := m.Status(cmd.Context())
+					if err != nil {
+						return err
+					}
+
+					w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+					fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+					for _, status := range statuses {
+						appliedAt := "pending"
+						if status.AppliedAt != nil {
+							appliedAt = status.AppliedAt.Format(time.DateTime)
+						}
+						fmt.Fprintf(w, "%06d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+					}
+					return w.Flush()
+				})
+			},
+		},
+		&cobra.Command{
+			Use:   "refresh-post-counts",
+			Short: "Recount the denormalized post counts of all users",
+			Long: `Recount the posts of every user and write the result to the postCount column of the user table.
+
+Run it before enabling store.denormalized-post-count again after it was disabled,
+while no apiserver is writing posts.`,
+			Args: cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return withDB(opts, func(_ *apiserver.Config, db *gorm.DB) error {
+					if err := store.RefreshPostCounts(cmd.Context(), db); err != nil {
+						return err
+					}
+					fmt.Fprintln(cmd.OutOrStdout(), "Post counts refreshed")
+					return nil
+				})
+			},
+		},
+		newMigrateCreateCommand(),
+	)
+
+	return cmd
+}
+
+// newMigrateCreateCommand 创建用于生成新迁移脚本的子命令，该命令只操作源码目录，不连接数据库.
+func newMigrateCreateCommand() *cobra.Command {
+	dir := migration.DefaultDir
+	cmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create empty up and down migration scripts for every database dialect",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			created, err := migration.Create(dir, args[0])
+			for _, filename := range created {
+				fmt.Fprintf(cmd.OutOrStdout(), "Created %s\n", filename)
+			}
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", dir, "Directory of the migration scripts in the source tree.")
+
+	return cmd
+}
+
+// withMigrator 根据配置连接数据库并创建 Migrator，然后执行 fn.
+func withMigrator(opts *options.ServerOptions, fn func(m *migration.Migrator) error) error {
+	return withDB(opts, func(cfg *apiserver.Config, db *gorm.DB) error {
+		m, err := migration.New(db, cfg.MigrateOptions)
+		if err != nil {
+			return err
+		}
+		return fn(m)
+	})
+}
+
+// withDB 根据配置连接数据库，然后执行 fn，执行完成后关闭数据库连接.
+func withDB(opts *options.ServerOptions, fn func(cfg *apiserver.Config, db *gorm.DB) error) error {
+	log.Init(logOptions())
+	defer log.Sync()
+
+	cfg, err := loadConfig(opts)
+	if err != nil {
+		return err
+	}
+
+	db, err := cfg.NewDB()
+	if err != nil {
+		return err
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	return fn(cfg, db)
+}
+
+// parseSteps 解析迁移的步数参数，未指定时返回默认值.
+func parseSteps(args []string, defaultSteps int) (int, error) {
+	if len(args) == 0 {
+		return defaultSteps, nil
+	}
+
+	steps, err := strconv.Atoi(args[0])
+	if err != nil || steps <= 0 {
+		return 0, fmt.Errorf("invalid number of migrations: %s", args[0])
+	}
+	return steps, nil
+}
+
+// printMigrations 打印本次执行或回滚的迁移.
+func printMigrations(cmd *cobra.Command, action string, migrations []*migration.Migration) {
+	if len(migrations) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No migrations to run")
+		return
+	}
+	for _, m := range migrations {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s %06d_%s\n", action, m.Version, m.Name)
+	}
+}
@@ -9,9 +9,23 @@ package options
 import (
 	"errors"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/TobyIcetea/miniblog/internal/apiserver"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/migration"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/cache"
+	"github.com/TobyIcetea/miniblog/internal/pkg/captcha"
+	"github.com/TobyIcetea/miniblog/internal/pkg/database"
+	"github.com/TobyIcetea/miniblog/internal/pkg/devauth"
+	"github.com/TobyIcetea/miniblog/internal/pkg/lockout"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mail"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mtls"
+	"github.com/TobyIcetea/miniblog/internal/pkg/oidc"
+	"github.com/TobyIcetea/miniblog/internal/pkg/password"
+	"github.com/TobyIcetea/miniblog/internal/pkg/ratelimit"
+	"github.com/TobyIcetea/miniblog/internal/pkg/tenant"
 	genericoptions "github.com/onexstack/onexstack/pkg/options"
 	stringsutil "github.com/onexstack/onexstack/pkg/util/strings"
 	"github.com/spf13/pflag"
@@ -34,32 +48,88 @@ type ServerOptions struct {
 	JWTKey string `json:"jwt-key" mapstructure:"jwt-key"`
 	// Expiration 定义 JWT Token 的过期时间
 	Expiration time.Duration `json:"expiration" mapstructure:"expiration"`
+	// TrustedProxies 定义可信代理的 IP 或 CIDR，只有来自可信代理的 X-Forwarded-For 才会被用于解析客户端 IP
+	TrustedProxies []string `json:"trusted-proxies" mapstructure:"trusted-proxies"`
 	// TLSOptions 包含 TLS 配置选项
 	TLSOptions *genericoptions.TLSOptions `json:"tls" mapstructure:"tls"`
 	// HTTPOptions 包含 HTTP 配置选项
 	HTTPOptions *genericoptions.HTTPOptions `json:"http" mapstructure:"http"`
 	// GRPCOptions 包含 gRPC 配置选项
 	GRPCOptions *genericoptions.GRPCOptions `json:"grpc" mapstructure:"grpc"`
+	// DatabaseDriver 定义使用的数据库驱动：mysql、postgresql、sqlite
+	DatabaseDriver string `json:"database-driver" mapstructure:"database-driver"`
 	// MySQLOptions 包含 MySQL 配置选项
 	MySQLOptions *genericoptions.MySQLOptions `json:"mysql" mapstructure:"mysql"`
+	// PostgreSQLOptions 包含 PostgreSQL 配置选项
+	PostgreSQLOptions *genericoptions.PostgreSQLOptions `json:"postgresql" mapstructure:"postgresql"`
+	// SQLiteOptions 包含 SQLite 配置选项
+	SQLiteOptions *database.SQLiteOptions `json:"sqlite" mapstructure:"sqlite"`
+	// ReplicaOptions 包含只读副本（读写分离）配置选项
+	ReplicaOptions *database.ReplicaOptions `json:"replica" mapstructure:"replica"`
+	// MigrateOptions 包含数据库迁移配置选项
+	MigrateOptions *migration.Options `json:"migrate" mapstructure:"migrate"`
+	// StoreOptions 包含 Store 层的配置选项
+	StoreOptions *store.Options `json:"store" mapstructure:"store"`
+	// RedisOptions 包含 Redis 配置选项
+	RedisOptions *genericoptions.RedisOptions `json:"redis" mapstructure:"redis"`
+	// MailOptions 包含邮件发送配置选项
+	MailOptions *mail.Options `json:"mail" mapstructure:"mail"`
+	// LockoutOptions 包含登录失败限制配置选项
+	LockoutOptions *lockout.Options `json:"lockout" mapstructure:"lockout"`
+	// OIDCOptions 包含第三方 OIDC 登录配置选项
+	OIDCOptions *oidc.Options `json:"oidc" mapstructure:"oidc"`
+	// MTLSOptions 包含双向 TLS 认证配置选项
+	MTLSOptions *mtls.Options `json:"mtls" mapstructure:"mtls"`
+	// TenantOptions 包含多租户解析配置选项
+	TenantOptions *tenant.Options `json:"tenant" mapstructure:"tenant"`
+	// PasswordOptions 包含密码策略和密码加密配置选项
+	PasswordOptions *password.Options `json:"password" mapstructure:"password"`
+	// RateLimitOptions 包含请求限流配置选项
+	RateLimitOptions *ratelimit.Options `json:"ratelimit" mapstructure:"ratelimit"`
+	// CacheOptions 包含用户和博客查询缓存配置选项
+	CacheOptions *cache.Options `json:"cache" mapstructure:"cache"`
+	// CaptchaOptions 包含注册和登录的人机验证配置选项
+	CaptchaOptions *captcha.Options `json:"captcha" mapstructure:"captcha"`
+	// DevAuthOptions 包含仅用于本地开发的认证模式配置选项
+	DevAuthOptions *devauth.Options `json:"dev-auth" mapstructure:"dev-auth"`
 }
 
 // NewServerOptions 创建带有默认值的 ServerOptions 实例.
 func NewServerOptions() *ServerOptions {
 	opts := &ServerOptions{
-		ServerMode:   apiserver.GRPCGatewayServerMode,
-		JWTKey:       "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5",
-		Expiration:   2 * time.Hour,
-		TLSOptions:   genericoptions.NewTLSOptions(),
-		HTTPOptions:  genericoptions.NewHTTPOptions(),
-		GRPCOptions:  genericoptions.NewGRPCOptions(),
-		MySQLOptions: genericoptions.NewMySQLOptions(),
+		ServerMode:        apiserver.GRPCGatewayServerMode,
+		JWTKey:            "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5",
+		Expiration:        2 * time.Hour,
+		TLSOptions:        genericoptions.NewTLSOptions(),
+		HTTPOptions:       genericoptions.NewHTTPOptions(),
+		GRPCOptions:       genericoptions.NewGRPCOptions(),
+		DatabaseDriver:    database.DriverMySQL,
+		MySQLOptions:      genericoptions.NewMySQLOptions(),
+		PostgreSQLOptions: genericoptions.NewPostgreSQLOptions(),
+		SQLiteOptions:     database.NewSQLiteOptions(),
+		ReplicaOptions:    database.NewReplicaOptions(),
+		MigrateOptions:    migration.NewOptions(),
+		StoreOptions:      store.NewOptions(),
+		RedisOptions:      genericoptions.NewRedisOptions(),
+		MailOptions:       mail.NewOptions(),
+		LockoutOptions:    lockout.NewOptions(),
+		OIDCOptions:       oidc.NewOptions(),
+		MTLSOptions:       mtls.NewOptions(),
+		TenantOptions:     tenant.NewOptions(),
+		PasswordOptions:   password.NewOptions(),
+		RateLimitOptions:  ratelimit.NewOptions(),
+		CacheOptions:      cache.NewOptions(),
+		CaptchaOptions:    captcha.NewOptions(),
+		DevAuthOptions:    devauth.NewOptions(),
 	}
 	opts.HTTPOptions.Addr = ":5555"
 	opts.GRPCOptions.Addr = ":6666"
 	opts.MySQLOptions.Username = "miniblog"
 	opts.MySQLOptions.Password = "miniblog1234"
 	opts.MySQLOptions.Database = "miniblog"
+	opts.PostgreSQLOptions.Username = "miniblog"
+	opts.PostgreSQLOptions.Password = "miniblog1234"
+	opts.PostgreSQLOptions.Database = "miniblog"
 	return opts
 }
 
@@ -70,10 +140,28 @@ func (o *ServerOptions) AddFlags(fs *pflag.FlagSet) {
 	// 绑定 JWT Token 的过期时间选项到命令行标志
 	// 参数名称为 --expiration，默认值为 o.Expiration
 	fs.DurationVar(&o.Expiration, "expiration", o.Expiration, "JWT Token expiration time.")
+	fs.StringSliceVar(&o.TrustedProxies, "trusted-proxies", o.TrustedProxies, "IPs or CIDRs of reverse proxies whose X-Forwarded-For header is trusted. Empty means no proxy is trusted.")
 	o.TLSOptions.AddFlags(fs)
 	o.HTTPOptions.AddFlags(fs)
 	o.GRPCOptions.AddFlags(fs)
+	fs.StringVar(&o.DatabaseDriver, "database-driver", o.DatabaseDriver, fmt.Sprintf("Database driver, available options: %v", database.Drivers()))
 	o.MySQLOptions.AddFlags(fs)
+	o.PostgreSQLOptions.AddFlags(fs)
+	o.SQLiteOptions.AddFlags(fs)
+	o.ReplicaOptions.AddFlags(fs)
+	o.MigrateOptions.AddFlags(fs)
+	o.StoreOptions.AddFlags(fs)
+	o.RedisOptions.AddFlags(fs)
+	o.MailOptions.AddFlags(fs)
+	o.LockoutOptions.AddFlags(fs)
+	o.OIDCOptions.AddFlags(fs)
+	o.MTLSOptions.AddFlags(fs)
+	o.TenantOptions.AddFlags(fs)
+	o.PasswordOptions.AddFlags(fs)
+	o.RateLimitOptions.AddFlags(fs)
+	o.CacheOptions.AddFlags(fs)
+	o.CaptchaOptions.AddFlags(fs)
+	o.DevAuthOptions.AddFlags(fs)
 }
 
 // Validate 校验 ServerOptions 中的选项是否合法.
@@ -90,10 +178,44 @@ func (o *ServerOptions) Validate() error {
 		errs = append(errs, errors.New("jwt-key must be at least 6 characters long"))
 	}
 
+	// 校验可信代理是否为合法的 IP 或 CIDR
+	for _, proxy := range o.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				errs = append(errs, fmt.Errorf("invalid trusted proxy: %s", proxy))
+			}
+		}
+	}
+
 	// 校验子选项
 	errs = append(errs, o.TLSOptions.Validate()...)
 	errs = append(errs, o.HTTPOptions.Validate()...)
-	errs = append(errs, o.MySQLOptions.Validate()...)
+	// 只校验所选数据库驱动的配置
+	switch o.DatabaseDriver {
+	case database.DriverMySQL:
+		errs = append(errs, o.MySQLOptions.Validate()...)
+	case database.DriverPostgreSQL:
+		errs = append(errs, o.PostgreSQLOptions.Validate()...)
+	case database.DriverSQLite:
+		errs = append(errs, o.SQLiteOptions.Validate()...)
+	default:
+		errs = append(errs, fmt.Errorf("invalid database driver: %s", o.DatabaseDriver))
+	}
+	errs = append(errs, o.ReplicaOptions.Validate()...)
+	errs = append(errs, o.MigrateOptions.Validate()...)
+	errs = append(errs, o.StoreOptions.Validate()...)
+	errs = append(errs, o.RedisOptions.Validate()...)
+	errs = append(errs, o.MailOptions.Validate()...)
+	errs = append(errs, o.LockoutOptions.Validate()...)
+	errs = append(errs, o.OIDCOptions.Validate()...)
+	errs = append(errs, o.MTLSOptions.Validate()...)
+	errs = append(errs, o.MTLSOptions.ValidateTLS(o.TLSOptions)...)
+	errs = append(errs, o.TenantOptions.Validate()...)
+	errs = append(errs, o.PasswordOptions.Validate()...)
+	errs = append(errs, o.RateLimitOptions.Validate()...)
+	errs = append(errs, o.CacheOptions.Validate()...)
+	errs = append(errs, o.CaptchaOptions.Validate()...)
+	errs = append(errs, o.DevAuthOptions.Validate()...)
 
 	// 如果是 gRPC 或 gRPC-Gateway 模式，校验 gRPC 配置
 	if stringsutil.StringIn(o.ServerMode, []string{apiserver.GRPCServerMode, apiserver.GRPCGatewayServerMode}) {
@@ -107,12 +229,30 @@ func (o *ServerOptions) Validate() error {
 // Config 基于 ServerOptions 构建 apiserver.Config.
 func (o *ServerOptions) Config() (*apiserver.Config, error) {
 	return &apiserver.Config{
-		ServerMode:   o.ServerMode,
-		JWTKey:       o.JWTKey,
-		Expiration:   o.Expiration,
-		TLSOptions:   o.TLSOptions,
-		HTTPOptions:  o.HTTPOptions,
-		GRPCOptions:  o.GRPCOptions,
-		MySQLOptions: o.MySQLOptions,
+		ServerMode:        o.ServerMode,
+		JWTKey:            o.JWTKey,
+		Expiration:        o.Expiration,
+		TrustedProxies:    o.TrustedProxies,
+		TLSOptions:        o.TLSOptions,
+		HTTPOptions:       o.HTTPOptions,
+		GRPCOptions:       o.GRPCOptions,
+		DatabaseDriver:    o.DatabaseDriver,
+		MySQLOptions:      o.MySQLOptions,
+		PostgreSQLOptions: o.PostgreSQLOptions,
+		SQLiteOptions:     o.SQLiteOptions,
+		ReplicaOptions:    o.ReplicaOptions,
+		MigrateOptions:    o.MigrateOptions,
+		StoreOptions:      o.StoreOptions,
+		RedisOptions:      o.RedisOptions,
+		MailOptions:       o.MailOptions,
+		LockoutOptions:    o.LockoutOptions,
+		OIDCOptions:       o.OIDCOptions,
+		MTLSOptions:       o.MTLSOptions,
+		TenantOptions:     o.TenantOptions,
+		PasswordOptions:   o.PasswordOptions,
+		RateLimitOptions:  o.RateLimitOptions,
+		CacheOptions:      o.CacheOptions,
+		CaptchaOptions:    o.CaptchaOptions,
+		DevAuthOptions:    o.DevAuthOptions,
 	}, nil
 }
@@ -8,6 +8,7 @@ package app
 
 import (
 	"github.com/TobyIcetea/miniblog/cmd/mb-apiserver/app/options"
+	"github.com/TobyIcetea/miniblog/internal/apiserver"
 	"github.com/TobyIcetea/miniblog/internal/pkg/log"
 	"github.com/TobyIcetea/miniblog/pkg/version"
 	"github.com/spf13/cobra"
@@ -74,6 +75,9 @@ The project features include:
 	// 添加 --version 标志
 	version.AddFlags(cmd.PersistentFlags())
 
+	// 添加数据库迁移子命令
+	cmd.AddCommand(newMigrateCommand(opts))
+
 	return cmd
 }
 
@@ -86,19 +90,8 @@ func run(opts *options.ServerOptions) error {
 	log.Init(logOptions())
 	defer log.Sync()
 
-	// 将 viper 中的配置解析到 opts
-	if err := viper.Unmarshal(opts); err != nil {
-		return err
-	}
-
-	// 校验命令行选项
-	if err := opts.Validate(); err != nil {
-		return err
-	}
-
 	// 获取应用配置
-	// 将命令行选项和应用配置分开，可以更加灵活的处理 2 种不同类型的配置
-	cfg, err := opts.Config()
+	cfg, err := loadConfig(opts)
 	if err != nil {
 		return err
 	}
@@ -114,6 +107,22 @@ func run(opts *options.ServerOptions) error {
 	return server.Run()
 }
 
+// loadConfig 将 viper 中的配置解析到 opts，校验后构建应用配置.
+func loadConfig(opts *options.ServerOptions) (*apiserver.Config, error) {
+	// 将 viper 中的配置解析到 opts
+	if err := viper.Unmarshal(opts); err != nil {
+		return nil, err
+	}
+
+	// 校验命令行选项
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	// 将命令行选项和应用配置分开，可以更加灵活的处理 2 种不同类型的配置
+	return opts.Config()
+}
+
 // 注意：viper.Get<Type>() 中 key 的名字需要使用 . 分割，以跟 YAML 中保持相同的缩进.
 func logOptions() *log.Options {
 	opts := log.NewOptions()
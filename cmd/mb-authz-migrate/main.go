@@ -0,0 +1,167 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// mb-authz-migrate 将 casbin_rule 表中基于 HTTP 路径或 gRPC 方法名的策略，
+// 转换为基于权限标识的策略，例如：
+//
+//	p, role::user, /v1/users/*, DELETE, deny
+//	p, role::user, /v1.MiniBlog/DeleteUser, CALL, deny
+//
+// 都会被转换为：
+//
+//	p, role::user, users.delete, CALL, deny
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/pkg/permission"
+	"github.com/onexstack/onexstack/pkg/db"
+	"github.com/spf13/pflag"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 帮助信息文本.
+const helpText = `Usage: mb-authz-migrate [flags]
+
+Convert casbin policies written against HTTP paths or gRPC method names
+into operation-level permission identifiers. Run with --dry-run first to
+review the changes.
+
+Flags:
+`
+
+// 命令行参数.
+var (
+	addr     = pflag.StringP("addr", "a", "127.0.0.1:3306", "MySQL host address.")
+	username = pflag.StringP("username", "u", "miniblog", "Username to connect to the database.")
+	password = pflag.StringP("password", "p", "miniblog1234", "Password to connect to the database.")
+	database = pflag.StringP("db", "d", "miniblog", "Database name to connect to.")
+	dryRun   = pflag.Bool("dry-run", false, "Print the conversion without writing to the database.")
+	help     = pflag.BoolP("help", "h", false, "Show this help message.")
+)
+
+// conversion 表示一条旧策略及其转换后的策略.
+type conversion struct {
+	old *model.CasbinRuleM
+	new []*model.CasbinRuleM
+}
+
+func main() {
+	// 设置自定义的使用说明函数
+	pflag.Usage = func() {
+		fmt.Printf("%s", helpText)
+		pflag.PrintDefaults()
+	}
+	pflag.Parse()
+
+	// 如果设置了帮助表示，则显示帮助信息并退出
+	if *help {
+		pflag.Usage()
+		return
+	}
+
+	// 初始化数据库连接
+	dbInstance, err := db.NewMySQL(&db.MySQLOptions{
+		Addr:     *addr,
+		Username: *username,
+		Password: *password,
+		Database: *database,
+	})
+	if err != nil {
+		log.Fatalf("Error initializing database: %v", err)
+	}
+
+	var rules []*model.CasbinRuleM
+	if err := dbInstance.Where("ptype = ?", "p").Order("id").Find(&rules).Error; err != nil {
+		log.Fatalf("Error loading casbin rules: %v", err)
+	}
+
+	conversions := convertRules(rules)
+	if len(conversions) == 0 {
+		fmt.Println("Nothing to migrate.")
+		return
+	}
+
+	if *dryRun {
+		fmt.Println("Dry run, no changes were written.")
+		return
+	}
+
+	if err := applyConversions(dbInstance, conversions); err != nil {
+		log.Fatalf("Error migrating casbin rules: %v", err)
+	}
+	fmt.Printf("Migrated %d rules.\n", len(conversions))
+}
+
+// convertRules 转换所有可以识别的旧策略，并打印转换结果.
+func convertRules(rules []*model.CasbinRuleM) []conversion {
+	var conversions []conversion
+	for _, rule := range rules {
+		object, action := value(rule.V1), value(rule.V2)
+		// 已经是权限标识或者通配策略的不需要转换
+		if !strings.HasPrefix(object, "/") {
+			continue
+		}
+
+		perms, ok := permission.Default.Convert(object, action)
+		if !ok {
+			fmt.Printf("skip    %s (no matching operation)\n", format(rule))
+			continue
+		}
+
+		c := conversion{old: rule}
+		for _, perm := range perms {
+			converted := *rule
+			converted.ID = 0
+			converted.V1 = &perm
+			converted.V2 = ptr(permission.Action)
+			c.new = append(c.new, &converted)
+			fmt.Printf("convert %s => %s\n", format(rule), format(&converted))
+		}
+		conversions = append(conversions, c)
+	}
+
+	return conversions
+}
+
+// applyConversions 在一个事务中删除旧策略并写入新策略，已经存在的新策略会被跳过.
+func applyConversions(dbInstance *gorm.DB, conversions []conversion) error {
+	return dbInstance.Transaction(func(tx *gorm.DB) error {
+		for _, c := range conversions {
+			if err := tx.Delete(c.old).Error; err != nil {
+				return err
+			}
+			for _, rule := range c.new {
+				if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(rule).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// format 返回策略的可读形式.
+func format(rule *model.CasbinRuleM) string {
+	fields := []string{value(rule.PType), value(rule.V0), value(rule.V1), value(rule.V2), value(rule.V3)}
+	return strings.Join(fields, ", ")
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/TobyIcetea/miniblog/examples/helper"
+	"github.com/TobyIcetea/miniblog/internal/pkg/captcha"
 	"github.com/TobyIcetea/miniblog/internal/pkg/known"
 	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
 	"github.com/google/uuid"
@@ -44,6 +45,7 @@ func main() {
 	_ = uuid.New().String()
 
 	createUserRequest := helper.ExampleCreateUserRequest()
+	createUserRequest.Captcha = helper.MustSolveCaptcha(ctx, client, captcha.ActionRegister)
 	createUserResponse, err := client.CreateUser(ctx, createUserRequest)
 	if err != nil {
 		log.Fatalf("Failed to create user: %v", err)
@@ -53,6 +55,7 @@ func main() {
 	loginResponse, err := client.Login(ctx, &apiv1.LoginRequest{
 		Username: createUserRequest.Username,
 		Password: createUserRequest.Password,
+		Captcha:  helper.MustSolveCaptcha(ctx, client, captcha.ActionLogin),
 	})
 	if err != nil {
 		log.Fatalf("Failed to login: %v", err)
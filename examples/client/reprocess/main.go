@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/TobyIcetea/miniblog/examples/helper"
+	"github.com/TobyIcetea/miniblog/internal/pkg/captcha"
 	"github.com/TobyIcetea/miniblog/internal/pkg/known"
 	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
 	"github.com/google/uuid"
@@ -46,6 +47,7 @@ func main() {
 	_ = uuid.New().String()
 
 	createUserRequest := helper.ExampleCreateUserRequest()
+	createUserRequest.Captcha = helper.MustSolveCaptcha(ctx, client, captcha.ActionRegister)
 	createUserRequest.Nickname = nil // 不设置 Nickname 字段
 	createUserResponse, err := client.CreateUser(ctx, createUserRequest)
 	if err != nil {
@@ -56,6 +58,7 @@ func main() {
 	loginResponse, err := client.Login(ctx, &apiv1.LoginRequest{
 		Username: createUserRequest.Username,
 		Password: createUserRequest.Password,
+		Captcha:  helper.MustSolveCaptcha(ctx, client, captcha.ActionLogin),
 	})
 	if err != nil {
 		log.Fatalf("Failed to login: %v", err)
@@ -87,6 +90,7 @@ func main() {
 	log.Printf("[GetUser		] Success to get user: %v", getUserResponse)
 
 	createUserRequest2 := helper.ExampleCreateUserRequest()
+	createUserRequest2.Captcha = helper.MustSolveCaptcha(ctx, client, captcha.ActionRegister)
 	createUserRequest2.Email = "bad email address" // 不设置 nickname 字段
 	_, err = client.CreateUser(ctx, createUserRequest2)
 	if !strings.Contains(err.Error(), "invalid email format") {
@@ -64,5 +64,5 @@ func main() {
 func doSomething() error {
 	// 这里返回了一个已经定义的错误类型 errno.ErrUsernameInvalid，但动态地设置了 Message 字段为 "Username is too short"。
 	// 重点是：虽然错误的 Message 不同，但错误的 Code 和 Reason 是一致的，这方便使用 Is 方法进行类型判断而不受具体内容影响。
-	return errno.ErrUsernameInvalid.WithMessage("Username is too short")
+	return errno.WithMessage(errno.ErrUsernameInvalid, "Username is too short")
 }
@@ -13,6 +13,7 @@ import (
 	"math/rand/v2"
 	"time"
 
+	"github.com/TobyIcetea/miniblog/internal/pkg/captcha"
 	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
 	"google.golang.org/grpc/metadata"
 	"k8s.io/utils/ptr"
@@ -47,6 +48,30 @@ func GeneratePhoneNumber() string {
 	return phone
 }
 
+// MustSolveCaptcha 获取并求解人机验证挑战，当前不需要人机验证时返回 nil
+// 参数：
+// - ctx：上下文对象
+// - client：MiniBlogClient 客户端，用于调用签发挑战接口
+// - action：挑战用于的操作，可选值为 login 和 register
+// 返回：
+// - 可以直接设置到登录或注册请求中的挑战答案
+func MustSolveCaptcha(ctx context.Context, client apiv1.MiniBlogClient, action string) *apiv1.Captcha {
+	resp, err := client.CreateCaptcha(ctx, &apiv1.CreateCaptchaRequest{Action: action})
+	if err != nil {
+		log.Printf("Failed to create captcha: %v", err)
+		panic(err)
+	}
+	if !resp.Required {
+		return nil
+	}
+
+	// 内置的工作量证明挑战可以在客户端直接求解
+	return &apiv1.Captcha{
+		CaptchaID: resp.CaptchaID,
+		Solution:  captcha.Solve(resp.CaptchaID, int(resp.Difficulty)),
+	}
+}
+
 // MustWithAdminToken 使用管理员 Token 创建带有授权信息的上下文
 // 参数：
 // - ctx：上下文对象
@@ -58,6 +83,7 @@ func MustWithAdminToken(ctx context.Context, client apiv1.MiniBlogClient) contex
 	loginResponse, err := client.Login(ctx, &apiv1.LoginRequest{
 		Username: "root",         // 固定的管理员用户名
 		Password: "miniblog1234", // 固定的管理员密码
+		Captcha:  MustSolveCaptcha(ctx, client, captcha.ActionLogin),
 	})
 	if err != nil {
 		log.Printf("Failed to login as root: %v", err)
@@ -0,0 +1,132 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/migration"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/database"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"golang.org/x/sync/errgroup"
+)
+
+// 统计一页用户的博客数量，对比以下几种实现方式：
+//   - PerUser：逐个用户查询博客数量（N+1 查询）；
+//   - PerUserConcurrent：并发地逐个用户查询博客数量；
+//   - GroupBy：在一条 GROUP BY 查询中统计所有用户的博客数量；
+//   - Denormalized：读取用户表中冗余保存的 postCount 列.
+//
+// 运行方式：go test -bench PostCount ./examples/performance/benchmark
+const (
+	benchmarkUsers        = 100
+	benchmarkPostsPerUser = 10
+)
+
+// newBenchmarkStore 创建一个 SQLite 内存数据库，并为每个用户写入相同数量的博客.
+func newBenchmarkStore(b *testing.B, opts *store.Options) (store.IStore, []string) {
+	b.Helper()
+	ctx := context.Background()
+
+	dbOptions := database.NewSQLiteOptions()
+	dbOptions.Path = database.MemoryPath
+	db, err := dbOptions.NewDB()
+	if err != nil {
+		b.Fatal(err)
+	}
+	m, err := migration.New(db, migration.NewOptions())
+	if err != nil {
+		b.Fatal(err)
+	}
+	if _, err := m.Up(ctx, 0); err != nil {
+		b.Fatal(err)
+	}
+
+	s, cleanup, err := store.ProvideStore(db, nil, nil, opts)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(cleanup)
+
+	userIDs := make([]string, 0, benchmarkUsers)
+	for i := 0; i < benchmarkUsers; i++ {
+		userM := &model.UserM{Username: fmt.Sprintf("user%d", i), Password: "miniblog1234", Phone: fmt.Sprintf("1880000%04d", i)}
+		if err := s.User().Create(ctx, userM); err != nil {
+			b.Fatal(err)
+		}
+		userIDs = append(userIDs, userM.UserID)
+
+		for j := 0; j < benchmarkPostsPerUser; j++ {
+			if err := s.Post().Create(ctx, &model.PostM{UserID: userM.UserID, Title: "title", Content: "content"}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	return s, userIDs
+}
+
+func BenchmarkPostCountPerUser(b *testing.B) {
+	s, userIDs := newBenchmarkStore(b, nil)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, userID := range userIDs {
+			if _, _, err := s.Post().List(ctx, where.F("userID", userID)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkPostCountPerUserConcurrent(b *testing.B) {
+	s, userIDs := newBenchmarkStore(b, nil)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var eg errgroup.Group
+		for _, userID := range userIDs {
+			eg.Go(func() error {
+				_, _, err := s.Post().List(ctx, where.F("userID", userID))
+				return err
+			})
+		}
+		if err := eg.Wait(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPostCountGroupBy(b *testing.B) {
+	s, userIDs := newBenchmarkStore(b, nil)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.Post().CountByUserIDs(ctx, userIDs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPostCountDenormalized(b *testing.B) {
+	s, userIDs := newBenchmarkStore(b, &store.Options{DenormalizedPostCount: true})
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.Post().CountByUserIDs(ctx, userIDs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -7,9 +7,17 @@
 package biz
 
 import (
+	auditv1 "github.com/TobyIcetea/miniblog/internal/apiserver/biz/v1/audit"
+	organizationv1 "github.com/TobyIcetea/miniblog/internal/apiserver/biz/v1/organization"
+	policyv1 "github.com/TobyIcetea/miniblog/internal/apiserver/biz/v1/policy"
 	postv1 "github.com/TobyIcetea/miniblog/internal/apiserver/biz/v1/post"
 	userv1 "github.com/TobyIcetea/miniblog/internal/apiserver/biz/v1/user"
 	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/captcha"
+	"github.com/TobyIcetea/miniblog/internal/pkg/lockout"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mail"
+	"github.com/TobyIcetea/miniblog/internal/pkg/oidc"
+	"github.com/TobyIcetea/miniblog/internal/pkg/password"
 	"github.com/TobyIcetea/miniblog/pkg/auth"
 	"github.com/google/wire"
 )
@@ -26,30 +34,57 @@ type IBiz interface {
 	UserV1() userv1.UserBiz
 	// 获取帖子业务接口
 	PostV1() postv1.PostBiz
+	// 获取访问控制策略业务接口
+	PolicyV1() policyv1.PolicyBiz
+	// 获取审计日志业务接口
+	AuditV1() auditv1.AuditBiz
+	// 获取组织业务接口
+	OrganizationV1() organizationv1.OrganizationBiz
 	// 获取帖子业务接口（v2 版本）
 	// PostV2() postv2.PostBiz
 }
 
 // biz 是 IBiz 的一个具体实现.
 type biz struct {
-	store store.IStore
-	authz *auth.Authz
+	store    store.IStore
+	authz    *auth.Authz
+	mailer   mail.Mailer
+	mailOpts *mail.Options
+	guard    *lockout.Guard
+	oidc     *oidc.Manager
+	policy   *password.Policy
+	captcha  *captcha.Manager
 }
 
 // 确保 biz 实现了 IBiz 接口.
 var _ IBiz = (*biz)(nil)
 
 // NewBiz 创建一个 IBiz 类型的实例.
-func NewBiz(store store.IStore, authz *auth.Authz) *biz {
-	return &biz{store: store, authz: authz}
+func NewBiz(store store.IStore, authz *auth.Authz, mailer mail.Mailer, mailOpts *mail.Options, guard *lockout.Guard, oidc *oidc.Manager, policy *password.Policy, captcha *captcha.Manager) *biz {
+	return &biz{store: store, authz: authz, mailer: mailer, mailOpts: mailOpts, guard: guard, oidc: oidc, policy: policy, captcha: captcha}
 }
 
 // UserBiz 返回一个 UserBiz 接口的实例.
 func (b *biz) UserV1() userv1.UserBiz {
-	return userv1.New(b.store, b.authz)
+	return userv1.New(b.store, b.authz, b.mailer, b.mailOpts, b.guard, b.oidc, b.policy, b.captcha)
 }
 
 // PostBiz 返回一个 PostBiz 接口的实例.
 func (b *biz) PostV1() postv1.PostBiz {
-	return postv1.New(b.store)
+	return postv1.New(b.store, b.authz)
+}
+
+// PolicyV1 返回一个 PolicyBiz 接口的实例.
+func (b *biz) PolicyV1() policyv1.PolicyBiz {
+	return policyv1.New(b.store, b.authz)
+}
+
+// AuditV1 返回一个 AuditBiz 接口的实例.
+func (b *biz) AuditV1() auditv1.AuditBiz {
+	return auditv1.New(b.store)
+}
+
+// OrganizationV1 返回一个 OrganizationBiz 接口的实例.
+func (b *biz) OrganizationV1() organizationv1.OrganizationBiz {
+	return organizationv1.New(b.store, b.authz)
 }
@@ -0,0 +1,84 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/pkg/conversion"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm/clause"
+)
+
+// AuditBiz 定义处理审计日志请求所需的方法.
+// 审计事件只能由中间件和业务层写入，这里只提供查询方法.
+type AuditBiz interface {
+	List(ctx context.Context, rq *apiv1.ListAuditEventsRequest) (*apiv1.ListAuditEventsResponse, error)
+
+	AuditExpansion
+}
+
+// AuditExpansion 定义审计日志操作的扩展方法.
+type AuditExpansion interface{}
+
+// auditBiz 是 AuditBiz 接口的实现.
+type auditBiz struct {
+	store store.IStore
+}
+
+// 确保 auditBiz 实现了 AuditBiz 接口.
+var _ AuditBiz = (*auditBiz)(nil)
+
+// New 创建 auditBiz 的实例.
+func New(store store.IStore) *auditBiz {
+	return &auditBiz{store: store}
+}
+
+// List 实现 AuditBiz 接口中的 List 方法.
+func (b *auditBiz) List(ctx context.Context, rq *apiv1.ListAuditEventsRequest) (*apiv1.ListAuditEventsResponse, error) {
+	whr := where.O(int(rq.GetOffset())).L(int(rq.GetLimit()))
+
+	// 空字符串表示不按该字段过滤
+	for column, value := range map[string]string{
+		"actor":     rq.GetActor(),
+		"subject":   rq.GetSubject(),
+		"action":    rq.GetAction(),
+		"outcome":   rq.GetOutcome(),
+		"requestID": rq.GetRequestID(),
+	} {
+		if value != "" {
+			whr = whr.F(column, value)
+		}
+	}
+	if rq.GetResource() != "" {
+		whr = whr.C(store.Contains("resource", rq.GetResource()))
+	}
+	// 时间格式已经在校验阶段检查过
+	if rq.GetStartTime() != "" {
+		startTime, _ := time.Parse(time.RFC3339, rq.GetStartTime())
+		whr = whr.C(clause.Gte{Column: "createdAt", Value: startTime})
+	}
+	if rq.GetEndTime() != "" {
+		endTime, _ := time.Parse(time.RFC3339, rq.GetEndTime())
+		whr = whr.C(clause.Lt{Column: "createdAt", Value: endTime})
+	}
+
+	count, eventList, err := b.store.Audit().List(ctx, whr)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*apiv1.AuditEvent, 0, len(eventList))
+	for _, event := range eventList {
+		events = append(events, conversion.AuditEventModelToAuditEventV1(event))
+	}
+
+	return &apiv1.ListAuditEventsResponse{TotalCount: count, Events: events}, nil
+}
@@ -0,0 +1,417 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package organization
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/pkg/audit"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/pkg/conversion"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	"github.com/TobyIcetea/miniblog/internal/pkg/permission"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/TobyIcetea/miniblog/pkg/auth"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm/clause"
+)
+
+// OrganizationBiz 定义处理组织（租户）请求所需的方法.
+type OrganizationBiz interface {
+	Create(ctx context.Context, rq *apiv1.CreateOrganizationRequest) (*apiv1.CreateOrganizationResponse, error)
+	Delete(ctx context.Context, rq *apiv1.DeleteOrganizationRequest) (*apiv1.DeleteOrganizationResponse, error)
+	Get(ctx context.Context, rq *apiv1.GetOrganizationRequest) (*apiv1.GetOrganizationResponse, error)
+	List(ctx context.Context, rq *apiv1.ListOrganizationsRequest) (*apiv1.ListOrganizationsResponse, error)
+
+	OrganizationExpansion
+}
+
+// OrganizationExpansion 定义组织成员管理和租户解析的扩展方法.
+type OrganizationExpansion interface {
+	ListMembers(ctx context.Context, rq *apiv1.ListOrganizationMembersRequest) (*apiv1.ListOrganizationMembersResponse, error)
+	AddMember(ctx context.Context, rq *apiv1.AddOrganizationMemberRequest) (*apiv1.AddOrganizationMemberResponse, error)
+	RemoveMember(ctx context.Context, rq *apiv1.RemoveOrganizationMemberRequest) (*apiv1.RemoveOrganizationMemberResponse, error)
+	Resolve(ctx context.Context, userID, tenant string) (string, error)
+}
+
+// memberRoles 定义组织成员角色与 casbin 租户角色的对应关系.
+var memberRoles = map[string]string{
+	"owner":  known.RoleTenantOwner,
+	"admin":  known.RoleTenantAdmin,
+	"member": known.RoleTenantMember,
+}
+
+// organizationBiz 是 OrganizationBiz 接口的实现.
+type organizationBiz struct {
+	store store.IStore
+	authz *auth.Authz
+}
+
+// 确保 organizationBiz 实现了 OrganizationBiz 接口.
+var _ OrganizationBiz = (*organizationBiz)(nil)
+
+// New 创建 organizationBiz 的实例.
+func New(store store.IStore, authz *auth.Authz) *organizationBiz {
+	return &organizationBiz{store: store, authz: authz}
+}
+
+// Create 实现 OrganizationBiz 接口中的 Create 方法，创建者会成为组织的所有者.
+func (b *organizationBiz) Create(ctx context.Context, rq *apiv1.CreateOrganizationRequest) (*apiv1.CreateOrganizationResponse, error) {
+	if _, err := b.store.Organization().Get(ctx, where.F("slug", rq.GetSlug())); err == nil {
+		return nil, errno.ErrOrganizationAlreadyExists
+	} else if !errors.Is(err, errno.ErrOrganizationNotFound) {
+		return nil, err
+	}
+
+	orgM := &model.OrganizationM{Slug: rq.GetSlug(), Name: rq.GetName(), OwnerID: contextx.UserID(ctx)}
+	if err := b.store.Organization().Create(ctx, orgM); err != nil {
+		return nil, err
+	}
+
+	if _, err := b.authz.SetTenantRole(orgM.OwnerID, known.RoleTenantOwner, orgM.OrgID); err != nil {
+		log.W(ctx).Errorw("Failed to add organization owner", "orgID", orgM.OrgID, "err", err)
+		// 回滚已经创建的组织，避免留下一个没有所有者的组织
+		_ = b.store.Organization().Delete(ctx, where.F("orgID", orgM.OrgID))
+		return nil, errno.WithMessage(errno.ErrUpdateOrganizationMember, "%v", err)
+	}
+
+	audit.Record(ctx, b.store.Audit(), "organization.create", organizationResource(orgM.OrgID), map[string]string{"slug": orgM.Slug, "name": orgM.Name})
+	return &apiv1.CreateOrganizationResponse{OrgID: orgM.OrgID}, nil
+}
+
+// Delete 实现 OrganizationBiz 接口中的 Delete 方法，同时删除组织内的所有博客和授权.
+func (b *organizationBiz) Delete(ctx context.Context, rq *apiv1.DeleteOrganizationRequest) (*apiv1.DeleteOrganizationResponse, error) {
+	if rq.GetOrgID() == known.DefaultTenantID {
+		return nil, errno.ErrOrganizationReserved
+	}
+	if err := b.authorize(ctx, rq.GetOrgID(), "organizations.delete"); err != nil {
+		return nil, err
+	}
+
+	// 在组织的租户内删除博客，datastore 会自动添加租户条件
+	tenantCtx := contextx.WithTenantID(ctx, rq.GetOrgID())
+	var postList []*model.PostM
+	err := b.store.TX(tenantCtx, func(ctx context.Context) error {
+		var err error
+		if _, postList, err = b.store.Post().List(ctx, where.F("tenantID", rq.GetOrgID())); err != nil {
+			return err
+		}
+		if err := b.store.Post().Delete(ctx, where.F("tenantID", rq.GetOrgID())); err != nil {
+			return err
+		}
+		return b.store.Organization().Delete(ctx, where.F("orgID", rq.GetOrgID()))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, postM := range postList {
+		if err := b.authz.RemoveResource(known.ResourcePostPrefix + postM.PostID); err != nil {
+			log.W(ctx).Errorw("Failed to remove post grants", "postID", postM.PostID, "err", err)
+		}
+	}
+	if err := b.authz.RemoveTenant(rq.GetOrgID()); err != nil {
+		log.W(ctx).Errorw("Failed to remove organization roles and policies", "orgID", rq.GetOrgID(), "err", err)
+		return nil, errno.WithMessage(errno.ErrUpdateOrganizationMember, "%v", err)
+	}
+
+	audit.Record(ctx, b.store.Audit(), "organization.delete", organizationResource(rq.GetOrgID()), map[string]int{"deletedPosts": len(postList)})
+	return &apiv1.DeleteOrganizationResponse{}, nil
+}
+
+// Get 实现 OrganizationBiz 接口中的 Get 方法.
+func (b *organizationBiz) Get(ctx context.Context, rq *apiv1.GetOrganizationRequest) (*apiv1.GetOrganizationResponse, error) {
+	if err := b.authorize(ctx, rq.GetOrgID(), "organizations.get"); err != nil {
+		return nil, err
+	}
+
+	orgM, err := b.store.Organization().Get(ctx, where.F("orgID", rq.GetOrgID()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv1.GetOrganizationResponse{Organization: conversion.OrganizationModelToOrganizationV1(orgM)}, nil
+}
+
+// List 实现 OrganizationBiz 接口中的 List 方法，返回当前用户所属的组织，包括默认组织.
+func (b *organizationBiz) List(ctx context.Context, rq *apiv1.ListOrganizationsRequest) (*apiv1.ListOrganizationsResponse, error) {
+	grants, err := b.authz.GetFilteredGroupingPolicy(0, contextx.UserID(ctx))
+	if err != nil {
+		return nil, errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+
+	orgIDs := []string{known.DefaultTenantID}
+	for _, grant := range grants {
+		if isMemberRole(grant[1]) {
+			orgIDs = append(orgIDs, grant[2])
+		}
+	}
+
+	whr := where.F("orgID", orgIDs).O(int(rq.GetOffset())).L(int(rq.GetLimit()))
+	count, orgList, err := b.store.Organization().List(ctx, whr)
+	if err != nil {
+		return nil, err
+	}
+
+	organizations := make([]*apiv1.Organization, 0, len(orgList))
+	for _, orgM := range orgList {
+		organizations = append(organizations, conversion.OrganizationModelToOrganizationV1(orgM))
+	}
+
+	return &apiv1.ListOrganizationsResponse{TotalCount: count, Organizations: organizations}, nil
+}
+
+// ListMembers 列出组织成员，结果按用户 ID 排序.
+func (b *organizationBiz) ListMembers(ctx context.Context, rq *apiv1.ListOrganizationMembersRequest) (*apiv1.ListOrganizationMembersResponse, error) {
+	if err := b.authorize(ctx, rq.GetOrgID(), "organizations.members.list"); err != nil {
+		return nil, err
+	}
+
+	grants, err := b.authz.TenantMembers(rq.GetOrgID())
+	if err != nil {
+		return nil, errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+
+	members := make([]*apiv1.OrganizationMember, 0, len(grants))
+	for _, grant := range grants {
+		if isMemberRole(grant[1]) {
+			members = append(members, &apiv1.OrganizationMember{UserID: grant[0], Role: memberRoleName(grant[1])})
+		}
+	}
+	slices.SortFunc(members, func(a, b *apiv1.OrganizationMember) int {
+		return strings.Compare(a.GetUserID(), b.GetUserID())
+	})
+
+	total := int64(len(members))
+	if offset := rq.GetOffset(); offset < total {
+		members = members[offset:]
+	} else {
+		members = nil
+	}
+	if limit := rq.GetLimit(); limit > 0 && limit < int64(len(members)) {
+		members = members[:limit]
+	}
+
+	return &apiv1.ListOrganizationMembersResponse{TotalCount: total, Members: members}, nil
+}
+
+// AddMember 添加组织成员，用户已经是成员时会修改其角色.
+// 只有组织所有者可以添加所有者，或者修改其他所有者的角色.
+func (b *organizationBiz) AddMember(ctx context.Context, rq *apiv1.AddOrganizationMemberRequest) (*apiv1.AddOrganizationMemberResponse, error) {
+	if rq.GetOrgID() == known.DefaultTenantID {
+		return nil, errno.ErrOrganizationReserved
+	}
+	if err := b.authorize(ctx, rq.GetOrgID(), "organizations.members.create"); err != nil {
+		return nil, err
+	}
+	if _, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID())); err != nil {
+		return nil, err
+	}
+
+	role := memberRoles[rq.GetRole()]
+	if role == "" {
+		role = known.RoleTenantMember
+	}
+
+	current, err := b.authz.TenantRoles(rq.GetUserID(), rq.GetOrgID())
+	if err != nil {
+		return nil, errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+	if role == known.RoleTenantOwner || slices.Contains(current, known.RoleTenantOwner) {
+		if err := b.ensureOwner(ctx, rq.GetOrgID()); err != nil {
+			return nil, err
+		}
+	}
+	if role != known.RoleTenantOwner && slices.Contains(current, known.RoleTenantOwner) {
+		if err := b.ensureAnotherOwner(rq.GetOrgID(), rq.GetUserID()); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := b.authz.SetTenantRole(rq.GetUserID(), role, rq.GetOrgID()); err != nil {
+		log.W(ctx).Errorw("Failed to set organization member role", "orgID", rq.GetOrgID(), "user", rq.GetUserID(), "role", role, "err", err)
+		return nil, errno.WithMessage(errno.ErrUpdateOrganizationMember, "%v", err)
+	}
+
+	audit.Record(ctx, b.store.Audit(), "organization.member.add", organizationResource(rq.GetOrgID()), map[string]string{"userID": rq.GetUserID(), "role": memberRoleName(role)})
+	return &apiv1.AddOrganizationMemberResponse{}, nil
+}
+
+// RemoveMember 移除组织成员，组织至少需要保留一个所有者.
+func (b *organizationBiz) RemoveMember(ctx context.Context, rq *apiv1.RemoveOrganizationMemberRequest) (*apiv1.RemoveOrganizationMemberResponse, error) {
+	if rq.GetOrgID() == known.DefaultTenantID {
+		return nil, errno.ErrOrganizationReserved
+	}
+	if err := b.authorize(ctx, rq.GetOrgID(), "organizations.members.delete"); err != nil {
+		return nil, err
+	}
+
+	current, err := b.authz.TenantRoles(rq.GetUserID(), rq.GetOrgID())
+	if err != nil {
+		return nil, errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+	if len(current) == 0 {
+		return nil, errno.ErrOrganizationMemberNotFound
+	}
+	if slices.Contains(current, known.RoleTenantOwner) {
+		if err := b.ensureOwner(ctx, rq.GetOrgID()); err != nil {
+			return nil, err
+		}
+		if err := b.ensureAnotherOwner(rq.GetOrgID(), rq.GetUserID()); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := b.authz.RemoveTenantMember(rq.GetUserID(), rq.GetOrgID()); err != nil {
+		log.W(ctx).Errorw("Failed to remove organization member", "orgID", rq.GetOrgID(), "user", rq.GetUserID(), "err", err)
+		return nil, errno.WithMessage(errno.ErrUpdateOrganizationMember, "%v", err)
+	}
+
+	audit.Record(ctx, b.store.Audit(), "organization.member.remove", organizationResource(rq.GetOrgID()), map[string]string{"userID": rq.GetUserID()})
+	return &apiv1.RemoveOrganizationMemberResponse{}, nil
+}
+
+// Resolve 将请求中指定的组织 ID 或者组织短名称解析为组织 ID，并校验用户是否可以访问该组织.
+// 所有用户都可以访问默认组织，其他组织只有成员和管理员可以访问.
+func (b *organizationBiz) Resolve(ctx context.Context, userID, tenant string) (string, error) {
+	if tenant == known.DefaultTenantID {
+		return known.DefaultTenantID, nil
+	}
+
+	orgM, err := b.store.Organization().Get(ctx, where.NewWhere().C(clause.Or(clause.Eq{Column: "orgID", Value: tenant}, clause.Eq{Column: "slug", Value: tenant})))
+	if err != nil {
+		return "", err
+	}
+
+	member, err := b.isMember(userID, orgM.OrgID)
+	if err != nil {
+		return "", errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+	if !member {
+		return "", errno.ErrNotOrganizationMember
+	}
+
+	return orgM.OrgID, nil
+}
+
+// authorize 检查组织是否存在，以及当前用户是否可以在组织内调用指定权限标识的接口.
+// 非组织成员返回 ErrOrganizationNotFound，避免泄露组织是否存在.
+func (b *organizationBiz) authorize(ctx context.Context, orgID, permissionID string) error {
+	userID := contextx.UserID(ctx)
+	if orgID == known.DefaultTenantID {
+		return nil
+	}
+
+	member, err := b.isMember(userID, orgID)
+	if err != nil {
+		return errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+	if !member {
+		return errno.ErrOrganizationNotFound
+	}
+	if _, err := b.store.Organization().Get(ctx, where.F("orgID", orgID)); err != nil {
+		return err
+	}
+
+	allowed, err := b.authz.Authorize(userID, orgID, permissionID, permission.Action)
+	if err != nil {
+		return errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+	if !allowed {
+		return errno.WithMessage(errno.ErrPermissionDenied, "access denied: %s in organization %s", permissionID, orgID)
+	}
+
+	return nil
+}
+
+// isMember 判断用户是否为组织成员，管理员被视为所有组织的成员.
+func (b *organizationBiz) isMember(userID, orgID string) (bool, error) {
+	roles, err := b.authz.TenantRoles(userID, orgID)
+	if err != nil {
+		return false, err
+	}
+	if slices.ContainsFunc(roles, isMemberRole) {
+		return true, nil
+	}
+
+	return b.isAdmin(userID)
+}
+
+// isAdmin 判断用户是否为管理员.
+func (b *organizationBiz) isAdmin(userID string) (bool, error) {
+	roles, err := b.authz.GetImplicitRolesForUser(userID, auth.DomainAll)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(roles, known.RoleAdmin), nil
+}
+
+// ensureOwner 确保当前用户是组织的所有者或者管理员.
+func (b *organizationBiz) ensureOwner(ctx context.Context, orgID string) error {
+	userID := contextx.UserID(ctx)
+	roles, err := b.authz.TenantRoles(userID, orgID)
+	if err != nil {
+		return errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+	if slices.Contains(roles, known.RoleTenantOwner) {
+		return nil
+	}
+	if admin, err := b.isAdmin(userID); err != nil {
+		return errno.WithMessage(errno.ErrInternal, "%v", err)
+	} else if admin {
+		return nil
+	}
+
+	return errno.WithMessage(errno.ErrPermissionDenied, "only organization owners can manage owners")
+}
+
+// ensureAnotherOwner 确保移除或者降级 userID 之后组织仍然有所有者.
+func (b *organizationBiz) ensureAnotherOwner(orgID, userID string) error {
+	grants, err := b.authz.TenantMembers(orgID)
+	if err != nil {
+		return errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+	for _, grant := range grants {
+		if grant[0] != userID && grant[1] == known.RoleTenantOwner {
+			return nil
+		}
+	}
+
+	return errno.ErrLastOrganizationOwner
+}
+
+// isMemberRole 判断角色是否为组织成员角色.
+func isMemberRole(role string) bool {
+	switch role {
+	case known.RoleTenantOwner, known.RoleTenantAdmin, known.RoleTenantMember:
+		return true
+	default:
+		return false
+	}
+}
+
+// memberRoleName 返回租户角色在接口中的名称.
+func memberRoleName(role string) string {
+	for name, r := range memberRoles {
+		if r == role {
+			return name
+		}
+	}
+	return role
+}
+
+// organizationResource 返回组织在审计日志中的资源名称.
+func organizationResource(orgID string) string {
+	return known.ResourceOrganizationPrefix + orgID
+}
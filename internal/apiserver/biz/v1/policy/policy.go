@@ -0,0 +1,403 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package policy
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/pkg/audit"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/TobyIcetea/miniblog/pkg/auth"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// PolicyBiz 定义处理访问控制策略请求所需的方法.
+type PolicyBiz interface {
+	List(ctx context.Context, rq *apiv1.ListPoliciesRequest) (*apiv1.ListPoliciesResponse, error)
+	Create(ctx context.Context, rq *apiv1.CreatePolicyRequest) (*apiv1.CreatePolicyResponse, error)
+	Delete(ctx context.Context, rq *apiv1.DeletePolicyRequest) (*apiv1.DeletePolicyResponse, error)
+
+	PolicyExpansion
+}
+
+// PolicyExpansion 定义角色管理和权限检查的扩展方法.
+type PolicyExpansion interface {
+	ListRoleAssignments(ctx context.Context, rq *apiv1.ListRoleAssignmentsRequest) (*apiv1.ListRoleAssignmentsResponse, error)
+	AssignRole(ctx context.Context, rq *apiv1.AssignRoleRequest) (*apiv1.AssignRoleResponse, error)
+	RevokeRole(ctx context.Context, rq *apiv1.RevokeRoleRequest) (*apiv1.RevokeRoleResponse, error)
+	ListRoles(ctx context.Context, rq *apiv1.ListRolesRequest) (*apiv1.ListRolesResponse, error)
+	CreateRole(ctx context.Context, rq *apiv1.CreateRoleRequest) (*apiv1.CreateRoleResponse, error)
+	DeleteRole(ctx context.Context, rq *apiv1.DeleteRoleRequest) (*apiv1.DeleteRoleResponse, error)
+	CheckPermission(ctx context.Context, rq *apiv1.CheckPermissionRequest) (*apiv1.CheckPermissionResponse, error)
+}
+
+// policyBiz 是 PolicyBiz 接口的实现.
+type policyBiz struct {
+	store store.IStore
+	authz *auth.Authz
+}
+
+// 确保 policyBiz 实现了 PolicyBiz 接口.
+var _ PolicyBiz = (*policyBiz)(nil)
+
+// New 创建 policyBiz 的实例.
+func New(store store.IStore, authz *auth.Authz) *policyBiz {
+	return &policyBiz{store: store, authz: authz}
+}
+
+// List 实现 PolicyBiz 接口中的 List 方法.
+func (b *policyBiz) List(ctx context.Context, rq *apiv1.ListPoliciesRequest) (*apiv1.ListPoliciesResponse, error) {
+	// casbin 的过滤条件中，空字符串表示匹配任意值
+	rules, err := b.authz.GetFilteredPolicy(0, rq.GetSubject(), rq.GetDomain())
+	if err != nil {
+		return nil, errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+
+	policies := make([]*apiv1.Policy, 0, len(rules))
+	for _, rule := range paginate(rules, rq.GetOffset(), rq.GetLimit()) {
+		policies = append(policies, ruleToPolicy(rule))
+	}
+
+	return &apiv1.ListPoliciesResponse{TotalCount: int64(len(rules)), Policies: policies}, nil
+}
+
+// Create 实现 PolicyBiz 接口中的 Create 方法.
+func (b *policyBiz) Create(ctx context.Context, rq *apiv1.CreatePolicyRequest) (*apiv1.CreatePolicyResponse, error) {
+	policy := &apiv1.Policy{Subject: rq.GetSubject(), Domain: domainOrDefault(rq.GetDomain()), Object: rq.GetObject(), Action: rq.GetAction(), Effect: effectOrDefault(rq.GetEffect())}
+
+	ok, err := b.authz.AddPolicy(policyToRule(policy))
+	if err != nil {
+		log.W(ctx).Errorw("Failed to add policy", "policy", policy, "err", err)
+		return nil, errno.WithMessage(errno.ErrUpdatePolicy, "%v", err)
+	}
+	if !ok {
+		return nil, errno.ErrPolicyAlreadyExists
+	}
+
+	audit.Record(ctx, b.store.Audit(), "policy.create", policy.GetSubject(), policy)
+	return &apiv1.CreatePolicyResponse{}, nil
+}
+
+// Delete 实现 PolicyBiz 接口中的 Delete 方法.
+func (b *policyBiz) Delete(ctx context.Context, rq *apiv1.DeletePolicyRequest) (*apiv1.DeletePolicyResponse, error) {
+	policy := &apiv1.Policy{Subject: rq.GetSubject(), Domain: domainOrDefault(rq.GetDomain()), Object: rq.GetObject(), Action: rq.GetAction(), Effect: effectOrDefault(rq.GetEffect())}
+
+	// 删除管理员的通配策略会导致所有管理员失去权限，并且无法再通过接口恢复
+	if policy.GetSubject() == known.RoleAdmin && policy.GetDomain() == auth.DomainAll && policy.GetObject() == "*" && policy.GetAction() == "*" {
+		return nil, errno.WithMessage(errno.ErrPermissionDenied, "the built-in administrator policy cannot be deleted")
+	}
+
+	ok, err := b.authz.RemovePolicy(policyToRule(policy))
+	if err != nil {
+		log.W(ctx).Errorw("Failed to remove policy", "policy", policy, "err", err)
+		return nil, errno.WithMessage(errno.ErrUpdatePolicy, "%v", err)
+	}
+	if !ok {
+		return nil, errno.ErrPolicyNotFound
+	}
+
+	audit.Record(ctx, b.store.Audit(), "policy.delete", policy.GetSubject(), policy)
+	return &apiv1.DeletePolicyResponse{}, nil
+}
+
+// ListRoleAssignments 列出角色分配记录，可以按主体或者角色过滤.
+func (b *policyBiz) ListRoleAssignments(ctx context.Context, rq *apiv1.ListRoleAssignmentsRequest) (*apiv1.ListRoleAssignmentsResponse, error) {
+	rules, err := b.authz.GetFilteredGroupingPolicy(0, rq.GetSubject(), rq.GetRole(), rq.GetDomain())
+	if err != nil {
+		return nil, errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+
+	assignments := make([]*apiv1.RoleAssignment, 0, len(rules))
+	for _, rule := range paginate(rules, rq.GetOffset(), rq.GetLimit()) {
+		assignments = append(assignments, ruleToRoleAssignment(rule))
+	}
+
+	return &apiv1.ListRoleAssignmentsResponse{TotalCount: int64(len(rules)), Assignments: assignments}, nil
+}
+
+// AssignRole 为用户分配角色，或者让一个角色继承另一个角色.
+func (b *policyBiz) AssignRole(ctx context.Context, rq *apiv1.AssignRoleRequest) (*apiv1.AssignRoleResponse, error) {
+	if err := b.ensureRole(rq.GetRole()); err != nil {
+		return nil, err
+	}
+	if err := b.ensureSubject(ctx, rq.GetSubject()); err != nil {
+		return nil, err
+	}
+
+	assignment := &apiv1.RoleAssignment{Subject: rq.GetSubject(), Role: rq.GetRole(), Domain: domainOrDefault(rq.GetDomain())}
+	ok, err := b.authz.AddGroupingPolicy(assignment.GetSubject(), assignment.GetRole(), assignment.GetDomain())
+	if err != nil {
+		log.W(ctx).Errorw("Failed to add grouping policy", "assignment", assignment, "err", err)
+		return nil, errno.WithMessage(errno.ErrAddRole, "%v", err)
+	}
+	if !ok {
+		return nil, errno.ErrRoleAssignmentAlreadyExists
+	}
+
+	audit.Record(ctx, b.store.Audit(), "role.assign", rq.GetSubject(), assignment)
+	return &apiv1.AssignRoleResponse{}, nil
+}
+
+// RevokeRole 撤销主体的角色.
+func (b *policyBiz) RevokeRole(ctx context.Context, rq *apiv1.RevokeRoleRequest) (*apiv1.RevokeRoleResponse, error) {
+	// 避免管理员误操作撤销自己的管理员角色，导致无法再管理权限
+	assignment := &apiv1.RoleAssignment{Subject: rq.GetSubject(), Role: rq.GetRole(), Domain: domainOrDefault(rq.GetDomain())}
+	if assignment.GetSubject() == contextx.UserID(ctx) && assignment.GetRole() == known.RoleAdmin && assignment.GetDomain() == auth.DomainAll {
+		return nil, errno.WithMessage(errno.ErrPermissionDenied, "you cannot revoke the administrator role from yourself")
+	}
+
+	ok, err := b.authz.RemoveGroupingPolicy(assignment.GetSubject(), assignment.GetRole(), assignment.GetDomain())
+	if err != nil {
+		log.W(ctx).Errorw("Failed to remove grouping policy", "assignment", assignment, "err", err)
+		return nil, errno.WithMessage(errno.ErrRemoveRole, "%v", err)
+	}
+	if !ok {
+		return nil, errno.ErrRoleAssignmentNotFound
+	}
+
+	audit.Record(ctx, b.store.Audit(), "role.revoke", rq.GetSubject(), assignment)
+	return &apiv1.RevokeRoleResponse{}, nil
+}
+
+// ListRoles 列出所有角色，包括内置角色和自定义角色.
+func (b *policyBiz) ListRoles(ctx context.Context, rq *apiv1.ListRolesRequest) (*apiv1.ListRolesResponse, error) {
+	roles, err := b.roles()
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv1.ListRolesResponse{Roles: roles}, nil
+}
+
+// CreateRole 定义一个自定义角色，包括该角色继承的角色和拥有的策略.
+func (b *policyBiz) CreateRole(ctx context.Context, rq *apiv1.CreateRoleRequest) (*apiv1.CreateRoleResponse, error) {
+	exists, err := b.roleExists(rq.GetRole())
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errno.ErrRoleAlreadyExists
+	}
+	for _, parent := range rq.GetInherits() {
+		if err := b.ensureRole(parent); err != nil {
+			return nil, err
+		}
+	}
+
+	policies := make([]*apiv1.Policy, 0, len(rq.GetPolicies()))
+	rules := make([][]string, 0, len(rq.GetPolicies()))
+	for _, p := range rq.GetPolicies() {
+		policy := &apiv1.Policy{Subject: rq.GetRole(), Domain: domainOrDefault(p.GetDomain()), Object: p.GetObject(), Action: p.GetAction(), Effect: effectOrDefault(p.GetEffect())}
+		policies = append(policies, policy)
+		rules = append(rules, policyToRule(policy))
+	}
+	if len(rules) > 0 {
+		if _, err := b.authz.AddPolicies(rules); err != nil {
+			log.W(ctx).Errorw("Failed to add role policies", "role", rq.GetRole(), "err", err)
+			return nil, errno.WithMessage(errno.ErrUpdatePolicy, "%v", err)
+		}
+	}
+
+	groupings := make([][]string, 0, len(rq.GetInherits()))
+	for _, parent := range rq.GetInherits() {
+		groupings = append(groupings, []string{rq.GetRole(), parent, auth.DomainAll})
+	}
+	if len(groupings) > 0 {
+		if _, err := b.authz.AddGroupingPolicies(groupings); err != nil {
+			log.W(ctx).Errorw("Failed to add role inheritance", "role", rq.GetRole(), "err", err)
+			// 回滚已经添加的策略，避免留下一个只定义了一半的角色
+			_, _ = b.authz.RemoveFilteredPolicy(0, rq.GetRole())
+			return nil, errno.WithMessage(errno.ErrAddRole, "%v", err)
+		}
+	}
+
+	audit.Record(ctx, b.store.Audit(), "role.create", rq.GetRole(), map[string]any{"inherits": rq.GetInherits(), "policies": policies})
+	return &apiv1.CreateRoleResponse{}, nil
+}
+
+// DeleteRole 删除自定义角色，同时删除该角色的策略和所有角色分配记录.
+func (b *policyBiz) DeleteRole(ctx context.Context, rq *apiv1.DeleteRoleRequest) (*apiv1.DeleteRoleResponse, error) {
+	if isBuiltinRole(rq.GetRole()) {
+		return nil, errno.ErrRoleReserved
+	}
+	if err := b.ensureRole(rq.GetRole()); err != nil {
+		return nil, err
+	}
+
+	if _, err := b.authz.DeleteRole(rq.GetRole()); err != nil {
+		log.W(ctx).Errorw("Failed to delete role", "role", rq.GetRole(), "err", err)
+		return nil, errno.WithMessage(errno.ErrRemoveRole, "%v", err)
+	}
+
+	audit.Record(ctx, b.store.Audit(), "role.delete", rq.GetRole(), nil)
+	return &apiv1.DeleteRoleResponse{}, nil
+}
+
+// CheckPermission 检查主体是否可以对资源执行操作，只做判断，不会修改任何策略.
+func (b *policyBiz) CheckPermission(ctx context.Context, rq *apiv1.CheckPermissionRequest) (*apiv1.CheckPermissionResponse, error) {
+	// 与添加策略和分配角色使用相同的默认租户，未指定租户时检查在所有租户内生效的策略
+	domain := domainOrDefault(rq.GetDomain())
+
+	var (
+		allowed bool
+		explain []string
+		err     error
+	)
+	// 与授权中间件保持一致：服务主体默认拒绝
+	if strings.HasPrefix(rq.GetSubject(), known.ServicePrincipalPrefix) {
+		allowed, explain, err = b.authz.AuthorizeExplicitEx(rq.GetSubject(), domain, rq.GetObject(), rq.GetAction())
+	} else {
+		allowed, explain, err = b.authz.EnforceEx(rq.GetSubject(), domain, rq.GetObject(), rq.GetAction())
+	}
+	if err != nil {
+		return nil, errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+
+	resp := &apiv1.CheckPermissionResponse{Allowed: allowed}
+	if len(explain) > 0 {
+		resp.MatchedPolicies = []*apiv1.Policy{ruleToPolicy(explain)}
+	}
+
+	return resp, nil
+}
+
+// roles 返回所有角色，结果按名称排序.
+func (b *policyBiz) roles() ([]string, error) {
+	roles := []string{known.RoleAdmin, known.RoleUser, known.RolePostViewer, known.RolePostEditor, known.RoleTenantOwner, known.RoleTenantAdmin, known.RoleTenantMember}
+
+	groupingRoles, err := b.authz.GetAllRoles()
+	if err != nil {
+		return nil, errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+	subjects, err := b.authz.GetAllSubjects()
+	if err != nil {
+		return nil, errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+
+	for _, name := range append(groupingRoles, subjects...) {
+		if strings.HasPrefix(name, known.RolePrefix) {
+			roles = append(roles, name)
+		}
+	}
+
+	slices.Sort(roles)
+	return slices.Compact(roles), nil
+}
+
+// roleExists 判断角色是否已经定义.
+// casbin 中没有单独的角色定义，只要角色出现在策略或者角色分配记录中，就认为角色存在.
+func (b *policyBiz) roleExists(role string) (bool, error) {
+	roles, err := b.roles()
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(roles, role), nil
+}
+
+// ensureRole 确保角色已经定义.
+func (b *policyBiz) ensureRole(role string) error {
+	exists, err := b.roleExists(role)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errno.ErrRoleNotFound
+	}
+
+	return nil
+}
+
+// ensureSubject 确保主体存在：角色必须已经定义，用户必须已经注册.
+func (b *policyBiz) ensureSubject(ctx context.Context, subject string) error {
+	if strings.HasPrefix(subject, known.RolePrefix) {
+		return b.ensureRole(subject)
+	}
+
+	_, err := b.store.User().Get(ctx, where.F("userID", subject))
+	return err
+}
+
+// isBuiltinRole 判断是否为内置角色.
+func isBuiltinRole(role string) bool {
+	switch role {
+	case known.RoleAdmin, known.RoleUser, known.RolePostViewer, known.RolePostEditor,
+		known.RoleTenantOwner, known.RoleTenantAdmin, known.RoleTenantMember:
+		return true
+	default:
+		return false
+	}
+}
+
+// effectOrDefault 返回策略效果，未指定时默认为 allow.
+func effectOrDefault(effect string) string {
+	if effect == "" {
+		return known.EffectAllow
+	}
+	return effect
+}
+
+// domainOrDefault 返回策略或者角色分配的租户，未指定时默认为所有租户.
+func domainOrDefault(domain string) string {
+	if domain == "" {
+		return auth.DomainAll
+	}
+	return domain
+}
+
+// policyToRule 将 Policy 转换为 casbin 的策略规则.
+func policyToRule(policy *apiv1.Policy) []string {
+	return []string{policy.GetSubject(), policy.GetDomain(), policy.GetObject(), policy.GetAction(), policy.GetEffect()}
+}
+
+// ruleToPolicy 将 casbin 的策略规则转换为 Policy.
+func ruleToPolicy(rule []string) *apiv1.Policy {
+	policy := &apiv1.Policy{Effect: known.EffectAllow}
+	for i, value := range rule {
+		switch i {
+		case 0:
+			policy.Subject = value
+		case 1:
+			policy.Domain = value
+		case 2:
+			policy.Object = value
+		case 3:
+			policy.Action = value
+		case 4:
+			policy.Effect = effectOrDefault(value)
+		}
+	}
+	return policy
+}
+
+// ruleToRoleAssignment 将 casbin 的角色分配规则转换为 RoleAssignment.
+func ruleToRoleAssignment(rule []string) *apiv1.RoleAssignment {
+	assignment := &apiv1.RoleAssignment{Subject: rule[0], Role: rule[1]}
+	if len(rule) > 2 {
+		assignment.Domain = rule[2]
+	}
+	return assignment
+}
+
+// paginate 对内存中的列表分页，limit 小于等于 0 时返回 offset 之后的所有元素.
+func paginate[T any](items []T, offset, limit int64) []T {
+	if offset >= int64(len(items)) {
+		return nil
+	}
+	items = items[offset:]
+	if limit > 0 && limit < int64(len(items)) {
+		items = items[:limit]
+	}
+	return items
+}
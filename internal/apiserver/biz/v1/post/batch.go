@@ -0,0 +1,103 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package post
+
+import (
+	"context"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/pkg/conversion"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/jinzhu/copier"
+	"github.com/onexstack/onexstack/pkg/errorsx"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// BatchGet 在一次查询中获取多篇文章，按请求中的 ID 顺序返回.
+// 与 Get 一样检查每篇文章的读取权限，不存在或者没有读取权限的文章 ID 在 missingPostIDs 中返回.
+func (b *postBiz) BatchGet(ctx context.Context, rq *apiv1.BatchGetPostsRequest) (*apiv1.BatchGetPostsResponse, error) {
+	_, postList, err := b.store.Post().List(ctx, where.F("postID", rq.GetPostIDs()))
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]*model.PostM, len(postList))
+	for _, postM := range postList {
+		if err := b.Authorize(ctx, postM, known.ActionRead); err != nil {
+			if errorsx.Is(err, errno.ErrPostNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		found[postM.PostID] = postM
+	}
+
+	ret := &apiv1.BatchGetPostsResponse{Posts: make([]*apiv1.Post, 0, len(found))}
+	for _, postID := range rq.GetPostIDs() {
+		postM, ok := found[postID]
+		if !ok {
+			ret.MissingPostIDs = append(ret.MissingPostIDs, postID)
+			continue
+		}
+		ret.Posts = append(ret.Posts, conversion.PostModelToPostV1(postM))
+	}
+
+	return ret, nil
+}
+
+// BatchCreate 批量创建文章.
+// 默认在一个事务中创建所有文章，任意一篇文章创建失败时回滚事务并返回该文章的错误.
+// partial 为 true 时每篇文章在各自的事务中创建，创建失败的文章只回滚自己的修改，并在响应的 errors 中返回.
+// 部分创建模式不使用外层事务，因为 PostgreSQL 中任意一条语句失败后整个事务都无法继续执行.
+func (b *postBiz) BatchCreate(ctx context.Context, rq *apiv1.BatchCreatePostsRequest) (*apiv1.BatchCreatePostsResponse, error) {
+	postList := make([]*model.PostM, 0, len(rq.GetPosts()))
+	for _, post := range rq.GetPosts() {
+		var postM model.PostM
+		_ = copier.Copy(&postM, post)
+		postM.UserID = contextx.UserID(ctx)
+		postList = append(postList, &postM)
+	}
+
+	ret := &apiv1.BatchCreatePostsResponse{PostIDs: make([]string, len(postList))}
+	if rq.GetPartial() {
+		for i, postM := range postList {
+			// 创建文章的同时会更新用户的文章数量，使用事务保证两者一致
+			err := b.store.TX(ctx, func(ctx context.Context) error {
+				return b.store.Post().Create(ctx, postM)
+			})
+			if err != nil {
+				log.W(ctx).Errorw("Failed to create post in batch", "index", i, "err", err)
+				ret.Errors = append(ret.Errors, conversion.ErrorToBatchItemErrorV1(i, "", err))
+				continue
+			}
+			ret.PostIDs[i] = postM.PostID
+		}
+		return ret, nil
+	}
+
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		for i, postM := range postList {
+			if err := b.store.Post().Create(ctx, postM); err != nil {
+				e := errorsx.FromError(err)
+				return errno.WithMessage(e, "posts[%d]: %s", i, e.Message)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, postM := range postList {
+		ret.PostIDs[i] = postM.PostID
+	}
+	return ret, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package post
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store/fake"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingStore 在创建标题为 fail 的文章时，写入文章之后返回错误，模拟创建过程中的后续语句失败.
+type failingStore struct {
+	store.IStore
+}
+
+func (s *failingStore) Post() store.PostStore {
+	return &failingPostStore{PostStore: s.IStore.Post()}
+}
+
+type failingPostStore struct {
+	store.PostStore
+}
+
+func (s *failingPostStore) Create(ctx context.Context, obj *model.PostM) error {
+	if err := s.PostStore.Create(ctx, obj); err != nil || obj.Title != "fail" {
+		return err
+	}
+	return errno.WithMessage(errno.ErrDBWrite, "%v", errors.New("injected failure"))
+}
+
+func newBatchRequest(partial bool, titles ...string) *apiv1.BatchCreatePostsRequest {
+	rq := &apiv1.BatchCreatePostsRequest{Partial: partial}
+	for _, title := range titles {
+		rq.Posts = append(rq.Posts, &apiv1.CreatePostRequest{Title: title, Content: "content"})
+	}
+	return rq
+}
+
+func TestBatchCreate(t *testing.T) {
+	ctx := contextx.WithUserID(context.Background(), "user-1")
+	tests := []struct {
+		name       string
+		rq         *apiv1.BatchCreatePostsRequest
+		wantErr    bool
+		wantTitles []string
+		// wantFailed 是创建失败的文章在请求中的下标
+		wantFailed []int32
+	}{
+		{name: "atomic", rq: newBatchRequest(false, "a", "b"), wantTitles: []string{"a", "b"}},
+		{name: "atomic rolls back", rq: newBatchRequest(false, "a", "fail", "b"), wantErr: true},
+		{name: "partial", rq: newBatchRequest(true, "a", "fail", "b"), wantTitles: []string{"a", "b"}, wantFailed: []int32{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &failingStore{IStore: fake.NewStore()}
+			b := New(s, nil)
+
+			ret, err := b.BatchCreate(ctx, tt.rq)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errno.ErrDBWrite)
+				assert.Contains(t, err.Error(), "posts[1]")
+			} else {
+				require.NoError(t, err)
+				require.Len(t, ret.GetPostIDs(), len(tt.rq.GetPosts()))
+				var failed []int32
+				for _, e := range ret.GetErrors() {
+					failed = append(failed, e.GetIndex())
+					assert.Empty(t, ret.GetPostIDs()[e.GetIndex()])
+					assert.Equal(t, errno.ErrDBWrite.Reason, e.GetReason())
+				}
+				assert.Equal(t, tt.wantFailed, failed)
+			}
+
+			_, postList, err := s.Post().List(ctx, where.NewWhere())
+			require.NoError(t, err)
+			var titles []string
+			for _, postM := range postList {
+				titles = append(titles, postM.Title)
+			}
+			assert.ElementsMatch(t, tt.wantTitles, titles)
+		})
+	}
+}
+
+func TestBatchGet(t *testing.T) {
+	ctx := contextx.WithUserID(context.Background(), "user-1")
+	b := New(fake.NewStore(), nil)
+
+	created, err := b.BatchCreate(ctx, newBatchRequest(false, "a", "b", "c"))
+	require.NoError(t, err)
+	ids := created.GetPostIDs()
+
+	ret, err := b.BatchGet(ctx, &apiv1.BatchGetPostsRequest{PostIDs: []string{ids[2], "post-missing", ids[0], ids[2]}})
+	require.NoError(t, err)
+	var titles []string
+	for _, post := range ret.GetPosts() {
+		titles = append(titles, post.GetTitle())
+	}
+	assert.Equal(t, []string{"c", "a", "c"}, titles)
+	assert.Equal(t, []string{"post-missing"}, ret.GetMissingPostIDs())
+}
@@ -8,14 +8,21 @@ package post
 
 import (
 	"context"
+	"strings"
 
 	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
 	"github.com/TobyIcetea/miniblog/internal/apiserver/pkg/conversion"
 	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
 	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/fieldmask"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
 	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/TobyIcetea/miniblog/pkg/auth"
 	"github.com/jinzhu/copier"
 	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm/clause"
 )
 
 // PostBiz 定义处理帖子请求所需的方法.
@@ -30,19 +37,25 @@ type PostBiz interface {
 }
 
 // PostExpansion 定义额外的帖子操作方法.
-type PostExpansion interface{}
+type PostExpansion interface {
+	Share(ctx context.Context, rq *apiv1.SharePostRequest) (*apiv1.SharePostResponse, error)
+	Unshare(ctx context.Context, rq *apiv1.UnsharePostRequest) (*apiv1.UnsharePostResponse, error)
+	BatchGet(ctx context.Context, rq *apiv1.BatchGetPostsRequest) (*apiv1.BatchGetPostsResponse, error)
+	BatchCreate(ctx context.Context, rq *apiv1.BatchCreatePostsRequest) (*apiv1.BatchCreatePostsResponse, error)
+}
 
 // postBiz 是 PostBiz 接口的实现.
 type postBiz struct {
 	store store.IStore
+	authz *auth.Authz
 }
 
 // 确保 postBiz 实现了 PostBiz 接口.
 var _ PostBiz = (*postBiz)(nil)
 
 // New 创建 postBiz 的实例.
-func New(store store.IStore) *postBiz {
-	return &postBiz{store: store}
+func New(store store.IStore, authz *auth.Authz) *postBiz {
+	return &postBiz{store: store, authz: authz}
 }
 
 // Create 实现 PostBiz 接口中的 Create 方法.
@@ -60,18 +73,18 @@ func (b *postBiz) Create(ctx context.Context, rq *apiv1.CreatePostRequest) (*api
 
 // Update 实现 PostBiz 接口中的 Update 方法.
 func (b *postBiz) Update(ctx context.Context, rq *apiv1.UpdatePostRequest) (*apiv1.UpdatePostResponse, error) {
-	whr := where.T(ctx).F("postID", rq.GetPostID())
-	postM, err := b.store.Post().Get(ctx, whr)
+	// 博客可能被分享给了当前用户，所以这里不用 where.T()，由 Authorize 检查权限
+	postM, err := b.store.Post().Get(ctx, where.F("postID", rq.GetPostID()))
 	if err != nil {
 		return nil, err
 	}
 
-	if rq.Title != nil {
-		postM.Title = rq.GetTitle()
+	if err := b.Authorize(ctx, postM, known.ActionWrite); err != nil {
+		return nil, err
 	}
 
-	if rq.Content != nil {
-		postM.Content = rq.GetContent()
+	if err := applyUpdate(postM, rq); err != nil {
+		return nil, err
 	}
 
 	if err := b.store.Post().Update(ctx, postM); err != nil {
@@ -82,29 +95,69 @@ func (b *postBiz) Update(ctx context.Context, rq *apiv1.UpdatePostRequest) (*api
 }
 
 // Delete 实现 PostBiz 接口中的 Delete 方法.
+// 只有博客的所有者可以删除博客，被分享的编辑者不能删除.
 func (b *postBiz) Delete(ctx context.Context, rq *apiv1.DeletePostRequest) (*apiv1.DeletePostResponse, error) {
 	whr := where.T(ctx).F("postID", rq.GetPostIDs())
+	_, postList, err := b.store.Post().List(ctx, whr)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := b.store.Post().Delete(ctx, whr); err != nil {
 		return nil, err
 	}
 
+	// 只清理当前用户自己博客上的授权，避免通过传入他人的博客 ID 撤销他人的分享
+	for _, postM := range postList {
+		if err := b.authz.RemoveResource(postResource(postM.PostID)); err != nil {
+			log.W(ctx).Errorw("Failed to remove post grants", "postID", postM.PostID, "err", err)
+		}
+	}
+
 	return &apiv1.DeletePostResponse{}, nil
 }
 
 // Get 实现 PostBiz 接口中的 Get 方法.
+// read_mask 不为空时只返回其中的字段.
 func (b *postBiz) Get(ctx context.Context, rq *apiv1.GetPostRequest) (*apiv1.GetPostResponse, error) {
-	whr := where.T(ctx).F("postID", rq.GetPostID())
-	postM, err := b.store.Post().Get(ctx, whr)
+	paths, err := fieldmask.Paths(rq.GetReadMask(), (&apiv1.Post{}).ProtoReflect().Descriptor())
+	if err != nil {
+		return nil, err
+	}
+
+	postM, err := b.store.Post().Get(ctx, where.F("postID", rq.GetPostID()))
 	if err != nil {
 		return nil, err
 	}
 
-	return &apiv1.GetPostResponse{Post: conversion.PostModelToPostV1(postM)}, nil
+	if err := b.Authorize(ctx, postM, known.ActionRead); err != nil {
+		return nil, err
+	}
+
+	post := conversion.PostModelToPostV1(postM)
+	fieldmask.Prune(post, paths)
+	return &apiv1.GetPostResponse{Post: post}, nil
 }
 
 // List 实现 PostBiz 接口中的 List 方法.
+// 返回当前用户自己的博客以及通过 SharePost 分享给当前用户的博客，read_mask 不为空时只返回其中的字段.
 func (b *postBiz) List(ctx context.Context, rq *apiv1.ListPostRequest) (*apiv1.ListPostResponse, error) {
+	paths, err := fieldmask.Paths(rq.GetReadMask(), (&apiv1.Post{}).ProtoReflect().Descriptor())
+	if err != nil {
+		return nil, err
+	}
+
+	sharedIDs, err := b.sharedPostIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
 	whr := where.T(ctx).P(int(rq.GetOffset()), int(rq.GetLimit()))
+	if len(sharedIDs) > 0 {
+		whr = where.P(int(rq.GetOffset()), int(rq.GetLimit())).C(clause.Or(
+			clause.Eq{Column: "userID", Value: contextx.UserID(ctx)},
+			clause.IN{Column: "postID", Values: sharedIDs},
+		))
+	}
 	count, postList, err := b.store.Post().List(ctx, whr)
 	if err != nil {
 		return nil, err
@@ -113,8 +166,56 @@ func (b *postBiz) List(ctx context.Context, rq *apiv1.ListPostRequest) (*apiv1.L
 	posts := make([]*apiv1.Post, 0, len(postList))
 	for _, post := range postList {
 		converted := conversion.PostModelToPostV1(post)
+		fieldmask.Prune(converted, paths)
 		posts = append(posts, converted)
 	}
 
 	return &apiv1.ListPostResponse{Posts: posts, TotalCount: count}, nil
 }
+
+// sharedPostIDs 返回分享给当前用户的博客 ID.
+func (b *postBiz) sharedPostIDs(ctx context.Context) ([]any, error) {
+	if b.authz == nil {
+		return nil, nil
+	}
+
+	resources, err := b.authz.SubjectResources(contextx.UserID(ctx), known.ResourcePostPrefix)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to list shared posts", "err", err)
+		return nil, errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+
+	ids := make([]any, 0, len(resources))
+	for _, resource := range resources {
+		ids = append(ids, strings.TrimPrefix(resource, known.ResourcePostPrefix))
+	}
+	return ids, nil
+}
+
+// applyUpdate 将更新请求中的字段应用到 postM 上.
+// 没有指定 update_mask 时只更新请求中设置了的字段，否则按照 AIP-134 更新掩码中的所有字段.
+func applyUpdate(postM *model.PostM, rq *apiv1.UpdatePostRequest) error {
+	paths, err := fieldmask.Paths(rq.GetUpdateMask(), rq.ProtoReflect().Descriptor(), known.PostUpdatableFields...)
+	if err != nil {
+		return err
+	}
+
+	if paths == nil {
+		if rq.Title != nil {
+			paths = append(paths, "title")
+		}
+		if rq.Content != nil {
+			paths = append(paths, "content")
+		}
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "title":
+			postM.Title = rq.GetTitle()
+		case "content":
+			postM.Content = rq.GetContent()
+		}
+	}
+	return nil
+}
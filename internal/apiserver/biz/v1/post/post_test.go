@@ -0,0 +1,92 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package post
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store/fake"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestUpdateMask(t *testing.T) {
+	ctx := contextx.WithUserID(context.Background(), "user-1")
+	tests := []struct {
+		name        string
+		rq          *apiv1.UpdatePostRequest
+		wantErr     bool
+		wantTitle   string
+		wantContent string
+	}{
+		{
+			name:        "without mask updates set fields",
+			rq:          &apiv1.UpdatePostRequest{Title: proto.String("new")},
+			wantTitle:   "new",
+			wantContent: "content",
+		},
+		{
+			name:        "mask ignores fields outside the mask",
+			rq:          &apiv1.UpdatePostRequest{Title: proto.String("new"), Content: proto.String("new"), UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"content"}}},
+			wantTitle:   "title",
+			wantContent: "new",
+		},
+		{
+			name:        "mask clears unset fields",
+			rq:          &apiv1.UpdatePostRequest{Title: proto.String("new"), UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"*"}}},
+			wantTitle:   "new",
+			wantContent: "",
+		},
+		{
+			name:    "immutable field",
+			rq:      &apiv1.UpdatePostRequest{UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"userID"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New(fake.NewStore(), nil)
+			created, err := b.Create(ctx, &apiv1.CreatePostRequest{Title: "title", Content: "content"})
+			require.NoError(t, err)
+
+			tt.rq.PostID = created.GetPostID()
+			_, err = b.Update(ctx, tt.rq)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errno.ErrInvalidArgument)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := b.Get(ctx, &apiv1.GetPostRequest{PostID: created.GetPostID()})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTitle, got.GetPost().GetTitle())
+			assert.Equal(t, tt.wantContent, got.GetPost().GetContent())
+		})
+	}
+}
+
+func TestReadMask(t *testing.T) {
+	ctx := contextx.WithUserID(context.Background(), "user-1")
+	b := New(fake.NewStore(), nil)
+	created, err := b.Create(ctx, &apiv1.CreatePostRequest{Title: "title", Content: "content"})
+	require.NoError(t, err)
+
+	mask := &fieldmaskpb.FieldMask{Paths: []string{"postID", "title"}}
+	got, err := b.Get(ctx, &apiv1.GetPostRequest{PostID: created.GetPostID(), ReadMask: mask})
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(&apiv1.Post{PostID: created.GetPostID(), Title: "title"}, got.GetPost()))
+
+	_, err = b.Get(ctx, &apiv1.GetPostRequest{PostID: created.GetPostID(), ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"author"}}})
+	assert.ErrorIs(t, err, errno.ErrInvalidArgument)
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package post
+
+import (
+	"context"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/pkg/audit"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// shareRoles 定义 SharePost 请求中的角色与 casbin 资源角色的对应关系.
+var shareRoles = map[string]string{
+	"viewer": known.RolePostViewer,
+	"editor": known.RolePostEditor,
+}
+
+// Authorize 检查当前用户是否可以对博客执行指定操作.
+// 博客的所有者拥有全部权限，其他用户需要通过 SharePost 获得授权，管理员可以操作所有博客，
+// 组织的所有者和管理员可以查看和修改组织内的所有博客.
+// 没有读取权限时返回 ErrPostNotFound，避免泄露博客是否存在.
+func (b *postBiz) Authorize(ctx context.Context, postM *model.PostM, action string) error {
+	userID := contextx.UserID(ctx)
+	if postM.UserID == userID {
+		return nil
+	}
+
+	allowed, err := b.authz.AuthorizeResource(userID, postM.TenantID, postResource(postM.PostID), action)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to authorize post access", "postID", postM.PostID, "action", action, "err", err)
+		return errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+	if allowed {
+		return nil
+	}
+
+	if action != known.ActionRead {
+		if canRead, _ := b.authz.AuthorizeResource(userID, postM.TenantID, postResource(postM.PostID), known.ActionRead); canRead {
+			return errno.ErrPermissionDenied
+		}
+	}
+	return errno.ErrPostNotFound
+}
+
+// Share 将博客分享给其他用户，被分享的用户可以作为查看者或者编辑者访问该博客.
+// 再次分享给同一个用户会替换该用户之前的角色.
+func (b *postBiz) Share(ctx context.Context, rq *apiv1.SharePostRequest) (*apiv1.SharePostResponse, error) {
+	postM, err := b.store.Post().Get(ctx, where.F("postID", rq.GetPostID()))
+	if err != nil {
+		return nil, err
+	}
+	if err := b.Authorize(ctx, postM, known.ActionShare); err != nil {
+		return nil, err
+	}
+	if rq.GetUserID() == postM.UserID {
+		return nil, errno.WithMessage(errno.ErrInvalidArgument, "a post cannot be shared with its owner")
+	}
+	if _, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID())); err != nil {
+		return nil, err
+	}
+	// 组织内的博客只能分享给组织成员，其他用户无法访问该组织
+	if postM.TenantID != known.DefaultTenantID {
+		roles, err := b.authz.TenantRoles(rq.GetUserID(), postM.TenantID)
+		if err != nil {
+			return nil, errno.WithMessage(errno.ErrInternal, "%v", err)
+		}
+		if len(roles) == 0 {
+			return nil, errno.WithMessage(errno.ErrInvalidArgument, "posts can only be shared with members of the organization")
+		}
+	}
+
+	if _, err := b.authz.GrantResourceRole(rq.GetUserID(), shareRoles[rq.GetRole()], postResource(postM.PostID)); err != nil {
+		log.W(ctx).Errorw("Failed to grant post role", "postID", postM.PostID, "user", rq.GetUserID(), "role", rq.GetRole(), "err", err)
+		return nil, errno.WithMessage(errno.ErrAddRole, "%v", err)
+	}
+
+	audit.Record(ctx, b.store.Audit(), "post.share", postResource(postM.PostID), map[string]string{"userID": rq.GetUserID(), "role": rq.GetRole()})
+	return &apiv1.SharePostResponse{}, nil
+}
+
+// Unshare 撤销分享给其他用户的博客访问权限.
+func (b *postBiz) Unshare(ctx context.Context, rq *apiv1.UnsharePostRequest) (*apiv1.UnsharePostResponse, error) {
+	postM, err := b.store.Post().Get(ctx, where.F("postID", rq.GetPostID()))
+	if err != nil {
+		return nil, err
+	}
+	if err := b.Authorize(ctx, postM, known.ActionShare); err != nil {
+		return nil, err
+	}
+
+	ok, err := b.authz.RevokeResourceRoles(rq.GetUserID(), postResource(postM.PostID))
+	if err != nil {
+		log.W(ctx).Errorw("Failed to revoke post roles", "postID", postM.PostID, "user", rq.GetUserID(), "err", err)
+		return nil, errno.WithMessage(errno.ErrRemoveRole, "%v", err)
+	}
+	if !ok {
+		return nil, errno.ErrPostShareNotFound
+	}
+
+	audit.Record(ctx, b.store.Audit(), "post.unshare", postResource(postM.PostID), map[string]string{"userID": rq.GetUserID()})
+	return &apiv1.UnsharePostResponse{}, nil
+}
+
+// postResource 返回博客在资源级别访问控制中的资源名称.
+func postResource(postID string) string {
+	return known.ResourcePostPrefix + postID
+}
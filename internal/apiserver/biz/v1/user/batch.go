@@ -0,0 +1,74 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package user
+
+import (
+	"context"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/pkg/conversion"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// BatchDelete 批量删除用户，只有管理员可以调用.
+// 在一个事务中删除所有存在的用户及其历史密码，不存在的用户和清理授权失败的用户在响应的 errors 中返回.
+func (b *userBiz) BatchDelete(ctx context.Context, rq *apiv1.BatchDeleteUsersRequest) (*apiv1.BatchDeleteUsersResponse, error) {
+	// 模拟登录时不允许删除用户
+	if contextx.Impersonating(ctx) {
+		return nil, errno.ErrImpersonationForbidden
+	}
+
+	_, userList, err := b.store.User().List(ctx, where.F("userID", rq.GetUserIDs()))
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(userList))
+	for _, userM := range userList {
+		userIDs = append(userIDs, userM.UserID)
+	}
+	if len(userIDs) > 0 {
+		err = b.store.TX(ctx, func(ctx context.Context) error {
+			if err := b.store.User().Delete(ctx, where.F("userID", userIDs)); err != nil {
+				return err
+			}
+			return b.store.PasswordHistory().Delete(ctx, where.F("userID", userIDs))
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deleted := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		deleted[userID] = false
+	}
+
+	ret := &apiv1.BatchDeleteUsersResponse{}
+	for i, userID := range rq.GetUserIDs() {
+		done, ok := deleted[userID]
+		if !ok {
+			ret.Errors = append(ret.Errors, conversion.ErrorToBatchItemErrorV1(i, userID, errno.ErrUserNotFound))
+			continue
+		}
+		// 重复的用户 ID 只处理一次
+		if done {
+			continue
+		}
+		deleted[userID] = true
+
+		if err := b.removeGrants(ctx, userID); err != nil {
+			ret.Errors = append(ret.Errors, conversion.ErrorToBatchItemErrorV1(i, userID, err))
+			continue
+		}
+		ret.DeletedUserIDs = append(ret.DeletedUserIDs, userID)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package user
+
+import (
+	"context"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateCaptcha 签发人机验证挑战，并告知客户端当前是否需要完成人机验证.
+func (b *userBiz) CreateCaptcha(ctx context.Context, rq *apiv1.CreateCaptchaRequest) (*apiv1.CreateCaptchaResponse, error) {
+	challenge, required, err := b.captcha.Issue(ctx, rq.GetAction(), contextx.ClientIP(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv1.CreateCaptchaResponse{
+		CaptchaID:  challenge.ID,
+		Provider:   challenge.Provider,
+		Difficulty: int32(challenge.Difficulty),
+		ExpireAt:   timestamppb.New(challenge.ExpireAt),
+		Required:   required,
+	}, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package user
+
+import (
+	"context"
+	"slices"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/pkg/audit"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/TobyIcetea/miniblog/pkg/auth"
+	"github.com/TobyIcetea/miniblog/pkg/token"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Impersonate 为管理员签发以指定用户身份访问接口的短期令牌.
+// 令牌中同时记录被模拟的用户和实际操作的管理员，模拟期间的每个请求都会写入审计日志.
+func (b *userBiz) Impersonate(ctx context.Context, rq *apiv1.ImpersonateRequest) (*apiv1.ImpersonateResponse, error) {
+	// 不允许在模拟登录期间再次模拟其他用户，避免实际操作者被隐藏
+	if contextx.Impersonating(ctx) {
+		return nil, errno.ErrImpersonationForbidden
+	}
+
+	actorID := contextx.UserID(ctx)
+	if rq.GetUserID() == actorID {
+		return nil, errno.WithMessage(errno.ErrInvalidArgument, "cannot impersonate yourself")
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID()))
+	if err != nil {
+		return nil, err
+	}
+
+	// 管理员账号不能被模拟，模拟登录只用于以普通用户的权限复现问题
+	roles, err := b.authz.GetImplicitRolesForUser(userM.UserID, auth.DomainAll)
+	if err != nil {
+		return nil, errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+	if slices.Contains(roles, known.RoleAdmin) {
+		return nil, errno.WithMessage(errno.ErrPermissionDenied, "cannot impersonate an administrator")
+	}
+
+	tokenStr, expireAt, err := token.SignImpersonation(userM.UserID, actorID, known.ImpersonationExpiration)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to sign impersonation token", "err", err)
+		return nil, errno.ErrSignToken
+	}
+
+	log.W(ctx).Warnw("Impersonation token issued", "actorID", actorID, "subject", userM.UserID, "reason", rq.GetReason())
+	audit.Record(ctx, b.store.Audit(), "user.impersonate", known.ResourceUserPrefix+userM.UserID, map[string]any{
+		"reason":   rq.GetReason(),
+		"expireAt": expireAt,
+	})
+
+	return &apiv1.ImpersonateResponse{Token: tokenStr, ExpireAt: timestamppb.New(expireAt)}, nil
+}
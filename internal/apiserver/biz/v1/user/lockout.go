@@ -0,0 +1,47 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package user
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/TobyIcetea/miniblog/pkg/auth"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// dummyPasswordHash 返回一个固定密码的哈希值，用于在用户不存在时执行等价的密码比对.
+// 使用与 auth.Encrypt 相同的参数生成，保证比对耗时与真实用户一致.
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hashed, _ := auth.Encrypt("miniblog-dummy-password")
+	return hashed
+})
+
+// Unlock 清除用户的登录失败记录，解除锁定.
+func (b *userBiz) Unlock(ctx context.Context, rq *apiv1.UnlockUserRequest) (*apiv1.UnlockUserResponse, error) {
+	userM, err := b.store.User().Get(ctx, where.F("userID", rq.GetUserID()))
+	if err != nil {
+		return nil, err
+	}
+
+	b.guard.Unlock(userM.Username)
+
+	log.W(ctx).Infow("User has been unlocked", "userID", userM.UserID, "username", userM.Username)
+	return &apiv1.UnlockUserResponse{}, nil
+}
+
+// tooManyLoginAttempts 返回登录被限制的错误，并在元数据中携带需要等待的秒数.
+func tooManyLoginAttempts(wait time.Duration) error {
+	err := *errno.ErrTooManyLoginAttempts
+	return err.KV("retryAfter", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	"github.com/TobyIcetea/miniblog/internal/pkg/oidc"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/TobyIcetea/miniblog/pkg/auth"
+	"github.com/TobyIcetea/miniblog/pkg/token"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// OIDCAuthorize 生成 state、nonce 和 PKCE 校验码，返回身份提供方的授权地址.
+// 这些值签名后作为会话令牌返回给客户端保存，服务端不需要保存登录会话.
+// 会话令牌不会出现在回调 URL 中，所以即使授权码在重定向过程中被截获，攻击者也无法完成 PKCE 校验.
+func (b *userBiz) OIDCAuthorize(ctx context.Context, rq *apiv1.OIDCAuthorizeRequest) (*apiv1.OIDCAuthorizeResponse, error) {
+	provider, err := b.oidcProvider(ctx, rq.GetProvider())
+	if err != nil {
+		return nil, err
+	}
+
+	state, nonce, verifier := oidc.NewState(), oidc.NewState(), oidc.NewVerifier()
+	sessionToken, _, err := token.SignScopedClaims(known.OIDCSessionScope, map[string]string{
+		"provider": provider.Name(),
+		"state":    state,
+		"nonce":    nonce,
+		"verifier": verifier,
+	}, b.oidc.SessionExpiration())
+	if err != nil {
+		log.W(ctx).Errorw("Failed to sign oidc session token", "err", err)
+		return nil, errno.ErrSignToken
+	}
+
+	return &apiv1.OIDCAuthorizeResponse{
+		AuthorizationURL: provider.AuthCodeURL(state, nonce, verifier),
+		SessionToken:     sessionToken,
+	}, nil
+}
+
+// OIDCCallback 校验会话令牌和 state，使用授权码换取并校验 ID Token，
+// 然后关联或创建本地用户，最后签发 miniblog 的访问令牌.
+// 用户开启了二次验证时不签发访问令牌，而是返回挑战令牌，由 LoginVerify 完成登录.
+func (b *userBiz) OIDCCallback(ctx context.Context, rq *apiv1.OIDCCallbackRequest) (*apiv1.OIDCCallbackResponse, error) {
+	session, err := token.ParseScopedClaims(rq.GetSessionToken(), known.OIDCSessionScope)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to parse oidc session token", "err", err)
+		return nil, errno.ErrOIDCSessionInvalid
+	}
+	if session["provider"] != rq.GetProvider() || subtle.ConstantTimeCompare([]byte(session["state"]), []byte(rq.GetState())) != 1 {
+		return nil, errno.ErrOIDCSessionInvalid
+	}
+
+	provider, err := b.oidcProvider(ctx, rq.GetProvider())
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := provider.Exchange(ctx, rq.GetCode(), session["verifier"], session["nonce"])
+	if err != nil {
+		log.W(ctx).Errorw("Failed to exchange oidc authorization code", "provider", rq.GetProvider(), "err", err)
+		return nil, errno.ErrOIDCLoginFailed
+	}
+
+	userM, created, err := b.resolveOIDCUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	// 与密码登录一致，开启了二次验证的用户只返回挑战令牌，由 LoginVerify 完成登录
+	challenge, err := b.mfaChallenge(ctx, userM.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != "" {
+		log.W(ctx).Infow("User passed oidc login, second factor required", "provider", identity.Provider, "subject", identity.Subject, "userID", userM.UserID)
+		return &apiv1.OIDCCallbackResponse{UserID: userM.UserID, Created: created, MfaRequired: true, ChallengeToken: challenge}, nil
+	}
+
+	tokenStr, expireAt, err := token.Sign(userM.UserID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to sign token", "err", err)
+		return nil, errno.ErrSignToken
+	}
+
+	log.W(ctx).Infow("User logged in via oidc", "provider", identity.Provider, "subject", identity.Subject, "userID", userM.UserID, "created", created)
+	return &apiv1.OIDCCallbackResponse{
+		Token:    tokenStr,
+		ExpireAt: timestamppb.New(expireAt),
+		UserID:   userM.UserID,
+		Created:  created,
+	}, nil
+}
+
+// oidcProvider 返回指定名称的身份提供方，并将错误转换为业务错误码.
+func (b *userBiz) oidcProvider(ctx context.Context, name string) (*oidc.Provider, error) {
+	provider, err := b.oidc.Provider(ctx, name)
+	if errors.Is(err, oidc.ErrProviderNotFound) {
+		return nil, errno.ErrOIDCProviderNotFound
+	}
+	if err != nil {
+		log.W(ctx).Errorw("Failed to discover oidc provider", "provider", name, "err", err)
+		return nil, errno.ErrOIDCProviderUnavailable
+	}
+
+	return provider, nil
+}
+
+// resolveOIDCUser 查找第三方身份关联的本地用户，查找顺序为：
+//  1. 已经关联过的身份（provider + subject）；
+//  2. 身份提供方确认过的邮箱，并且只有唯一的本地用户使用该邮箱；
+//  3. 以上都不满足时创建一个新用户.
+//
+// 未经验证的邮箱不会用于关联已有用户，否则任何人都可以在身份提供方填写他人的邮箱来接管账号.
+func (b *userBiz) resolveOIDCUser(ctx context.Context, identity *oidc.Identity) (*model.UserM, bool, error) {
+	identityM, err := b.store.Identity().Get(ctx, where.F("provider", identity.Provider, "subject", identity.Subject))
+	switch {
+	case err == nil:
+		userM, err := b.store.User().Get(ctx, where.F("userID", identityM.UserID))
+		return userM, false, err
+	case !errors.Is(err, errno.ErrIdentityNotFound):
+		return nil, false, err
+	}
+
+	if identity.EmailVerified && identity.Email != "" {
+		count, users, err := b.store.User().List(ctx, where.F("email", identity.Email))
+		if err != nil {
+			return nil, false, err
+		}
+		if count == 1 {
+			if err := b.linkIdentity(ctx, users[0], identity); err != nil {
+				return nil, false, err
+			}
+			return users[0], false, nil
+		}
+	}
+
+	userM, err := b.createOIDCUser(ctx, identity)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return userM, true, nil
+}
+
+// linkIdentity 将第三方身份关联到已有用户.
+func (b *userBiz) linkIdentity(ctx context.Context, userM *model.UserM, identity *oidc.Identity) error {
+	return b.store.Identity().Create(ctx, &model.UserIdentityM{
+		UserID:   userM.UserID,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	})
+}
+
+// createOIDCUser 根据第三方身份创建新用户，并赋予普通用户角色.
+// 新用户的密码是随机生成的，用户只能通过第三方登录，或者通过重置密码设置自己的密码.
+func (b *userBiz) createOIDCUser(ctx context.Context, identity *oidc.Identity) (*model.UserM, error) {
+	userM := &model.UserM{
+		Username:      oidcUsername(identity),
+		Password:      randomHex(32),
+		Nickname:      truncate(identity.Name, 29),
+		Email:         identity.Email,
+		EmailVerified: identity.EmailVerified,
+		// user 表的 phone 字段有唯一索引，第三方登录创建的用户没有手机号，使用随机占位值避免冲突
+		Phone: "oidc_" + randomHex(11),
+	}
+
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.store.User().Create(ctx, userM); err != nil {
+			return err
+		}
+		return b.linkIdentity(ctx, userM, identity)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := b.authz.AddGroupingPolicy(userM.UserID, known.RoleUser, auth.DomainAll); err != nil {
+		log.W(ctx).Errorw("Failed to add grouping policy for user", "user", userM.UserID, "role", known.RoleUser)
+		return nil, errno.WithMessage(errno.ErrAddRole, "%v", err)
+	}
+
+	return userM, nil
+}
+
+// oidcUsername 根据第三方身份生成一个符合用户名规则的用户名：
+// 只包含字母、数字和下划线，长度不超过 20，并追加随机后缀避免重名.
+func oidcUsername(identity *oidc.Identity) string {
+	base := identity.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	base = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return -1
+		}
+	}, base)
+	if len(base) < 3 {
+		base = "user"
+	}
+
+	return truncate(base, 13) + "_" + randomHex(6)
+}
+
+// randomHex 返回一个长度为 n 的随机十六进制字符串.
+func randomHex(n int) string {
+	raw := make([]byte, (n+1)/2)
+	_, _ = rand.Read(raw)
+	return hex.EncodeToString(raw)[:n]
+}
+
+// truncate 将字符串截断为最多 n 个字符.
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
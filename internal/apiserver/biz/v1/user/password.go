@@ -0,0 +1,281 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/url"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mail"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/TobyIcetea/miniblog/pkg/auth"
+	"github.com/TobyIcetea/miniblog/pkg/token"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// maxPendingMails 是后台同时发送的密码重置邮件的最大数量.
+const maxPendingMails = 16
+
+// 重置密码和验证邮箱使用的令牌都绑定了签发时的状态指纹：
+// 重置令牌绑定当前的密码哈希，密码修改后令牌自动失效；
+// 验证令牌绑定当前的邮箱地址，邮箱验证通过或邮箱被修改后令牌不再可用.
+// 这样不需要额外的存储就可以保证令牌只能使用一次.
+
+// RequestPasswordReset 向邮箱对应的所有用户发送密码重置邮件.
+// 为了避免通过该接口探测邮箱是否已注册，无论邮箱是否存在都返回成功，并且邮件在后台异步发送.
+func (b *userBiz) RequestPasswordReset(ctx context.Context, rq *apiv1.RequestPasswordResetRequest) (*apiv1.RequestPasswordResetResponse, error) {
+	_, userList, err := b.store.User().List(ctx, where.F("email", rq.GetEmail()))
+	if err != nil {
+		return nil, err
+	}
+
+	// 异步发送邮件时，请求上下文可能已经结束，所以这里使用不会被取消的上下文.
+	// 同时发送的邮件数量受 mailSlots 限制，没有空闲名额时放弃发送，避免大量请求创建无限多的 goroutine
+	sendCtx := context.WithoutCancel(ctx)
+	for _, userM := range userList {
+		select {
+		case b.mailSlots <- struct{}{}:
+		default:
+			log.W(ctx).Warnw("Too many pending password reset emails, dropping", "userID", userM.UserID)
+			continue
+		}
+
+		go func(userM *model.UserM) {
+			defer func() { <-b.mailSlots }()
+			if err := b.sendPasswordResetEmail(sendCtx, userM); err != nil {
+				log.W(sendCtx).Errorw("Failed to send password reset email", "userID", userM.UserID, "err", err)
+			}
+		}(userM)
+	}
+
+	return &apiv1.RequestPasswordResetResponse{}, nil
+}
+
+// ResetPassword 校验重置令牌并设置新密码.
+func (b *userBiz) ResetPassword(ctx context.Context, rq *apiv1.ResetPasswordRequest) (*apiv1.ResetPasswordResponse, error) {
+	userID, state, err := token.ParseStateBound(rq.GetToken(), known.PasswordResetScope)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to parse password reset token", "err", err)
+		return nil, errno.ErrPasswordResetTokenInvalid
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", userID))
+	if err != nil {
+		return nil, errno.ErrPasswordResetTokenInvalid
+	}
+
+	// 密码已经被修改过（包括已经使用该令牌重置过），令牌失效
+	if !stateMatches(state, userM.Password) {
+		return nil, errno.ErrPasswordResetTokenInvalid
+	}
+
+	// 重置密码时才能确定用户，所以在这里检查新密码是否包含用户名以及是否与历史密码重复
+	if err := b.policy.Check(rq.GetNewPassword(), userM.Username); err != nil {
+		return nil, err
+	}
+	if err := b.checkPasswordHistory(ctx, userM, rq.GetNewPassword()); err != nil {
+		return nil, err
+	}
+	// 条件更新保证令牌只能使用一次：并发使用同一个令牌时，只有一个请求能够修改密码
+	if err := b.setPassword(ctx, userM, rq.GetNewPassword(), errno.ErrPasswordResetTokenInvalid); err != nil {
+		return nil, err
+	}
+
+	log.W(ctx).Infow("Password has been reset", "userID", userID)
+	return &apiv1.ResetPasswordResponse{}, nil
+}
+
+// SendVerificationEmail 向当前用户的邮箱发送验证邮件.
+func (b *userBiz) SendVerificationEmail(ctx context.Context, rq *apiv1.SendVerificationEmailRequest) (*apiv1.SendVerificationEmailResponse, error) {
+	userM, err := b.store.User().Get(ctx, where.F("userID", contextx.UserID(ctx)))
+	if err != nil {
+		return nil, err
+	}
+
+	if userM.EmailVerified {
+		return nil, errno.ErrEmailAlreadyVerified
+	}
+
+	tokenStr, _, err := token.SignStateBound(known.EmailVerificationScope, userM.UserID, fingerprint(userM.Email), known.EmailVerificationExpiration)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to sign email verification token", "err", err)
+		return nil, errno.ErrSignToken
+	}
+
+	msg, err := mail.Render(mail.TemplateEmailVerification, map[string]any{
+		"Username":  userM.Username,
+		"Email":     userM.Email,
+		"Link":      b.mailOpts.Link("/verify-email", url.Values{"token": {tokenStr}}),
+		"ExpiresIn": known.EmailVerificationExpiration.String(),
+	}, userM.Email)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to render email verification mail", "err", err)
+		return nil, errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+
+	if err := b.mailer.Send(ctx, msg); err != nil {
+		log.W(ctx).Errorw("Failed to send email verification mail", "err", err)
+		return nil, errno.ErrSendMail
+	}
+
+	return &apiv1.SendVerificationEmailResponse{}, nil
+}
+
+// VerifyEmail 校验验证令牌并将用户邮箱标记为已验证.
+func (b *userBiz) VerifyEmail(ctx context.Context, rq *apiv1.VerifyEmailRequest) (*apiv1.VerifyEmailResponse, error) {
+	userID, state, err := token.ParseStateBound(rq.GetToken(), known.EmailVerificationScope)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to parse email verification token", "err", err)
+		return nil, errno.ErrEmailVerificationTokenInvalid
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", userID))
+	if err != nil {
+		return nil, errno.ErrEmailVerificationTokenInvalid
+	}
+
+	// 签发令牌后邮箱被修改过，令牌失效
+	if !stateMatches(state, userM.Email) {
+		return nil, errno.ErrEmailVerificationTokenInvalid
+	}
+	if userM.EmailVerified {
+		return nil, errno.ErrEmailAlreadyVerified
+	}
+
+	userM.EmailVerified = true
+	if err := b.store.User().Update(ctx, userM); err != nil {
+		return nil, err
+	}
+
+	return &apiv1.VerifyEmailResponse{}, nil
+}
+
+// sendPasswordResetEmail 为指定用户签发重置令牌并发送密码重置邮件.
+func (b *userBiz) sendPasswordResetEmail(ctx context.Context, userM *model.UserM) error {
+	tokenStr, _, err := token.SignStateBound(known.PasswordResetScope, userM.UserID, fingerprint(userM.Password), known.PasswordResetExpiration)
+	if err != nil {
+		return err
+	}
+
+	msg, err := mail.Render(mail.TemplatePasswordReset, map[string]any{
+		"Username":  userM.Username,
+		"Link":      b.mailOpts.Link("/reset-password", url.Values{"token": {tokenStr}}),
+		"ExpiresIn": known.PasswordResetExpiration.String(),
+	}, userM.Email)
+	if err != nil {
+		return err
+	}
+
+	return b.mailer.Send(ctx, msg)
+}
+
+// fingerprint 计算令牌中绑定的状态指纹，令牌中不直接保存密码哈希或邮箱等敏感信息.
+func fingerprint(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:16])
+}
+
+// stateMatches 判断令牌中的状态指纹是否与当前状态一致.
+func stateMatches(state string, current string) bool {
+	return state != "" && subtle.ConstantTimeCompare([]byte(state), []byte(fingerprint(current))) == 1
+}
+
+// checkPasswordHistory 校验新密码没有与当前密码以及最近使用过的历史密码重复.
+func (b *userBiz) checkPasswordHistory(ctx context.Context, userM *model.UserM, newPassword string) error {
+	size := b.policy.HistorySize()
+	if size <= 0 {
+		return nil
+	}
+
+	if auth.Compare(userM.Password, newPassword) == nil {
+		return errno.ErrPasswordReused
+	}
+	if size == 1 {
+		return nil
+	}
+
+	_, histories, err := b.store.PasswordHistory().List(ctx, where.F("userID", userM.UserID).L(size-1))
+	if err != nil {
+		return err
+	}
+	for _, history := range histories {
+		if auth.Compare(history.Password, newPassword) == nil {
+			return errno.ErrPasswordReused
+		}
+	}
+	return nil
+}
+
+// setPassword 加密并保存用户的新密码，同时将旧密码记录到历史密码中.
+// 只有用户当前的密码仍然是 userM 中的密码时才会修改，密码已经被其他请求修改时返回 conflict.
+func (b *userBiz) setPassword(ctx context.Context, userM *model.UserM, newPassword string, conflict error) error {
+	hashed, err := auth.Encrypt(newPassword)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to encrypt password", "err", err)
+		return errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+
+	oldPassword := userM.Password
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		ok, err := b.store.User().UpdatePassword(ctx, userM.UserID, oldPassword, hashed)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			log.W(ctx).Warnw("Password was changed concurrently", "userID", userM.UserID)
+			return conflict
+		}
+
+		// 当前密码已经参与了历史密码检查，所以只需要保存 HistorySize - 1 条历史密码
+		keep := b.policy.HistorySize() - 1
+		if keep <= 0 {
+			return b.store.PasswordHistory().Delete(ctx, where.F("userID", userM.UserID))
+		}
+		if err := b.store.PasswordHistory().Create(ctx, &model.PasswordHistoryM{UserID: userM.UserID, Password: oldPassword}); err != nil {
+			return err
+		}
+		return b.store.PasswordHistory().Prune(ctx, userM.UserID, keep)
+	})
+	if err != nil {
+		return err
+	}
+
+	userM.Password = hashed
+	return nil
+}
+
+// rehashPassword 使用当前配置的加密算法和参数重新加密用户密码.
+// 重新加密失败不影响登录，下次登录时会再次尝试.
+// 注意：密码重置令牌绑定了密码密文，重新加密后未使用的重置令牌会失效.
+func (b *userBiz) rehashPassword(ctx context.Context, userM *model.UserM, plainPassword string) {
+	hashed, err := auth.Encrypt(plainPassword)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to rehash password", "userID", userM.UserID, "err", err)
+		return
+	}
+
+	// 只在密码没有被其他请求修改时保存，避免用旧密码覆盖并发设置的新密码
+	ok, err := b.store.User().UpdatePassword(ctx, userM.UserID, userM.Password, hashed)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to save rehashed password", "userID", userM.UserID, "err", err)
+		return
+	}
+	if !ok {
+		log.W(ctx).Infow("Password was changed concurrently, skipping rehash", "userID", userM.UserID)
+		return
+	}
+	userM.Password = hashed
+	log.W(ctx).Infow("Password has been rehashed with current parameters", "userID", userM.UserID)
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store/fake"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/lockout"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mail"
+	"github.com/TobyIcetea/miniblog/internal/pkg/password"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/TobyIcetea/miniblog/pkg/auth"
+	"github.com/TobyIcetea/miniblog/pkg/token"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gatedMailer 统计正在发送和已经发送的邮件数量，可以通过 gate 阻塞发送.
+type gatedMailer struct {
+	gate chan struct{}
+
+	mu       sync.Mutex
+	inflight int
+	peak     int
+	sent     int
+}
+
+func (m *gatedMailer) Send(ctx context.Context, msg *mail.Message) error {
+	m.mu.Lock()
+	m.inflight++
+	m.peak = max(m.peak, m.inflight)
+	m.mu.Unlock()
+
+	if m.gate != nil {
+		<-m.gate
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inflight--
+	m.sent++
+	return nil
+}
+
+// stats 返回正在发送的邮件数量、同时发送的峰值和已经发送的邮件数量.
+func (m *gatedMailer) stats() (inflight int, peak int, sent int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.inflight, m.peak, m.sent
+}
+
+// newTestBiz 基于内存 Store 创建 userBiz.
+func newTestBiz(t *testing.T, mailer mail.Mailer) *userBiz {
+	t.Helper()
+
+	token.Init("Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5", known.XUserID, 2*time.Hour)
+	policy, err := password.NewPolicy(password.NewOptions())
+	require.NoError(t, err)
+	if mailer == nil {
+		mailer = &gatedMailer{}
+	}
+	return New(fake.NewStore(), nil, mailer, mail.NewOptions(), lockout.NewGuard(lockout.NewOptions()), nil, policy, nil)
+}
+
+// createUser 在 b 的 Store 中创建一个密码为 miniblog1234 的用户.
+func createUser(t *testing.T, b *userBiz, username string, email string, phone string) *model.UserM {
+	t.Helper()
+
+	userM := &model.UserM{Username: username, Password: "miniblog1234", Nickname: username, Email: email, Phone: phone}
+	require.NoError(t, b.store.User().Create(context.Background(), userM))
+	return userM
+}
+
+func TestResetPasswordSingleUse(t *testing.T) {
+	b := newTestBiz(t, nil)
+	userM := createUser(t, b, "alice", "alice@example.com", "18130000001")
+	tokenStr, _, err := token.SignStateBound(known.PasswordResetScope, userM.UserID, fingerprint(userM.Password), known.PasswordResetExpiration)
+	require.NoError(t, err)
+
+	// 并发使用同一个重置令牌时只有一个请求能够修改密码
+	const n = 5
+	var succeeded atomic.Int64
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := b.ResetPassword(context.Background(), &apiv1.ResetPasswordRequest{Token: tokenStr, NewPassword: fmt.Sprintf("newpassword%d", i)})
+			if err == nil {
+				succeeded.Add(1)
+				return
+			}
+			assert.ErrorIs(t, err, errno.ErrPasswordResetTokenInvalid)
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 1, succeeded.Load())
+
+	got, err := b.store.User().Get(context.Background(), where.F("userID", userM.UserID))
+	require.NoError(t, err)
+	assert.Error(t, auth.Compare(got.Password, "miniblog1234"))
+	_, histories, err := b.store.PasswordHistory().List(context.Background(), where.F("userID", userM.UserID))
+	require.NoError(t, err)
+	assert.Len(t, histories, 1)
+}
+
+func TestRequestPasswordResetBounded(t *testing.T) {
+	mailer := &gatedMailer{gate: make(chan struct{})}
+	b := newTestBiz(t, mailer)
+	for i := range maxPendingMails + 4 {
+		createUser(t, b, fmt.Sprintf("user%d", i), "shared@example.com", fmt.Sprintf("181300001%02d", i))
+	}
+
+	_, err := b.RequestPasswordReset(context.Background(), &apiv1.RequestPasswordResetRequest{Email: "shared@example.com"})
+	require.NoError(t, err)
+
+	// 超过上限的邮件被丢弃，后台同时发送的邮件数量不超过上限
+	require.Eventually(t, func() bool {
+		inflight, _, _ := mailer.stats()
+		return inflight == maxPendingMails
+	}, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	close(mailer.gate)
+	require.Eventually(t, func() bool { return len(b.mailSlots) == 0 }, time.Second, time.Millisecond)
+
+	_, peak, sent := mailer.stats()
+	assert.Equal(t, maxPendingMails, peak)
+	assert.Equal(t, maxPendingMails, sent)
+}
@@ -0,0 +1,210 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/TobyIcetea/miniblog/pkg/auth"
+	"github.com/TobyIcetea/miniblog/pkg/token"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// LoginVerify 使用 Login 返回的挑战令牌和动态码（或恢复码）完成二次验证登录.
+func (b *userBiz) LoginVerify(ctx context.Context, rq *apiv1.LoginVerifyRequest) (*apiv1.LoginVerifyResponse, error) {
+	userID, err := token.ParseScoped(rq.GetChallengeToken(), known.MFAChallengeScope)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to parse mfa challenge token", "err", err)
+		return nil, errno.ErrMFAChallengeInvalid
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("userID", userID))
+	if err != nil {
+		return nil, errno.ErrMFAChallengeInvalid
+	}
+
+	// 动态码错误与密码错误一样计入账号的失败次数，避免在挑战令牌有效期内暴力猜测动态码
+	clientIP := contextx.ClientIP(ctx)
+	if wait, ok := b.guard.Check(userM.Username, clientIP); !ok {
+		log.W(ctx).Warnw("Second factor verification rejected due to too many failed attempts", "userID", userID, "clientIP", clientIP, "retryAfter", wait)
+		return nil, tooManyLoginAttempts(wait)
+	}
+
+	totpM, err := b.store.TOTP().Get(ctx, where.F("userID", userID))
+	if err != nil || !totpM.Enabled {
+		return nil, errno.ErrMFAChallengeInvalid
+	}
+
+	if err := b.verifySecondFactor(ctx, totpM, rq.GetCode()); err != nil {
+		if errors.Is(err, errno.ErrTOTPCodeInvalid) {
+			b.guard.Fail(userM.Username, clientIP)
+		}
+		return nil, err
+	}
+	b.guard.Succeed(userM.Username)
+
+	tokenStr, expireAt, err := token.Sign(userID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to sign token", "err", err)
+		return nil, errno.ErrSignToken
+	}
+
+	return &apiv1.LoginVerifyResponse{Token: tokenStr, ExpireAt: timestamppb.New(expireAt)}, nil
+}
+
+// EnrollTOTP 为当前用户生成一个新的 TOTP 密钥，此时二次验证还未启用，需要调用 EnableTOTP 确认.
+func (b *userBiz) EnrollTOTP(ctx context.Context, rq *apiv1.EnrollTOTPRequest) (*apiv1.EnrollTOTPResponse, error) {
+	// 模拟登录时不允许为被模拟用户注册二次验证
+	if contextx.Impersonating(ctx) {
+		return nil, errno.ErrImpersonationForbidden
+	}
+
+	userID := contextx.UserID(ctx)
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		log.W(ctx).Errorw("Failed to generate totp secret", "err", err)
+		return nil, errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+
+	totpM, err := b.store.TOTP().Get(ctx, where.F("userID", userID))
+	switch {
+	case errors.Is(err, errno.ErrTOTPNotEnrolled):
+		totpM = &model.UserTOTPM{UserID: userID, Secret: secret}
+		if err := b.store.TOTP().Create(ctx, totpM); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case totpM.Enabled:
+		return nil, errno.ErrTOTPAlreadyEnabled
+	default:
+		// 重复注册时使用新的密钥覆盖尚未启用的旧密钥
+		totpM.Secret = secret
+		totpM.LastUsedStep = 0
+		if err := b.store.TOTP().Update(ctx, totpM); err != nil {
+			return nil, err
+		}
+	}
+
+	return &apiv1.EnrollTOTPResponse{
+		Secret: secret,
+		Uri:    auth.TOTPURI(known.TOTPIssuer, contextx.Username(ctx), secret),
+	}, nil
+}
+
+// EnableTOTP 校验动态码，校验通过后启用二次验证并生成一次性恢复码.
+func (b *userBiz) EnableTOTP(ctx context.Context, rq *apiv1.EnableTOTPRequest) (*apiv1.EnableTOTPResponse, error) {
+	// 模拟登录时不允许为被模拟用户启用二次验证
+	if contextx.Impersonating(ctx) {
+		return nil, errno.ErrImpersonationForbidden
+	}
+
+	userID := contextx.UserID(ctx)
+
+	totpM, err := b.store.TOTP().Get(ctx, where.F("userID", userID))
+	if err != nil {
+		return nil, err
+	}
+	if totpM.Enabled {
+		return nil, errno.ErrTOTPAlreadyEnabled
+	}
+
+	step, ok := auth.ValidateTOTP(totpM.Secret, rq.GetCode(), time.Now(), totpM.LastUsedStep)
+	if !ok {
+		return nil, errno.ErrTOTPCodeInvalid
+	}
+
+	codes, err := auth.GenerateRecoveryCodes(known.RecoveryCodeCount)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to generate recovery codes", "err", err)
+		return nil, errno.WithMessage(errno.ErrInternal, "%v", err)
+	}
+
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		// 重新启用时，旧的恢复码全部作废
+		if err := b.store.RecoveryCode().Delete(ctx, where.F("userID", userID)); err != nil {
+			return err
+		}
+
+		for _, code := range codes {
+			if err := b.store.RecoveryCode().Create(ctx, &model.RecoveryCodeM{UserID: userID, CodeHash: auth.HashRecoveryCode(code)}); err != nil {
+				return err
+			}
+		}
+
+		totpM.Enabled = true
+		totpM.LastUsedStep = step
+		return b.store.TOTP().Update(ctx, totpM)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv1.EnableTOTPResponse{RecoveryCodes: codes}, nil
+}
+
+// mfaEnabled 判断用户是否启用了二次验证.
+func (b *userBiz) mfaEnabled(ctx context.Context, userID string) (bool, error) {
+	count, _, err := b.store.TOTP().List(ctx, where.F("userID", userID, "enabled", true))
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// mfaChallenge 在用户开启了二次验证时签发挑战令牌，未开启时返回空字符串.
+func (b *userBiz) mfaChallenge(ctx context.Context, userID string) (string, error) {
+	mfaEnabled, err := b.mfaEnabled(ctx, userID)
+	if err != nil || !mfaEnabled {
+		return "", err
+	}
+
+	challenge, _, err := token.SignScoped(known.MFAChallengeScope, userID, known.MFAChallengeExpiration)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to sign mfa challenge token", "err", err)
+		return "", errno.ErrSignToken
+	}
+	return challenge, nil
+}
+
+// verifySecondFactor 校验 TOTP 动态码或恢复码，两者都是一次性的.
+func (b *userBiz) verifySecondFactor(ctx context.Context, totpM *model.UserTOTPM, code string) error {
+	if step, ok := auth.ValidateTOTP(totpM.Secret, code, time.Now(), totpM.LastUsedStep); ok {
+		// 并发请求使用同一个动态码时，只有一个请求能够推进时间步
+		consumed, err := b.store.TOTP().ConsumeStep(ctx, totpM.UserID, step)
+		if err != nil {
+			return err
+		}
+		if !consumed {
+			return errno.ErrTOTPCodeInvalid
+		}
+		return nil
+	}
+
+	consumed, err := b.store.RecoveryCode().Consume(ctx, totpM.UserID, auth.HashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		log.W(ctx).Warnw("Second factor verification failed", "userID", totpM.UserID)
+		return errno.ErrTOTPCodeInvalid
+	}
+
+	log.W(ctx).Infow("Recovery code used for login", "userID", totpM.UserID)
+	return nil
+}
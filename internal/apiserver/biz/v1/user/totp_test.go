@@ -0,0 +1,184 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package user
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/oidc"
+	"github.com/TobyIcetea/miniblog/internal/pkg/oidc/oidctest"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/TobyIcetea/miniblog/pkg/auth"
+	"github.com/TobyIcetea/miniblog/pkg/token"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// enableTOTP 为用户启用二次验证，返回 TOTP 密钥.
+func enableTOTP(t *testing.T, b *userBiz, userID string, recoveryCodes ...string) string {
+	t.Helper()
+
+	secret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+	require.NoError(t, b.store.TOTP().Create(context.Background(), &model.UserTOTPM{UserID: userID, Secret: secret, Enabled: true}))
+	for _, code := range recoveryCodes {
+		require.NoError(t, b.store.RecoveryCode().Create(context.Background(), &model.RecoveryCodeM{UserID: userID, CodeHash: auth.HashRecoveryCode(code)}))
+	}
+	return secret
+}
+
+// loginChallenge 使用密码登录开启了二次验证的用户，返回挑战令牌.
+func loginChallenge(t *testing.T, b *userBiz, username string) string {
+	t.Helper()
+
+	resp, err := b.Login(context.Background(), &apiv1.LoginRequest{Username: username, Password: "miniblog1234"})
+	require.NoError(t, err)
+	require.True(t, resp.GetMfaRequired())
+	return resp.GetChallengeToken()
+}
+
+func TestLoginMFAChallenge(t *testing.T) {
+	b := newTestBiz(t, nil)
+	alice := createUser(t, b, "alice", "alice@example.com", "18130000001")
+	createUser(t, b, "bob", "bob@example.com", "18130000002")
+	enableTOTP(t, b, alice.UserID)
+
+	// 开启了二次验证的用户只得到挑战令牌，挑战令牌不能作为访问令牌使用
+	resp, err := b.Login(context.Background(), &apiv1.LoginRequest{Username: "alice", Password: "miniblog1234"})
+	require.NoError(t, err)
+	assert.True(t, resp.GetMfaRequired())
+	assert.Empty(t, resp.GetToken())
+	userID, err := token.ParseScoped(resp.GetChallengeToken(), known.MFAChallengeScope)
+	require.NoError(t, err)
+	assert.Equal(t, alice.UserID, userID)
+
+	// 没有开启二次验证的用户直接得到访问令牌
+	resp, err = b.Login(context.Background(), &apiv1.LoginRequest{Username: "bob", Password: "miniblog1234"})
+	require.NoError(t, err)
+	assert.False(t, resp.GetMfaRequired())
+	assert.Empty(t, resp.GetChallengeToken())
+	assert.NotEmpty(t, resp.GetToken())
+}
+
+func TestLoginVerifyStepReplay(t *testing.T) {
+	b := newTestBiz(t, nil)
+	userM := createUser(t, b, "alice", "alice@example.com", "18130000001")
+	secret := enableTOTP(t, b, userM.UserID)
+	challenge := loginChallenge(t, b, "alice")
+
+	code, err := auth.GenerateTOTPCode(secret, time.Now())
+	require.NoError(t, err)
+	resp, err := b.LoginVerify(context.Background(), &apiv1.LoginVerifyRequest{ChallengeToken: challenge, Code: code})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.GetToken())
+
+	// 同一个动态码不能再次使用
+	_, err = b.LoginVerify(context.Background(), &apiv1.LoginVerifyRequest{ChallengeToken: challenge, Code: code})
+	assert.ErrorIs(t, err, errno.ErrTOTPCodeInvalid)
+}
+
+func TestLoginVerifyConcurrentReplay(t *testing.T) {
+	b := newTestBiz(t, nil)
+	userM := createUser(t, b, "alice", "alice@example.com", "18130000001")
+	secret := enableTOTP(t, b, userM.UserID)
+	challenge := loginChallenge(t, b, "alice")
+
+	code, err := auth.GenerateTOTPCode(secret, time.Now())
+	require.NoError(t, err)
+
+	// 并发请求使用同一个动态码时只有一个请求能够登录成功
+	var succeeded atomic.Int64
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := b.LoginVerify(context.Background(), &apiv1.LoginVerifyRequest{ChallengeToken: challenge, Code: code}); err == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 1, succeeded.Load())
+}
+
+func TestLoginVerifyRecoveryCodeSingleUse(t *testing.T) {
+	b := newTestBiz(t, nil)
+	userM := createUser(t, b, "alice", "alice@example.com", "18130000001")
+	enableTOTP(t, b, userM.UserID, "recovery-code-1", "recovery-code-2")
+	challenge := loginChallenge(t, b, "alice")
+
+	_, err := b.LoginVerify(context.Background(), &apiv1.LoginVerifyRequest{ChallengeToken: challenge, Code: "recovery-code-1"})
+	require.NoError(t, err)
+
+	// 已经使用过的恢复码失效，其他恢复码仍然可以使用
+	_, err = b.LoginVerify(context.Background(), &apiv1.LoginVerifyRequest{ChallengeToken: challenge, Code: "recovery-code-1"})
+	assert.ErrorIs(t, err, errno.ErrTOTPCodeInvalid)
+	_, err = b.LoginVerify(context.Background(), &apiv1.LoginVerifyRequest{ChallengeToken: challenge, Code: "recovery-code-2"})
+	assert.NoError(t, err)
+}
+
+func TestOIDCCallbackMFAChallenge(t *testing.T) {
+	b := newTestBiz(t, nil)
+	issuer := oidctest.NewIssuer(t, "miniblog")
+	b.oidc = oidc.NewManager(&oidc.Options{
+		Providers: []oidc.ProviderOptions{{
+			Name:        "mock",
+			Issuer:      issuer.URL,
+			ClientID:    "miniblog",
+			RedirectURL: "http://127.0.0.1:5555/oidc/mock/callback",
+		}},
+		SessionExpiration: time.Minute,
+	})
+
+	userM := createUser(t, b, "alice", "alice@example.com", "18130000001")
+	require.NoError(t, b.store.Identity().Create(context.Background(), &model.UserIdentityM{UserID: userM.UserID, Provider: "mock", Subject: "subject-1"}))
+
+	callback := func() *apiv1.OIDCCallbackResponse {
+		start, err := b.OIDCAuthorize(context.Background(), &apiv1.OIDCAuthorizeRequest{Provider: "mock"})
+		require.NoError(t, err)
+		authURL, err := url.Parse(start.GetAuthorizationURL())
+		require.NoError(t, err)
+		query := authURL.Query()
+
+		code := issuer.Authorize(query.Get("code_challenge"), query.Get("nonce"), jwt.MapClaims{"sub": "subject-1"})
+		resp, err := b.OIDCCallback(context.Background(), &apiv1.OIDCCallbackRequest{
+			Provider:     "mock",
+			Code:         code,
+			State:        query.Get("state"),
+			SessionToken: start.GetSessionToken(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, userM.UserID, resp.GetUserID())
+		return resp
+	}
+
+	resp := callback()
+	assert.False(t, resp.GetMfaRequired())
+	assert.NotEmpty(t, resp.GetToken())
+
+	// 开启二次验证之后，第三方登录同样只返回挑战令牌，由 LoginVerify 完成登录
+	secret := enableTOTP(t, b, userM.UserID)
+	resp = callback()
+	assert.True(t, resp.GetMfaRequired())
+	assert.Empty(t, resp.GetToken())
+
+	code, err := auth.GenerateTOTPCode(secret, time.Now())
+	require.NoError(t, err)
+	verified, err := b.LoginVerify(context.Background(), &apiv1.LoginVerifyRequest{ChallengeToken: resp.GetChallengeToken(), Code: code})
+	require.NoError(t, err)
+	assert.NotEmpty(t, verified.GetToken())
+}
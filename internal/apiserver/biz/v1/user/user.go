@@ -8,21 +8,26 @@ package user
 
 import (
 	"context"
-	"sync"
+	"errors"
 
 	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
 	"github.com/TobyIcetea/miniblog/internal/apiserver/pkg/conversion"
 	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/captcha"
 	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
 	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/fieldmask"
 	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/lockout"
 	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mail"
+	"github.com/TobyIcetea/miniblog/internal/pkg/oidc"
+	"github.com/TobyIcetea/miniblog/internal/pkg/password"
 	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
 	"github.com/TobyIcetea/miniblog/pkg/auth"
 	"github.com/TobyIcetea/miniblog/pkg/token"
 	"github.com/jinzhu/copier"
 	"github.com/onexstack/onexstack/pkg/store/where"
-	"golang.org/x/sync/errgroup"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -42,37 +47,88 @@ type UserExpansion interface {
 	Login(ctx context.Context, rq *apiv1.LoginRequest) (*apiv1.LoginResponse, error)
 	RefreshToken(ctx context.Context, rq *apiv1.RefreshTokenRequest) (*apiv1.RefreshTokenResponse, error)
 	ChangePassword(ctx context.Context, rq *apiv1.ChangePasswordRequest) (*apiv1.ChangePasswordResponse, error)
-	ListWithBadPerformance(ctx context.Context, rq *apiv1.ListUserRequest) (*apiv1.ListUserResponse, error)
+	LoginVerify(ctx context.Context, rq *apiv1.LoginVerifyRequest) (*apiv1.LoginVerifyResponse, error)
+	EnrollTOTP(ctx context.Context, rq *apiv1.EnrollTOTPRequest) (*apiv1.EnrollTOTPResponse, error)
+	EnableTOTP(ctx context.Context, rq *apiv1.EnableTOTPRequest) (*apiv1.EnableTOTPResponse, error)
+	Unlock(ctx context.Context, rq *apiv1.UnlockUserRequest) (*apiv1.UnlockUserResponse, error)
+	OIDCAuthorize(ctx context.Context, rq *apiv1.OIDCAuthorizeRequest) (*apiv1.OIDCAuthorizeResponse, error)
+	OIDCCallback(ctx context.Context, rq *apiv1.OIDCCallbackRequest) (*apiv1.OIDCCallbackResponse, error)
+	RequestPasswordReset(ctx context.Context, rq *apiv1.RequestPasswordResetRequest) (*apiv1.RequestPasswordResetResponse, error)
+	ResetPassword(ctx context.Context, rq *apiv1.ResetPasswordRequest) (*apiv1.ResetPasswordResponse, error)
+	SendVerificationEmail(ctx context.Context, rq *apiv1.SendVerificationEmailRequest) (*apiv1.SendVerificationEmailResponse, error)
+	VerifyEmail(ctx context.Context, rq *apiv1.VerifyEmailRequest) (*apiv1.VerifyEmailResponse, error)
+	Impersonate(ctx context.Context, rq *apiv1.ImpersonateRequest) (*apiv1.ImpersonateResponse, error)
+	CreateCaptcha(ctx context.Context, rq *apiv1.CreateCaptchaRequest) (*apiv1.CreateCaptchaResponse, error)
+	BatchDelete(ctx context.Context, rq *apiv1.BatchDeleteUsersRequest) (*apiv1.BatchDeleteUsersResponse, error)
 }
 
 // userBiz 是 UserBiz 接口的实现.
 type userBiz struct {
-	store store.IStore
-	authz *auth.Authz
+	store    store.IStore
+	authz    *auth.Authz
+	mailer   mail.Mailer
+	mailOpts *mail.Options
+	guard    *lockout.Guard
+	oidc     *oidc.Manager
+	policy   *password.Policy
+	captcha  *captcha.Manager
+
+	// mailSlots 限制后台同时发送的邮件数量
+	mailSlots chan struct{}
 }
 
 // 确保 userBiz 实现了 UserBiz 接口.
 var _ UserBiz = (*userBiz)(nil)
 
-func New(store store.IStore, authz *auth.Authz) *userBiz {
-	return &userBiz{store: store, authz: authz}
+func New(store store.IStore, authz *auth.Authz, mailer mail.Mailer, mailOpts *mail.Options, guard *lockout.Guard, oidc *oidc.Manager, policy *password.Policy, captcha *captcha.Manager) *userBiz {
+	return &userBiz{store: store, authz: authz, mailer: mailer, mailOpts: mailOpts, guard: guard, oidc: oidc, policy: policy, captcha: captcha, mailSlots: make(chan struct{}, maxPendingMails)}
 }
 
 // Login 实现 UserBiz 接口中的 Login 方法.
 func (b *userBiz) Login(ctx context.Context, rq *apiv1.LoginRequest) (*apiv1.LoginResponse, error) {
+	// 失败次数过多时，在查询数据库和校验密码之前直接拒绝
+	clientIP := contextx.ClientIP(ctx)
+	if wait, ok := b.guard.Check(rq.GetUsername(), clientIP); !ok {
+		log.W(ctx).Warnw("Login rejected due to too many failed attempts", "username", rq.GetUsername(), "clientIP", clientIP, "retryAfter", wait)
+		return nil, tooManyLoginAttempts(wait)
+	}
+
 	// 获取登录用户的所有信息
 	whr := where.F("username", rq.GetUsername())
 	userM, err := b.store.User().Get(ctx, whr)
-	if err != nil {
-		return nil, errno.ErrUserNotFound
+	if err != nil && !errors.Is(err, errno.ErrUserNotFound) {
+		return nil, err
+	}
+
+	// 用户不存在时也要执行一次等价的密码比对，使得两种情况的耗时一致，无法通过响应时间枚举用户名
+	hashedPassword := dummyPasswordHash()
+	if userM != nil {
+		hashedPassword = userM.Password
 	}
 
 	// 对比传入的明文密码和数据库中已加密过的密码是否匹配
-	if err := auth.Compare(userM.Password, rq.GetPassword()); err != nil {
-		log.W(ctx).Errorw("Failed to compare password", "err", err)
-		return nil, errno.ErrPasswordInvalid
+	if err := auth.Compare(hashedPassword, rq.GetPassword()); err != nil || userM == nil {
+		b.guard.Fail(rq.GetUsername(), clientIP)
+		log.W(ctx).Warnw("Login failed", "username", rq.GetUsername(), "clientIP", clientIP)
+		return nil, errno.ErrInvalidCredentials
+	}
+
+	// 密码使用的加密算法或参数已经过时，使用当前配置重新加密
+	if auth.NeedsRehash(userM.Password) {
+		b.rehashPassword(ctx, userM, rq.GetPassword())
 	}
 
+	// 如果用户开启了二次验证，则只返回一个短期的挑战令牌，由 LoginVerify 完成登录
+	challenge, err := b.mfaChallenge(ctx, userM.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != "" {
+		return &apiv1.LoginResponse{MfaRequired: true, ChallengeToken: challenge}, nil
+	}
+	// 开启了二次验证的用户，在 LoginVerify 校验通过之后才清除失败记录
+	b.guard.Succeed(rq.GetUsername())
+
 	// 如果匹配成功，说明登录成功，签发 token 并返回
 	tokenStr, expireAt, err := token.Sign(userM.UserID)
 	if err != nil {
@@ -85,6 +141,11 @@ func (b *userBiz) Login(ctx context.Context, rq *apiv1.LoginRequest) (*apiv1.Log
 
 // 当用户的令牌即将过期时，可以调用此方法生成一个新的令牌.
 func (b *userBiz) RefreshToken(ctx context.Context, rq *apiv1.RefreshTokenRequest) (*apiv1.RefreshTokenResponse, error) {
+	// 模拟登录令牌不能刷新，否则会得到一个不带操作者、有效期更长的普通令牌
+	if contextx.Impersonating(ctx) {
+		return nil, errno.ErrImpersonationForbidden
+	}
+
 	tokenStr, expireAt, err := token.Sign(contextx.UserID(ctx))
 	if err != nil {
 		log.W(ctx).Errorw("Failed to sign token", "err", err)
@@ -96,6 +157,11 @@ func (b *userBiz) RefreshToken(ctx context.Context, rq *apiv1.RefreshTokenReques
 
 // ChangePassword 实现 UserBiz 接口中的 ChangePassword 方法.
 func (b *userBiz) ChangePassword(ctx context.Context, rq *apiv1.ChangePasswordRequest) (*apiv1.ChangePasswordResponse, error) {
+	// 模拟登录时不允许修改被模拟用户的密码
+	if contextx.Impersonating(ctx) {
+		return nil, errno.ErrImpersonationForbidden
+	}
+
 	userM, err := b.store.User().Get(ctx, where.T(ctx))
 	if err != nil {
 		return nil, err
@@ -106,8 +172,10 @@ func (b *userBiz) ChangePassword(ctx context.Context, rq *apiv1.ChangePasswordRe
 		return nil, errno.ErrPasswordInvalid
 	}
 
-	userM.Password, _ = auth.Encrypt(rq.GetNewPassword())
-	if err := b.store.User().Update(ctx, userM); err != nil {
+	if err := b.checkPasswordHistory(ctx, userM, rq.GetNewPassword()); err != nil {
+		return nil, err
+	}
+	if err := b.setPassword(ctx, userM, rq.GetNewPassword(), errno.ErrPasswordInvalid); err != nil {
 		return nil, err
 	}
 
@@ -123,9 +191,9 @@ func (b *userBiz) Create(ctx context.Context, rq *apiv1.CreateUserRequest) (*api
 		return nil, err
 	}
 
-	if _, err := b.authz.AddGroupingPolicy(userM.UserID, known.RoleUser); err != nil {
+	if _, err := b.authz.AddGroupingPolicy(userM.UserID, known.RoleUser, auth.DomainAll); err != nil {
 		log.W(ctx).Errorw("Failed to add grouping policy for user", "user", userM.UserID, "role", known.RoleUser)
-		return nil, errno.ErrAddRole.WithMessage(err.Error())
+		return nil, errno.WithMessage(errno.ErrAddRole, "%v", err)
 	}
 
 	return &apiv1.CreateUserResponse{UserID: userM.UserID}, nil
@@ -138,17 +206,8 @@ func (b *userBiz) Update(ctx context.Context, rq *apiv1.UpdateUserRequest) (*api
 		return nil, err
 	}
 
-	if rq.Username != nil {
-		userM.Username = rq.GetUsername()
-	}
-	if rq.Email != nil {
-		userM.Email = rq.GetEmail()
-	}
-	if rq.Nickname != nil {
-		userM.Nickname = rq.GetNickname()
-	}
-	if rq.Phone != nil {
-		userM.Phone = rq.GetPhone()
+	if err := applyUpdate(userM, rq); err != nil {
+		return nil, err
 	}
 
 	if err := b.store.User().Update(ctx, userM); err != nil {
@@ -160,32 +219,70 @@ func (b *userBiz) Update(ctx context.Context, rq *apiv1.UpdateUserRequest) (*api
 
 // Delete 实现 UserBiz 接口中的 Delete 方法.
 func (b *userBiz) Delete(ctx context.Context, rq *apiv1.DeleteUserRequest) (*apiv1.DeleteUserResponse, error) {
+	// 模拟登录时不允许删除用户
+	if contextx.Impersonating(ctx) {
+		return nil, errno.ErrImpersonationForbidden
+	}
+
 	// 只有 `root` 用户可以删除用户，并且可以删除其他用户
 	// 所以这里不用 where.T(), 因为 where.T() 会查询 `root` 用户自己
 	if err := b.store.User().Delete(ctx, where.F("userID", rq.GetUserID())); err != nil {
 		return nil, err
 	}
+	if err := b.store.PasswordHistory().Delete(ctx, where.F("userID", rq.GetUserID())); err != nil {
+		return nil, err
+	}
 
-	if _, err := b.authz.RemoveGroupingPolicy(rq.GetUserID(), known.RoleUser); err != nil {
-		log.W(ctx).Errorw("Failed to remove grouping policy for user", "user", rq.GetUserID(), "role", known.RoleUser)
-		return nil, errno.ErrRemoveRole.WithMessage(err.Error())
+	if err := b.removeGrants(ctx, rq.GetUserID()); err != nil {
+		return nil, err
 	}
 
 	return &apiv1.DeleteUserResponse{}, nil
 }
 
+// removeGrants 删除已删除用户的所有授权.
+func (b *userBiz) removeGrants(ctx context.Context, userID string) error {
+	// 删除用户在所有租户内的角色，包括组织成员身份
+	if _, err := b.authz.RemoveFilteredGroupingPolicy(0, userID); err != nil {
+		log.W(ctx).Errorw("Failed to remove grouping policies for user", "user", userID, "err", err)
+		return errno.WithMessage(errno.ErrRemoveRole, "%v", err)
+	}
+
+	// 删除其他用户分享给该用户的博客授权
+	if err := b.authz.RemoveResourceSubject(userID); err != nil {
+		log.W(ctx).Errorw("Failed to remove resource grants for user", "user", userID, "err", err)
+		return errno.WithMessage(errno.ErrRemoveRole, "%v", err)
+	}
+
+	return nil
+}
+
 // Get 实现 UserBiz 接口中的 Get 方法.
+// read_mask 不为空时只返回其中的字段.
 func (b *userBiz) Get(ctx context.Context, rq *apiv1.GetUserRequest) (*apiv1.GetUserResponse, error) {
+	paths, err := fieldmask.Paths(rq.GetReadMask(), (&apiv1.User{}).ProtoReflect().Descriptor())
+	if err != nil {
+		return nil, err
+	}
+
 	userM, err := b.store.User().Get(ctx, where.T(ctx))
 	if err != nil {
 		return nil, err
 	}
 
-	return &apiv1.GetUserResponse{User: conversion.UserModelToUserV1(userM)}, nil
+	user := conversion.UserModelToUserV1(userM)
+	fieldmask.Prune(user, paths)
+	return &apiv1.GetUserResponse{User: user}, nil
 }
 
 // List 实现 UserBiz 接口中的 List 方法.
+// read_mask 不为空时只返回其中的字段，不需要返回 postCount 时不统计博客数量.
 func (b *userBiz) List(ctx context.Context, rq *apiv1.ListUserRequest) (*apiv1.ListUserResponse, error) {
+	paths, err := fieldmask.Paths(rq.GetReadMask(), (&apiv1.User{}).ProtoReflect().Descriptor())
+	if err != nil {
+		return nil, err
+	}
+
 	whr := where.P(int(rq.GetOffset()), int(rq.GetLimit()))
 	if contextx.Username(ctx) != known.AdminUsername {
 		whr.T(ctx)
@@ -196,42 +293,24 @@ func (b *userBiz) List(ctx context.Context, rq *apiv1.ListUserRequest) (*apiv1.L
 		return nil, err
 	}
 
-	var m sync.Map
-	eg, ctx := errgroup.WithContext(ctx)
-
-	// 设置最大并发数量为常量 MaxConcurrency
-	eg.SetLimit(known.MaxErrGroupConcurrency)
-
-	// 使用 goroutine 提高性能
-	for _, user := range userList {
-		eg.Go(func() error {
-			select {
-			case <-ctx.Done():
-				return nil
-			default:
-				count, _, err := b.store.Post().List(ctx, where.T(ctx))
-				if err != nil {
-					return err
-				}
-
-				converted := conversion.UserModelToUserV1(user)
-				converted.PostCount = count
-				m.Store(user.ID, converted)
-
-				return nil
-			}
-		})
-	}
-
-	if err := eg.Wait(); err != nil {
-		log.W(ctx).Errorw("Failed to wait all function calls returned", "err", err)
-		return nil, err
+	// 在一条查询中统计所有用户的博客数量，避免逐个用户查询
+	var postCounts map[string]int64
+	if fieldmask.Contains(paths, "postCount") {
+		userIDs := make([]string, 0, len(userList))
+		for _, user := range userList {
+			userIDs = append(userIDs, user.UserID)
+		}
+		if postCounts, err = b.store.Post().CountByUserIDs(ctx, userIDs); err != nil {
+			return nil, err
+		}
 	}
 
 	users := make([]*apiv1.User, 0, len(userList))
-	for _, item := range userList {
-		user, _ := m.Load(item.ID)
-		users = append(users, user.(*apiv1.User))
+	for _, user := range userList {
+		converted := conversion.UserModelToUserV1(user)
+		converted.PostCount = postCounts[user.UserID]
+		fieldmask.Prune(converted, paths)
+		users = append(users, converted)
 	}
 
 	log.W(ctx).Debugw("Get users from backend storeage", "count", len(users))
@@ -239,31 +318,45 @@ func (b *userBiz) List(ctx context.Context, rq *apiv1.ListUserRequest) (*apiv1.L
 	return &apiv1.ListUserResponse{TotalCount: count, Users: users}, nil
 }
 
-// ListWithBadPerformance 是性能交叉的实现方式（已废弃）.
-func (b *userBiz) ListWithBadPerformance(ctx context.Context, rq *apiv1.ListUserRequest) (*apiv1.ListUserResponse, error) {
-	whr := where.P(int(rq.GetOffset()), int(rq.GetLimit()))
-	if contextx.Username(ctx) != known.AdminUsername {
-		whr.T(ctx)
-	}
-
-	count, userList, err := b.store.User().List(ctx, whr)
+// applyUpdate 将更新请求中的字段应用到 userM 上.
+// 没有指定 update_mask 时只更新请求中设置了的字段，否则按照 AIP-134 更新掩码中的所有字段，
+// 掩码中没有设置的字段会被清空.
+func applyUpdate(userM *model.UserM, rq *apiv1.UpdateUserRequest) error {
+	paths, err := fieldmask.Paths(rq.GetUpdateMask(), rq.ProtoReflect().Descriptor(), known.UserUpdatableFields...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	users := make([]*apiv1.User, 0, len(userList))
-	for _, user := range userList {
-		count, _, err := b.store.Post().List(ctx, where.T(ctx))
-		if err != nil {
-			return nil, err
+	if paths == nil {
+		if rq.Username != nil {
+			paths = append(paths, "username")
+		}
+		if rq.Nickname != nil {
+			paths = append(paths, "nickname")
+		}
+		if rq.Email != nil {
+			paths = append(paths, "email")
+		}
+		if rq.Phone != nil {
+			paths = append(paths, "phone")
 		}
-
-		converted := conversion.UserModelToUserV1(user)
-		converted.PostCount = count
-		users = append(users, converted)
 	}
 
-	log.W(ctx).Debugw("Get users from backend storage", "count", len(users))
-
-	return &apiv1.ListUserResponse{TotalCount: count, Users: users}, nil
+	for _, path := range paths {
+		switch path {
+		case "username":
+			userM.Username = rq.GetUsername()
+		case "nickname":
+			userM.Nickname = rq.GetNickname()
+		case "email":
+			if rq.GetEmail() != userM.Email {
+				// 修改邮箱后需要重新验证
+				userM.Email = rq.GetEmail()
+				userM.EmailVerified = false
+			}
+		case "phone":
+			userM.Phone = rq.GetPhone()
+		}
+	}
+	return nil
 }
@@ -8,10 +8,13 @@ package apiserver
 
 import (
 	"context"
+	"net/http"
 	"strings"
 
 	handler "github.com/TobyIcetea/miniblog/internal/apiserver/handler/grpc"
 	mw "github.com/TobyIcetea/miniblog/internal/pkg/middleware/grpc"
+	"github.com/TobyIcetea/miniblog/internal/pkg/permission"
+	"github.com/TobyIcetea/miniblog/internal/pkg/ratelimit"
 	"github.com/TobyIcetea/miniblog/internal/pkg/server"
 	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
@@ -19,6 +22,7 @@ import (
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/onexstack/onexstack/pkg/validation"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
 )
 
@@ -42,25 +46,16 @@ var _ server.Server = (*grpcServer)(nil)
 func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
 	// 配置 gRPC 服务器选项，包括拦截器链
 	serverOptions := []grpc.ServerOption{
-		// 注意拦截器顺序！
-		grpc.ChainUnaryInterceptor(
-			// 请求 ID 拦截器
-			mw.RequestIDInterceptor(),
-			// 认证拦截器
-			selector.UnaryServerInterceptor(mw.AuthnInterceptor(c.retriever), NewAuthnWhiteListMatcher()),
-			// 授权拦截器
-			selector.UnaryServerInterceptor(mw.AuthzInterceptor(c.authz), NewAuthzWhiteListMatcher()),
-			// 请求默认值设置拦截器
-			mw.DefaulterInterceptor(),
-			// 数据校验拦截器
-			mw.ValidatorInterceptor(validation.NewValidator(c.val)),
-		),
+		grpc.ChainUnaryInterceptor(c.unaryInterceptors()...),
+		// 流式接口在建立流时限流
+		grpc.ChainStreamInterceptor(mw.RateLimitStreamInterceptor(c.limiter, permission.Default)),
 	}
 
 	// 创建 gRPC 服务器
 	grpcsrv, err := server.NewGRPCServer(
 		c.cfg.GRPCOptions,
 		c.cfg.TLSOptions,
+		c.cfg.MTLSOptions,
 		serverOptions,
 		func(s grpc.ServiceRegistrar) {
 			apiv1.RegisterMiniBlogServer(s, handler.NewHandler(c.biz))
@@ -92,9 +87,36 @@ func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
 		c.cfg.HTTPOptions,
 		grpcOptions,
 		c.cfg.TLSOptions,
+		c.cfg.MTLSOptions,
 		func(mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+			// 注册 Prometheus 指标路由
+			metrics := promhttp.Handler()
+			if err := mux.HandlePath(http.MethodGet, "/metrics", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+				metrics.ServeHTTP(w, r)
+			}); err != nil {
+				return err
+			}
 			return apiv1.RegisterMiniBlogHandler(context.Background(), mux, conn)
 		},
+		// 将租户请求头和开发认证模式的请求头透传给 gRPC 服务器
+		runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+			if strings.EqualFold(key, c.tenants.Header()) {
+				return strings.ToLower(key), true
+			}
+			if c.cfg.DevAuthOptions.Enabled && strings.EqualFold(key, c.cfg.DevAuthOptions.Header) {
+				return strings.ToLower(key), true
+			}
+			return runtime.DefaultHeaderMatcher(key)
+		}),
+		// 限流相关的响应头原样返回给客户端，其他响应头使用默认前缀
+		runtime.WithOutgoingHeaderMatcher(func(key string) (string, bool) {
+			for _, header := range []string{ratelimit.HeaderLimit, ratelimit.HeaderRemaining, ratelimit.HeaderReset, ratelimit.HeaderRetryAfter} {
+				if strings.EqualFold(key, header) {
+					return header, true
+				}
+			}
+			return runtime.MetadataHeaderPrefix + key, true
+		}),
 	)
 	if err != nil {
 		return nil, err
@@ -109,6 +131,39 @@ func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
 	}, nil
 }
 
+// unaryInterceptors 返回 gRPC 服务器的一元拦截器链.
+func (c *ServerConfig) unaryInterceptors() []grpc.UnaryServerInterceptor {
+	// 认证拦截器，开发认证模式下直接信任元数据中的用户 ID
+	authn := mw.AuthnInterceptor(c.retriever, c.mapper)
+	if c.cfg.DevAuthOptions.Enabled {
+		authn = mw.AuthnBypassInterceptor(c.cfg.DevAuthOptions.Header, c.retriever)
+	}
+
+	// 注意拦截器顺序！
+	return []grpc.UnaryServerInterceptor{
+		// 请求 ID 拦截器
+		mw.RequestIDInterceptor(),
+		// 客户端 IP 拦截器
+		mw.ClientIPInterceptor(c.cfg.TrustedProxies...),
+		// 认证拦截器
+		selector.UnaryServerInterceptor(authn, NewAuthnWhiteListMatcher()),
+		// 租户拦截器，需要在认证之后、授权之前执行
+		selector.UnaryServerInterceptor(mw.TenantInterceptor(c.tenants, c.tenantRetriever), NewAuthnWhiteListMatcher()),
+		// 审计拦截器，需要在认证之后执行，才能记录操作者，并且能记录后续拦截器拒绝的请求
+		mw.AuditInterceptor(c.auditor, permission.Default),
+		// 请求限流拦截器，需要在认证之后执行，才能按用户 ID 计数
+		mw.RateLimitInterceptor(c.limiter, permission.Default),
+		// 授权拦截器
+		selector.UnaryServerInterceptor(mw.AuthzInterceptor(c.authz, permission.Default), NewAuthzWhiteListMatcher()),
+		// 模拟登录拦截器，禁止模拟登录时调用敏感接口
+		selector.UnaryServerInterceptor(mw.ImpersonationInterceptor(permission.Default, impersonationForbidden), NewAuthzWhiteListMatcher()),
+		// 请求默认值设置拦截器
+		mw.DefaulterInterceptor(),
+		// 数据校验拦截器
+		mw.ValidatorInterceptor(validation.NewValidator(c.val)),
+	}
+}
+
 // RunOrDie 启动 gRPC 服务器或 HTTP 反向代理服务器，异常时退出.
 func (s *grpcServer) RunOrDie() {
 	s.srv.RunOrDie()
@@ -122,9 +177,16 @@ func (s *grpcServer) GracefulStop(ctx context.Context) {
 // NewAuthnWhiteListMatcher 创建认证白名单匹配器.
 func NewAuthnWhiteListMatcher() selector.Matcher {
 	whitelist := map[string]struct{}{
-		apiv1.MiniBlog_Healthz_FullMethodName:    {},
-		apiv1.MiniBlog_CreateUser_FullMethodName: {},
-		apiv1.MiniBlog_Login_FullMethodName:      {},
+		apiv1.MiniBlog_Healthz_FullMethodName:              {},
+		apiv1.MiniBlog_CreateUser_FullMethodName:           {},
+		apiv1.MiniBlog_Login_FullMethodName:                {},
+		apiv1.MiniBlog_CreateCaptcha_FullMethodName:        {},
+		apiv1.MiniBlog_LoginVerify_FullMethodName:          {},
+		apiv1.MiniBlog_RequestPasswordReset_FullMethodName: {},
+		apiv1.MiniBlog_ResetPassword_FullMethodName:        {},
+		apiv1.MiniBlog_VerifyEmail_FullMethodName:          {},
+		apiv1.MiniBlog_OIDCAuthorize_FullMethodName:        {},
+		apiv1.MiniBlog_OIDCCallback_FullMethodName:         {},
 	}
 	return selector.MatchFunc(func(ctx context.Context, call interceptors.CallMeta) bool {
 		_, ok := whitelist[call.FullMethod()]
@@ -135,9 +197,16 @@ func NewAuthnWhiteListMatcher() selector.Matcher {
 // NewAuthzWhiteListMatcher 创建授权白名单匹配器.
 func NewAuthzWhiteListMatcher() selector.Matcher {
 	whiteList := map[string]struct{}{
-		apiv1.MiniBlog_Healthz_FullMethodName:    {},
-		apiv1.MiniBlog_CreateUser_FullMethodName: {},
-		apiv1.MiniBlog_Login_FullMethodName:      {},
+		apiv1.MiniBlog_Healthz_FullMethodName:              {},
+		apiv1.MiniBlog_CreateUser_FullMethodName:           {},
+		apiv1.MiniBlog_Login_FullMethodName:                {},
+		apiv1.MiniBlog_CreateCaptcha_FullMethodName:        {},
+		apiv1.MiniBlog_LoginVerify_FullMethodName:          {},
+		apiv1.MiniBlog_RequestPasswordReset_FullMethodName: {},
+		apiv1.MiniBlog_ResetPassword_FullMethodName:        {},
+		apiv1.MiniBlog_VerifyEmail_FullMethodName:          {},
+		apiv1.MiniBlog_OIDCAuthorize_FullMethodName:        {},
+		apiv1.MiniBlog_OIDCCallback_FullMethodName:         {},
 	}
 	return selector.MatchFunc(func(ctx context.Context, call interceptors.CallMeta) bool {
 		_, ok := whiteList[call.FullMethod()]
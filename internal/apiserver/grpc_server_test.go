@@ -0,0 +1,267 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package apiserver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	handler "github.com/TobyIcetea/miniblog/internal/apiserver/handler/grpc"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/migration"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/cache"
+	"github.com/TobyIcetea/miniblog/internal/pkg/captcha"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/database"
+	"github.com/TobyIcetea/miniblog/internal/pkg/devauth"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/lockout"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mail"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mtls"
+	"github.com/TobyIcetea/miniblog/internal/pkg/oidc"
+	"github.com/TobyIcetea/miniblog/internal/pkg/password"
+	"github.com/TobyIcetea/miniblog/internal/pkg/ratelimit"
+	"github.com/TobyIcetea/miniblog/internal/pkg/tenant"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/TobyIcetea/miniblog/pkg/auth"
+	"github.com/TobyIcetea/miniblog/pkg/token"
+	"github.com/onexstack/onexstack/pkg/errorsx"
+	genericoptions "github.com/onexstack/onexstack/pkg/options"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// newTestServerConfig 基于 SQLite 内存数据库创建 ServerConfig，数据库中已经执行了所有迁移.
+func newTestServerConfig(t *testing.T) *ServerConfig {
+	t.Helper()
+
+	cfg := &Config{
+		ServerMode:       GRPCServerMode,
+		JWTKey:           "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5",
+		Expiration:       2 * time.Hour,
+		TLSOptions:       genericoptions.NewTLSOptions(),
+		HTTPOptions:      genericoptions.NewHTTPOptions(),
+		GRPCOptions:      genericoptions.NewGRPCOptions(),
+		DatabaseDriver:   database.DriverSQLite,
+		SQLiteOptions:    database.NewSQLiteOptions(),
+		ReplicaOptions:   database.NewReplicaOptions(),
+		MigrateOptions:   migration.NewOptions(),
+		StoreOptions:     store.NewOptions(),
+		RedisOptions:     genericoptions.NewRedisOptions(),
+		MailOptions:      mail.NewOptions(),
+		LockoutOptions:   lockout.NewOptions(),
+		OIDCOptions:      oidc.NewOptions(),
+		MTLSOptions:      mtls.NewOptions(),
+		TenantOptions:    tenant.NewOptions(),
+		PasswordOptions:  password.NewOptions(),
+		RateLimitOptions: ratelimit.NewOptions(),
+		CacheOptions:     cache.NewOptions(),
+		CaptchaOptions:   captcha.NewOptions(),
+		DevAuthOptions:   devauth.NewOptions(),
+	}
+	cfg.SQLiteOptions.Path = database.MemoryPath
+	cfg.MigrateOptions.Auto = true
+
+	where.RegisterTenant("userID", func(ctx context.Context) string {
+		return contextx.UserID(ctx)
+	})
+	token.Init(cfg.JWTKey, known.XUserID, cfg.Expiration)
+
+	c, cleanup, err := cfg.NewServerConfig()
+	require.NoError(t, err)
+	t.Cleanup(cleanup)
+	return c
+}
+
+// newTestGRPCClient 使用 c 的拦截器链在内存中启动 gRPC 服务器，并返回连接到该服务器的客户端.
+func newTestGRPCClient(t *testing.T, c *ServerConfig) apiv1.MiniBlogClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(c.unaryInterceptors()...))
+	apiv1.RegisterMiniBlogServer(srv, handler.NewHandler(c.biz))
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return apiv1.NewMiniBlogClient(conn)
+}
+
+// testStore 返回 c 使用的 IStore.
+func testStore(c *ServerConfig) store.IStore {
+	return c.retriever.(*UserRetriever).store
+}
+
+// withToken 返回携带 Bearer 令牌的请求上下文.
+func withToken(tokenStr string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+tokenStr)
+}
+
+// createTestUser 在数据库中创建一个普通用户，手机号需要在所有用户中唯一.
+func createTestUser(t *testing.T, c *ServerConfig, username string, phone string) *model.UserM {
+	t.Helper()
+
+	userM := &model.UserM{Username: username, Password: "miniblog1234", Nickname: username, Email: username + "@example.com", Phone: phone}
+	require.NoError(t, testStore(c).User().Create(context.Background(), userM))
+	return userM
+}
+
+func TestGRPCImpersonationForbidden(t *testing.T) {
+	c := newTestServerConfig(t)
+	client := newTestGRPCClient(t, c)
+
+	root, err := testStore(c).User().Get(context.Background(), where.F("username", known.AdminUsername))
+	require.NoError(t, err)
+	userM := createTestUser(t, c, "alice", "18120000001")
+
+	// 普通令牌可以刷新
+	tokenStr, _, err := token.Sign(userM.UserID)
+	require.NoError(t, err)
+	_, err = client.RefreshToken(withToken(tokenStr), &apiv1.RefreshTokenRequest{})
+	require.NoError(t, err)
+
+	// 模拟登录令牌不能刷新，也不能为被模拟用户注册二次验证
+	tokenStr, _, err = token.SignImpersonation(userM.UserID, root.UserID, known.ImpersonationExpiration)
+	require.NoError(t, err)
+	_, err = client.RefreshToken(withToken(tokenStr), &apiv1.RefreshTokenRequest{})
+	assert.Equal(t, errno.ErrImpersonationForbidden.Reason, errorsx.FromError(err).Reason)
+	_, err = client.EnrollTOTP(withToken(tokenStr), &apiv1.EnrollTOTPRequest{UserID: userM.UserID})
+	assert.Equal(t, errno.ErrImpersonationForbidden.Reason, errorsx.FromError(err).Reason)
+}
+
+func TestGRPCAudit(t *testing.T) {
+	c := newTestServerConfig(t)
+	client := newTestGRPCClient(t, c)
+
+	userM := createTestUser(t, c, "alice", "18120000001")
+	tokenStr, _, err := token.Sign(userM.UserID)
+	require.NoError(t, err)
+
+	_, err = client.UpdateUser(withToken(tokenStr), &apiv1.UpdateUserRequest{UserID: userM.UserID, Nickname: proto.String("alice2")})
+	require.NoError(t, err)
+	// 只读方法不记录审计事件
+	_, err = client.GetUser(withToken(tokenStr), &apiv1.GetUserRequest{UserID: userM.UserID})
+	require.NoError(t, err)
+
+	_, events, err := testStore(c).Audit().List(context.Background(), where.NewWhere())
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, apiv1.MiniBlog_UpdateUser_FullMethodName, events[0].Action)
+	assert.Equal(t, userM.UserID, events[0].Actor)
+	assert.Equal(t, known.ResourceUserPrefix+userM.UserID, events[0].Resource)
+	assert.Equal(t, known.AuditOutcomeSuccess, events[0].Outcome)
+}
+
+func TestGRPCLoginVerifyLockout(t *testing.T) {
+	c := newTestServerConfig(t)
+	client := newTestGRPCClient(t, c)
+
+	userM := createTestUser(t, c, "alice", "18120000001")
+	secret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+	require.NoError(t, testStore(c).TOTP().Create(context.Background(), &model.UserTOTPM{UserID: userM.UserID, Secret: secret, Enabled: true}))
+
+	login, err := client.Login(context.Background(), &apiv1.LoginRequest{Username: "alice", Password: "miniblog1234"})
+	require.NoError(t, err)
+	require.True(t, login.GetMfaRequired())
+
+	// 错误的动态码计入失败次数，超过免费次数后即使动态码正确也会被拒绝
+	for range lockout.NewOptions().FreeAttempts + 1 {
+		_, err = client.LoginVerify(context.Background(), &apiv1.LoginVerifyRequest{ChallengeToken: login.GetChallengeToken(), Code: "000000"})
+		assert.Equal(t, errno.ErrTOTPCodeInvalid.Reason, errorsx.FromError(err).Reason)
+	}
+	code, err := auth.GenerateTOTPCode(secret, time.Now())
+	require.NoError(t, err)
+	_, err = client.LoginVerify(context.Background(), &apiv1.LoginVerifyRequest{ChallengeToken: login.GetChallengeToken(), Code: code})
+	assert.Equal(t, errno.ErrTooManyLoginAttempts.Reason, errorsx.FromError(err).Reason)
+}
+
+func TestGRPCPolicyDefaultDomain(t *testing.T) {
+	c := newTestServerConfig(t)
+	client := newTestGRPCClient(t, c)
+
+	root, err := testStore(c).User().Get(context.Background(), where.F("username", known.AdminUsername))
+	require.NoError(t, err)
+	userM := createTestUser(t, c, "alice", "18120000001")
+	tokenStr, _, err := token.Sign(root.UserID)
+	require.NoError(t, err)
+	ctx := withToken(tokenStr)
+
+	// 不指定租户添加的策略和角色分配，不指定租户检查时同样生效
+	_, err = client.CreatePolicy(ctx, &apiv1.CreatePolicyRequest{Subject: "role::qa", Object: "posts.list", Action: "CALL", Effect: known.EffectDeny})
+	require.NoError(t, err)
+	_, err = client.AssignRole(ctx, &apiv1.AssignRoleRequest{Subject: userM.UserID, Role: "role::qa"})
+	require.NoError(t, err)
+
+	resp, err := client.CheckPermission(ctx, &apiv1.CheckPermissionRequest{Subject: userM.UserID, Object: "posts.list", Action: "CALL"})
+	require.NoError(t, err)
+	assert.False(t, resp.GetAllowed())
+	require.Len(t, resp.GetMatchedPolicies(), 1)
+	assert.Equal(t, auth.DomainAll, resp.GetMatchedPolicies()[0].GetDomain())
+
+	_, err = client.DeletePolicy(ctx, &apiv1.DeletePolicyRequest{Subject: "role::qa", Object: "posts.list", Action: "CALL", Effect: known.EffectDeny})
+	require.NoError(t, err)
+	resp, err = client.CheckPermission(ctx, &apiv1.CheckPermissionRequest{Subject: userM.UserID, Object: "posts.list", Action: "CALL"})
+	require.NoError(t, err)
+	assert.True(t, resp.GetAllowed())
+}
+
+func TestGRPCListSharedPosts(t *testing.T) {
+	c := newTestServerConfig(t)
+	client := newTestGRPCClient(t, c)
+
+	alice := createTestUser(t, c, "alice", "18120000001")
+	bob := createTestUser(t, c, "bob", "18120000002")
+	aliceToken, _, err := token.Sign(alice.UserID)
+	require.NoError(t, err)
+	bobToken, _, err := token.Sign(bob.UserID)
+	require.NoError(t, err)
+
+	shared, err := client.CreatePost(withToken(aliceToken), &apiv1.CreatePostRequest{Title: "shared", Content: "content"})
+	require.NoError(t, err)
+	_, err = client.CreatePost(withToken(aliceToken), &apiv1.CreatePostRequest{Title: "private", Content: "content"})
+	require.NoError(t, err)
+	own, err := client.CreatePost(withToken(bobToken), &apiv1.CreatePostRequest{Title: "own", Content: "content"})
+	require.NoError(t, err)
+
+	listPostIDs := func() []string {
+		resp, err := client.ListPost(withToken(bobToken), &apiv1.ListPostRequest{Limit: 10})
+		require.NoError(t, err)
+		ids := make([]string, 0, len(resp.GetPosts()))
+		for _, post := range resp.GetPosts() {
+			ids = append(ids, post.GetPostID())
+		}
+		assert.EqualValues(t, len(ids), resp.GetTotalCount())
+		return ids
+	}
+	assert.ElementsMatch(t, []string{own.GetPostID()}, listPostIDs())
+
+	// 分享给 bob 的博客出现在 bob 的博客列表中，撤销分享之后不再出现
+	_, err = client.SharePost(withToken(aliceToken), &apiv1.SharePostRequest{PostID: shared.GetPostID(), UserID: bob.UserID, Role: "viewer"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{own.GetPostID(), shared.GetPostID()}, listPostIDs())
+
+	_, err = client.UnsharePost(withToken(aliceToken), &apiv1.UnsharePostRequest{PostID: shared.GetPostID(), UserID: bob.UserID})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{own.GetPostID()}, listPostIDs())
+}
@@ -0,0 +1,18 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package grpc
+
+import (
+	"context"
+
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+)
+
+// ListAuditEvents 列出审计事件.
+func (h *Handler) ListAuditEvents(ctx context.Context, rq *apiv1.ListAuditEventsRequest) (*apiv1.ListAuditEventsResponse, error) {
+	return h.biz.AuditV1().List(ctx, rq)
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package grpc
+
+import (
+	"context"
+
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+)
+
+// CreateOrganization 创建组织.
+func (h *Handler) CreateOrganization(ctx context.Context, rq *apiv1.CreateOrganizationRequest) (*apiv1.CreateOrganizationResponse, error) {
+	return h.biz.OrganizationV1().Create(ctx, rq)
+}
+
+// DeleteOrganization 删除组织.
+func (h *Handler) DeleteOrganization(ctx context.Context, rq *apiv1.DeleteOrganizationRequest) (*apiv1.DeleteOrganizationResponse, error) {
+	return h.biz.OrganizationV1().Delete(ctx, rq)
+}
+
+// GetOrganization 获取组织详情.
+func (h *Handler) GetOrganization(ctx context.Context, rq *apiv1.GetOrganizationRequest) (*apiv1.GetOrganizationResponse, error) {
+	return h.biz.OrganizationV1().Get(ctx, rq)
+}
+
+// ListOrganizations 列出当前用户所属的组织.
+func (h *Handler) ListOrganizations(ctx context.Context, rq *apiv1.ListOrganizationsRequest) (*apiv1.ListOrganizationsResponse, error) {
+	return h.biz.OrganizationV1().List(ctx, rq)
+}
+
+// ListOrganizationMembers 列出组织成员.
+func (h *Handler) ListOrganizationMembers(ctx context.Context, rq *apiv1.ListOrganizationMembersRequest) (*apiv1.ListOrganizationMembersResponse, error) {
+	return h.biz.OrganizationV1().ListMembers(ctx, rq)
+}
+
+// AddOrganizationMember 添加组织成员.
+func (h *Handler) AddOrganizationMember(ctx context.Context, rq *apiv1.AddOrganizationMemberRequest) (*apiv1.AddOrganizationMemberResponse, error) {
+	return h.biz.OrganizationV1().AddMember(ctx, rq)
+}
+
+// RemoveOrganizationMember 移除组织成员.
+func (h *Handler) RemoveOrganizationMember(ctx context.Context, rq *apiv1.RemoveOrganizationMemberRequest) (*apiv1.RemoveOrganizationMemberResponse, error) {
+	return h.biz.OrganizationV1().RemoveMember(ctx, rq)
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package grpc
+
+import (
+	"context"
+
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+)
+
+// ListPolicies 列出访问控制策略.
+func (h *Handler) ListPolicies(ctx context.Context, rq *apiv1.ListPoliciesRequest) (*apiv1.ListPoliciesResponse, error) {
+	return h.biz.PolicyV1().List(ctx, rq)
+}
+
+// CreatePolicy 添加访问控制策略.
+func (h *Handler) CreatePolicy(ctx context.Context, rq *apiv1.CreatePolicyRequest) (*apiv1.CreatePolicyResponse, error) {
+	return h.biz.PolicyV1().Create(ctx, rq)
+}
+
+// DeletePolicy 删除访问控制策略.
+func (h *Handler) DeletePolicy(ctx context.Context, rq *apiv1.DeletePolicyRequest) (*apiv1.DeletePolicyResponse, error) {
+	return h.biz.PolicyV1().Delete(ctx, rq)
+}
+
+// ListRoleAssignments 列出角色分配记录.
+func (h *Handler) ListRoleAssignments(ctx context.Context, rq *apiv1.ListRoleAssignmentsRequest) (*apiv1.ListRoleAssignmentsResponse, error) {
+	return h.biz.PolicyV1().ListRoleAssignments(ctx, rq)
+}
+
+// AssignRole 分配角色.
+func (h *Handler) AssignRole(ctx context.Context, rq *apiv1.AssignRoleRequest) (*apiv1.AssignRoleResponse, error) {
+	return h.biz.PolicyV1().AssignRole(ctx, rq)
+}
+
+// RevokeRole 撤销角色.
+func (h *Handler) RevokeRole(ctx context.Context, rq *apiv1.RevokeRoleRequest) (*apiv1.RevokeRoleResponse, error) {
+	return h.biz.PolicyV1().RevokeRole(ctx, rq)
+}
+
+// ListRoles 列出所有角色.
+func (h *Handler) ListRoles(ctx context.Context, rq *apiv1.ListRolesRequest) (*apiv1.ListRolesResponse, error) {
+	return h.biz.PolicyV1().ListRoles(ctx, rq)
+}
+
+// CreateRole 定义自定义角色.
+func (h *Handler) CreateRole(ctx context.Context, rq *apiv1.CreateRoleRequest) (*apiv1.CreateRoleResponse, error) {
+	return h.biz.PolicyV1().CreateRole(ctx, rq)
+}
+
+// DeleteRole 删除自定义角色.
+func (h *Handler) DeleteRole(ctx context.Context, rq *apiv1.DeleteRoleRequest) (*apiv1.DeleteRoleResponse, error) {
+	return h.biz.PolicyV1().DeleteRole(ctx, rq)
+}
+
+// CheckPermission 检查主体是否拥有权限.
+func (h *Handler) CheckPermission(ctx context.Context, rq *apiv1.CheckPermissionRequest) (*apiv1.CheckPermissionResponse, error) {
+	return h.biz.PolicyV1().CheckPermission(ctx, rq)
+}
@@ -36,3 +36,23 @@ func (h *Handler) GetPost(ctx context.Context, rq *apiv1.GetPostRequest) (*apiv1
 func (h *Handler) ListPost(ctx context.Context, rq *apiv1.ListPostRequest) (*apiv1.ListPostResponse, error) {
 	return h.biz.PostV1().List(ctx, rq)
 }
+
+// SharePost 分享博客.
+func (h *Handler) SharePost(ctx context.Context, rq *apiv1.SharePostRequest) (*apiv1.SharePostResponse, error) {
+	return h.biz.PostV1().Share(ctx, rq)
+}
+
+// UnsharePost 取消分享博客.
+func (h *Handler) UnsharePost(ctx context.Context, rq *apiv1.UnsharePostRequest) (*apiv1.UnsharePostResponse, error) {
+	return h.biz.PostV1().Unshare(ctx, rq)
+}
+
+// BatchGetPosts 批量获取博客帖子.
+func (h *Handler) BatchGetPosts(ctx context.Context, rq *apiv1.BatchGetPostsRequest) (*apiv1.BatchGetPostsResponse, error) {
+	return h.biz.PostV1().BatchGet(ctx, rq)
+}
+
+// BatchCreatePosts 批量创建博客帖子.
+func (h *Handler) BatchCreatePosts(ctx context.Context, rq *apiv1.BatchCreatePostsRequest) (*apiv1.BatchCreatePostsResponse, error) {
+	return h.biz.PostV1().BatchCreate(ctx, rq)
+}
@@ -17,6 +17,26 @@ func (h *Handler) Login(ctx context.Context, rq *apiv1.LoginRequest) (*apiv1.Log
 	return h.biz.UserV1().Login(ctx, rq)
 }
 
+// CreateCaptcha 签发人机验证挑战.
+func (h *Handler) CreateCaptcha(ctx context.Context, rq *apiv1.CreateCaptchaRequest) (*apiv1.CreateCaptchaResponse, error) {
+	return h.biz.UserV1().CreateCaptcha(ctx, rq)
+}
+
+// LoginVerify 二次验证登录.
+func (h *Handler) LoginVerify(ctx context.Context, rq *apiv1.LoginVerifyRequest) (*apiv1.LoginVerifyResponse, error) {
+	return h.biz.UserV1().LoginVerify(ctx, rq)
+}
+
+// OIDCAuthorize 发起 OIDC 登录.
+func (h *Handler) OIDCAuthorize(ctx context.Context, rq *apiv1.OIDCAuthorizeRequest) (*apiv1.OIDCAuthorizeResponse, error) {
+	return h.biz.UserV1().OIDCAuthorize(ctx, rq)
+}
+
+// OIDCCallback 完成 OIDC 登录.
+func (h *Handler) OIDCCallback(ctx context.Context, rq *apiv1.OIDCCallbackRequest) (*apiv1.OIDCCallbackResponse, error) {
+	return h.biz.UserV1().OIDCCallback(ctx, rq)
+}
+
 // RefreshToken 刷新令牌.
 func (h *Handler) RefreshToken(ctx context.Context, rq *apiv1.RefreshTokenRequest) (*apiv1.RefreshTokenResponse, error) {
 	return h.biz.UserV1().RefreshToken(ctx, rq)
@@ -27,6 +47,46 @@ func (h *Handler) ChangePassword(ctx context.Context, rq *apiv1.ChangePasswordRe
 	return h.biz.UserV1().ChangePassword(ctx, rq)
 }
 
+// EnrollTOTP 注册 TOTP 二次验证.
+func (h *Handler) EnrollTOTP(ctx context.Context, rq *apiv1.EnrollTOTPRequest) (*apiv1.EnrollTOTPResponse, error) {
+	return h.biz.UserV1().EnrollTOTP(ctx, rq)
+}
+
+// EnableTOTP 启用 TOTP 二次验证.
+func (h *Handler) EnableTOTP(ctx context.Context, rq *apiv1.EnableTOTPRequest) (*apiv1.EnableTOTPResponse, error) {
+	return h.biz.UserV1().EnableTOTP(ctx, rq)
+}
+
+// RequestPasswordReset 请求重置密码.
+func (h *Handler) RequestPasswordReset(ctx context.Context, rq *apiv1.RequestPasswordResetRequest) (*apiv1.RequestPasswordResetResponse, error) {
+	return h.biz.UserV1().RequestPasswordReset(ctx, rq)
+}
+
+// ResetPassword 重置密码.
+func (h *Handler) ResetPassword(ctx context.Context, rq *apiv1.ResetPasswordRequest) (*apiv1.ResetPasswordResponse, error) {
+	return h.biz.UserV1().ResetPassword(ctx, rq)
+}
+
+// SendVerificationEmail 发送邮箱验证邮件.
+func (h *Handler) SendVerificationEmail(ctx context.Context, rq *apiv1.SendVerificationEmailRequest) (*apiv1.SendVerificationEmailResponse, error) {
+	return h.biz.UserV1().SendVerificationEmail(ctx, rq)
+}
+
+// VerifyEmail 验证邮箱.
+func (h *Handler) VerifyEmail(ctx context.Context, rq *apiv1.VerifyEmailRequest) (*apiv1.VerifyEmailResponse, error) {
+	return h.biz.UserV1().VerifyEmail(ctx, rq)
+}
+
+// UnlockUser 解除用户锁定.
+func (h *Handler) UnlockUser(ctx context.Context, rq *apiv1.UnlockUserRequest) (*apiv1.UnlockUserResponse, error) {
+	return h.biz.UserV1().Unlock(ctx, rq)
+}
+
+// Impersonate 模拟登录.
+func (h *Handler) Impersonate(ctx context.Context, rq *apiv1.ImpersonateRequest) (*apiv1.ImpersonateResponse, error) {
+	return h.biz.UserV1().Impersonate(ctx, rq)
+}
+
 // CreateUser 创建新用户.
 func (h *Handler) CreateUser(ctx context.Context, rq *apiv1.CreateUserRequest) (*apiv1.CreateUserResponse, error) {
 	return h.biz.UserV1().Create(ctx, rq)
@@ -42,6 +102,11 @@ func (h *Handler) DeleteUser(ctx context.Context, rq *apiv1.DeleteUserRequest) (
 	return h.biz.UserV1().Delete(ctx, rq)
 }
 
+// BatchDeleteUsers 批量删除用户.
+func (h *Handler) BatchDeleteUsers(ctx context.Context, rq *apiv1.BatchDeleteUsersRequest) (*apiv1.BatchDeleteUsersResponse, error) {
+	return h.biz.UserV1().BatchDelete(ctx, rq)
+}
+
 // GetUser 获取用户信息.
 func (h *Handler) GetUser(ctx context.Context, rq *apiv1.GetUserRequest) (*apiv1.GetUserResponse, error) {
 	return h.biz.UserV1().Get(ctx, rq)
@@ -0,0 +1,17 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/onexstack/onexstack/pkg/core"
+)
+
+// ListAuditEvents 列出审计事件.
+func (h *Handler) ListAuditEvents(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.AuditV1().List, h.val.ValidateListAuditEventsRequest)
+}
@@ -7,8 +7,16 @@
 package http
 
 import (
+	"io"
+
 	"github.com/TobyIcetea/miniblog/internal/apiserver/biz"
+	"github.com/TobyIcetea/miniblog/internal/pkg/fieldmask"
 	"github.com/TobyIcetea/miniblog/internal/pkg/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/onexstack/onexstack/pkg/core"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // Handler 处理博客模块的请求.
@@ -24,3 +32,38 @@ func NewHandler(biz biz.IBiz, val *validation.Validator) *Handler {
 		val: val,
 	}
 }
+
+// protoJSONBinder 使用 protojson 解析请求体，然后绑定 URI 参数.
+// 与 grpc-gateway 保持一致，FieldMask 使用逗号分隔的字符串表示，例如 "updateMask": "title,content".
+func protoJSONBinder(c *gin.Context) core.Binder {
+	return func(rq any) error {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return err
+		}
+		if len(body) > 0 {
+			if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, rq.(proto.Message)); err != nil {
+				return err
+			}
+		}
+		return c.ShouldBindUri(rq)
+	}
+}
+
+// readMaskBinder 使用 bind 绑定请求，然后将查询参数 fields 解析为请求的 read_mask 字段.
+// fields 可以是逗号分隔的字段列表，也可以重复出现，例如 ?fields=title,content 或者 ?fields=title&fields=content.
+func readMaskBinder(c *gin.Context, bind func(any) error) core.Binder {
+	return func(rq any) error {
+		if err := bind(rq); err != nil {
+			return err
+		}
+
+		mask := fieldmask.Parse(c.QueryArray("fields")...)
+		if mask == nil {
+			return nil
+		}
+		m := rq.(proto.Message).ProtoReflect()
+		m.Set(m.Descriptor().Fields().ByName("read_mask"), protoreflect.ValueOfMessage(mask.ProtoReflect()))
+		return nil
+	}
+}
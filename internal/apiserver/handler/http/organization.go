@@ -0,0 +1,59 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/onexstack/onexstack/pkg/core"
+)
+
+// CreateOrganization 创建组织.
+func (h *Handler) CreateOrganization(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.OrganizationV1().Create, h.val.ValidateCreateOrganizationRequest)
+}
+
+// DeleteOrganization 删除组织.
+func (h *Handler) DeleteOrganization(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.OrganizationV1().Delete, h.val.ValidateDeleteOrganizationRequest)
+}
+
+// GetOrganization 获取组织详情.
+func (h *Handler) GetOrganization(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.OrganizationV1().Get, h.val.ValidateGetOrganizationRequest)
+}
+
+// ListOrganizations 列出当前用户所属的组织.
+func (h *Handler) ListOrganizations(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.OrganizationV1().List, h.val.ValidateListOrganizationsRequest)
+}
+
+// ListOrganizationMembers 列出组织成员，组织 ID 来自 URI，分页参数来自查询参数.
+func (h *Handler) ListOrganizationMembers(c *gin.Context) {
+	binder := func(rq any) error {
+		if err := c.ShouldBindQuery(rq); err != nil {
+			return err
+		}
+		return c.ShouldBindUri(rq)
+	}
+	core.HandleRequest(c, binder, h.biz.OrganizationV1().ListMembers, h.val.ValidateListOrganizationMembersRequest)
+}
+
+// AddOrganizationMember 添加组织成员，组织 ID 来自 URI，用户和角色来自请求体.
+func (h *Handler) AddOrganizationMember(c *gin.Context) {
+	binder := func(rq any) error {
+		if err := c.ShouldBindJSON(rq); err != nil {
+			return err
+		}
+		return c.ShouldBindUri(rq)
+	}
+	core.HandleRequest(c, binder, h.biz.OrganizationV1().AddMember, h.val.ValidateAddOrganizationMemberRequest)
+}
+
+// RemoveOrganizationMember 移除组织成员.
+func (h *Handler) RemoveOrganizationMember(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.OrganizationV1().RemoveMember, h.val.ValidateRemoveOrganizationMemberRequest)
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/onexstack/onexstack/pkg/core"
+)
+
+// ListPolicies 列出访问控制策略.
+func (h *Handler) ListPolicies(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.PolicyV1().List, h.val.ValidateListPoliciesRequest)
+}
+
+// CreatePolicy 添加访问控制策略.
+func (h *Handler) CreatePolicy(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PolicyV1().Create, h.val.ValidateCreatePolicyRequest)
+}
+
+// DeletePolicy 删除访问控制策略.
+func (h *Handler) DeletePolicy(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PolicyV1().Delete, h.val.ValidateDeletePolicyRequest)
+}
+
+// ListRoleAssignments 列出角色分配记录.
+func (h *Handler) ListRoleAssignments(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.PolicyV1().ListRoleAssignments, h.val.ValidateListRoleAssignmentsRequest)
+}
+
+// AssignRole 分配角色.
+func (h *Handler) AssignRole(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PolicyV1().AssignRole, h.val.ValidateAssignRoleRequest)
+}
+
+// RevokeRole 撤销角色.
+func (h *Handler) RevokeRole(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PolicyV1().RevokeRole, h.val.ValidateRevokeRoleRequest)
+}
+
+// ListRoles 列出所有角色.
+func (h *Handler) ListRoles(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.PolicyV1().ListRoles, h.val.ValidateListRolesRequest)
+}
+
+// CreateRole 定义自定义角色.
+func (h *Handler) CreateRole(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PolicyV1().CreateRole, h.val.ValidateCreateRoleRequest)
+}
+
+// DeleteRole 删除自定义角色.
+func (h *Handler) DeleteRole(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.PolicyV1().DeleteRole, h.val.ValidateDeleteRoleRequest)
+}
+
+// CheckPermission 检查主体是否拥有权限.
+func (h *Handler) CheckPermission(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PolicyV1().CheckPermission, h.val.ValidateCheckPermissionRequest)
+}
@@ -16,22 +16,48 @@ func (h *Handler) CreatePost(c *gin.Context) {
 	core.HandleJSONRequest(c, h.biz.PostV1().Create, h.val.ValidateCreatePostRequest)
 }
 
-// UpdatePost 更新博客帖子.
+// UpdatePost 更新博客帖子，请求体中的 updateMask 指定要更新的字段.
 func (h *Handler) UpdatePost(c *gin.Context) {
-	core.HandleJSONRequest(c, h.biz.PostV1().Update, h.val.ValidateUpdatePostRequest)
+	core.HandleRequest(c, protoJSONBinder(c), h.biz.PostV1().Update, h.val.ValidateUpdatePostRequest)
 }
 
 // DeletePost 删除博客帖子.
 func (h *Handler) DeletePost(c *gin.Context) {
-	core.HandleUriRequest(c, h.biz.PostV1().Delete, h.val.ValidateDeletePostRequest)
+	core.HandleJSONRequest(c, h.biz.PostV1().Delete, h.val.ValidateDeletePostRequest)
 }
 
-// GetPost 获取博客帖子.
+// GetPost 获取博客帖子，查询参数 fields 指定要返回的字段.
 func (h *Handler) GetPost(c *gin.Context) {
-	core.HandleUriRequest(c, h.biz.PostV1().Get, h.val.ValidateGetPostRequest)
+	core.HandleRequest(c, readMaskBinder(c, c.ShouldBindUri), h.biz.PostV1().Get, h.val.ValidateGetPostRequest)
 }
 
-// ListPost 列出用户的所有博客帖子.
+// ListPost 列出用户的所有博客帖子，查询参数 fields 指定要返回的字段.
 func (h *Handler) ListPost(c *gin.Context) {
-	core.HandleQueryRequest(c, h.biz.PostV1().List, h.val.ValidateListPostRequest)
+	core.HandleRequest(c, readMaskBinder(c, c.ShouldBindQuery), h.biz.PostV1().List, h.val.ValidateListPostRequest)
+}
+
+// SharePost 分享博客帖子，博客 ID 来自 URI，被分享的用户和角色来自请求体.
+func (h *Handler) SharePost(c *gin.Context) {
+	binder := func(rq any) error {
+		if err := c.ShouldBindJSON(rq); err != nil {
+			return err
+		}
+		return c.ShouldBindUri(rq)
+	}
+	core.HandleRequest(c, binder, h.biz.PostV1().Share, h.val.ValidateSharePostRequest)
+}
+
+// UnsharePost 取消分享博客帖子.
+func (h *Handler) UnsharePost(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.PostV1().Unshare, h.val.ValidateUnsharePostRequest)
+}
+
+// BatchGetPosts 批量获取博客帖子，博客 ID 列表来自查询参数 postIDs.
+func (h *Handler) BatchGetPosts(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.PostV1().BatchGet, h.val.ValidateBatchGetPostsRequest)
+}
+
+// BatchCreatePosts 批量创建博客帖子.
+func (h *Handler) BatchCreatePosts(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PostV1().BatchCreate, h.val.ValidateBatchCreatePostsRequest)
 }
@@ -16,6 +16,33 @@ func (h *Handler) Login(c *gin.Context) {
 	core.HandleJSONRequest(c, h.biz.UserV1().Login, h.val.ValidateLoginRequest)
 }
 
+// CreateCaptcha 签发人机验证挑战.
+func (h *Handler) CreateCaptcha(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().CreateCaptcha, h.val.ValidateCreateCaptchaRequest)
+}
+
+// LoginVerify 使用二次验证动态码完成登录并返回 JWT Token.
+func (h *Handler) LoginVerify(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().LoginVerify, h.val.ValidateLoginVerifyRequest)
+}
+
+// OIDCAuthorize 发起 OIDC 登录，返回身份提供方的授权地址.
+func (h *Handler) OIDCAuthorize(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.UserV1().OIDCAuthorize, h.val.ValidateOIDCAuthorizeRequest)
+}
+
+// OIDCCallback 使用授权码完成 OIDC 登录并返回 JWT Token.
+// 身份提供方名称来自 URI，授权码等参数来自请求体.
+func (h *Handler) OIDCCallback(c *gin.Context) {
+	binder := func(rq any) error {
+		if err := c.ShouldBindJSON(rq); err != nil {
+			return err
+		}
+		return c.ShouldBindUri(rq)
+	}
+	core.HandleRequest(c, binder, h.biz.UserV1().OIDCCallback, h.val.ValidateOIDCCallbackRequest)
+}
+
 // RefreshToken 刷新 JWT Token.
 func (h *Handler) RefreshToken(c *gin.Context) {
 	core.HandleJSONRequest(c, h.biz.UserV1().RefreshToken)
@@ -26,14 +53,62 @@ func (h *Handler) ChangePassword(c *gin.Context) {
 	core.HandleJSONRequest(c, h.biz.UserV1().ChangePassword, h.val.ValidateChangePasswordRequest)
 }
 
+// EnrollTOTP 注册 TOTP 二次验证.
+func (h *Handler) EnrollTOTP(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().EnrollTOTP, h.val.ValidateEnrollTOTPRequest)
+}
+
+// EnableTOTP 校验动态码并启用 TOTP 二次验证.
+func (h *Handler) EnableTOTP(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().EnableTOTP, h.val.ValidateEnableTOTPRequest)
+}
+
+// RequestPasswordReset 请求重置密码，向用户邮箱发送重置链接.
+func (h *Handler) RequestPasswordReset(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().RequestPasswordReset, h.val.ValidateRequestPasswordResetRequest)
+}
+
+// ResetPassword 使用重置令牌设置新密码.
+func (h *Handler) ResetPassword(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().ResetPassword, h.val.ValidateResetPasswordRequest)
+}
+
+// SendVerificationEmail 向用户邮箱发送验证邮件.
+func (h *Handler) SendVerificationEmail(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().SendVerificationEmail, h.val.ValidateSendVerificationEmailRequest)
+}
+
+// VerifyEmail 使用验证令牌完成邮箱验证.
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().VerifyEmail, h.val.ValidateVerifyEmailRequest)
+}
+
+// UnlockUser 解除用户因登录失败次数过多导致的锁定.
+func (h *Handler) UnlockUser(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.UserV1().Unlock, h.val.ValidateUnlockUserRequest)
+}
+
+// Impersonate 以指定用户的身份签发短期令牌，用户 ID 来自 URI，模拟原因来自可选的请求体.
+func (h *Handler) Impersonate(c *gin.Context) {
+	binder := func(rq any) error {
+		if c.Request.ContentLength != 0 {
+			if err := c.ShouldBindJSON(rq); err != nil {
+				return err
+			}
+		}
+		return c.ShouldBindUri(rq)
+	}
+	core.HandleRequest(c, binder, h.biz.UserV1().Impersonate, h.val.ValidateImpersonateRequest)
+}
+
 // CreateUser 创建新用户.
 func (h *Handler) CreateUser(c *gin.Context) {
 	core.HandleJSONRequest(c, h.biz.UserV1().Create, h.val.ValidateCreateUserRequest)
 }
 
-// UpdateUser 更新用户信息.
+// UpdateUser 更新用户信息，请求体中的 updateMask 指定要更新的字段.
 func (h *Handler) UpdateUser(c *gin.Context) {
-	core.HandleJSONRequest(c, h.biz.UserV1().Update, h.val.ValidateUpdateUserRequest)
+	core.HandleRequest(c, protoJSONBinder(c), h.biz.UserV1().Update, h.val.ValidateUpdateUserRequest)
 }
 
 // DeleteUser 删除用户.
@@ -41,12 +116,17 @@ func (h *Handler) DeleteUser(c *gin.Context) {
 	core.HandleUriRequest(c, h.biz.UserV1().Delete, h.val.ValidateDeleteUserRequest)
 }
 
-// GetUser 获取用户信息.
+// BatchDeleteUsers 批量删除用户.
+func (h *Handler) BatchDeleteUsers(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().BatchDelete, h.val.ValidateBatchDeleteUsersRequest)
+}
+
+// GetUser 获取用户信息，查询参数 fields 指定要返回的字段.
 func (h *Handler) GetUser(c *gin.Context) {
-	core.HandleUriRequest(c, h.biz.UserV1().Get, h.val.ValidateGetUsreRequest)
+	core.HandleRequest(c, readMaskBinder(c, c.ShouldBindUri), h.biz.UserV1().Get, h.val.ValidateGetUsreRequest)
 }
 
-// ListUser 列出用户信息.
+// ListUser 列出用户信息，查询参数 fields 指定要返回的字段.
 func (h *Handler) ListUser(c *gin.Context) {
-	core.HandleQueryRequest(c, h.biz.UserV1().List, h.val.ValidateListUserRequest)
+	core.HandleRequest(c, readMaskBinder(c, c.ShouldBindQuery), h.biz.UserV1().List, h.val.ValidateListUserRequest)
 }
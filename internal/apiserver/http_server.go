@@ -11,10 +11,12 @@ import (
 
 	"github.com/gin-contrib/pprof"
 	"github.com/onexstack/onexstack/pkg/core"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	handler "github.com/TobyIcetea/miniblog/internal/apiserver/handler/http"
 	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
 	mw "github.com/TobyIcetea/miniblog/internal/pkg/middleware/gin"
+	"github.com/TobyIcetea/miniblog/internal/pkg/permission"
 	"github.com/TobyIcetea/miniblog/internal/pkg/server"
 	"github.com/gin-gonic/gin"
 )
@@ -28,19 +30,37 @@ type ginServer struct {
 var _ server.Server = (*ginServer)(nil)
 
 // NewGinServer 初始化一个新的 Gin 服务器实例.
-func (c *ServerConfig) NewGinServer() server.Server {
+func (c *ServerConfig) NewGinServer() (server.Server, error) {
+	engine, err := c.newGinEngine()
+	if err != nil {
+		return nil, err
+	}
+
+	httpsrv, err := server.NewHTTPServer(c.cfg.HTTPOptions, c.cfg.TLSOptions, c.cfg.MTLSOptions, engine)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ginServer{srv: httpsrv}, nil
+}
+
+// newGinEngine 创建注册了全局中间件和所有路由的 Gin 引擎.
+func (c *ServerConfig) newGinEngine() (*gin.Engine, error) {
 	// 创建 Gin 引擎
 	engine := gin.New()
 
-	// 注册全局中间件，用于恢复 panic、设置 HTTP 头，添加请求 ID 等
-	engine.Use(gin.Recovery(), mw.NoCache, mw.Cors, mw.Secure, mw.RequestIDMiddleware())
+	// 只信任配置的代理转发的 X-Forwarded-For，默认不信任任何代理，直接使用 TCP 对端地址作为客户端 IP
+	if err := engine.SetTrustedProxies(c.cfg.TrustedProxies); err != nil {
+		return nil, err
+	}
+
+	// 注册全局中间件，用于恢复 panic、设置 HTTP 头，添加请求 ID、记录客户端 IP、记录审计日志等
+	engine.Use(gin.Recovery(), mw.NoCache, mw.Cors, mw.Secure, mw.RequestIDMiddleware(), mw.ClientIPMiddleware(), mw.AuditMiddleware(c.auditor))
 
 	// 注册 REST API 路由
 	c.InstallRESTAPI(engine)
 
-	httpsrv := server.NewHTTPServer(c.cfg.HTTPOptions, c.cfg.TLSOptions, engine)
-
-	return &ginServer{srv: httpsrv}
+	return engine, nil
 }
 
 // 注册 API 路由。路由的路径和 HTTP 方法，严格遵循 REST 规范.
@@ -54,12 +74,38 @@ func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 	// 注册健康检查接口
 	engine.GET("/healthz", handler.Healthz)
 
+	// 认证中间件，开发认证模式下直接信任请求头中的用户 ID
+	authn := mw.AuthnMiddleware(c.retriever, c.mapper)
+	if c.cfg.DevAuthOptions.Enabled {
+		authn = mw.AuthnBypassMiddleware(c.cfg.DevAuthOptions.Header, c.retriever)
+	}
+
+	// 请求限流中间件，不需要认证的接口按客户端 IP 计数
+	rateLimit := mw.RateLimitMiddleware(c.limiter, permission.Default)
+
 	// 注册用户登录和令牌刷新接口。这 2 个接口比较简单，所以没有 API 版本
-	engine.POST("/login", handler.Login)
+	// 注册人机验证挑战签发接口，登录和注册需要人机验证时先调用该接口
+	engine.POST("/captcha", rateLimit, handler.CreateCaptcha)
+	engine.POST("/login", rateLimit, handler.Login)
+	// 开启二次验证的用户需要再调用该接口完成登录
+	engine.POST("/login/verify", rateLimit, handler.LoginVerify)
 	// 注意：认证中间件要在 hadnler.RefreshToken 之前加载
-	engine.PUT("/refresh-token", mw.AuthnMiddleware(c.retriever), handler.RefreshToken)
-
-	authMiddlewares := []gin.HandlerFunc{mw.AuthnMiddleware(c.retriever), mw.AuthzMiddleware(c.authz)}
+	engine.PUT("/refresh-token", authn, mw.ImpersonationMiddleware(permission.Default, impersonationForbidden), rateLimit, handler.RefreshToken)
+	// 注册密码重置和邮箱验证接口，这些接口通过邮件中的令牌认证，不需要 JWT 认证
+	engine.POST("/password-reset", rateLimit, handler.RequestPasswordReset)
+	engine.PUT("/password-reset", rateLimit, handler.ResetPassword)
+	engine.POST("/verify-email", rateLimit, handler.VerifyEmail)
+	// 注册第三方 OIDC 登录接口
+	engine.POST("/oidc/:provider/authorize", rateLimit, handler.OIDCAuthorize)
+	engine.POST("/oidc/:provider/callback", rateLimit, handler.OIDCCallback)
+
+	authMiddlewares := []gin.HandlerFunc{
+		authn,
+		mw.TenantMiddleware(c.tenants, c.tenantRetriever),
+		rateLimit,
+		mw.AuthzMiddleware(c.authz, permission.Default),
+		mw.ImpersonationMiddleware(permission.Default, impersonationForbidden),
+	}
 
 	// 注册 v1 版本 API 路由分组
 	v1 := engine.Group("/v1")
@@ -67,32 +113,83 @@ func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 		// 用户相关路由
 		userv1 := v1.Group("/users")
 		{
-			userv1.POST("", handler.CreateUser) // 创建用户。这里要注意注册用户的时候是不需要 JWT 认证的
+			userv1.POST("", rateLimit, handler.CreateUser) // 创建用户。这里要注意注册用户的时候是不需要 JWT 认证的
 			userv1.Use(authMiddlewares...)
-			userv1.PUT(":userID/change-password", handler.ChangePassword) // 修改用户密码
-			userv1.PUT(":userID", handler.UpdateUser)                     // 更新用户信息
-			userv1.DELETE(":userID", handler.DeleteUser)                  // 删除用户
-			userv1.GET(":userID", handler.GetUser)                        // 查询用户详情
-			userv1.GET("", handler.ListUser)                              // 查询用户列表
+			userv1.PUT(":userID/change-password", handler.ChangePassword)            // 修改用户密码
+			userv1.POST(":userID/totp", handler.EnrollTOTP)                          // 注册 TOTP 二次验证
+			userv1.PUT(":userID/totp/enable", handler.EnableTOTP)                    // 启用 TOTP 二次验证
+			userv1.POST(":userID/verification-email", handler.SendVerificationEmail) // 发送邮箱验证邮件
+			userv1.PUT(":userID", handler.UpdateUser)                                // 更新用户信息
+			userv1.POST(":userID/impersonate", handler.Impersonate)                  // 模拟登录
+			userv1.DELETE(":userID", handler.DeleteUser)                             // 删除用户
+			userv1.DELETE("batch", handler.BatchDeleteUsers)                         // 批量删除用户
+			userv1.GET(":userID", handler.GetUser)                                   // 查询用户详情
+			userv1.GET("", handler.ListUser)                                         // 查询用户列表
+		}
+
+		// 登录锁定相关路由，仅管理员可以访问
+		lockoutv1 := v1.Group("/lockouts", authMiddlewares...)
+		{
+			lockoutv1.DELETE(":userID", handler.UnlockUser) // 解除用户锁定
+		}
+
+		// 访问控制策略相关路由，仅管理员可以访问
+		policyv1 := v1.Group("", authMiddlewares...)
+		{
+			policyv1.GET("policies", handler.ListPolicies)                // 列出访问控制策略
+			policyv1.POST("policies", handler.CreatePolicy)               // 添加访问控制策略
+			policyv1.DELETE("policies", handler.DeletePolicy)             // 删除访问控制策略
+			policyv1.GET("role-assignments", handler.ListRoleAssignments) // 列出角色分配
+			policyv1.POST("role-assignments", handler.AssignRole)         // 分配角色
+			policyv1.DELETE("role-assignments", handler.RevokeRole)       // 撤销角色
+			policyv1.GET("roles", handler.ListRoles)                      // 列出所有角色
+			policyv1.POST("roles", handler.CreateRole)                    // 定义自定义角色
+			policyv1.DELETE("roles/:role", handler.DeleteRole)            // 删除自定义角色
+			policyv1.POST("permissions/check", handler.CheckPermission)   // 检查主体是否拥有权限
+		}
+
+		// 审计日志相关路由，仅管理员可以访问
+		auditv1 := v1.Group("/audit-events", authMiddlewares...)
+		{
+			auditv1.GET("", handler.ListAuditEvents) // 列出审计事件
+		}
+
+		// 组织相关路由
+		orgv1 := v1.Group("/organizations", authMiddlewares...)
+		{
+			orgv1.POST("", handler.CreateOrganization)                               // 创建组织
+			orgv1.DELETE(":orgID", handler.DeleteOrganization)                       // 删除组织
+			orgv1.GET(":orgID", handler.GetOrganization)                             // 查询组织详情
+			orgv1.GET("", handler.ListOrganizations)                                 // 查询当前用户所属的组织列表
+			orgv1.GET(":orgID/members", handler.ListOrganizationMembers)             // 查询组织成员列表
+			orgv1.POST(":orgID/members", handler.AddOrganizationMember)              // 添加组织成员
+			orgv1.DELETE(":orgID/members/:userID", handler.RemoveOrganizationMember) // 移除组织成员
 		}
 
 		// 博客相关路由
 		postv1 := v1.Group("/posts", authMiddlewares...)
 		{
-			postv1.POST("", handler.CreatePost)          // 创建博客
-			postv1.PUT(":postID", handler.UpdatePost)    // 更新博客
-			postv1.DELETE(":postID", handler.DeletePost) // 删除博客
-			postv1.GET(":postID", handler.GetPost)       // 查询博客详情
-			postv1.GET("", handler.ListPost)             // 查询博客列表
+			postv1.POST("", handler.CreatePost)                          // 创建博客
+			postv1.PUT(":postID", handler.UpdatePost)                    // 更新博客
+			postv1.DELETE("", handler.DeletePost)                        // 删除博客
+			postv1.GET(":postID", handler.GetPost)                       // 查询博客详情
+			postv1.GET("batch", handler.BatchGetPosts)                   // 批量查询博客详情
+			postv1.POST("batch", handler.BatchCreatePosts)               // 批量创建博客
+			postv1.GET("", handler.ListPost)                             // 查询博客列表
+			postv1.POST(":postID/shares", handler.SharePost)             // 分享博客
+			postv1.DELETE(":postID/shares/:userID", handler.UnsharePost) // 取消分享博客
 		}
 	}
 }
 
-// InstallGenericAPI 注册业务无关的路由，例如 pprof、404 处理等.
+// InstallGenericAPI 注册业务无关的路由，例如 pprof、Prometheus 指标、404 处理等.
 func InstallGenericAPI(engine *gin.Engine) {
 	// 注册 pprof 路由
 	pprof.Register(engine)
 
+	// 注册 Prometheus 指标路由
+	engine.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
 	// 注册 404 路由处理
 	engine.NoRoute(func(c *gin.Context) {
 		core.WriteResponse(c, errno.ErrPageNotFound, nil)
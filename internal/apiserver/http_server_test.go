@@ -0,0 +1,69 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package apiserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/devauth"
+	"github.com/TobyIcetea/miniblog/internal/pkg/permission"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRoutesHavePermission 确保 Gin 模式下的每个业务路由都能解析到与 gRPC 方法相同的权限标识.
+func TestRoutesHavePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	(&ServerConfig{cfg: &Config{DevAuthOptions: devauth.NewOptions()}}).InstallRESTAPI(engine)
+
+	for _, route := range engine.Routes() {
+		if strings.HasPrefix(route.Path, "/debug/") || route.Path == "/metrics" {
+			continue
+		}
+		if _, ok := permission.Default.ForRoute(route.Method, route.Path); !ok {
+			t.Errorf("route %s %s does not match any RPC http rule", route.Method, route.Path)
+		}
+	}
+}
+
+// TestGinClientIP 确保只有来自可信代理的 X-Forwarded-For 才会被用于解析客户端 IP.
+func TestGinClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name    string
+		trusted []string
+		want    string
+	}{
+		{name: "no trusted proxies", want: "10.0.0.2"},
+		{name: "trusted proxy", trusted: []string{"10.0.0.0/8"}, want: "198.51.100.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ServerConfig{cfg: &Config{DevAuthOptions: devauth.NewOptions(), TrustedProxies: tt.trusted}}
+			engine, err := c.newGinEngine()
+			require.NoError(t, err)
+			engine.GET("/test/ip", func(c *gin.Context) {
+				c.String(http.StatusOK, contextx.ClientIP(c.Request.Context()))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test/ip", nil)
+			req.RemoteAddr = "10.0.0.2:4000"
+			req.Header.Set("X-Forwarded-For", "1.2.3.4, 198.51.100.1")
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Body.String())
+		})
+	}
+}
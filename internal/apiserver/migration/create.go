@@ -0,0 +1,55 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// DefaultDir 是迁移脚本在源码仓库中的目录，相对于仓库根目录.
+const DefaultDir = "internal/apiserver/migration/sql"
+
+// nameRegexp 匹配合法的迁移名称.
+var nameRegexp = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Create 在 dir 目录下为每种数据库方言创建新版本的空迁移脚本，返回创建的文件路径.
+// 新版本号为 dir 目录中已有的最大版本号加 1.
+func Create(dir string, name string) ([]string, error) {
+	if !nameRegexp.MatchString(name) {
+		return nil, errors.New("migration name must only contain lowercase letters, digits and underscores")
+	}
+
+	var version uint64
+	for _, dialect := range Dialects() {
+		migrations, err := Load(os.DirFS(dir), dialect)
+		if err != nil {
+			return nil, err
+		}
+		if len(migrations) > 0 {
+			version = max(version, migrations[len(migrations)-1].Version)
+		}
+	}
+	version++
+
+	var created []string
+	for _, dialect := range Dialects() {
+		for _, direction := range []string{"up", "down"} {
+			filename := filepath.Join(dir, dialect, fmt.Sprintf("%06d_%s.%s.sql", version, name, direction))
+			content := fmt.Sprintf("-- %06d_%s (%s): 在这里编写 %s 迁移语句，语句以行尾的分号结束.\n", version, name, dialect, direction)
+			if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+				return created, err
+			}
+			created = append(created, filename)
+		}
+	}
+
+	return created, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// lockName 是 MySQL 中迁移锁的名称.
+	lockName = "miniblog:migrate"
+	// lockKey 是 PostgreSQL 中迁移锁的 advisory lock 键.
+	lockKey = 7283513001
+	// lockRetryInterval 是获取迁移锁失败后重试的间隔.
+	lockRetryInterval = 500 * time.Millisecond
+)
+
+// localLock 用于 SQLite 等没有数据库级别锁的场景，只在当前进程内互斥.
+var localLock sync.Mutex
+
+// acquireLock 获取迁移锁，保证同一时刻只有一个副本执行迁移，返回的函数用于释放锁.
+// MySQL 和 PostgreSQL 使用会话级别的锁，锁绑定在一个独立的连接上，进程异常退出时数据库会自动释放锁.
+// SQLite 只能被单个节点访问，这里只做进程内的互斥.
+func acquireLock(ctx context.Context, db *gorm.DB, timeout time.Duration) (func(), error) {
+	var tryQuery, releaseQuery string
+	var arg any
+	switch db.Dialector.Name() {
+	case "mysql":
+		tryQuery, releaseQuery, arg = "SELECT GET_LOCK(?, 0)", "SELECT RELEASE_LOCK(?)", lockName
+	case "postgres":
+		tryQuery, releaseQuery, arg = "SELECT pg_try_advisory_lock($1)", "SELECT pg_advisory_unlock($1)", int64(lockKey)
+	default:
+		localLock.Lock()
+		return localLock.Unlock, nil
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for {
+		var locked sql.NullBool
+		if err := conn.QueryRowContext(ctx, tryQuery, arg).Scan(&locked); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("acquire migration lock: %w", err)
+		}
+		if locked.Valid && locked.Bool {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+			return nil, errors.New("acquire migration lock: timed out waiting for another migration to finish")
+		case <-time.After(lockRetryInterval):
+		}
+	}
+
+	return func() {
+		_, _ = conn.ExecContext(context.Background(), releaseQuery, arg)
+		_ = conn.Close()
+	}, nil
+}
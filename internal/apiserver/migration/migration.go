@@ -0,0 +1,353 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package migration 提供内置在 apiserver 中的数据库版本化迁移.
+//
+// 迁移脚本按数据库方言存放在 sql/<dialect> 目录下，文件名格式为 <version>_<name>.up.sql 和
+// <version>_<name>.down.sql，并在编译时嵌入二进制文件. 已经执行的版本记录在 schema_migrations 表中.
+//
+// 脚本中的语句以行尾的分号分隔. 存储过程、触发器等语句体内包含分号时，需要使用
+// "-- +statement begin" 和 "-- +statement end" 包裹.
+package migration
+
+import (
+	"cmp"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	"gorm.io/gorm"
+)
+
+const (
+	// statementBegin 标记一个包含分号的完整语句的开始.
+	statementBegin = "-- +statement begin"
+	// statementEnd 标记一个包含分号的完整语句的结束.
+	statementEnd = "-- +statement end"
+)
+
+//go:embed sql
+var files embed.FS
+
+// filenameRegexp 匹配迁移脚本的文件名.
+var filenameRegexp = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Dialects 返回内置了迁移脚本的数据库方言，名称与 GORM Dialector 的名称一致.
+func Dialects() []string {
+	return []string{"mysql", "postgres", "sqlite"}
+}
+
+// Migration 表示一个版本的迁移.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status 表示一个版本的迁移状态.
+type Status struct {
+	Version uint64
+	Name    string
+	// AppliedAt 是迁移的执行时间，为空表示尚未执行
+	AppliedAt *time.Time
+}
+
+// schemaMigration 是记录已执行迁移版本的数据表.
+type schemaMigration struct {
+	Version   uint64    `gorm:"column:version;primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"column:name;size:255;not null"`
+	AppliedAt time.Time `gorm:"column:appliedAt;not null"`
+}
+
+// TableName 返回迁移版本表的表名.
+func (*schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator 负责执行和回滚数据库迁移.
+type Migrator struct {
+	db          *gorm.DB
+	migrations  []*Migration
+	lockTimeout time.Duration
+}
+
+// New 根据数据库方言加载内置的迁移脚本并创建 Migrator 实例.
+func New(db *gorm.DB, opts *Options) (*Migrator, error) {
+	migrations, err := Load(files, path.Join("sql", db.Dialector.Name()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations, lockTimeout: opts.LockTimeout}, nil
+}
+
+// Load 从 fsys 的 dir 目录中加载迁移脚本，并按版本号升序返回.
+func Load(fsys fs.FS, dir string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		matches := filenameRegexp.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("invalid migration filename: %s", entry.Name())
+		}
+
+		version, _ := strconv.ParseUint(matches[1], 10, 64)
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = m
+		}
+		if m.Name != matches[2] {
+			return nil, fmt.Errorf("migration %d has different names: %s and %s", version, m.Name, matches[2])
+		}
+		if matches[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", m.Version, m.Name)
+		}
+		migrations = append(migrations, m)
+	}
+	slices.SortFunc(migrations, func(a, b *Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return migrations, nil
+}
+
+// Up 按版本号升序执行未执行的迁移，steps 小于等于 0 时执行全部迁移，返回本次执行的迁移.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]*Migration, error) {
+	release, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []*Migration
+	for _, migration := range m.migrations {
+		if steps > 0 && len(done) >= steps {
+			break
+		}
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		err := m.exec(ctx, migration.Up, func(tx *gorm.DB) error {
+			return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("apply migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		log.Infow("Applied database migration", "version", migration.Version, "name", migration.Name)
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// Down 按版本号倒序回滚最近执行的 steps 个迁移，返回本次回滚的迁移.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	if steps <= 0 {
+		return nil, errors.New("steps must be greater than 0")
+	}
+
+	release, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]uint64, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	slices.SortFunc(versions, func(a, b uint64) int { return cmp.Compare(b, a) })
+
+	var done []*Migration
+	for _, version := range versions[:min(steps, len(versions))] {
+		idx := slices.IndexFunc(m.migrations, func(migration *Migration) bool { return migration.Version == version })
+		if idx < 0 {
+			return done, fmt.Errorf("migration %d is applied but its scripts are not found", version)
+		}
+
+		migration := m.migrations[idx]
+		err := m.exec(ctx, migration.Down, func(tx *gorm.DB) error {
+			return tx.Delete(&schemaMigration{Version: version}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("revert migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		log.Infow("Reverted database migration", "version", migration.Version, "name", migration.Name)
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// Status 返回所有迁移的执行状态，已经执行但脚本不存在的版本也会包含在结果中.
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := &Status{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &record.AppliedAt
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range applied {
+		statuses = append(statuses, &Status{Version: record.Version, Name: record.Name, AppliedAt: &record.AppliedAt})
+	}
+	slices.SortFunc(statuses, func(a, b *Status) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return statuses, nil
+}
+
+// lock 获取迁移锁，并确保迁移版本表存在.
+func (m *Migrator) lock(ctx context.Context) (func(), error) {
+	release, err := acquireLock(ctx, m.db, m.lockTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.db.WithContext(ctx).AutoMigrate(&schemaMigration{}); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+// applied 返回已经执行的迁移版本.
+func (m *Migrator) applied(ctx context.Context) (map[uint64]*schemaMigration, error) {
+	db := m.db.WithContext(ctx)
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		return map[uint64]*schemaMigration{}, nil
+	}
+
+	var records []*schemaMigration
+	if err := db.Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[uint64]*schemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// exec 在一个事务中执行迁移脚本并更新迁移版本表.
+// 注意：MySQL 的 DDL 语句会隐式提交事务，脚本执行失败时已经执行的 DDL 不会回滚.
+func (m *Migrator) exec(ctx context.Context, script string, record func(tx *gorm.DB) error) error {
+	statements, err := splitStatements(script)
+	if err != nil {
+		return err
+	}
+
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return record(tx)
+	})
+}
+
+// splitStatements 将迁移脚本拆分为单独的 SQL 语句，并去掉空行和注释行.
+// 部分数据库驱动（例如 MySQL）默认不允许在一次调用中执行多条语句.
+func splitStatements(script string) ([]string, error) {
+	var statements []string
+	var buf strings.Builder
+	flush := func() {
+		if statement := strings.TrimSpace(buf.String()); statement != "" {
+			statements = append(statements, statement)
+		}
+		buf.Reset()
+	}
+
+	inBlock := false
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == statementBegin:
+			if inBlock {
+				return nil, errors.New("nested statement block")
+			}
+			flush()
+			inBlock = true
+			continue
+		case trimmed == statementEnd:
+			if !inBlock {
+				return nil, errors.New("statement end without begin")
+			}
+			flush()
+			inBlock = false
+			continue
+		case !inBlock && (trimmed == "" || strings.HasPrefix(trimmed, "--")):
+			continue
+		}
+
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+		if !inBlock && strings.HasSuffix(trimmed, ";") {
+			flush()
+		}
+	}
+	if inBlock {
+		return nil, errors.New("unterminated statement block")
+	}
+	flush()
+
+	return statements, nil
+}
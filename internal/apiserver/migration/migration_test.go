@@ -0,0 +1,162 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package migration
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestMigrator(t *testing.T) (*Migrator, *gorm.DB) {
+	t.Helper()
+
+	opts := database.NewSQLiteOptions()
+	opts.Path = database.MemoryPath
+	db, err := opts.NewDB()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	m, err := New(db, NewOptions())
+	require.NoError(t, err)
+	return m, db
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	m, db := newTestMigrator(t)
+
+	done, err := m.Up(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, done, len(m.migrations))
+
+	// 再次执行时没有需要执行的迁移
+	done, err = m.Up(ctx, 0)
+	require.NoError(t, err)
+	assert.Empty(t, done)
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.NotNil(t, status.AppliedAt, "migration %d", status.Version)
+	}
+
+	var root model.UserM
+	require.NoError(t, db.Where(map[string]any{"username": "root"}).First(&root).Error)
+	assert.Equal(t, "user-000000", root.UserID)
+
+	done, err = m.Down(ctx, len(m.migrations))
+	require.NoError(t, err)
+	require.Len(t, done, len(m.migrations))
+	assert.False(t, db.Migrator().HasTable(&model.UserM{}))
+
+	statuses, err = m.Status(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.Nil(t, status.AppliedAt, "migration %d", status.Version)
+	}
+
+	done, err = m.Up(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+	assert.Equal(t, m.migrations[0].Version, done[0].Version)
+}
+
+// TestSchemaMatchesModels 确保迁移后的表结构包含 GORM 模型中定义的所有列和索引.
+func TestSchemaMatchesModels(t *testing.T) {
+	m, db := newTestMigrator(t)
+	_, err := m.Up(context.Background(), 0)
+	require.NoError(t, err)
+
+	models := []any{
+		&model.UserM{},
+		&model.PostM{},
+		&model.UserTOTPM{},
+		&model.RecoveryCodeM{},
+		&model.PasswordHistoryM{},
+		&model.UserIdentityM{},
+		&model.AuditEventM{},
+		&model.OrganizationM{},
+		&model.CasbinRuleM{},
+	}
+	for _, obj := range models {
+		stmt := &gorm.Statement{DB: db}
+		require.NoError(t, stmt.Parse(obj))
+		require.True(t, db.Migrator().HasTable(obj), "table %s", stmt.Schema.Table)
+
+		for _, field := range stmt.Schema.Fields {
+			assert.True(t, db.Migrator().HasColumn(obj, field.DBName), "column %s.%s", stmt.Schema.Table, field.DBName)
+		}
+		for _, idx := range stmt.Schema.ParseIndexes() {
+			assert.True(t, db.Migrator().HasIndex(obj, idx.Name), "index %s.%s", stmt.Schema.Table, idx.Name)
+		}
+	}
+}
+
+func TestAuditEventAppendOnly(t *testing.T) {
+	m, db := newTestMigrator(t)
+	_, err := m.Up(context.Background(), 0)
+	require.NoError(t, err)
+
+	event := &model.AuditEventM{Action: "user.create", Outcome: "success"}
+	require.NoError(t, db.Create(event).Error)
+
+	assert.Error(t, db.Model(event).Update("outcome", "failure").Error)
+	assert.Error(t, db.Delete(event).Error)
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- comment
+CREATE TABLE a (id int);
+
+INSERT INTO a VALUES (1),
+(2);
+-- +statement begin
+CREATE TRIGGER t BEFORE DELETE ON a
+BEGIN
+  SELECT RAISE(ABORT, 'no');
+END;
+-- +statement end
+`
+	statements, err := splitStatements(script)
+	require.NoError(t, err)
+	require.Len(t, statements, 3)
+	assert.Equal(t, "CREATE TABLE a (id int);", statements[0])
+	assert.Equal(t, "INSERT INTO a VALUES (1),\n(2);", statements[1])
+	assert.Contains(t, statements[2], "SELECT RAISE(ABORT, 'no');\nEND;")
+
+	_, err = splitStatements("-- +statement begin\nSELECT 1;")
+	assert.Error(t, err)
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	for _, dialect := range Dialects() {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, dialect), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, dialect, "000007_init.up.sql"), []byte("SELECT 1;"), 0o644))
+	}
+
+	created, err := Create(dir, "add_column")
+	require.NoError(t, err)
+	assert.Len(t, created, 2*len(Dialects()))
+	assert.FileExists(t, filepath.Join(dir, "mysql", "000008_add_column.up.sql"))
+	assert.FileExists(t, filepath.Join(dir, "sqlite", "000008_add_column.down.sql"))
+
+	_, err = Create(dir, "Bad-Name")
+	assert.Error(t, err)
+}
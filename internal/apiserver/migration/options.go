@@ -0,0 +1,47 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package migration
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Options 包含数据库迁移相关的配置选项.
+type Options struct {
+	// Auto 表示是否在服务启动时自动执行所有未执行的迁移
+	Auto bool `json:"auto" mapstructure:"auto"`
+	// LockTimeout 是等待迁移锁的最长时间，多个副本同时启动时只有获得锁的副本会执行迁移
+	LockTimeout time.Duration `json:"lock-timeout" mapstructure:"lock-timeout"`
+}
+
+// NewOptions 创建带有默认值的 Options 实例.
+func NewOptions() *Options {
+	return &Options{
+		Auto:        false,
+		LockTimeout: time.Minute,
+	}
+}
+
+// AddFlags 将数据库迁移相关的选项绑定到命令行标志.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Auto, "migrate.auto", o.Auto, "Apply pending database migrations when the server starts.")
+	fs.DurationVar(&o.LockTimeout, "migrate.lock-timeout", o.LockTimeout, "Maximum time to wait for the migration lock held by another replica.")
+}
+
+// Validate 校验数据库迁移配置选项是否合法.
+func (o *Options) Validate() []error {
+	errs := []error{}
+
+	if o.LockTimeout <= 0 {
+		errs = append(errs, errors.New("migrate.lock-timeout must be greater than 0"))
+	}
+
+	return errs
+}
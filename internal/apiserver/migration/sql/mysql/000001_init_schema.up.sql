@@ -0,0 +1,130 @@
+-- 初始化数据库表结构，索引名称与 GORM 模型中的定义保持一致.
+
+CREATE TABLE IF NOT EXISTS `user` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `username` varchar(255) NOT NULL DEFAULT '' COMMENT '用户名（唯一）',
+  `password` varchar(255) NOT NULL DEFAULT '' COMMENT '用户密码（加密后）',
+  `nickname` varchar(30) NOT NULL DEFAULT '' COMMENT '用户昵称',
+  `email` varchar(256) NOT NULL DEFAULT '' COMMENT '用户电子邮箱地址',
+  `emailVerified` tinyint(1) NOT NULL DEFAULT 0 COMMENT '用户电子邮箱是否已验证',
+  `phone` varchar(16) NOT NULL DEFAULT '' COMMENT '用户手机号',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '用户创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '用户最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_user_userID` (`userID`),
+  UNIQUE KEY `idx_user_username` (`username`),
+  UNIQUE KEY `idx_user_phone` (`phone`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='用户表';
+
+CREATE TABLE IF NOT EXISTS `post` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `tenantID` varchar(64) NOT NULL DEFAULT 'default' COMMENT '所属租户（组织）ID',
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `postID` varchar(35) NOT NULL DEFAULT '' COMMENT '博文唯一 ID',
+  `title` varchar(256) NOT NULL DEFAULT '' COMMENT '博文标题',
+  `content` longtext NOT NULL COMMENT '博文内容',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '博文创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '博文最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_post_postID` (`postID`),
+  KEY `idx_post_userID` (`userID`),
+  KEY `idx_post_tenantID` (`tenantID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='博文表';
+
+CREATE TABLE IF NOT EXISTS `user_totp` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `secret` varchar(64) NOT NULL DEFAULT '' COMMENT 'TOTP 密钥（Base32 编码）',
+  `enabled` tinyint(1) NOT NULL DEFAULT 0 COMMENT '是否已启用二次验证',
+  `lastUsedStep` bigint NOT NULL DEFAULT 0 COMMENT '最近一次成功校验的时间步，用于防重放',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_user_totp_userID` (`userID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='用户 TOTP 二次验证表';
+
+CREATE TABLE IF NOT EXISTS `recovery_code` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `codeHash` varchar(64) NOT NULL DEFAULT '' COMMENT '恢复码摘要',
+  `usedAt` datetime DEFAULT NULL COMMENT '使用时间，为空表示未使用',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_recovery_code_userID` (`userID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='二次验证恢复码表';
+
+CREATE TABLE IF NOT EXISTS `password_history` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `password` varchar(255) NOT NULL DEFAULT '' COMMENT '历史密码的密文',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_password_history_userID` (`userID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='历史密码表';
+
+CREATE TABLE IF NOT EXISTS `user_identity` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `userID` varchar(36) NOT NULL DEFAULT '' COMMENT '用户唯一 ID',
+  `provider` varchar(64) NOT NULL DEFAULT '' COMMENT '身份提供方名称',
+  `subject` varchar(255) NOT NULL DEFAULT '' COMMENT '用户在身份提供方中的唯一标识',
+  `email` varchar(256) NOT NULL DEFAULT '' COMMENT '身份提供方返回的电子邮箱地址',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_user_identity_provider_subject` (`provider`, `subject`),
+  KEY `idx_user_identity_userID` (`userID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='第三方登录身份关联表';
+
+CREATE TABLE IF NOT EXISTS `audit_event` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `requestID` varchar(36) NOT NULL DEFAULT '' COMMENT '请求 ID',
+  `actor` varchar(36) NOT NULL DEFAULT '' COMMENT '操作者的用户 ID',
+  `subject` varchar(36) NOT NULL DEFAULT '' COMMENT '模拟登录时被模拟的用户 ID',
+  `action` varchar(128) NOT NULL DEFAULT '' COMMENT '操作类型，例如 policy.create、gRPC 方法名或 HTTP 路由',
+  `resource` varchar(255) NOT NULL DEFAULT '' COMMENT '操作的资源',
+  `outcome` varchar(16) NOT NULL DEFAULT '' COMMENT '操作结果，可选值为 success 和 failure',
+  `reason` varchar(128) NOT NULL DEFAULT '' COMMENT '操作失败时的错误原因',
+  `detail` text NOT NULL COMMENT '操作详情（JSON 格式）',
+  `clientIP` varchar(64) NOT NULL DEFAULT '' COMMENT '客户端 IP 地址',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '事件发生时间',
+  PRIMARY KEY (`id`),
+  KEY `idx_audit_event_actor` (`actor`),
+  KEY `idx_audit_event_subject` (`subject`),
+  KEY `idx_audit_event_action` (`action`),
+  KEY `idx_audit_event_requestID` (`requestID`),
+  KEY `idx_audit_event_createdAt` (`createdAt`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='审计事件表';
+
+-- 审计事件表只允许追加，禁止修改和删除已有记录
+DROP TRIGGER IF EXISTS `audit_event_no_update`;
+CREATE TRIGGER `audit_event_no_update` BEFORE UPDATE ON `audit_event` FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_event is append-only';
+DROP TRIGGER IF EXISTS `audit_event_no_delete`;
+CREATE TRIGGER `audit_event_no_delete` BEFORE DELETE ON `audit_event` FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_event is append-only';
+
+CREATE TABLE IF NOT EXISTS `organization` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `orgID` varchar(36) NOT NULL DEFAULT '' COMMENT '组织唯一 ID',
+  `slug` varchar(63) NOT NULL DEFAULT '' COMMENT '组织短名称，用于子域名解析',
+  `name` varchar(255) NOT NULL DEFAULT '' COMMENT '组织名称',
+  `ownerID` varchar(36) NOT NULL DEFAULT '' COMMENT '创建者的用户 ID',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '组织创建时间',
+  `updatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '组织最后修改时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_organization_orgID` (`orgID`),
+  UNIQUE KEY `idx_organization_slug` (`slug`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='组织（租户）表';
+
+-- 表结构与 Casbin GORM 适配器保持一致
+CREATE TABLE IF NOT EXISTS `casbin_rule` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `ptype` varchar(100) DEFAULT NULL,
+  `v0` varchar(100) DEFAULT NULL,
+  `v1` varchar(100) DEFAULT NULL,
+  `v2` varchar(100) DEFAULT NULL,
+  `v3` varchar(100) DEFAULT NULL,
+  `v4` varchar(100) DEFAULT NULL,
+  `v5` varchar(100) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_casbin_rule` (`ptype`, `v0`, `v1`, `v2`, `v3`, `v4`, `v5`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
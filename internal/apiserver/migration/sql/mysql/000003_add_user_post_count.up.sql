@@ -0,0 +1,5 @@
+-- 为用户表添加冗余的博客数量列，开启 store.denormalized-post-count 时由 Store 层维护.
+
+ALTER TABLE `user` ADD COLUMN `postCount` bigint NOT NULL DEFAULT 0 COMMENT '用户的博客数量（冗余字段）' AFTER `phone`;
+
+UPDATE `user` SET `postCount` = (SELECT COUNT(*) FROM `post` WHERE `post`.`userID` = `user`.`userID`);
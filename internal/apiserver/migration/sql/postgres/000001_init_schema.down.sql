@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS "casbin_rule";
+DROP TABLE IF EXISTS "organization";
+DROP TABLE IF EXISTS "audit_event";
+DROP FUNCTION IF EXISTS audit_event_append_only();
+DROP TABLE IF EXISTS "user_identity";
+DROP TABLE IF EXISTS "password_history";
+DROP TABLE IF EXISTS "recovery_code";
+DROP TABLE IF EXISTS "user_totp";
+DROP TABLE IF EXISTS "post";
+DROP TABLE IF EXISTS "user";
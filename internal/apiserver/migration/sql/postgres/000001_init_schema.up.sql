@@ -0,0 +1,127 @@
+-- 初始化数据库表结构，索引名称与 GORM 模型中的定义保持一致.
+-- 列名使用驼峰命名，需要使用双引号包裹.
+
+CREATE TABLE IF NOT EXISTS "user" (
+  "id" bigserial PRIMARY KEY,
+  "userID" varchar(36) NOT NULL DEFAULT '',
+  "username" varchar(255) NOT NULL DEFAULT '',
+  "password" varchar(255) NOT NULL DEFAULT '',
+  "nickname" varchar(30) NOT NULL DEFAULT '',
+  "email" varchar(256) NOT NULL DEFAULT '',
+  "emailVerified" boolean NOT NULL DEFAULT false,
+  "phone" varchar(16) NOT NULL DEFAULT '',
+  "createdAt" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updatedAt" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_userID" ON "user" ("userID");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_username" ON "user" ("username");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_phone" ON "user" ("phone");
+
+CREATE TABLE IF NOT EXISTS "post" (
+  "id" bigserial PRIMARY KEY,
+  "tenantID" varchar(64) NOT NULL DEFAULT 'default',
+  "userID" varchar(36) NOT NULL DEFAULT '',
+  "postID" varchar(35) NOT NULL DEFAULT '',
+  "title" varchar(256) NOT NULL DEFAULT '',
+  "content" text NOT NULL DEFAULT '',
+  "createdAt" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updatedAt" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_post_postID" ON "post" ("postID");
+CREATE INDEX IF NOT EXISTS "idx_post_userID" ON "post" ("userID");
+CREATE INDEX IF NOT EXISTS "idx_post_tenantID" ON "post" ("tenantID");
+
+CREATE TABLE IF NOT EXISTS "user_totp" (
+  "id" bigserial PRIMARY KEY,
+  "userID" varchar(36) NOT NULL DEFAULT '',
+  "secret" varchar(64) NOT NULL DEFAULT '',
+  "enabled" boolean NOT NULL DEFAULT false,
+  "lastUsedStep" bigint NOT NULL DEFAULT 0,
+  "createdAt" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updatedAt" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_totp_userID" ON "user_totp" ("userID");
+
+CREATE TABLE IF NOT EXISTS "recovery_code" (
+  "id" bigserial PRIMARY KEY,
+  "userID" varchar(36) NOT NULL DEFAULT '',
+  "codeHash" varchar(64) NOT NULL DEFAULT '',
+  "usedAt" timestamptz,
+  "createdAt" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "idx_recovery_code_userID" ON "recovery_code" ("userID");
+
+CREATE TABLE IF NOT EXISTS "password_history" (
+  "id" bigserial PRIMARY KEY,
+  "userID" varchar(36) NOT NULL DEFAULT '',
+  "password" varchar(255) NOT NULL DEFAULT '',
+  "createdAt" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "idx_password_history_userID" ON "password_history" ("userID");
+
+CREATE TABLE IF NOT EXISTS "user_identity" (
+  "id" bigserial PRIMARY KEY,
+  "userID" varchar(36) NOT NULL DEFAULT '',
+  "provider" varchar(64) NOT NULL DEFAULT '',
+  "subject" varchar(255) NOT NULL DEFAULT '',
+  "email" varchar(256) NOT NULL DEFAULT '',
+  "createdAt" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updatedAt" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_identity_provider_subject" ON "user_identity" ("provider", "subject");
+CREATE INDEX IF NOT EXISTS "idx_user_identity_userID" ON "user_identity" ("userID");
+
+CREATE TABLE IF NOT EXISTS "audit_event" (
+  "id" bigserial PRIMARY KEY,
+  "requestID" varchar(36) NOT NULL DEFAULT '',
+  "actor" varchar(36) NOT NULL DEFAULT '',
+  "subject" varchar(36) NOT NULL DEFAULT '',
+  "action" varchar(128) NOT NULL DEFAULT '',
+  "resource" varchar(255) NOT NULL DEFAULT '',
+  "outcome" varchar(16) NOT NULL DEFAULT '',
+  "reason" varchar(128) NOT NULL DEFAULT '',
+  "detail" text NOT NULL DEFAULT '',
+  "clientIP" varchar(64) NOT NULL DEFAULT '',
+  "createdAt" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS "idx_audit_event_actor" ON "audit_event" ("actor");
+CREATE INDEX IF NOT EXISTS "idx_audit_event_subject" ON "audit_event" ("subject");
+CREATE INDEX IF NOT EXISTS "idx_audit_event_action" ON "audit_event" ("action");
+CREATE INDEX IF NOT EXISTS "idx_audit_event_requestID" ON "audit_event" ("requestID");
+CREATE INDEX IF NOT EXISTS "idx_audit_event_createdAt" ON "audit_event" ("createdAt");
+
+-- 审计事件表只允许追加，禁止修改和删除已有记录
+-- +statement begin
+CREATE OR REPLACE FUNCTION audit_event_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_event is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +statement end
+DROP TRIGGER IF EXISTS "audit_event_no_modify" ON "audit_event";
+CREATE TRIGGER "audit_event_no_modify" BEFORE UPDATE OR DELETE ON "audit_event" FOR EACH ROW EXECUTE FUNCTION audit_event_append_only();
+
+CREATE TABLE IF NOT EXISTS "organization" (
+  "id" bigserial PRIMARY KEY,
+  "orgID" varchar(36) NOT NULL DEFAULT '',
+  "slug" varchar(63) NOT NULL DEFAULT '',
+  "name" varchar(255) NOT NULL DEFAULT '',
+  "ownerID" varchar(36) NOT NULL DEFAULT '',
+  "createdAt" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updatedAt" timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_organization_orgID" ON "organization" ("orgID");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_organization_slug" ON "organization" ("slug");
+
+-- 表结构与 Casbin GORM 适配器保持一致
+CREATE TABLE IF NOT EXISTS "casbin_rule" (
+  "id" bigserial PRIMARY KEY,
+  "ptype" varchar(100),
+  "v0" varchar(100),
+  "v1" varchar(100),
+  "v2" varchar(100),
+  "v3" varchar(100),
+  "v4" varchar(100),
+  "v5" varchar(100)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_casbin_rule" ON "casbin_rule" ("ptype", "v0", "v1", "v2", "v3", "v4", "v5");
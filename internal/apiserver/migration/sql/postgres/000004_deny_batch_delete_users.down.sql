@@ -0,0 +1 @@
+DELETE FROM "casbin_rule" WHERE "ptype" = 'p' AND "v0" = 'role::user' AND "v2" = 'users.batch-delete';
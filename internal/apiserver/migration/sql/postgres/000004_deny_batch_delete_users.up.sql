@@ -0,0 +1,5 @@
+-- 批量删除用户与删除用户一样，仅管理员可以调用.
+
+INSERT INTO "casbin_rule" ("ptype", "v0", "v1", "v2", "v3", "v4", "v5") VALUES
+('p', 'role::user', '*', 'users.batch-delete', 'CALL', 'deny', '')
+ON CONFLICT DO NOTHING;
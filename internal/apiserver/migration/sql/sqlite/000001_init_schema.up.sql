@@ -0,0 +1,129 @@
+-- 初始化数据库表结构，索引名称与 GORM 模型中的定义保持一致.
+
+CREATE TABLE IF NOT EXISTS `user` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `userID` text NOT NULL DEFAULT '',
+  `username` text NOT NULL DEFAULT '',
+  `password` text NOT NULL DEFAULT '',
+  `nickname` text NOT NULL DEFAULT '',
+  `email` text NOT NULL DEFAULT '',
+  `emailVerified` numeric NOT NULL DEFAULT false,
+  `phone` text NOT NULL DEFAULT '',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_user_userID` ON `user` (`userID`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_user_username` ON `user` (`username`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_user_phone` ON `user` (`phone`);
+
+CREATE TABLE IF NOT EXISTS `post` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `tenantID` text NOT NULL DEFAULT 'default',
+  `userID` text NOT NULL DEFAULT '',
+  `postID` text NOT NULL DEFAULT '',
+  `title` text NOT NULL DEFAULT '',
+  `content` text NOT NULL DEFAULT '',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_post_postID` ON `post` (`postID`);
+CREATE INDEX IF NOT EXISTS `idx_post_userID` ON `post` (`userID`);
+CREATE INDEX IF NOT EXISTS `idx_post_tenantID` ON `post` (`tenantID`);
+
+CREATE TABLE IF NOT EXISTS `user_totp` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `userID` text NOT NULL DEFAULT '',
+  `secret` text NOT NULL DEFAULT '',
+  `enabled` numeric NOT NULL DEFAULT false,
+  `lastUsedStep` integer NOT NULL DEFAULT 0,
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_user_totp_userID` ON `user_totp` (`userID`);
+
+CREATE TABLE IF NOT EXISTS `recovery_code` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `userID` text NOT NULL DEFAULT '',
+  `codeHash` text NOT NULL DEFAULT '',
+  `usedAt` datetime,
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS `idx_recovery_code_userID` ON `recovery_code` (`userID`);
+
+CREATE TABLE IF NOT EXISTS `password_history` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `userID` text NOT NULL DEFAULT '',
+  `password` text NOT NULL DEFAULT '',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS `idx_password_history_userID` ON `password_history` (`userID`);
+
+CREATE TABLE IF NOT EXISTS `user_identity` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `userID` text NOT NULL DEFAULT '',
+  `provider` text NOT NULL DEFAULT '',
+  `subject` text NOT NULL DEFAULT '',
+  `email` text NOT NULL DEFAULT '',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_user_identity_provider_subject` ON `user_identity` (`provider`, `subject`);
+CREATE INDEX IF NOT EXISTS `idx_user_identity_userID` ON `user_identity` (`userID`);
+
+CREATE TABLE IF NOT EXISTS `audit_event` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `requestID` text NOT NULL DEFAULT '',
+  `actor` text NOT NULL DEFAULT '',
+  `subject` text NOT NULL DEFAULT '',
+  `action` text NOT NULL DEFAULT '',
+  `resource` text NOT NULL DEFAULT '',
+  `outcome` text NOT NULL DEFAULT '',
+  `reason` text NOT NULL DEFAULT '',
+  `detail` text NOT NULL DEFAULT '',
+  `clientIP` text NOT NULL DEFAULT '',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS `idx_audit_event_actor` ON `audit_event` (`actor`);
+CREATE INDEX IF NOT EXISTS `idx_audit_event_subject` ON `audit_event` (`subject`);
+CREATE INDEX IF NOT EXISTS `idx_audit_event_action` ON `audit_event` (`action`);
+CREATE INDEX IF NOT EXISTS `idx_audit_event_requestID` ON `audit_event` (`requestID`);
+CREATE INDEX IF NOT EXISTS `idx_audit_event_createdAt` ON `audit_event` (`createdAt`);
+
+-- 审计事件表只允许追加，禁止修改和删除已有记录
+-- +statement begin
+CREATE TRIGGER IF NOT EXISTS `audit_event_no_update` BEFORE UPDATE ON `audit_event`
+BEGIN
+  SELECT RAISE(ABORT, 'audit_event is append-only');
+END;
+-- +statement end
+-- +statement begin
+CREATE TRIGGER IF NOT EXISTS `audit_event_no_delete` BEFORE DELETE ON `audit_event`
+BEGIN
+  SELECT RAISE(ABORT, 'audit_event is append-only');
+END;
+-- +statement end
+
+CREATE TABLE IF NOT EXISTS `organization` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `orgID` text NOT NULL DEFAULT '',
+  `slug` text NOT NULL DEFAULT '',
+  `name` text NOT NULL DEFAULT '',
+  `ownerID` text NOT NULL DEFAULT '',
+  `createdAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updatedAt` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_organization_orgID` ON `organization` (`orgID`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_organization_slug` ON `organization` (`slug`);
+
+-- 表结构与 Casbin GORM 适配器保持一致
+CREATE TABLE IF NOT EXISTS `casbin_rule` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `ptype` text,
+  `v0` text,
+  `v1` text,
+  `v2` text,
+  `v3` text,
+  `v4` text,
+  `v5` text
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_casbin_rule` ON `casbin_rule` (`ptype`, `v0`, `v1`, `v2`, `v3`, `v4`, `v5`);
@@ -0,0 +1,3 @@
+DELETE FROM `casbin_rule` WHERE `ptype` IN ('p', 'p2', 'g') AND `v0` IN ('user-000000', 'role::admin', 'role::user', 'role::tenant-member', 'role::tenant-admin', 'role::tenant-owner', 'role::post-viewer', 'role::post-editor');
+DELETE FROM `organization` WHERE `orgID` = 'default';
+DELETE FROM `user` WHERE `userID` = 'user-000000';
@@ -0,0 +1,34 @@
+-- 初始化 root 用户（初始密码为 miniblog1234）、默认组织和默认的授权策略.
+
+INSERT OR IGNORE INTO `user` (`userID`, `username`, `password`, `nickname`, `email`, `phone`) VALUES
+('user-000000', 'root', '$2a$10$ctsFXEUAMd7rXXpmccNlO.ZRiYGYz0eOfj8EicPGWqiz64YBBgR1y', 'colin404', 'colin404@foxmail.com', '18110000000');
+
+INSERT OR IGNORE INTO `organization` (`orgID`, `slug`, `name`, `ownerID`) VALUES
+('default', 'default', 'Default', 'user-000000');
+
+INSERT OR IGNORE INTO `casbin_rule` (`ptype`, `v0`, `v1`, `v2`, `v3`, `v4`, `v5`) VALUES
+('g', 'user-000000', 'role::admin', '*', '', '', ''),
+('p', 'role::admin', '*', '*', '*', 'allow', ''),
+('p', 'role::user', '*', 'users.delete', 'CALL', 'deny', ''),
+('p', 'role::user', '*', 'users.list', 'CALL', 'deny', ''),
+('p', 'role::user', '*', 'lockouts.delete', 'CALL', 'deny', ''),
+('p', 'role::user', '*', 'policies.*', 'CALL', 'deny', ''),
+('p', 'role::user', '*', 'role-assignments.*', 'CALL', 'deny', ''),
+('p', 'role::user', '*', 'roles.*', 'CALL', 'deny', ''),
+('p', 'role::user', '*', 'permissions.*', 'CALL', 'deny', ''),
+('p', 'role::user', '*', 'users.impersonate', 'CALL', 'deny', ''),
+('p', 'role::user', '*', 'audit-events.*', 'CALL', 'deny', ''),
+('p', 'role::tenant-member', '*', 'organizations.delete', 'CALL', 'deny', ''),
+('p', 'role::tenant-member', '*', 'organizations.members.create', 'CALL', 'deny', ''),
+('p', 'role::tenant-member', '*', 'organizations.members.delete', 'CALL', 'deny', ''),
+('p', 'role::tenant-admin', '*', 'organizations.delete', 'CALL', 'deny', ''),
+('p2', 'role::post-viewer', 'post:*', 'read', '', '', ''),
+('p2', 'role::post-editor', 'post:*', 'read', '', '', ''),
+('p2', 'role::post-editor', 'post:*', 'write', '', '', ''),
+('p2', 'role::admin', 'post:*', 'read', '', '', ''),
+('p2', 'role::admin', 'post:*', 'write', '', '', ''),
+('p2', 'role::admin', 'post:*', 'share', '', '', ''),
+('p2', 'role::tenant-owner', 'post:*', 'read', '', '', ''),
+('p2', 'role::tenant-owner', 'post:*', 'write', '', '', ''),
+('p2', 'role::tenant-admin', 'post:*', 'read', '', '', ''),
+('p2', 'role::tenant-admin', 'post:*', 'write', '', '', '');
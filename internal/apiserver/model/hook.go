@@ -19,6 +19,13 @@ func (m *PostM) AfterCreate(tx *gorm.DB) error {
 	return tx.Save(m).Error
 }
 
+// AfterCreate 在创建数据库记录之后生成 orgID.
+func (m *OrganizationM) AfterCreate(tx *gorm.DB) error {
+	m.OrgID = rid.OrgID.New(uint64(m.ID))
+
+	return tx.Save(m).Error
+}
+
 // BeforeCreate 在创建数据库记录之前加密明文密码.
 func (m *UserM) BeforeCreate(tx *gorm.DB) error {
 	// Encrypt the user password
@@ -13,6 +13,7 @@ const TableNamePostM = "post"
 // PostM 博文表
 type PostM struct {
 	ID        int64     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	TenantID  string    `gorm:"column:tenantID;not null;default:default;comment:所属租户（组织）ID" json:"tenantID"`           // 所属租户（组织）ID
 	UserID    string    `gorm:"column:userID;not null;comment:用户唯一 ID" json:"userID"`                                  // 用户唯一 ID
 	PostID    string    `gorm:"column:postID;not null;uniqueIndex:idx_post_postID;comment:博文唯一 ID" json:"postID"`      // 博文唯一 ID
 	Title     string    `gorm:"column:title;not null;comment:博文标题" json:"title"`                                       // 博文标题
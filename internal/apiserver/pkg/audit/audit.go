@@ -0,0 +1,55 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package audit 提供写入审计事件的辅助函数.
+// 审计事件只追加不修改，既包括中间件记录的每次请求，也包括业务层记录的关键操作详情.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+)
+
+// Record 记录一条业务操作成功的审计事件，操作者、请求 ID 和客户端 IP 从上下文中获取.
+// 审计事件在操作成功之后写入，此时操作已经生效，所以写入失败只记录错误日志，不影响请求结果.
+func Record(ctx context.Context, s store.AuditStore, action, resource string, detail any) {
+	event := &model.AuditEventM{
+		Action:   action,
+		Resource: resource,
+		Outcome:  known.AuditOutcomeSuccess,
+	}
+	if detail != nil {
+		data, err := json.Marshal(detail)
+		if err != nil {
+			log.W(ctx).Errorw("Failed to marshal audit event detail", "action", action, "err", err)
+		}
+		event.Detail = string(data)
+	}
+
+	Write(ctx, s, event)
+}
+
+// Write 补全审计事件中的请求上下文信息并写入存储.
+// 模拟登录时操作者记录为实际发起请求的管理员，被模拟的用户记录在 Subject 中.
+func Write(ctx context.Context, s store.AuditStore, event *model.AuditEventM) {
+	event.RequestID = contextx.RequestID(ctx)
+	event.Actor = contextx.UserID(ctx)
+	event.ClientIP = contextx.ClientIP(ctx)
+	if contextx.Impersonating(ctx) {
+		event.Actor = contextx.ActorID(ctx)
+		event.Subject = contextx.UserID(ctx)
+	}
+
+	if err := s.Create(ctx, event); err != nil {
+		log.W(ctx).Errorw("Failed to record audit event", "action", event.Action, "resource", event.Resource, "err", err)
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package conversion
+
+import (
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/onexstack/onexstack/pkg/core"
+)
+
+// AuditEventModelToAuditEventV1 将模型层的 AuditEventM（审计事件模型对象）转换为 Protobuf 层的 AuditEvent（v1 审计事件对象）.
+func AuditEventModelToAuditEventV1(eventModel *model.AuditEventM) *apiv1.AuditEvent {
+	var protoEvent apiv1.AuditEvent
+	_ = core.CopyWithConverters(&protoEvent, eventModel)
+	return &protoEvent
+}
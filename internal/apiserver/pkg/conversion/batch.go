@@ -0,0 +1,24 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package conversion
+
+import (
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/onexstack/onexstack/pkg/errorsx"
+)
+
+// ErrorToBatchItemErrorV1 将批量操作中第 index 个条目的错误转换为 Protobuf 层的 BatchItemError.
+func ErrorToBatchItemErrorV1(index int, id string, err error) *apiv1.BatchItemError {
+	errx := errorsx.FromError(err)
+	return &apiv1.BatchItemError{
+		Index:   int32(index),
+		Id:      id,
+		Code:    int32(errx.Code),
+		Reason:  errx.Reason,
+		Message: errx.Message,
+	}
+}
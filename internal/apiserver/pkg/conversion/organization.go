@@ -0,0 +1,20 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package conversion
+
+import (
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/onexstack/onexstack/pkg/core"
+)
+
+// OrganizationModelToOrganizationV1 将模型层的 OrganizationM（组织模型对象）转换为 Protobuf 层的 Organization（v1 组织对象）.
+func OrganizationModelToOrganizationV1(orgModel *model.OrganizationM) *apiv1.Organization {
+	var protoOrg apiv1.Organization
+	_ = core.CopyWithConverters(&protoOrg, orgModel)
+	return &protoOrg
+}
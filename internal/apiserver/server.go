@@ -8,19 +8,33 @@ package apiserver
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"github.com/TobyIcetea/miniblog/internal/apiserver/biz"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/migration"
 	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/pkg/audit"
 	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/cache"
+	"github.com/TobyIcetea/miniblog/internal/pkg/captcha"
 	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/database"
+	"github.com/TobyIcetea/miniblog/internal/pkg/devauth"
 	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/lockout"
 	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mail"
 	mw "github.com/TobyIcetea/miniblog/internal/pkg/middleware/gin"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mtls"
+	"github.com/TobyIcetea/miniblog/internal/pkg/oidc"
+	"github.com/TobyIcetea/miniblog/internal/pkg/password"
+	"github.com/TobyIcetea/miniblog/internal/pkg/ratelimit"
 	"github.com/TobyIcetea/miniblog/internal/pkg/server"
+	"github.com/TobyIcetea/miniblog/internal/pkg/tenant"
 	"github.com/TobyIcetea/miniblog/internal/pkg/validation"
 	"github.com/TobyIcetea/miniblog/pkg/auth"
 	"github.com/TobyIcetea/miniblog/pkg/token"
@@ -40,18 +54,50 @@ const (
 
 // 不用 viper.Get，是因为这种方式能更加清晰的知道应用提供了哪些配置项.
 type Config struct {
-	ServerMode   string
-	JWTKey       string
-	Expiration   time.Duration
-	TLSOptions   *genericoptions.TLSOptions
-	HTTPOptions  *genericoptions.HTTPOptions
-	GRPCOptions  *genericoptions.GRPCOptions
-	MySQLOptions *genericoptions.MySQLOptions
+	ServerMode        string
+	JWTKey            string
+	Expiration        time.Duration
+	TrustedProxies    []string
+	TLSOptions        *genericoptions.TLSOptions
+	HTTPOptions       *genericoptions.HTTPOptions
+	GRPCOptions       *genericoptions.GRPCOptions
+	DatabaseDriver    string
+	MySQLOptions      *genericoptions.MySQLOptions
+	PostgreSQLOptions *genericoptions.PostgreSQLOptions
+	SQLiteOptions     *database.SQLiteOptions
+	ReplicaOptions    *database.ReplicaOptions
+	MigrateOptions    *migration.Options
+	StoreOptions      *store.Options
+	RedisOptions      *genericoptions.RedisOptions
+	MailOptions       *mail.Options
+	LockoutOptions    *lockout.Options
+	OIDCOptions       *oidc.Options
+	MTLSOptions       *mtls.Options
+	TenantOptions     *tenant.Options
+	PasswordOptions   *password.Options
+	RateLimitOptions  *ratelimit.Options
+	CacheOptions      *cache.Options
+	CaptchaOptions    *captcha.Options
+	DevAuthOptions    *devauth.Options
+}
+
+// impersonationForbidden 列出模拟登录期间禁止调用的接口权限标识.
+// 这些接口会修改账号凭据或者延长令牌有效期，只能由用户本人操作.
+var impersonationForbidden = []string{
+	"auth.refresh",
+	"users.password.update",
+	"users.totp.create",
+	"users.totp.enable",
+	"users.delete",
+	"users.batch-delete",
+	"users.impersonate",
 }
 
 // HTTP 反向代理服务器依赖 gRPC 服务器，所以在开启 HTTP 反向代理服务器时，会先启动 gRPC 服务器.
 type UnionServer struct {
 	srv server.Server
+	// cleanup 在服务器停止后释放数据库连接等资源
+	cleanup func()
 }
 
 // ServerConfig 包含服务器的核心依赖和配置.
@@ -60,12 +106,19 @@ type ServerConfig struct {
 	biz       biz.IBiz
 	val       *validation.Validator
 	retriever mw.UserRetriever
+	auditor   mw.Auditor
 	authz     *auth.Authz
+	mapper    *mtls.Mapper
+	// tenants 用来从请求头或子域名中解析租户
+	tenants         *tenant.Resolver
+	tenantRetriever mw.TenantRetriever
+	limiter         *ratelimit.Limiter
 }
 
 // NewUnionServer 根据配置创建联合服务器.
 func (cfg *Config) NewUnionServer() (*UnionServer, error) {
-	// 注册租户解析函数，通过上下文获取用户 ID
+	// 注册资源归属解析函数，通过上下文获取用户 ID，用于按用户过滤资源.
+	// 注意：这里的 "租户" 是 where 包的概念，组织（租户）的数据隔离由 store 层的回调完成
 	where.RegisterTenant("userID", func(ctx context.Context) string {
 		return contextx.UserID(ctx)
 	})
@@ -73,15 +126,41 @@ func (cfg *Config) NewUnionServer() (*UnionServer, error) {
 	// 初始化 token 包的签名密钥、认证 Key 以及 Token 默认过期时间
 	token.Init(cfg.JWTKey, known.XUserID, cfg.Expiration)
 
+	// 设置新密码使用的加密算法和参数
+	auth.SetHashOptions(cfg.PasswordOptions.HashOptions())
+
+	// 开发认证模式下任何人都可以冒充任意用户，只允许监听本地回环地址
+	if cfg.DevAuthOptions.Enabled {
+		for _, addr := range cfg.listenAddrs() {
+			if err := devauth.CheckLoopback(addr); err != nil {
+				return nil, err
+			}
+		}
+		log.Warnw("!!! DEV AUTH MODE IS ENABLED: tokens are NOT verified and any caller can act as any user by setting the header. NEVER use this in production !!!",
+			"header", cfg.DevAuthOptions.Header, "addrs", cfg.listenAddrs())
+	}
+
 	log.Infow("Initializing federation server", "server-mode", cfg.ServerMode)
 
 	// 创建服务器配置，这些配置可用来创建服务器
-	srv, err := InitializeWebServer(cfg)
+	srv, cleanup, err := InitializeWebServer(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return &UnionServer{srv: srv}, nil
+	return &UnionServer{srv: srv, cleanup: cleanup}, nil
+}
+
+// listenAddrs 返回当前服务器模式下需要监听的地址.
+func (cfg *Config) listenAddrs() []string {
+	switch cfg.ServerMode {
+	case GRPCServerMode:
+		return []string{cfg.GRPCOptions.Addr}
+	case GRPCGatewayServerMode:
+		return []string{cfg.GRPCOptions.Addr, cfg.HTTPOptions.Addr}
+	default:
+		return []string{cfg.HTTPOptions.Addr}
+	}
 }
 
 // Run 运行应用.
@@ -105,38 +184,162 @@ func (s *UnionServer) Run() error {
 
 	// 先关闭依赖的服务，再关闭被依赖的服务
 	s.srv.GracefulStop(ctx)
+	s.cleanup()
 
 	log.Infow("Server exited")
 	return nil
 }
 
-// NewServerConfig 创建一个 *ServerConfig 示例.
-func (cfg *Config) NewServerConfig() (*ServerConfig, error) {
+// NewServerConfig 创建一个 *ServerConfig 示例，返回的清理函数用于释放数据库连接、Redis 客户端等资源.
+// 任意一步失败时，会释放之前已经创建的资源.
+func (cfg *Config) NewServerConfig() (_ *ServerConfig, _ func(), err error) {
+	var cleanups []func()
+	cleanup := func() {
+		// 与创建顺序相反，先释放依赖其他资源的资源
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			cleanups[i]()
+		}
+	}
+	defer func() {
+		if err != nil {
+			cleanup()
+		}
+	}()
+
 	// 初始化数据库连接
-	db, err := cfg.NewDB()
+	db, dbCleanup, err := ProvideDB(cfg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	cleanups = append(cleanups, dbCleanup)
+	replicas, replicasCleanup, err := ProvideReplicas(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanups = append(cleanups, replicasCleanup)
+	ds, storeCleanup, err := store.ProvideStore(db, replicas, cfg.ReplicaOptions, cfg.StoreOptions)
+	if err != nil {
+		return nil, nil, err
 	}
-	store := store.NewStore(db)
+	cleanups = append(cleanups, storeCleanup)
+
+	// 初始化用户和博客查询缓存
+	c, cacheCleanup, err := cache.NewCache(cfg.CacheOptions, cfg.RedisOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanups = append(cleanups, cacheCleanup)
+	store := store.ProvideCachedStore(ds, c, cfg.CacheOptions)
 
 	// 初始化权限认证模块
 	authz, err := auth.NewAuthz(store.DB(context.TODO()))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// 初始化密码策略
+	policy, err := password.NewPolicy(cfg.PasswordOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 初始化人机验证
+	captchaManager, err := captcha.NewManager(cfg.CaptchaOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 初始化邮件发送器
+	mailer, err := mail.NewMailer(cfg.MailOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 初始化请求限流器
+	limiter, limiterCleanup, err := ratelimit.NewLimiter(cfg.RateLimitOptions, cfg.RedisOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanups = append(cleanups, limiterCleanup)
+
+	// 初始化客户端证书到服务主体的映射
+	mapper, err := mtls.NewMapper(cfg.MTLSOptions)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return &ServerConfig{
+	serverConfig := &ServerConfig{
 		cfg:       cfg,
-		biz:       biz.NewBiz(store, authz),
-		val:       validation.New(store),
+		biz:       biz.NewBiz(store, authz, mailer, cfg.MailOptions, lockout.NewGuard(cfg.LockoutOptions), oidc.NewManager(cfg.OIDCOptions), policy, captchaManager),
+		val:       validation.New(store, policy, captchaManager),
 		retriever: &UserRetriever{store: store},
+		auditor:   &AuditRecorder{store: store},
 		authz:     authz,
-	}, nil
+		mapper:    mapper,
+		tenants:   tenant.NewResolver(cfg.TenantOptions),
+		limiter:   limiter,
+	}
+	serverConfig.tenantRetriever = &TenantRetriever{biz: serverConfig.biz}
+	return serverConfig, cleanup, nil
 }
 
-// NewDB 创建一个 *gorm.DB 实例.
+// NewDB 根据配置的数据库驱动创建一个 *gorm.DB 实例.
 func (cfg *Config) NewDB() (*gorm.DB, error) {
-	return cfg.MySQLOptions.NewDB()
+	switch cfg.DatabaseDriver {
+	case database.DriverPostgreSQL:
+		return cfg.PostgreSQLOptions.NewDB()
+	case database.DriverSQLite:
+		return cfg.SQLiteOptions.NewDB()
+	case database.DriverMySQL, "":
+		return cfg.MySQLOptions.NewDB()
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", cfg.DatabaseDriver)
+	}
+}
+
+// NewReplicas 根据配置创建只读副本的 *gorm.DB 实例.
+// 只读副本使用与主库相同的数据库驱动和连接配置，只替换地址（SQLite 为数据库文件路径）.
+func (cfg *Config) NewReplicas() (store.Replicas, error) {
+	if cfg.ReplicaOptions == nil {
+		return nil, nil
+	}
+
+	replicas := make(store.Replicas, 0, len(cfg.ReplicaOptions.Addrs))
+	for _, addr := range cfg.ReplicaOptions.Addrs {
+		replicaCfg := *cfg
+		switch cfg.DatabaseDriver {
+		case database.DriverPostgreSQL:
+			opts := *cfg.PostgreSQLOptions
+			opts.Addr = addr
+			replicaCfg.PostgreSQLOptions = &opts
+		case database.DriverSQLite:
+			opts := *cfg.SQLiteOptions
+			opts.Path = addr
+			replicaCfg.SQLiteOptions = &opts
+		default:
+			opts := *cfg.MySQLOptions
+			opts.Addr = addr
+			replicaCfg.MySQLOptions = &opts
+		}
+
+		db, err := replicaCfg.NewDB()
+		if err != nil {
+			closeDBs(replicas...)
+			return nil, fmt.Errorf("connect to replica %s: %w", addr, err)
+		}
+		replicas = append(replicas, db)
+	}
+
+	return replicas, nil
+}
+
+// closeDBs 关闭数据库连接，用于创建失败时释放已经创建的连接.
+func closeDBs(dbs ...*gorm.DB) {
+	for _, db := range dbs {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	}
 }
 
 // UserRetriever 定义一个用户数据获取器，用来管理用户信息.
@@ -149,9 +352,56 @@ func (r *UserRetriever) GetUser(ctx context.Context, userID string) (*model.User
 	return r.store.User().Get(ctx, where.F("userID", userID))
 }
 
-// ProvideDB 根据配置提供一个数据库实例。
-func ProvideDB(cfg *Config) (*gorm.DB, error) {
-	return cfg.NewDB()
+// AuditRecorder 定义一个审计事件记录器，供中间件写入审计日志.
+type AuditRecorder struct {
+	store store.IStore
+}
+
+// Record 补全审计事件中的请求上下文信息并写入数据库.
+func (r *AuditRecorder) Record(ctx context.Context, event *model.AuditEventM) {
+	audit.Write(ctx, r.store.Audit(), event)
+}
+
+// TenantRetriever 定义一个租户获取器，用来校验用户请求的租户.
+type TenantRetriever struct {
+	biz biz.IBiz
+}
+
+// GetTenant 将请求中的租户（组织 ID 或短名称）解析为组织 ID，并校验用户是否为组织成员.
+func (r *TenantRetriever) GetTenant(ctx context.Context, userID string, tenant string) (string, error) {
+	return r.biz.OrganizationV1().Resolve(ctx, userID, tenant)
+}
+
+// ProvideDB 根据配置提供一个数据库实例，返回的清理函数用于关闭数据库连接.
+// 开启自动迁移时，会在返回之前执行所有未执行的数据库迁移.
+// datastore 关闭时也会关闭该连接，重复关闭不会产生错误.
+func ProvideDB(cfg *Config) (*gorm.DB, func(), error) {
+	db, err := cfg.NewDB()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cfg.MigrateOptions != nil && cfg.MigrateOptions.Auto {
+		migrator, err := migration.New(db, cfg.MigrateOptions)
+		if err == nil {
+			_, err = migrator.Up(context.Background(), 0)
+		}
+		if err != nil {
+			closeDBs(db)
+			return nil, nil, err
+		}
+	}
+
+	return db, func() { closeDBs(db) }, nil
+}
+
+// ProvideReplicas 根据配置提供只读副本的数据库实例，返回的清理函数用于关闭数据库连接.
+func ProvideReplicas(cfg *Config) (store.Replicas, func(), error) {
+	replicas, err := cfg.NewReplicas()
+	if err != nil {
+		return nil, nil, err
+	}
+	return replicas, func() { closeDBs(replicas...) }, nil
 }
 
 func NewWebServer(serverMode string, serverConfig *ServerConfig) (server.Server, error) {
@@ -161,7 +411,7 @@ func NewWebServer(serverMode string, serverConfig *ServerConfig) (server.Server,
 	// 默认为 gRPC 服务器模式.
 	switch serverMode {
 	case GinServerMode:
-		return serverConfig.NewGinServer(), nil
+		return serverConfig.NewGinServer()
 	default:
 		return serverConfig.NewGRPCServerOr()
 	}
@@ -0,0 +1,49 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/onexstack/onexstack/pkg/store/where"
+)
+
+// AuditStore 定义了 audit_event 模块在 store 层所实现的方法.
+// 审计事件只允许追加和查询，所以这里不提供 Update 和 Delete 方法.
+type AuditStore interface {
+	Create(ctx context.Context, obj *model.AuditEventM) error
+	List(ctx context.Context, opts *where.Options) (int64, []*model.AuditEventM, error)
+
+	AuditExpansion
+}
+
+// AuditExpansion 定义了审计事件操作的附加方法.
+type AuditExpansion interface{}
+
+// auditStore 是 AuditStore 接口的实现，只暴露 Store 中的 Create 和 List 方法.
+type auditStore struct {
+	store *Store[model.AuditEventM]
+}
+
+// 确保 auditStore 实现了 AuditStore 接口.
+var _ AuditStore = (*auditStore)(nil)
+
+// newAuditStore 创建 auditStore 的实例.
+func newAuditStore(store *datastore) *auditStore {
+	return &auditStore{newGenericStore[model.AuditEventM](store)}
+}
+
+// Create 插入一条审计事件记录.
+func (s *auditStore) Create(ctx context.Context, obj *model.AuditEventM) error {
+	return s.store.Create(ctx, obj)
+}
+
+// List 返回审计事件列表和总数.
+func (s *auditStore) List(ctx context.Context, opts *where.Options) (int64, []*model.AuditEventM, error) {
+	return s.store.List(ctx, opts)
+}
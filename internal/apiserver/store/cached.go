@@ -0,0 +1,394 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/pkg/cache"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/sync/singleflight"
+)
+
+// cacheRequests 统计缓存的命中情况，result 取值为 hit、negative_hit、miss 和 error.
+var cacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "miniblog",
+	Subsystem: "store_cache",
+	Name:      "requests_total",
+	Help:      "Number of cached store lookups, partitioned by cache and result.",
+}, []string{"cache", "result"})
+
+// cacheTxKey 用于在 context.Context 中保存事务中需要在事务结束后失效的缓存键.
+type cacheTxKey struct{}
+
+// cachedStore 在 IStore 之上为 UserStore 和 PostStore 提供旁路缓存（cache-aside）.
+//
+// 只有仅按 userID 或 postID 精确查询的 Get 会使用缓存，其他查询直接访问数据库.
+// 写操作成功之后删除相关的缓存，事务中的读操作不使用缓存，事务中的写操作会在事务结束之后再次删除相关缓存，
+// 避免事务提交前被其他请求读到并缓存旧数据. 缓存的用户数据包含加密后的密码.
+type cachedStore struct {
+	IStore
+
+	cache cache.Cache
+	users *cacheAside[model.UserM]
+	posts *cacheAside[model.PostM]
+}
+
+// 确保 cachedStore 实现了 IStore 接口.
+var _ IStore = (*cachedStore)(nil)
+
+// NewCachedStore 创建一个使用 c 缓存用户和博客查询结果的 IStore.
+func NewCachedStore(store IStore, c cache.Cache, opts *cache.Options) IStore {
+	return &cachedStore{
+		IStore: store,
+		cache:  c,
+		users:  newCacheAside[model.UserM]("user", c, opts, errno.ErrUserNotFound),
+		posts:  newCacheAside[model.PostM]("post", c, opts, errno.ErrPostNotFound),
+	}
+}
+
+// ProvideCachedStore 根据缓存配置提供 IStore 实例，未开启缓存时直接返回 datastore，供 Wire 使用.
+func ProvideCachedStore(store *datastore, c cache.Cache, opts *cache.Options) IStore {
+	if c == nil {
+		return store
+	}
+	return NewCachedStore(store, c, opts)
+}
+
+// TX 在事务中执行 fn，事务结束之后删除事务中写操作涉及的缓存.
+func (s *cachedStore) TX(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(cacheTxKey{}).(*[]string); ok {
+		return s.IStore.TX(ctx, fn)
+	}
+
+	var keys []string
+	defer func() {
+		// 无论提交还是回滚，事务中可能已经有请求读到了旧数据并写入缓存
+		if len(keys) == 0 {
+			return
+		}
+		if err := s.cache.Delete(context.WithoutCancel(ctx), keys...); err != nil {
+			log.Errorw("Failed to invalidate cache", "err", err, "keys", keys)
+		}
+	}()
+	return s.IStore.TX(context.WithValue(ctx, cacheTxKey{}, &keys), fn)
+}
+
+// User 返回带缓存的 UserStore.
+func (s *cachedStore) User() UserStore {
+	return &cachedUserStore{UserStore: s.IStore.User(), cache: s.users}
+}
+
+// Post 返回带缓存的 PostStore.
+func (s *cachedStore) Post() PostStore {
+	return &cachedPostStore{PostStore: s.IStore.Post(), cache: s.posts}
+}
+
+// cachedUserStore 是带缓存的 UserStore.
+type cachedUserStore struct {
+	UserStore
+	cache *cacheAside[model.UserM]
+}
+
+// Create 插入一条用户记录，并删除该用户不存在的缓存.
+func (s *cachedUserStore) Create(ctx context.Context, obj *model.UserM) error {
+	if err := s.UserStore.Create(ctx, obj); err != nil {
+		return err
+	}
+	s.cache.invalidate(ctx, userCacheKey(obj.UserID))
+	return nil
+}
+
+// Update 更新用户记录，并删除该用户的缓存.
+func (s *cachedUserStore) Update(ctx context.Context, obj *model.UserM) error {
+	if err := s.UserStore.Update(ctx, obj); err != nil {
+		return err
+	}
+	s.cache.invalidate(ctx, userCacheKey(obj.UserID))
+	return nil
+}
+
+// UpdatePassword 有条件地更新用户密码，并删除该用户的缓存.
+func (s *cachedUserStore) UpdatePassword(ctx context.Context, userID string, oldPassword string, newPassword string) (bool, error) {
+	ok, err := s.UserStore.UpdatePassword(ctx, userID, oldPassword, newPassword)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		s.cache.invalidate(ctx, userCacheKey(userID))
+	}
+	return ok, nil
+}
+
+// BatchCreate 插入多条用户记录，并删除这些用户不存在的缓存.
+func (s *cachedUserStore) BatchCreate(ctx context.Context, objs []*model.UserM) error {
+	if err := s.UserStore.BatchCreate(ctx, objs); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		keys = append(keys, userCacheKey(obj.UserID))
+	}
+	s.cache.invalidate(ctx, keys...)
+	return nil
+}
+
+// Upsert 插入或更新用户记录，并删除该用户的缓存.
+func (s *cachedUserStore) Upsert(ctx context.Context, obj *model.UserM) error {
+	if err := s.UserStore.Upsert(ctx, obj); err != nil {
+		return err
+	}
+	s.cache.invalidate(ctx, userCacheKey(obj.UserID))
+	return nil
+}
+
+// Delete 根据条件删除用户记录，并删除被删除用户的缓存.
+func (s *cachedUserStore) Delete(ctx context.Context, opts *where.Options) error {
+	_, users, err := s.UserStore.List(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if err := s.UserStore.Delete(ctx, opts); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(users))
+	for _, user := range users {
+		keys = append(keys, userCacheKey(user.UserID))
+	}
+	s.cache.invalidate(ctx, keys...)
+	return nil
+}
+
+// Get 根据条件查询用户记录，仅按 userID 查询时使用缓存.
+func (s *cachedUserStore) Get(ctx context.Context, opts *where.Options) (*model.UserM, error) {
+	userID, ok := lookupID(opts, "userID")
+	if !ok {
+		return s.UserStore.Get(ctx, opts)
+	}
+	return s.cache.get(ctx, userCacheKey(userID), func(ctx context.Context) (*model.UserM, error) {
+		return s.UserStore.Get(ctx, opts)
+	})
+}
+
+// cachedPostStore 是带缓存的 PostStore.
+type cachedPostStore struct {
+	PostStore
+	cache *cacheAside[model.PostM]
+}
+
+// Create 插入一条博客记录，并删除该博客不存在的缓存.
+func (s *cachedPostStore) Create(ctx context.Context, obj *model.PostM) error {
+	if err := s.PostStore.Create(ctx, obj); err != nil {
+		return err
+	}
+	s.cache.invalidate(ctx, postCacheKeys(ctx, obj)...)
+	return nil
+}
+
+// Update 更新博客记录，并删除该博客的缓存.
+func (s *cachedPostStore) Update(ctx context.Context, obj *model.PostM) error {
+	if err := s.PostStore.Update(ctx, obj); err != nil {
+		return err
+	}
+	s.cache.invalidate(ctx, postCacheKeys(ctx, obj)...)
+	return nil
+}
+
+// BatchCreate 插入多条博客记录，并删除这些博客不存在的缓存.
+func (s *cachedPostStore) BatchCreate(ctx context.Context, objs []*model.PostM) error {
+	if err := s.PostStore.BatchCreate(ctx, objs); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, 2*len(objs))
+	for _, obj := range objs {
+		keys = append(keys, postCacheKeys(ctx, obj)...)
+	}
+	s.cache.invalidate(ctx, keys...)
+	return nil
+}
+
+// Upsert 插入或更新博客记录，并删除该博客的缓存.
+func (s *cachedPostStore) Upsert(ctx context.Context, obj *model.PostM) error {
+	if err := s.PostStore.Upsert(ctx, obj); err != nil {
+		return err
+	}
+	s.cache.invalidate(ctx, postCacheKeys(ctx, obj)...)
+	return nil
+}
+
+// Delete 根据条件删除博客记录，并删除被删除博客的缓存.
+func (s *cachedPostStore) Delete(ctx context.Context, opts *where.Options) error {
+	_, posts, err := s.PostStore.List(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if err := s.PostStore.Delete(ctx, opts); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, 2*len(posts))
+	for _, post := range posts {
+		keys = append(keys, postCacheKeys(ctx, post)...)
+	}
+	s.cache.invalidate(ctx, keys...)
+	return nil
+}
+
+// Get 根据条件查询博客记录，仅按 postID 查询时使用缓存.
+func (s *cachedPostStore) Get(ctx context.Context, opts *where.Options) (*model.PostM, error) {
+	postID, ok := lookupID(opts, "postID")
+	if !ok {
+		return s.PostStore.Get(ctx, opts)
+	}
+	return s.cache.get(ctx, postCacheKey(contextx.TenantID(ctx), postID), func(ctx context.Context) (*model.PostM, error) {
+		return s.PostStore.Get(ctx, opts)
+	})
+}
+
+// userCacheKey 返回用户的缓存键.
+func userCacheKey(userID string) string {
+	return "user:" + userID
+}
+
+// postCacheKey 返回博客的缓存键. 博客按租户隔离，不同租户查询同一个博客的结果不同，因此缓存键包含上下文中的租户 ID.
+func postCacheKey(tenantID string, postID string) string {
+	return "post:" + tenantID + ":" + postID
+}
+
+// postCacheKeys 返回博客可能对应的所有缓存键，包括所属租户、当前上下文中的租户以及不区分租户的查询.
+func postCacheKeys(ctx context.Context, obj *model.PostM) []string {
+	keys := []string{postCacheKey("", obj.PostID)}
+	for _, tenantID := range []string{obj.TenantID, contextx.TenantID(ctx)} {
+		if tenantID != "" {
+			keys = append(keys, postCacheKey(tenantID, obj.PostID))
+		}
+	}
+	return keys
+}
+
+// lookupID 判断 opts 是否仅按 column 精确查询单个 ID，是则返回该 ID.
+func lookupID(opts *where.Options, column string) (string, bool) {
+	if opts == nil || len(opts.Filters) != 1 || len(opts.Clauses) != 0 || len(opts.Queries) != 0 || opts.Offset != 0 {
+		return "", false
+	}
+	id, ok := opts.Filters[column].(string)
+	return id, ok && id != ""
+}
+
+// cacheAside 实现单个模型的旁路缓存，缓存值为 JSON 编码的模型，不存在的记录缓存为空值.
+type cacheAside[T any] struct {
+	name        string
+	cache       cache.Cache
+	ttl         time.Duration
+	negativeTTL time.Duration
+	notFound    error
+
+	// group 合并同一个缓存键上并发的未命中请求，避免缓存击穿
+	group singleflight.Group
+}
+
+// newCacheAside 创建 cacheAside 实例，notFound 是记录不存在时 Store 返回的错误.
+func newCacheAside[T any](name string, c cache.Cache, opts *cache.Options, notFound error) *cacheAside[T] {
+	return &cacheAside[T]{name: name, cache: c, ttl: opts.TTL, negativeTTL: opts.NegativeTTL, notFound: notFound}
+}
+
+// get 返回 key 对应的记录，缓存未命中时调用 load 查询数据库并写入缓存. 事务中直接调用 load.
+func (c *cacheAside[T]) get(ctx context.Context, key string, load func(ctx context.Context) (*T, error)) (*T, error) {
+	if _, ok := ctx.Value(cacheTxKey{}).(*[]string); ok {
+		return load(ctx)
+	}
+
+	data, ok, err := c.cache.Get(ctx, key)
+	switch {
+	case err != nil:
+		log.Warnw("Failed to read from cache", "err", err, "key", key)
+		cacheRequests.WithLabelValues(c.name, "error").Inc()
+	case ok && len(data) == 0:
+		cacheRequests.WithLabelValues(c.name, "negative_hit").Inc()
+		return nil, c.notFound
+	case ok:
+		if obj, err := c.decode(data); err == nil {
+			cacheRequests.WithLabelValues(c.name, "hit").Inc()
+			return obj, nil
+		}
+		log.Warnw("Failed to decode cached value", "err", err, "key", key)
+		cacheRequests.WithLabelValues(c.name, "error").Inc()
+	default:
+		cacheRequests.WithLabelValues(c.name, "miss").Inc()
+	}
+
+	// 并发请求共享同一次查询的结果，每个请求各自解码，避免调用方修改共享的对象.
+	// 查询结果会被多个请求共享，因此不受第一个请求取消的影响，合并的键包含租户 ID，避免不同租户共享查询结果.
+	// 未命中时从主库查询，避免从延迟的只读副本读到旧数据并写入缓存.
+	v, err, _ := c.group.Do(contextx.TenantID(ctx)+"|"+key, func() (any, error) {
+		ctx := WithPrimary(context.WithoutCancel(ctx))
+		obj, err := load(ctx)
+		if err != nil {
+			if errors.Is(err, c.notFound) && c.negativeTTL > 0 {
+				c.set(ctx, key, []byte{}, c.negativeTTL)
+			}
+			return nil, err
+		}
+
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		c.set(ctx, key, data, c.ttl)
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.decode(v.([]byte))
+}
+
+// invalidate 删除缓存键. 在事务中时同时记录缓存键，在事务结束之后再次删除.
+func (c *cacheAside[T]) invalidate(ctx context.Context, keys ...string) {
+	if pending, ok := ctx.Value(cacheTxKey{}).(*[]string); ok {
+		*pending = append(*pending, keys...)
+	}
+	c.delete(ctx, keys...)
+}
+
+// delete 删除缓存键，删除失败时只记录日志，缓存会在过期之后自动失效.
+func (c *cacheAside[T]) delete(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	if err := c.cache.Delete(ctx, keys...); err != nil {
+		log.Errorw("Failed to invalidate cache", "err", err, "keys", keys)
+	}
+}
+
+// set 写入缓存，写入失败时只记录日志.
+func (c *cacheAside[T]) set(ctx context.Context, key string, data []byte, ttl time.Duration) {
+	if err := c.cache.Set(ctx, key, data, ttl); err != nil {
+		log.Warnw("Failed to write to cache", "err", err, "key", key)
+	}
+}
+
+// decode 解码缓存值.
+func (c *cacheAside[T]) decode(data []byte) (*T, error) {
+	var obj T
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
@@ -0,0 +1,275 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store/fake"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store/storetest"
+	"github.com/TobyIcetea/miniblog/internal/pkg/cache"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/rid"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingStore 统计 UserStore.Get 的调用次数，可以通过 gate 阻塞查询.
+type countingStore struct {
+	store.IStore
+	gets     atomic.Int64
+	gate     chan struct{}
+	canceled atomic.Bool
+}
+
+func (s *countingStore) User() store.UserStore {
+	return &countingUserStore{UserStore: s.IStore.User(), s: s}
+}
+
+type countingUserStore struct {
+	store.UserStore
+	s *countingStore
+}
+
+func (u *countingUserStore) Get(ctx context.Context, opts *where.Options) (*model.UserM, error) {
+	u.s.gets.Add(1)
+	if u.s.gate != nil {
+		<-u.s.gate
+	}
+	if ctx.Err() != nil {
+		u.s.canceled.Store(true)
+		return nil, ctx.Err()
+	}
+	return u.UserStore.Get(ctx, opts)
+}
+
+// newCaches 返回测试使用的所有缓存后端.
+func newCaches(t *testing.T) map[string]cache.Cache {
+	mr := miniredis.RunT(t)
+	return map[string]cache.Cache{
+		cache.BackendMemory: cache.NewMemoryCache(100),
+		cache.BackendRedis:  cache.NewRedisCache(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "test:"),
+	}
+}
+
+// cacheRequests 返回指定缓存和结果的请求数.
+func cacheRequests(t *testing.T, name string, result string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "miniblog_store_cache_requests_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["cache"] == name && labels["result"] == result {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func TestCachedStoreConformance(t *testing.T) {
+	for name, c := range newCaches(t) {
+		t.Run(name, func(t *testing.T) {
+			storetest.Run(t, func(t *testing.T) store.IStore {
+				return store.NewCachedStore(fake.NewStore(), c, cache.NewOptions())
+			})
+		})
+	}
+}
+
+func TestCachedStore(t *testing.T) {
+	for name, c := range newCaches(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			inner := &countingStore{IStore: fake.NewStore()}
+			s := store.NewCachedStore(inner, c, cache.NewOptions())
+
+			user := &model.UserM{Username: "cached-" + name, Password: "miniblog1234", Nickname: "before", Phone: "1" + name}
+			require.NoError(t, s.User().Create(ctx, user))
+
+			hits := cacheRequests(t, "user", "hit")
+			for i := 0; i < 3; i++ {
+				got, err := s.User().Get(ctx, where.F("userID", user.UserID))
+				require.NoError(t, err)
+				assert.Equal(t, "before", got.Nickname)
+			}
+			assert.EqualValues(t, 1, inner.gets.Load())
+			assert.Equal(t, hits+2, cacheRequests(t, "user", "hit"))
+
+			// 调用方修改返回的对象不会影响缓存
+			got, err := s.User().Get(ctx, where.F("userID", user.UserID))
+			require.NoError(t, err)
+			got.Nickname = "after"
+			require.NoError(t, s.User().Update(ctx, got))
+			got, err = s.User().Get(ctx, where.F("userID", user.UserID))
+			require.NoError(t, err)
+			assert.Equal(t, "after", got.Nickname)
+			assert.EqualValues(t, 2, inner.gets.Load())
+
+			// 其他查询条件不使用缓存
+			_, err = s.User().Get(ctx, where.F("username", user.Username))
+			require.NoError(t, err)
+			assert.EqualValues(t, 3, inner.gets.Load())
+
+			require.NoError(t, s.User().Delete(ctx, where.F("username", user.Username)))
+			_, err = s.User().Get(ctx, where.F("userID", user.UserID))
+			assert.ErrorIs(t, err, errno.ErrUserNotFound)
+		})
+	}
+}
+
+func TestCachedStoreNegativeLookups(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingStore{IStore: fake.NewStore()}
+	s := store.NewCachedStore(inner, cache.NewMemoryCache(100), cache.NewOptions())
+
+	// 内存实现中第一个用户的 userID 由自增 ID 1 生成
+	userID := rid.UserID.New(1)
+	for i := 0; i < 2; i++ {
+		_, err := s.User().Get(ctx, where.F("userID", userID))
+		assert.ErrorIs(t, err, errno.ErrUserNotFound)
+	}
+	assert.EqualValues(t, 1, inner.gets.Load())
+
+	// 创建记录之后删除记录不存在的缓存
+	user := &model.UserM{Username: "negative", Password: "miniblog1234", Phone: "18100000000"}
+	require.NoError(t, s.User().Create(ctx, user))
+	require.Equal(t, userID, user.UserID)
+	_, err := s.User().Get(ctx, where.F("userID", userID))
+	require.NoError(t, err)
+
+	// 关闭不存在记录的缓存时每次都查询数据库
+	opts := cache.NewOptions()
+	opts.NegativeTTL = 0
+	inner = &countingStore{IStore: fake.NewStore()}
+	s = store.NewCachedStore(inner, cache.NewMemoryCache(100), opts)
+	for i := 0; i < 2; i++ {
+		_, err := s.User().Get(ctx, where.F("userID", "user-missing"))
+		assert.ErrorIs(t, err, errno.ErrUserNotFound)
+	}
+	assert.EqualValues(t, 2, inner.gets.Load())
+}
+
+func TestCachedStoreSingleflight(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingStore{IStore: fake.NewStore()}
+	s := store.NewCachedStore(inner, cache.NewMemoryCache(100), cache.NewOptions())
+
+	user := &model.UserM{Username: "stampede", Password: "miniblog1234", Phone: "18100000001"}
+	require.NoError(t, s.User().Create(ctx, user))
+
+	inner.gate = make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.User().Get(ctx, where.F("userID", user.UserID))
+			assert.NoError(t, err)
+		}()
+	}
+
+	// 等待第一个请求开始查询，其他请求等待该查询的结果
+	require.Eventually(t, func() bool { return inner.gets.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	close(inner.gate)
+	wg.Wait()
+	assert.EqualValues(t, 1, inner.gets.Load())
+}
+
+func TestCachedStoreSingleflightIsolation(t *testing.T) {
+	inner := &countingStore{IStore: fake.NewStore()}
+	s := store.NewCachedStore(inner, cache.NewMemoryCache(100), cache.NewOptions())
+
+	user := &model.UserM{Username: "isolation", Password: "miniblog1234", Phone: "18100000002"}
+	require.NoError(t, s.User().Create(context.Background(), user))
+
+	inner.gate = make(chan struct{})
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	ctxs := []context.Context{
+		canceledCtx,
+		canceledCtx,
+		contextx.WithTenantID(context.Background(), "org-a"),
+	}
+	var wg sync.WaitGroup
+	for _, ctx := range ctxs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.User().Get(ctx, where.F("userID", user.UserID))
+			assert.NoError(t, err)
+		}()
+	}
+
+	// 不同租户的请求不合并，第一个请求取消之后共享的查询仍然完成
+	require.Eventually(t, func() bool { return inner.gets.Load() == 2 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	close(inner.gate)
+	wg.Wait()
+	assert.EqualValues(t, 2, inner.gets.Load())
+	assert.False(t, inner.canceled.Load())
+}
+
+func TestCachedStorePostTenantAndTX(t *testing.T) {
+	s := store.NewCachedStore(fake.NewStore(), cache.NewMemoryCache(100), cache.NewOptions())
+	ctxA := contextx.WithTenantID(context.Background(), "org-a")
+	ctxB := contextx.WithTenantID(context.Background(), "org-b")
+
+	post := &model.PostM{UserID: "user-1", Title: "before", Content: "content"}
+	require.NoError(t, s.Post().Create(ctxA, post))
+
+	got, err := s.Post().Get(ctxA, where.F("postID", post.PostID))
+	require.NoError(t, err)
+	assert.Equal(t, "before", got.Title)
+	_, err = s.Post().Get(ctxB, where.F("postID", post.PostID))
+	assert.ErrorIs(t, err, errno.ErrPostNotFound)
+
+	// 回滚的事务中的修改不会留在缓存中
+	err = s.TX(ctxA, func(ctx context.Context) error {
+		got.Title = "rolled back"
+		require.NoError(t, s.Post().Update(ctx, got))
+		got, err := s.Post().Get(ctx, where.F("postID", post.PostID))
+		require.NoError(t, err)
+		assert.Equal(t, "rolled back", got.Title)
+		return errors.New("rollback")
+	})
+	require.Error(t, err)
+	got, err = s.Post().Get(ctxA, where.F("postID", post.PostID))
+	require.NoError(t, err)
+	assert.Equal(t, "before", got.Title)
+
+	require.NoError(t, s.TX(ctxA, func(ctx context.Context) error {
+		got.Title = "after"
+		return s.Post().Update(ctx, got)
+	}))
+	got, err = s.Post().Get(ctxA, where.F("postID", post.PostID))
+	require.NoError(t, err)
+	assert.Equal(t, "after", got.Title)
+
+	require.NoError(t, s.Post().Delete(ctxA, where.F("postID", post.PostID)))
+	_, err = s.Post().Get(ctxA, where.F("postID", post.PostID))
+	assert.ErrorIs(t, err, errno.ErrPostNotFound)
+}
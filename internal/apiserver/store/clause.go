@@ -0,0 +1,39 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// likeEscaper 转义 LIKE 模式中的通配符，避免用户输入被当作通配符处理.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ContainsExpr 是匹配列值包含指定子串的查询条件，子串中的通配符会被转义.
+type ContainsExpr struct {
+	Column string
+	Value  string
+}
+
+// 确保 ContainsExpr 实现了 clause.Expression 接口.
+var _ clause.Expression = ContainsExpr{}
+
+// Contains 返回一个匹配 column 列的值包含 value 的查询条件.
+func Contains(column string, value string) ContainsExpr {
+	return ContainsExpr{Column: column, Value: value}
+}
+
+// Build 构建 LIKE 查询语句. 这里显式指定转义字符，PostgreSQL 和 SQLite 默认没有 LIKE 转义字符.
+func (expr ContainsExpr) Build(builder clause.Builder) {
+	builder.WriteQuoted(clause.Column{Name: expr.Column})
+	builder.WriteString(" LIKE ")
+	builder.AddVar(builder, "%"+likeEscaper.Replace(expr.Value)+"%")
+	builder.WriteString(" ESCAPE ")
+	builder.AddVar(builder, `\`)
+}
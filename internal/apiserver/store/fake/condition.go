@@ -0,0 +1,267 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package fake
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// matcher 判断一条记录是否满足查询条件.
+type matcher struct {
+	ctx    context.Context
+	schema *schema.Schema
+}
+
+// hasConditions 判断查询条件是否为空，与 GORM 一样，没有任何条件时不允许删除记录.
+func hasConditions(opts *where.Options) bool {
+	return opts != nil && (len(opts.Filters) > 0 || len(opts.Clauses) > 0 || len(opts.Queries) > 0)
+}
+
+// match 判断记录 rv 是否满足 opts 中的过滤条件，不包含分页条件.
+// 原生 SQL 条件（where.Options.Q）无法在内存中执行，会返回错误.
+func (m *matcher) match(rv reflect.Value, opts *where.Options) (bool, error) {
+	if opts == nil {
+		return true, nil
+	}
+	if len(opts.Queries) > 0 {
+		return false, fmt.Errorf("raw SQL conditions are not supported: %v", opts.Queries[0].Query)
+	}
+
+	for key, value := range opts.Filters {
+		column, ok := key.(string)
+		if !ok {
+			return false, fmt.Errorf("unsupported filter key type %T", key)
+		}
+		ok, err := m.eq(rv, column, value)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	for _, expr := range opts.Clauses {
+		ok, err := m.eval(rv, expr)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// eval 计算查询条件表达式.
+func (m *matcher) eval(rv reflect.Value, expr clause.Expression) (bool, error) {
+	switch expr := expr.(type) {
+	case clause.Eq:
+		return m.eq(rv, expr.Column, expr.Value)
+	case clause.Neq:
+		ok, err := m.eq(rv, expr.Column, expr.Value)
+		return !ok, err
+	case clause.IN:
+		return m.eq(rv, expr.Column, expr.Values)
+	case clause.Gt:
+		return m.cmp(rv, expr.Column, expr.Value, func(c int) bool { return c > 0 })
+	case clause.Gte:
+		return m.cmp(rv, expr.Column, expr.Value, func(c int) bool { return c >= 0 })
+	case clause.Lt:
+		return m.cmp(rv, expr.Column, expr.Value, func(c int) bool { return c < 0 })
+	case clause.Lte:
+		return m.cmp(rv, expr.Column, expr.Value, func(c int) bool { return c <= 0 })
+	case clause.Like:
+		value, err := m.value(rv, expr.Column)
+		if err != nil {
+			return false, err
+		}
+		s, ok := value.(string)
+		return ok && likeRegexp(fmt.Sprint(expr.Value)).MatchString(s), nil
+	case store.ContainsExpr:
+		value, err := m.value(rv, expr.Column)
+		if err != nil {
+			return false, err
+		}
+		s, ok := value.(string)
+		return ok && strings.Contains(s, expr.Value), nil
+	case clause.AndConditions:
+		for _, e := range expr.Exprs {
+			if ok, err := m.eval(rv, e); err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	case clause.OrConditions:
+		for _, e := range expr.Exprs {
+			if ok, err := m.eval(rv, e); err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case clause.NotConditions:
+		for _, e := range expr.Exprs {
+			if ok, err := m.eval(rv, e); err != nil || ok {
+				return false, err
+			}
+		}
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported condition %T", expr)
+	}
+}
+
+// eq 判断列值是否等于 value. value 为切片时判断列值是否在切片中，value 为 nil 时判断列值是否为 NULL.
+func (m *matcher) eq(rv reflect.Value, column any, value any) (bool, error) {
+	actual, err := m.value(rv, column)
+	if err != nil {
+		return false, err
+	}
+
+	if value == nil {
+		return actual == nil, nil
+	}
+	if values := reflect.ValueOf(value); values.Kind() == reflect.Slice && values.Type().Elem().Kind() != reflect.Uint8 {
+		for i := 0; i < values.Len(); i++ {
+			if c, ok := compare(actual, values.Index(i).Interface()); ok && c == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	c, ok := compare(actual, value)
+	return ok && c == 0, nil
+}
+
+// cmp 比较列值与 value，与 SQL 一样，NULL 和任何值比较的结果都为 false.
+func (m *matcher) cmp(rv reflect.Value, column any, value any, fn func(int) bool) (bool, error) {
+	actual, err := m.value(rv, column)
+	if err != nil {
+		return false, err
+	}
+
+	c, ok := compare(actual, value)
+	return ok && fn(c), nil
+}
+
+// value 返回记录中指定列的值，指针类型的值会被解引用，NULL 返回 nil.
+func (m *matcher) value(rv reflect.Value, column any) (any, error) {
+	var name string
+	switch column := column.(type) {
+	case string:
+		name = column
+	case clause.Column:
+		name = column.Name
+	default:
+		return nil, fmt.Errorf("unsupported column type %T", column)
+	}
+
+	field := m.schema.LookUpField(name)
+	if field == nil {
+		return nil, fmt.Errorf("unknown column %s in table %s", name, m.schema.Table)
+	}
+	return normalize(field.ReflectValueOf(m.ctx, rv).Interface()), nil
+}
+
+// normalize 将值转换为便于比较的类型：整数转换为 int64，浮点数转换为 float64，指针被解引用.
+func normalize(v any) any {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	default:
+		return rv.Interface()
+	}
+}
+
+// compare 比较两个值，第二个返回值表示两个值是否可以比较.
+func compare(a, b any) (int, bool) {
+	a, b = normalize(a), normalize(b)
+	if a == nil || b == nil {
+		return 0, false
+	}
+
+	switch a := a.(type) {
+	case int64:
+		switch b := b.(type) {
+		case int64:
+			return cmp.Compare(a, b), true
+		case float64:
+			return cmp.Compare(float64(a), b), true
+		}
+	case float64:
+		switch b := b.(type) {
+		case int64:
+			return cmp.Compare(a, float64(b)), true
+		case float64:
+			return cmp.Compare(a, b), true
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b), true
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			if a == b {
+				return 0, true
+			}
+			if !a {
+				return -1, true
+			}
+			return 1, true
+		}
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return a.Compare(b), true
+		}
+	}
+
+	return 0, false
+}
+
+// likeRegexp 将 SQL LIKE 模式转换为正则表达式，% 匹配任意字符串，_ 匹配单个字符.
+func likeRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile("(?s)" + b.String())
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package fake
+
+import (
+	"context"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm/clause"
+)
+
+// userStore 是 store.UserStore 的内存实现.
+type userStore struct {
+	*crud[model.UserM]
+}
+
+// 确保 userStore 实现了 store.UserStore 接口.
+var _ store.UserStore = (*userStore)(nil)
+
+// UpdatePassword 仅当用户当前的密码密文为 oldPassword 时更新密码.
+func (s *userStore) UpdatePassword(ctx context.Context, userID string, oldPassword string, newPassword string) (bool, error) {
+	affected, err := s.update(ctx, where.F("userID", userID, "password", oldPassword), func(obj *model.UserM) {
+		obj.Password = newPassword
+	})
+	return affected == 1, err
+}
+
+// postStore 是 store.PostStore 的内存实现.
+type postStore struct {
+	*crud[model.PostM]
+}
+
+// 确保 postStore 实现了 store.PostStore 接口.
+var _ store.PostStore = (*postStore)(nil)
+
+// CountByUserIDs 返回每个用户在所有租户中的帖子数量.
+func (s *postStore) CountByUserIDs(ctx context.Context, userIDs []string) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	_, posts, err := s.List(contextx.WithTenantID(ctx, ""), where.F("userID", userIDs))
+	if err != nil {
+		return nil, err
+	}
+	for _, post := range posts {
+		counts[post.UserID]++
+	}
+	return counts, nil
+}
+
+// totpStore 是 store.TOTPStore 的内存实现.
+type totpStore struct {
+	*crud[model.UserTOTPM]
+}
+
+// 确保 totpStore 实现了 store.TOTPStore 接口.
+var _ store.TOTPStore = (*totpStore)(nil)
+
+// ConsumeStep 原子地推进用户最近一次使用的 TOTP 时间步.
+func (s *totpStore) ConsumeStep(ctx context.Context, userID string, step int64) (bool, error) {
+	whr := where.F("userID", userID).C(clause.Lt{Column: "lastUsedStep", Value: step})
+	affected, err := s.update(ctx, whr, func(obj *model.UserTOTPM) {
+		obj.LastUsedStep = step
+	})
+	return affected == 1, err
+}
+
+// recoveryCodeStore 是 store.RecoveryCodeStore 的内存实现.
+type recoveryCodeStore struct {
+	*crud[model.RecoveryCodeM]
+}
+
+// 确保 recoveryCodeStore 实现了 store.RecoveryCodeStore 接口.
+var _ store.RecoveryCodeStore = (*recoveryCodeStore)(nil)
+
+// Consume 将恢复码标记为已使用，已经使用过的恢复码不会被再次标记.
+func (s *recoveryCodeStore) Consume(ctx context.Context, userID string, codeHash string) (bool, error) {
+	whr := where.F("userID", userID, "codeHash", codeHash).C(clause.Eq{Column: "usedAt", Value: nil})
+	now := time.Now()
+	affected, err := s.update(ctx, whr, func(obj *model.RecoveryCodeM) {
+		obj.UsedAt = &now
+	})
+	return affected == 1, err
+}
+
+// passwordHistoryStore 是 store.PasswordHistoryStore 的内存实现.
+type passwordHistoryStore struct {
+	*crud[model.PasswordHistoryM]
+}
+
+// 确保 passwordHistoryStore 实现了 store.PasswordHistoryStore 接口.
+var _ store.PasswordHistoryStore = (*passwordHistoryStore)(nil)
+
+// Prune 删除用户最近 keep 条以外的历史密码.
+func (s *passwordHistoryStore) Prune(ctx context.Context, userID string, keep int) error {
+	_, histories, err := s.List(ctx, where.F("userID", userID))
+	if err != nil {
+		return err
+	}
+	if len(histories) <= keep {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(histories)-keep)
+	for _, history := range histories[keep:] {
+		ids = append(ids, history.ID)
+	}
+	return s.Delete(ctx, where.F("id", ids))
+}
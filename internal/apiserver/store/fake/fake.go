@@ -0,0 +1,188 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package fake 提供 store.IStore 的内存实现，用于 Biz 层和 Handler 层的单元测试.
+//
+// 内存实现的行为与基于 GORM 的实现保持一致，包括：
+//   - 支持 where.Options 中的 Filters、Clauses 以及分页条件，不支持原生 SQL 条件（where.Options.Q）；
+//   - 按上下文中的租户 ID 隔离包含 TenantID 字段的数据；
+//   - 创建记录时加密用户密码并生成 userID、postID、orgID 等资源 ID；
+//   - 检查唯一索引约束；
+//   - 事务函数返回错误或 panic 时回滚事务中的修改.
+//
+// 两种实现的一致性由 storetest 包中的测试用例保证.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/rid"
+	"github.com/TobyIcetea/miniblog/pkg/auth"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm"
+)
+
+// transactionKey 用于在 context.Context 中标记当前处于事务中.
+type transactionKey struct{}
+
+// Store 是 store.IStore 的内存实现，可以被多个 goroutine 并发使用.
+type Store struct {
+	// mu 保护所有数据表
+	mu sync.RWMutex
+	// txMu 保证同一时刻只有一个事务在执行
+	txMu sync.Mutex
+
+	users           *table[model.UserM]
+	posts           *table[model.PostM]
+	totps           *table[model.UserTOTPM]
+	recoveryCodes   *table[model.RecoveryCodeM]
+	passwordHistory *table[model.PasswordHistoryM]
+	identities      *table[model.UserIdentityM]
+	auditEvents     *table[model.AuditEventM]
+	organizations   *table[model.OrganizationM]
+	snapshotFuncs   []func() func()
+}
+
+// 确保 Store 实现了 store.IStore 接口.
+var _ store.IStore = (*Store)(nil)
+
+// NewStore 创建一个没有任何数据的内存 Store.
+func NewStore() *Store {
+	s := &Store{
+		users:           newTable[model.UserM](),
+		posts:           newTable[model.PostM](),
+		totps:           newTable[model.UserTOTPM](),
+		recoveryCodes:   newTable[model.RecoveryCodeM](),
+		passwordHistory: newTable[model.PasswordHistoryM](),
+		identities:      newTable[model.UserIdentityM](),
+		auditEvents:     newTable[model.AuditEventM](),
+		organizations:   newTable[model.OrganizationM](),
+	}
+	s.snapshotFuncs = []func() func(){
+		s.users.snapshot,
+		s.posts.snapshot,
+		s.totps.snapshot,
+		s.recoveryCodes.snapshot,
+		s.passwordHistory.snapshot,
+		s.identities.snapshot,
+		s.auditEvents.snapshot,
+		s.organizations.snapshot,
+	}
+
+	return s
+}
+
+// DB 始终返回 nil，内存实现没有对应的 *gorm.DB 实例.
+func (s *Store) DB(ctx context.Context, wheres ...where.Where) *gorm.DB {
+	return nil
+}
+
+// Close 不做任何处理，内存实现没有需要释放的资源.
+func (s *Store) Close() error {
+	return nil
+}
+
+// TX 在事务中执行 fn，fn 返回错误或 panic 时回滚 fn 中的所有修改.
+// 事务之间串行执行，但事务外的写操作不会被隔离，嵌套调用 TX 时复用外层事务.
+func (s *Store) TX(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(transactionKey{}).(bool); ok {
+		return fn(ctx)
+	}
+
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.Lock()
+	restores := make([]func(), 0, len(s.snapshotFuncs))
+	for _, snapshot := range s.snapshotFuncs {
+		restores = append(restores, snapshot())
+	}
+	s.mu.Unlock()
+
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, restore := range restores {
+			restore()
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, transactionKey{}, true)); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+// User 返回一个实现了 UserStore 接口的实例.
+func (s *Store) User() store.UserStore {
+	return &userStore{newCRUD(s, s.users, errno.ErrUserNotFound)}
+}
+
+// Post 返回一个实现了 PostStore 接口的实例.
+func (s *Store) Post() store.PostStore {
+	return &postStore{newCRUD(s, s.posts, errno.ErrPostNotFound)}
+}
+
+// TOTP 返回一个实现了 TOTPStore 接口的实例.
+func (s *Store) TOTP() store.TOTPStore {
+	return &totpStore{newCRUD(s, s.totps, errno.ErrTOTPNotEnrolled)}
+}
+
+// RecoveryCode 返回一个实现了 RecoveryCodeStore 接口的实例.
+func (s *Store) RecoveryCode() store.RecoveryCodeStore {
+	return &recoveryCodeStore{newCRUD(s, s.recoveryCodes, errno.ErrRecoveryCodeInvalid)}
+}
+
+// PasswordHistory 返回一个实现了 PasswordHistoryStore 接口的实例.
+func (s *Store) PasswordHistory() store.PasswordHistoryStore {
+	return &passwordHistoryStore{newCRUD(s, s.passwordHistory, errno.ErrNotFound)}
+}
+
+// Identity 返回一个实现了 IdentityStore 接口的实例.
+func (s *Store) Identity() store.IdentityStore {
+	return newCRUD(s, s.identities, errno.ErrIdentityNotFound)
+}
+
+// Audit 返回一个实现了 AuditStore 接口的实例.
+func (s *Store) Audit() store.AuditStore {
+	return newCRUD(s, s.auditEvents, errno.ErrNotFound)
+}
+
+// Organization 返回一个实现了 OrganizationStore 接口的实例.
+func (s *Store) Organization() store.OrganizationStore {
+	return newCRUD(s, s.organizations, errno.ErrOrganizationNotFound)
+}
+
+// beforeCreate 模拟模型的 BeforeCreate 钩子.
+func beforeCreate(obj any) error {
+	if m, ok := obj.(*model.UserM); ok {
+		var err error
+		m.Password, err = auth.Encrypt(m.Password)
+		return err
+	}
+	return nil
+}
+
+// afterCreate 模拟模型的 AfterCreate 钩子，根据自增 ID 生成资源 ID.
+func afterCreate(obj any) {
+	switch m := obj.(type) {
+	case *model.UserM:
+		m.UserID = rid.UserID.New(uint64(m.ID))
+	case *model.PostM:
+		m.PostID = rid.PostID.New(uint64(m.ID))
+	case *model.OrganizationM:
+		m.OrgID = rid.OrgID.New(uint64(m.ID))
+	}
+}
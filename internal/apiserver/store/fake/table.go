@@ -0,0 +1,399 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/onexstack/onexstack/pkg/errorsx"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm/schema"
+)
+
+// tenantField 是租户隔离的数据表中保存租户 ID 的模型字段名，与 store 包保持一致.
+const tenantField = "TenantID"
+
+// table 是一张内存数据表，记录按主键升序保存.
+type table[T any] struct {
+	schema *schema.Schema
+	rows   []*T
+	nextID int64
+}
+
+// newTable 根据模型 T 的 GORM 定义创建一张内存数据表.
+func newTable[T any]() *table[T] {
+	sch, err := schema.Parse(new(T), &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		panic(fmt.Sprintf("parse schema of %T: %v", new(T), err))
+	}
+
+	return &table[T]{schema: sch, nextID: 1}
+}
+
+// snapshot 保存数据表当前的记录，返回的函数用于恢复到保存时的状态.
+// 与 MySQL 和 PostgreSQL 一样，事务回滚时不会回收已经分配的自增 ID.
+func (t *table[T]) snapshot() func() {
+	rows := make([]*T, len(t.rows))
+	for i, row := range t.rows {
+		rows[i] = clone(row)
+	}
+	return func() { t.rows = rows }
+}
+
+// crud 是一张内存数据表的通用增删改查实现，方法签名与 store 包中的各个 Store 接口一致.
+type crud[T any] struct {
+	store    *Store
+	table    *table[T]
+	notFound *errorsx.ErrorX
+}
+
+// newCRUD 创建 crud 实例，notFound 是 Get 未查询到记录时返回的错误.
+func newCRUD[T any](store *Store, table *table[T], notFound *errorsx.ErrorX) *crud[T] {
+	return &crud[T]{store: store, table: table, notFound: notFound}
+}
+
+// Create 插入一条记录，并模拟 GORM 钩子生成资源 ID.
+func (c *crud[T]) Create(ctx context.Context, obj *T) error {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	return c.insert(ctx, obj)
+}
+
+// Update 根据主键更新记录，与 GORM 的 Save 一样，主键为空时插入一条新记录.
+func (c *crud[T]) Update(ctx context.Context, obj *T) error {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	rv := reflect.ValueOf(obj).Elem()
+	pk := c.table.schema.PrioritizedPrimaryField
+	id, zero := pk.ValueOf(ctx, rv)
+	if zero {
+		return c.insert(ctx, obj)
+	}
+
+	idx := slices.IndexFunc(c.table.rows, func(row *T) bool {
+		rowID, _ := pk.ValueOf(ctx, reflect.ValueOf(row).Elem())
+		return rowID == id
+	})
+	if idx >= 0 && !c.inTenant(ctx, reflect.ValueOf(c.table.rows[idx]).Elem()) {
+		return dbWriteError(fmt.Errorf("record %v belongs to another tenant", id))
+	}
+
+	now := time.Now()
+	for _, field := range c.table.schema.Fields {
+		if field.AutoUpdateTime > 0 {
+			_ = field.Set(ctx, rv, now)
+		}
+	}
+	if err := c.checkUnique(ctx, rv); err != nil {
+		return err
+	}
+
+	if idx < 0 {
+		c.table.rows = append(c.table.rows, clone(obj))
+		c.sort(ctx)
+		return nil
+	}
+	c.table.rows[idx] = clone(obj)
+	return nil
+}
+
+// BatchCreate 插入多条记录，任意一条记录插入失败时不会插入任何记录.
+func (c *crud[T]) BatchCreate(ctx context.Context, objs []*T) error {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	restore := c.table.snapshot()
+	for _, obj := range objs {
+		if err := c.insert(ctx, obj); err != nil {
+			restore()
+			return err
+		}
+	}
+	return nil
+}
+
+// Upsert 按主键插入或更新记录，与 GORM 的 ON CONFLICT UPDATE ALL 一样，更新时保留记录的创建时间并执行创建钩子.
+func (c *crud[T]) Upsert(ctx context.Context, obj *T) error {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	rv := reflect.ValueOf(obj).Elem()
+	pk := c.table.schema.PrioritizedPrimaryField
+	id, zero := pk.ValueOf(ctx, rv)
+	idx := slices.IndexFunc(c.table.rows, func(row *T) bool {
+		rowID, _ := pk.ValueOf(ctx, reflect.ValueOf(row).Elem())
+		return !zero && rowID == id
+	})
+	if idx < 0 {
+		return c.insert(ctx, obj)
+	}
+
+	old := reflect.ValueOf(c.table.rows[idx]).Elem()
+	if !c.inTenant(ctx, old) {
+		return dbWriteError(fmt.Errorf("record %v belongs to another tenant", id))
+	}
+	if err := beforeCreate(obj); err != nil {
+		return dbWriteError(err)
+	}
+	c.fillTenant(ctx, rv)
+
+	now := time.Now()
+	for _, field := range c.table.schema.Fields {
+		if field.AutoCreateTime > 0 {
+			value, _ := field.ValueOf(ctx, old)
+			_ = field.Set(ctx, rv, value)
+		} else if field.AutoUpdateTime > 0 {
+			_ = field.Set(ctx, rv, now)
+		}
+	}
+	afterCreate(obj)
+
+	if err := c.checkUnique(ctx, rv); err != nil {
+		return err
+	}
+	c.table.rows[idx] = clone(obj)
+	return nil
+}
+
+// Delete 删除满足条件的记录，与 GORM 一样，没有任何条件时返回错误.
+func (c *crud[T]) Delete(ctx context.Context, opts *where.Options) error {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	if !hasConditions(opts) && !c.tenantScoped(ctx) {
+		return dbWriteError(errors.New("WHERE conditions required"))
+	}
+
+	rows, err := c.filter(ctx, opts)
+	if err != nil {
+		return dbWriteError(err)
+	}
+	c.table.rows = slices.DeleteFunc(c.table.rows, func(row *T) bool {
+		return slices.Contains(rows, row)
+	})
+	return nil
+}
+
+// Get 返回满足条件的主键最小的记录.
+func (c *crud[T]) Get(ctx context.Context, opts *where.Options) (*T, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	rows, err := c.filter(ctx, opts)
+	if err != nil {
+		return nil, dbReadError(err)
+	}
+	if opts != nil && opts.Offset > 0 {
+		rows = rows[min(opts.Offset, len(rows)):]
+	}
+	if len(rows) == 0 {
+		return nil, c.notFound
+	}
+	return clone(rows[0]), nil
+}
+
+// List 按主键倒序返回满足条件的记录，以及不分页时的记录总数.
+func (c *crud[T]) List(ctx context.Context, opts *where.Options) (int64, []*T, error) {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	rows, err := c.filter(ctx, opts)
+	if err != nil {
+		return 0, nil, dbReadError(err)
+	}
+	count := int64(len(rows))
+
+	slices.Reverse(rows)
+	if opts != nil {
+		rows = rows[min(max(opts.Offset, 0), len(rows)):]
+		if opts.Limit >= 0 {
+			rows = rows[:min(opts.Limit, len(rows))]
+		}
+	}
+
+	ret := make([]*T, 0, len(rows))
+	for _, row := range rows {
+		ret = append(ret, clone(row))
+	}
+	return count, ret, nil
+}
+
+// insert 插入一条记录，调用方需要持有写锁.
+func (c *crud[T]) insert(ctx context.Context, obj *T) error {
+	if err := beforeCreate(obj); err != nil {
+		return dbWriteError(err)
+	}
+
+	rv := reflect.ValueOf(obj).Elem()
+	sch := c.table.schema
+	c.fillTenant(ctx, rv)
+
+	now := time.Now()
+	for _, field := range sch.Fields {
+		if _, zero := field.ValueOf(ctx, rv); zero && (field.AutoCreateTime > 0 || field.AutoUpdateTime > 0) {
+			_ = field.Set(ctx, rv, now)
+		}
+	}
+
+	pk := sch.PrioritizedPrimaryField
+	if id, zero := pk.ValueOf(ctx, rv); zero {
+		_ = pk.Set(ctx, rv, c.table.nextID)
+		c.table.nextID++
+	} else {
+		c.table.nextID = max(c.table.nextID, normalize(id).(int64)+1)
+	}
+	afterCreate(obj)
+
+	if err := c.checkUnique(ctx, rv); err != nil {
+		return err
+	}
+	c.table.rows = append(c.table.rows, clone(obj))
+	c.sort(ctx)
+	return nil
+}
+
+// fillTenant 与 store 包的租户隔离回调一样，使用上下文中的租户 ID 填充记录中为空的租户 ID.
+func (c *crud[T]) fillTenant(ctx context.Context, rv reflect.Value) {
+	tenantID := contextx.TenantID(ctx)
+	field := c.table.schema.LookUpField(tenantField)
+	if tenantID == "" || field == nil {
+		return
+	}
+	if _, zero := field.ValueOf(ctx, rv); zero {
+		_ = field.Set(ctx, rv, tenantID)
+	}
+}
+
+// filter 返回当前租户内满足条件的记录，结果按主键升序排列，调用方需要持有锁.
+func (c *crud[T]) filter(ctx context.Context, opts *where.Options) ([]*T, error) {
+	m := &matcher{ctx: ctx, schema: c.table.schema}
+
+	var rows []*T
+	for _, row := range c.table.rows {
+		rv := reflect.ValueOf(row).Elem()
+		if !c.inTenant(ctx, rv) {
+			continue
+		}
+		ok, err := m.match(rv, opts)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// tenantScoped 判断当前语句是否会按租户过滤.
+func (c *crud[T]) tenantScoped(ctx context.Context) bool {
+	return contextx.TenantID(ctx) != "" && c.table.schema.LookUpField(tenantField) != nil
+}
+
+// update 在写锁内修改满足条件的记录，返回修改的记录数.
+func (c *crud[T]) update(ctx context.Context, opts *where.Options, fn func(obj *T)) (int64, error) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+
+	rows, err := c.filter(ctx, opts)
+	if err != nil {
+		return 0, dbWriteError(err)
+	}
+
+	now := time.Now()
+	for _, row := range rows {
+		fn(row)
+		for _, field := range c.table.schema.Fields {
+			if field.AutoUpdateTime > 0 {
+				_ = field.Set(ctx, reflect.ValueOf(row).Elem(), now)
+			}
+		}
+	}
+	return int64(len(rows)), nil
+}
+
+// inTenant 判断记录是否属于上下文中的租户，与 store 包的租户隔离回调保持一致.
+func (c *crud[T]) inTenant(ctx context.Context, rv reflect.Value) bool {
+	tenantID := contextx.TenantID(ctx)
+	field := c.table.schema.LookUpField(tenantField)
+	if tenantID == "" || field == nil {
+		return true
+	}
+
+	value, _ := field.ValueOf(ctx, rv)
+	return value == tenantID
+}
+
+// checkUnique 检查记录是否违反唯一索引约束，主键相同的记录视为同一条记录.
+func (c *crud[T]) checkUnique(ctx context.Context, rv reflect.Value) error {
+	sch := c.table.schema
+	pk := sch.PrioritizedPrimaryField
+	id, _ := pk.ValueOf(ctx, rv)
+
+	for _, idx := range sch.ParseIndexes() {
+		if idx.Class != "UNIQUE" {
+			continue
+		}
+		for _, row := range c.table.rows {
+			other := reflect.ValueOf(row).Elem()
+			if otherID, _ := pk.ValueOf(ctx, other); otherID == id {
+				continue
+			}
+
+			duplicate := true
+			for _, opt := range idx.Fields {
+				a, _ := opt.Field.ValueOf(ctx, rv)
+				b, _ := opt.Field.ValueOf(ctx, other)
+				if c, ok := compare(a, b); !ok || c != 0 {
+					duplicate = false
+					break
+				}
+			}
+			if duplicate {
+				return dbWriteError(fmt.Errorf("duplicate entry for key '%s.%s'", sch.Table, idx.Name))
+			}
+		}
+	}
+	return nil
+}
+
+// sort 按主键升序排列记录.
+func (c *crud[T]) sort(ctx context.Context) {
+	pk := c.table.schema.PrioritizedPrimaryField
+	slices.SortFunc(c.table.rows, func(a, b *T) int {
+		idA, _ := pk.ValueOf(ctx, reflect.ValueOf(a).Elem())
+		idB, _ := pk.ValueOf(ctx, reflect.ValueOf(b).Elem())
+		r, _ := compare(idA, idB)
+		return r
+	})
+}
+
+// clone 返回记录的浅拷贝，避免调用方修改返回值时影响内存中的数据.
+func clone[T any](obj *T) *T {
+	c := *obj
+	return &c
+}
+
+// dbReadError 返回数据库读取失败错误.
+func dbReadError(err error) error {
+	return errno.WithMessage(errno.ErrDBRead, "%v", err)
+}
+
+// dbWriteError 返回数据库写入失败错误.
+func dbWriteError(err error) error {
+	return errno.WithMessage(errno.ErrDBWrite, "%v", err)
+}
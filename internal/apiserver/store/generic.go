@@ -0,0 +1,177 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Repository 定义了单个模型的通用增删改查方法，T 是 GORM 模型类型.
+type Repository[T any] interface {
+	Create(ctx context.Context, obj *T) error
+	Update(ctx context.Context, obj *T) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*T, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*T, error)
+	// BatchCreate 在一个事务中插入多条记录，任意一条记录插入失败时不会插入任何记录
+	BatchCreate(ctx context.Context, objs []*T) error
+	// Upsert 按主键插入或更新记录，与 Create 一样会执行模型的创建钩子
+	Upsert(ctx context.Context, obj *T) error
+}
+
+// StoreOption 定义 Store 的可选配置.
+type StoreOption func(*storeOptions)
+
+// storeOptions 是 Store 的可选配置.
+type storeOptions struct {
+	notFound error
+	logger   ErrorLogger
+}
+
+// WithNotFound 设置 Get 没有查询到记录时返回的错误，默认为 errno.ErrNotFound.
+func WithNotFound(err error) StoreOption {
+	return func(o *storeOptions) {
+		o.notFound = err
+	}
+}
+
+// WithLogger 设置记录数据库操作失败日志的钩子，默认使用 Logger.
+func WithLogger(logger ErrorLogger) StoreOption {
+	return func(o *storeOptions) {
+		o.logger = logger
+	}
+}
+
+// Store 是 Repository 基于 GORM 的通用实现，读操作会发送到只读副本，写操作发送到主库或上下文中的事务.
+// 数据库错误会被转换为 errno.ErrDBRead 或 errno.ErrDBWrite，记录不存在的错误由 WithNotFound 指定.
+type Store[T any] struct {
+	store *datastore
+	// resource 是日志中使用的资源名称，即模型对应的数据表名
+	resource string
+	storeOptions
+}
+
+// 确保 Store 实现了 Repository 接口.
+var _ Repository[struct{}] = (*Store[struct{}])(nil)
+
+// newGenericStore 创建模型 T 的 Store 实例.
+func newGenericStore[T any](store *datastore, opts ...StoreOption) *Store[T] {
+	s := &Store[T]{
+		store:        store,
+		resource:     fmt.Sprintf("%T", new(T)),
+		storeOptions: storeOptions{notFound: errno.ErrNotFound, logger: NewLogger()},
+	}
+	if tabler, ok := any(new(T)).(schema.Tabler); ok {
+		s.resource = tabler.TableName()
+	}
+	for _, opt := range opts {
+		opt(&s.storeOptions)
+	}
+	return s
+}
+
+// Create 插入一条记录.
+func (s *Store[T]) Create(ctx context.Context, obj *T) error {
+	if err := s.store.DB(ctx).Create(obj).Error; err != nil {
+		s.logger.Error(ctx, err, fmt.Sprintf("Failed to insert %s into database", s.resource), s.resource, obj)
+		return dbWriteError(err)
+	}
+
+	return nil
+}
+
+// Update 根据主键更新记录.
+func (s *Store[T]) Update(ctx context.Context, obj *T) error {
+	if err := s.store.DB(ctx).Save(obj).Error; err != nil {
+		s.logger.Error(ctx, err, fmt.Sprintf("Failed to update %s in database", s.resource), s.resource, obj)
+		return dbWriteError(err)
+	}
+
+	return nil
+}
+
+// Delete 根据条件删除记录，没有满足条件的记录时不返回错误.
+func (s *Store[T]) Delete(ctx context.Context, opts *where.Options) error {
+	err := s.store.DB(ctx, opts).Delete(new(T)).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		s.logger.Error(ctx, err, fmt.Sprintf("Failed to delete %s from database", s.resource), "conditions", opts)
+		return dbWriteError(err)
+	}
+
+	return nil
+}
+
+// Get 根据条件查询一条记录.
+func (s *Store[T]) Get(ctx context.Context, opts *where.Options) (*T, error) {
+	var obj T
+	if err := s.store.readDB(ctx, opts).First(&obj).Error; err != nil {
+		s.logger.Error(ctx, err, fmt.Sprintf("Failed to retrieve %s from database", s.resource), "conditions", opts)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, s.notFound
+		}
+		return nil, dbReadError(err)
+	}
+
+	return &obj, nil
+}
+
+// List 返回满足条件的记录列表和总数.
+func (s *Store[T]) List(ctx context.Context, opts *where.Options) (count int64, ret []*T, err error) {
+	count, err = findAndCount(s.store.readDB(ctx), opts, &ret)
+	if err != nil {
+		s.logger.Error(ctx, err, fmt.Sprintf("Failed to list %s from database", s.resource), "conditions", opts)
+		err = dbReadError(err)
+	}
+	return
+}
+
+// BatchCreate 在一个事务中插入多条记录.
+// 资源 ID 由 AfterCreate 钩子根据自增 ID 生成，插入时资源 ID 的唯一索引列还是空值，
+// 因此逐条插入记录，而不是在一条 INSERT 语句中插入多条记录.
+func (s *Store[T]) BatchCreate(ctx context.Context, objs []*T) error {
+	if len(objs) == 0 {
+		return nil
+	}
+
+	return s.store.TX(ctx, func(ctx context.Context) error {
+		for _, obj := range objs {
+			if err := s.Create(ctx, obj); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Upsert 按主键插入或更新记录，主键为空或记录不存在时插入，否则更新记录除创建时间外的所有字段.
+// 冲突时不会检查已有记录所属的租户，调用方需要确保主键来自当前租户内查询到的记录.
+func (s *Store[T]) Upsert(ctx context.Context, obj *T) error {
+	if err := s.store.DB(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(obj).Error; err != nil {
+		s.logger.Error(ctx, err, fmt.Sprintf("Failed to upsert %s into database", s.resource), s.resource, obj)
+		return dbWriteError(err)
+	}
+
+	return nil
+}
+
+// dbReadError 返回携带原始错误信息的数据库读取失败错误.
+func dbReadError(err error) error {
+	return errno.WithMessage(errno.ErrDBRead, "%v", err)
+}
+
+// dbWriteError 返回携带原始错误信息的数据库写入失败错误.
+func dbWriteError(err error) error {
+	return errno.WithMessage(errno.ErrDBWrite, "%v", err)
+}
@@ -0,0 +1,259 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/database"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/onexstack/onexstack/pkg/errorsx"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// widgetM 是仅用于测试 Store[T] 的模型.
+type widgetM struct {
+	ID        int64     `gorm:"column:id;primaryKey"`
+	Name      string    `gorm:"column:name;uniqueIndex"`
+	Size      int       `gorm:"column:size"`
+	CreatedAt time.Time `gorm:"column:createdAt"`
+	UpdatedAt time.Time `gorm:"column:updatedAt"`
+}
+
+func (*widgetM) TableName() string { return "widget" }
+
+var errWidgetNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.WidgetNotFound", Message: "Widget not found."}
+
+// recordingLogger 记录 Store[T] 输出的错误日志.
+type recordingLogger struct {
+	msgs []string
+}
+
+func (l *recordingLogger) Error(ctx context.Context, err error, msg string, kvs ...any) {
+	l.msgs = append(l.msgs, msg)
+}
+
+// newWidgetStore 基于 SQLite 内存数据库创建 widgetM 的 Store，数据库中预先插入名为 a 和 b 的记录.
+func newWidgetStore(t *testing.T, logger ErrorLogger) *Store[widgetM] {
+	t.Helper()
+
+	opts := database.NewSQLiteOptions()
+	opts.Path = database.MemoryPath
+	db, err := opts.NewDB()
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&widgetM{}))
+
+	ds, err := New(db)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ds.Close() })
+
+	s := newGenericStore[widgetM](ds, WithNotFound(errWidgetNotFound), WithLogger(logger))
+	require.NoError(t, s.BatchCreate(context.Background(), []*widgetM{{Name: "a", Size: 1}, {Name: "b", Size: 2}}))
+	return s
+}
+
+func TestStore(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(ctx context.Context, s *Store[widgetM]) error
+		// wantErr 是期望返回的错误，为空时期望操作成功
+		wantErr error
+		// wantNames 是操作之后数据表中所有记录的名称
+		wantNames []string
+		// wantLogs 是期望输出的错误日志条数
+		wantLogs int
+	}{
+		{
+			name:      "create",
+			run:       func(ctx context.Context, s *Store[widgetM]) error { return s.Create(ctx, &widgetM{Name: "c"}) },
+			wantNames: []string{"a", "b", "c"},
+		},
+		{
+			name:      "create duplicate",
+			run:       func(ctx context.Context, s *Store[widgetM]) error { return s.Create(ctx, &widgetM{Name: "a"}) },
+			wantErr:   errno.ErrDBWrite,
+			wantNames: []string{"a", "b"},
+			wantLogs:  1,
+		},
+		{
+			name: "update",
+			run: func(ctx context.Context, s *Store[widgetM]) error {
+				obj, err := s.Get(ctx, where.F("name", "a"))
+				if err != nil {
+					return err
+				}
+				obj.Name = "c"
+				return s.Update(ctx, obj)
+			},
+			wantNames: []string{"b", "c"},
+		},
+		{
+			name:      "delete",
+			run:       func(ctx context.Context, s *Store[widgetM]) error { return s.Delete(ctx, where.F("name", "a")) },
+			wantNames: []string{"b"},
+		},
+		{
+			name:      "delete without matches",
+			run:       func(ctx context.Context, s *Store[widgetM]) error { return s.Delete(ctx, where.F("name", "c")) },
+			wantNames: []string{"a", "b"},
+		},
+		{
+			name: "get not found",
+			run: func(ctx context.Context, s *Store[widgetM]) error {
+				_, err := s.Get(ctx, where.F("name", "c"))
+				return err
+			},
+			wantErr:   errWidgetNotFound,
+			wantNames: []string{"a", "b"},
+			wantLogs:  1,
+		},
+		{
+			name: "get with invalid condition",
+			run: func(ctx context.Context, s *Store[widgetM]) error {
+				_, err := s.Get(ctx, where.F("missing", "c"))
+				return err
+			},
+			wantErr:   errno.ErrDBRead,
+			wantNames: []string{"a", "b"},
+			wantLogs:  1,
+		},
+		{
+			name: "batch create",
+			run: func(ctx context.Context, s *Store[widgetM]) error {
+				return s.BatchCreate(ctx, []*widgetM{{Name: "c"}, {Name: "d"}})
+			},
+			wantNames: []string{"a", "b", "c", "d"},
+		},
+		{
+			name: "batch create rolls back on error",
+			run: func(ctx context.Context, s *Store[widgetM]) error {
+				return s.BatchCreate(ctx, []*widgetM{{Name: "c"}, {Name: "a"}})
+			},
+			wantErr:   errno.ErrDBWrite,
+			wantNames: []string{"a", "b"},
+			wantLogs:  1,
+		},
+		{
+			name:      "batch create nothing",
+			run:       func(ctx context.Context, s *Store[widgetM]) error { return s.BatchCreate(ctx, nil) },
+			wantNames: []string{"a", "b"},
+		},
+		{
+			name:      "upsert inserts",
+			run:       func(ctx context.Context, s *Store[widgetM]) error { return s.Upsert(ctx, &widgetM{Name: "c"}) },
+			wantNames: []string{"a", "b", "c"},
+		},
+		{
+			name: "upsert updates",
+			run: func(ctx context.Context, s *Store[widgetM]) error {
+				obj, err := s.Get(ctx, where.F("name", "a"))
+				if err != nil {
+					return err
+				}
+				return s.Upsert(ctx, &widgetM{ID: obj.ID, Name: "c"})
+			},
+			wantNames: []string{"b", "c"},
+		},
+		{
+			name: "upsert violates unique index",
+			run: func(ctx context.Context, s *Store[widgetM]) error {
+				obj, err := s.Get(ctx, where.F("name", "a"))
+				if err != nil {
+					return err
+				}
+				return s.Upsert(ctx, &widgetM{ID: obj.ID, Name: "b"})
+			},
+			wantErr:   errno.ErrDBWrite,
+			wantNames: []string{"a", "b"},
+			wantLogs:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			logger := &recordingLogger{}
+			s := newWidgetStore(t, logger)
+
+			err := tt.run(ctx, s)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, logger.msgs, tt.wantLogs)
+
+			count, objs, err := s.List(ctx, where.NewWhere())
+			require.NoError(t, err)
+			assert.EqualValues(t, len(tt.wantNames), count)
+			names := make([]string, 0, len(objs))
+			for _, obj := range objs {
+				names = append(names, obj.Name)
+			}
+			assert.ElementsMatch(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestStoreList(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      *where.Options
+		wantCount int64
+		wantNames []string
+	}{
+		{name: "all", opts: where.NewWhere(), wantCount: 4, wantNames: []string{"d", "c", "b", "a"}},
+		{name: "limit", opts: where.L(2), wantCount: 4, wantNames: []string{"d", "c"}},
+		{name: "offset and limit", opts: where.O(1).L(2), wantCount: 4, wantNames: []string{"c", "b"}},
+		{name: "offset beyond total", opts: where.O(10).L(2), wantCount: 4, wantNames: []string{}},
+		{name: "filter with limit", opts: where.F("size", 1).L(1), wantCount: 2, wantNames: []string{"c"}},
+		{name: "query with offset", opts: where.NewWhere().Q("size > ?", 1).O(1).L(1), wantCount: 2, wantNames: []string{"b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := newWidgetStore(t, &recordingLogger{})
+			require.NoError(t, s.BatchCreate(ctx, []*widgetM{{Name: "c", Size: 1}, {Name: "d", Size: 2}}))
+
+			count, objs, err := s.List(ctx, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCount, count)
+			names := make([]string, 0, len(objs))
+			for _, obj := range objs {
+				names = append(names, obj.Name)
+			}
+			assert.Equal(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestStoreUpsertKeepsCreatedAt(t *testing.T) {
+	ctx := context.Background()
+	s := newWidgetStore(t, &recordingLogger{})
+
+	obj, err := s.Get(ctx, where.F("name", "a"))
+	require.NoError(t, err)
+
+	require.NoError(t, s.Upsert(ctx, &widgetM{ID: obj.ID, Name: "a", Size: 10}))
+	got, err := s.Get(ctx, where.F("name", "a"))
+	require.NoError(t, err)
+	assert.Equal(t, 10, got.Size)
+	assert.Equal(t, obj.CreatedAt.Unix(), got.CreatedAt.Unix())
+}
+
+func TestStoreDefaults(t *testing.T) {
+	s := newGenericStore[widgetM](&datastore{})
+	assert.Equal(t, "widget", s.resource)
+	assert.Equal(t, errno.ErrNotFound, s.notFound)
+	assert.IsType(t, &Logger{}, s.logger)
+}
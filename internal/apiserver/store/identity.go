@@ -0,0 +1,35 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+)
+
+// IdentityStore 定义了 user_identity 模块在 store 层所实现的方法.
+type IdentityStore interface {
+	Repository[model.UserIdentityM]
+
+	IdentityExpansion
+}
+
+// IdentityExpansion 定义了第三方登录身份操作的附加方法.
+type IdentityExpansion interface{}
+
+// identityStore 是 IdentityStore 接口的实现.
+type identityStore struct {
+	*Store[model.UserIdentityM]
+}
+
+// 确保 identityStore 实现了 IdentityStore 接口.
+var _ IdentityStore = (*identityStore)(nil)
+
+// newIdentityStore 创建 identityStore 的实例.
+func newIdentityStore(store *datastore) *identityStore {
+	return &identityStore{newGenericStore[model.UserIdentityM](store, WithNotFound(errno.ErrIdentityNotFound))}
+}
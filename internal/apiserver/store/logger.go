@@ -6,18 +6,30 @@
 
 package store
 
-import "github.com/TobyIcetea/miniblog/internal/pkg/log"
+import (
+	"context"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+)
+
+// ErrorLogger 定义记录数据库操作失败日志的方法，用于 Store[T] 的日志钩子.
+type ErrorLogger interface {
+	Error(ctx context.Context, err error, msg string, kvs ...any)
+}
 
 // Logger is a logger that implements the logger interface.
 // Is uses the log package to log error messages with additional context.
 type Logger struct{}
 
+// 确保 Logger 实现了 ErrorLogger 接口.
+var _ ErrorLogger = (*Logger)(nil)
+
 // NewLogger creates and returns a new instance of Logger.
 func NewLogger() *Logger {
 	return &Logger{}
 }
 
 // Error logs an error message with the provided context using the log package.
-func (l *Logger) Error(err error, msg string, kvs ...any) {
-	log.Errorw(msg, append(kvs, "err", err)...)
+func (l *Logger) Error(ctx context.Context, err error, msg string, kvs ...any) {
+	log.W(ctx).Errorw(msg, append(kvs, "err", err)...)
 }
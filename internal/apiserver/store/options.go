@@ -0,0 +1,45 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// WithPostCountColumn 开启在用户表的 postCount 列中冗余保存用户的帖子数量.
+// 创建和删除帖子时在同一个事务中更新该列，统计帖子数量时直接读取该列.
+func WithPostCountColumn() Option {
+	return func(store *datastore) {
+		store.postCountColumn = true
+	}
+}
+
+// Options 包含 Store 层的配置选项.
+type Options struct {
+	// DenormalizedPostCount 表示是否在用户表的 postCount 列中维护用户的博客数量，
+	// 开启后统计博客数量时直接读取该列，不再按用户分组统计博客表，两种方式都统计用户在所有租户中的博客数量.
+	// 关闭期间写入的博客不会更新该列，重新开启之前需要执行 mb-apiserver migrate refresh-post-counts 修正
+	DenormalizedPostCount bool `json:"denormalized-post-count" mapstructure:"denormalized-post-count"`
+}
+
+// NewOptions 创建带有默认值的 Options 实例.
+func NewOptions() *Options {
+	return &Options{
+		DenormalizedPostCount: false,
+	}
+}
+
+// AddFlags 将 Store 层的选项绑定到命令行标志.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.DenormalizedPostCount, "store.denormalized-post-count", o.DenormalizedPostCount,
+		"Maintain post counts in the postCount column of the user table and read counts from it. Run 'migrate refresh-post-counts' before enabling it again after it was disabled.")
+}
+
+// Validate 校验 Store 层的配置选项是否合法.
+func (o *Options) Validate() []error {
+	return []error{}
+}
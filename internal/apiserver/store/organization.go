@@ -0,0 +1,35 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+)
+
+// OrganizationStore 定义了 organization 模块在 store 层所实现的方法.
+type OrganizationStore interface {
+	Repository[model.OrganizationM]
+
+	OrganizationExpansion
+}
+
+// OrganizationExpansion 定义了组织操作的附加方法.
+type OrganizationExpansion interface{}
+
+// organizationStore 是 OrganizationStore 接口的实现.
+type organizationStore struct {
+	*Store[model.OrganizationM]
+}
+
+// 确保 organizationStore 实现了 OrganizationStore 接口.
+var _ OrganizationStore = (*organizationStore)(nil)
+
+// newOrganizationStore 创建 organizationStore 的实例.
+func newOrganizationStore(store *datastore) *organizationStore {
+	return &organizationStore{newGenericStore[model.OrganizationM](store, WithNotFound(errno.ErrOrganizationNotFound))}
+}
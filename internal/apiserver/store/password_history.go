@@ -0,0 +1,60 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+)
+
+// PasswordHistoryStore 定义了 password_history 模块在 store 层所实现的方法.
+type PasswordHistoryStore interface {
+	Repository[model.PasswordHistoryM]
+
+	PasswordHistoryExpansion
+}
+
+// PasswordHistoryExpansion 定义了历史密码操作的附加方法.
+type PasswordHistoryExpansion interface {
+	// Prune 只保留用户最近的 keep 条历史密码，删除更早的记录.
+	Prune(ctx context.Context, userID string, keep int) error
+}
+
+// passwordHistoryStore 是 PasswordHistoryStore 接口的实现.
+type passwordHistoryStore struct {
+	*Store[model.PasswordHistoryM]
+	store *datastore
+}
+
+// 确保 passwordHistoryStore 实现了 PasswordHistoryStore 接口.
+var _ PasswordHistoryStore = (*passwordHistoryStore)(nil)
+
+// newPasswordHistoryStore 创建 passwordHistoryStore 的实例.
+func newPasswordHistoryStore(store *datastore) *passwordHistoryStore {
+	return &passwordHistoryStore{Store: newGenericStore[model.PasswordHistoryM](store), store: store}
+}
+
+// Prune 删除用户最近 keep 条以外的历史密码.
+func (s *passwordHistoryStore) Prune(ctx context.Context, userID string, keep int) error {
+	var ids []int64
+	err := s.store.DB(ctx).Model(new(model.PasswordHistoryM)).Where(map[string]any{"userID": userID}).Order("id desc").Pluck("id", &ids).Error
+	if err != nil {
+		log.Errorw("Failed to list password history ids", "err", err, "userID", userID)
+		return dbReadError(err)
+	}
+	if len(ids) <= keep {
+		return nil
+	}
+
+	if err := s.store.DB(ctx).Where("id IN ?", ids[keep:]).Delete(new(model.PasswordHistoryM)).Error; err != nil {
+		log.Errorw("Failed to prune password histories", "err", err, "userID", userID)
+		return dbWriteError(err)
+	}
+	return nil
+}
@@ -8,31 +8,38 @@ package store
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 
 	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
 	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
 	"github.com/TobyIcetea/miniblog/internal/pkg/log"
 	"github.com/onexstack/onexstack/pkg/store/where"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// postCountColumn 是用户表中冗余保存用户帖子数量的列.
+const postCountColumn = "postCount"
+
 // PostStore 定义了 post 模块在 store 层所实现的方法.
 type PostStore interface {
-	Create(ctx context.Context, obj *model.PostM) error
-	Update(ctx context.Context, obj *model.PostM) error
-	Delete(ctx context.Context, opts *where.Options) error
-	Get(ctx context.Context, opts *where.Options) (*model.PostM, error)
-	List(ctx context.Context, opts *where.Options) (int64, []*model.PostM, error)
+	Repository[model.PostM]
 
 	PostExpansion
 }
 
 // PostExpansion 定义了帖子操作的附加方法.
-type PostExpansion interface{}
+type PostExpansion interface {
+	// CountByUserIDs 返回每个用户的帖子数量，没有帖子的用户不包含在返回结果中
+	CountByUserIDs(ctx context.Context, userIDs []string) (map[string]int64, error)
+}
 
 // postStore 是 PostStore 接口的实现.
+// 开启冗余的帖子数量时，写操作会在同一个事务中维护用户表的 postCount 列.
 type postStore struct {
+	*Store[model.PostM]
 	store *datastore
 }
 
@@ -41,60 +48,194 @@ var _ PostStore = (*postStore)(nil)
 
 // newPostStore 创建 postStore 的实例.
 func newPostStore(store *datastore) *postStore {
-	return &postStore{store: store}
+	return &postStore{Store: newGenericStore[model.PostM](store, WithNotFound(errno.ErrPostNotFound)), store: store}
 }
 
 // Create 插入一条帖子记录.
 func (s *postStore) Create(ctx context.Context, obj *model.PostM) error {
-	if err := s.store.DB(ctx).Create(&obj).Error; err != nil {
-		log.Errorw("Failed to insert post into database", "err", err, "post", obj)
-		return errno.ErrDBWrite.WithMessage(err.Error())
+	if !s.store.postCountColumn {
+		return s.Store.Create(ctx, obj)
 	}
 
-	return nil
+	return s.store.TX(ctx, func(ctx context.Context) error {
+		if err := s.Store.Create(ctx, obj); err != nil {
+			return err
+		}
+		return s.addPostCounts(ctx, map[string]int64{obj.UserID: 1})
+	})
 }
 
-// Update 更新帖子数据库记录.
-func (s *postStore) Update(ctx context.Context, obj *model.PostM) error {
-	if err := s.store.DB(ctx).Save(&obj).Error; err != nil {
-		log.Errorw("Failed to update post in database", "err", err, "post", obj)
-		return errno.ErrDBWrite.WithMessage(err.Error())
+// BatchCreate 在一个事务中插入多条帖子记录.
+func (s *postStore) BatchCreate(ctx context.Context, objs []*model.PostM) error {
+	if !s.store.postCountColumn {
+		return s.Store.BatchCreate(ctx, objs)
 	}
 
-	return nil
+	return s.store.TX(ctx, func(ctx context.Context) error {
+		if err := s.Store.BatchCreate(ctx, objs); err != nil {
+			return err
+		}
+		deltas := make(map[string]int64)
+		for _, obj := range objs {
+			deltas[obj.UserID]++
+		}
+		return s.addPostCounts(ctx, deltas)
+	})
+}
+
+// Upsert 按主键插入或更新帖子记录，帖子所属的用户变化时同时更新新旧用户的帖子数量.
+func (s *postStore) Upsert(ctx context.Context, obj *model.PostM) error {
+	if !s.store.postCountColumn {
+		return s.Store.Upsert(ctx, obj)
+	}
+
+	return s.store.TX(ctx, func(ctx context.Context) error {
+		var old model.PostM
+		found := false
+		if obj.ID != 0 {
+			err := s.store.DB(ctx).Where("id = ?", obj.ID).Take(&old).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				log.W(ctx).Errorw("Failed to retrieve post from database", "err", err, "id", obj.ID)
+				return dbReadError(err)
+			}
+			found = err == nil
+		}
+
+		if err := s.Store.Upsert(ctx, obj); err != nil {
+			return err
+		}
+
+		deltas := map[string]int64{obj.UserID: 1}
+		if found {
+			deltas[old.UserID]--
+		}
+		return s.addPostCounts(ctx, deltas)
+	})
 }
 
 // Delete 根据条件删除帖子记录.
 func (s *postStore) Delete(ctx context.Context, opts *where.Options) error {
-	err := s.store.DB(ctx, opts).Delete(new(model.PostM)).Error
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		log.Errorw("Failed to delete post from database", "err", err, "conditions", opts)
-		return errno.ErrDBWrite.WithMessage(err.Error())
+	if !s.store.postCountColumn {
+		return s.Store.Delete(ctx, opts)
 	}
 
-	return nil
+	return s.store.TX(ctx, func(ctx context.Context) error {
+		counts, err := s.countBy(s.store.DB(ctx, opts))
+		if err != nil {
+			log.Errorw("Failed to count posts to delete", "err", err, "conditions", opts)
+			return dbReadError(err)
+		}
+		if err := s.Store.Delete(ctx, opts); err != nil {
+			return err
+		}
+
+		for userID, count := range counts {
+			counts[userID] = -count
+		}
+		return s.addPostCounts(ctx, counts)
+	})
 }
 
-// Get 根据条件查询帖子记录.
-func (s *postStore) Get(ctx context.Context, opts *where.Options) (*model.PostM, error) {
-	var obj model.PostM
-	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil {
-		log.Errorw("Failed to retrieve post from database", "err", err, "conditions", opts)
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errno.ErrPostNotFound
+// CountByUserIDs 返回每个用户在所有租户中的帖子数量.
+// 开启冗余的帖子数量时读取用户表的 postCount 列，否则在一条 GROUP BY 查询中按用户统计帖子表.
+// 用户不属于任何租户，两种方式都不按租户隔离，保证统计结果一致.
+func (s *postStore) CountByUserIDs(ctx context.Context, userIDs []string) (map[string]int64, error) {
+	if len(userIDs) == 0 {
+		return map[string]int64{}, nil
+	}
+
+	var counts map[string]int64
+	var err error
+	if s.store.postCountColumn {
+		counts, err = s.readPostCounts(ctx, userIDs)
+	} else {
+		counts, err = s.countBy(s.store.readDB(contextx.WithTenantID(ctx, "")).Where(map[string]any{"userID": userIDs}))
+	}
+	if err != nil {
+		log.Errorw("Failed to count posts from database", "err", err, "userIDs", userIDs)
+		return nil, dbReadError(err)
+	}
+	return counts, nil
+}
+
+// countBy 按用户分组统计 db 中的查询条件匹配的帖子数量.
+func (s *postStore) countBy(db *gorm.DB) (map[string]int64, error) {
+	userID := clause.Column{Name: "userID"}
+	rows, err := db.Model(new(model.PostM)).
+		Select("?, COUNT(*)", userID).
+		Clauses(clause.GroupBy{Columns: []clause.Column{userID}}).
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanCounts(rows)
+}
+
+// readPostCounts 从用户表的 postCount 列中读取用户的帖子数量，帖子数量为 0 的用户不包含在返回结果中.
+// postCount 列没有定义在 model.UserM 中，避免更新用户时使用查询到的旧值覆盖该列.
+func (s *postStore) readPostCounts(ctx context.Context, userIDs []string) (map[string]int64, error) {
+	rows, err := s.store.readDB(ctx).Table(model.TableNameUserM).
+		Select("?, ?", clause.Column{Name: "userID"}, clause.Column{Name: postCountColumn}).
+		Where(map[string]any{"userID": userIDs}).
+		Where(clause.Gt{Column: clause.Column{Name: postCountColumn}, Value: 0}).
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanCounts(rows)
+}
+
+// addPostCounts 将 deltas 中的增量累加到对应用户的 postCount 列.
+func (s *postStore) addPostCounts(ctx context.Context, deltas map[string]int64) error {
+	for userID, delta := range deltas {
+		if delta == 0 {
+			continue
+		}
+
+		err := s.store.DB(ctx).Table(model.TableNameUserM).
+			Where(map[string]any{"userID": userID}).
+			UpdateColumn(postCountColumn, gorm.Expr("? + ?", clause.Column{Name: postCountColumn}, delta)).Error
+		if err != nil {
+			log.Errorw("Failed to update post count in database", "err", err, "userID", userID)
+			return dbWriteError(err)
 		}
-		return nil, errno.ErrDBRead.WithMessage(err.Error())
 	}
 
-	return &obj, nil
+	return nil
 }
 
-// List 返回帖子列表和总数.
-func (s *postStore) List(ctx context.Context, opts *where.Options) (count int64, ret []*model.PostM, err error) {
-	err = s.store.DB(ctx, opts).Order("id desc").Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
+// RefreshPostCounts 重新统计所有用户的帖子数量并写入用户表的 postCount 列.
+// 该操作会更新用户表的每一行，并且可能覆盖同时进行的增量更新，只应该在关闭写入时作为运维操作执行，
+// 例如在关闭冗余的帖子数量期间写入过帖子，重新开启之前修正偏差.
+func RefreshPostCounts(ctx context.Context, db *gorm.DB) error {
+	count := gorm.Expr("(SELECT COUNT(*) FROM ? WHERE ? = ?)",
+		clause.Table{Name: model.TableNamePostM},
+		clause.Column{Table: model.TableNamePostM, Name: "userID"},
+		clause.Column{Table: model.TableNameUserM, Name: "userID"},
+	)
+	err := db.WithContext(ctx).Session(&gorm.Session{AllowGlobalUpdate: true}).Table(model.TableNameUserM).UpdateColumn(postCountColumn, count).Error
 	if err != nil {
-		log.Errorw("Failed to list posts from database", "err", err, "conditions", opts)
-		err = errno.ErrDBRead.WithMessage(err.Error())
+		log.Errorw("Failed to refresh post counts in database", "err", err)
+		return dbWriteError(err)
+	}
+
+	return nil
+}
+
+// scanCounts 读取 (userID, count) 形式的查询结果.
+func scanCounts(rows *sql.Rows) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var userID string
+		var count int64
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, err
+		}
+		counts[userID] = count
 	}
-	return
+	return counts, rows.Err()
 }
@@ -0,0 +1,104 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDenormalizedPostCount(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	// 关闭冗余的帖子数量时创建的帖子不会更新 postCount 列
+	plain, err := store.New(db)
+	require.NoError(t, err)
+	users := make([]string, 0, 2)
+	for _, username := range []string{"alice", "bob"} {
+		userM := &model.UserM{Username: username, Password: "miniblog1234", Phone: "1" + username}
+		require.NoError(t, plain.User().Create(ctx, userM))
+		users = append(users, userM.UserID)
+	}
+	for i := 0; i < 2; i++ {
+		require.NoError(t, plain.Post().Create(ctx, &model.PostM{UserID: users[0], Title: "title", Content: "content"}))
+	}
+
+	opts := store.NewOptions()
+	opts.DenormalizedPostCount = true
+	s, cleanup, err := store.ProvideStore(db, nil, nil, opts)
+	require.NoError(t, err)
+	t.Cleanup(cleanup)
+
+	// 开启时不会自动重新统计，需要显式执行
+	counts, err := s.Post().CountByUserIDs(ctx, users)
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+	require.NoError(t, store.RefreshPostCounts(ctx, db))
+	counts, err = s.Post().CountByUserIDs(ctx, users)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{users[0]: 2}, counts)
+
+	require.NoError(t, s.Post().Create(ctx, &model.PostM{UserID: users[1], Title: "title", Content: "content"}))
+	require.NoError(t, s.Post().Delete(ctx, where.F("userID", users[0])))
+	counts, err = s.Post().CountByUserIDs(ctx, users)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{users[1]: 1}, counts)
+
+	// 事务回滚时帖子数量也会回滚
+	err = s.TX(ctx, func(ctx context.Context) error {
+		require.NoError(t, s.Post().Create(ctx, &model.PostM{UserID: users[1], Title: "title", Content: "content"}))
+		return assert.AnError
+	})
+	require.ErrorIs(t, err, assert.AnError)
+	counts, err = s.Post().CountByUserIDs(ctx, users)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{users[1]: 1}, counts)
+
+	// 批量创建和 Upsert 时同样维护帖子数量，Upsert 修改帖子所属的用户时同时更新新旧用户
+	postList := []*model.PostM{{UserID: users[0], Title: "a", Content: "content"}, {UserID: users[0], Title: "b", Content: "content"}}
+	require.NoError(t, s.Post().BatchCreate(ctx, postList))
+	require.NoError(t, s.Post().Upsert(ctx, &model.PostM{ID: postList[0].ID, UserID: users[1], Title: "a", Content: "content"}))
+	require.NoError(t, s.Post().Upsert(ctx, &model.PostM{UserID: users[0], Title: "c", Content: "content"}))
+	counts, err = s.Post().CountByUserIDs(ctx, users)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{users[0]: 2, users[1]: 2}, counts)
+}
+
+// TestPostCountScope 确保两种统计方式的结果一致，都统计用户在所有租户中的帖子数量.
+func TestPostCountScope(t *testing.T) {
+	db := newTestDB(t)
+	plain, err := store.New(db)
+	require.NoError(t, err)
+	opts := store.NewOptions()
+	opts.DenormalizedPostCount = true
+	denormalized, cleanup, err := store.ProvideStore(db, nil, nil, opts)
+	require.NoError(t, err)
+	t.Cleanup(cleanup)
+
+	userM := &model.UserM{Username: "alice", Password: "miniblog1234", Phone: "1alice"}
+	require.NoError(t, denormalized.User().Create(context.Background(), userM))
+	for _, tenantID := range []string{"org-1", "org-1", "org-2"} {
+		ctx := contextx.WithTenantID(context.Background(), tenantID)
+		require.NoError(t, denormalized.Post().Create(ctx, &model.PostM{UserID: userM.UserID, Title: "title", Content: "content"}))
+	}
+
+	ctx := contextx.WithTenantID(context.Background(), "org-1")
+	want := map[string]int64{userM.UserID: 3}
+	for _, s := range []store.IStore{plain, denormalized} {
+		counts, err := s.Post().CountByUserIDs(ctx, []string{userM.UserID})
+		require.NoError(t, err)
+		assert.Equal(t, want, counts)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	"gorm.io/gorm/clause"
+)
+
+// RecoveryCodeStore 定义了 recovery_code 模块在 store 层所实现的方法.
+type RecoveryCodeStore interface {
+	Repository[model.RecoveryCodeM]
+
+	RecoveryCodeExpansion
+}
+
+// RecoveryCodeExpansion 定义了恢复码操作的附加方法.
+type RecoveryCodeExpansion interface {
+	// Consume 原子地将用户的某个未使用恢复码标记为已使用，返回值表示是否标记成功.
+	Consume(ctx context.Context, userID string, codeHash string) (bool, error)
+}
+
+// recoveryCodeStore 是 RecoveryCodeStore 接口的实现.
+type recoveryCodeStore struct {
+	*Store[model.RecoveryCodeM]
+	store *datastore
+}
+
+// 确保 recoveryCodeStore 实现了 RecoveryCodeStore 接口.
+var _ RecoveryCodeStore = (*recoveryCodeStore)(nil)
+
+// newRecoveryCodeStore 创建 recoveryCodeStore 的实例.
+func newRecoveryCodeStore(store *datastore) *recoveryCodeStore {
+	return &recoveryCodeStore{Store: newGenericStore[model.RecoveryCodeM](store, WithNotFound(errno.ErrRecoveryCodeInvalid)), store: store}
+}
+
+// Consume 将恢复码标记为已使用，已经使用过的恢复码不会被再次标记.
+func (s *recoveryCodeStore) Consume(ctx context.Context, userID string, codeHash string) (bool, error) {
+	db := s.store.DB(ctx).Model(new(model.RecoveryCodeM)).
+		Where(map[string]any{"userID": userID, "codeHash": codeHash}).
+		Where(clause.Eq{Column: "usedAt", Value: nil}).
+		Update("usedAt", time.Now())
+	if err := db.Error; err != nil {
+		log.Errorw("Failed to consume recovery code", "err", err, "userID", userID)
+		return false, dbWriteError(err)
+	}
+
+	return db.RowsAffected == 1, nil
+}
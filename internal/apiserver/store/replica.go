@@ -0,0 +1,244 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm"
+)
+
+const (
+	// writeTrackerKey 是 *gorm.DB 中保存当前写入用户的键，由 datastore.DB 设置.
+	writeTrackerKey = "miniblog:write_tracker"
+	// maxTrackedWriters 是写入记录器中保存的最大用户数，超过时清理已经过期的记录.
+	maxTrackedWriters = 10000
+)
+
+// Replicas 是只读副本数据库实例列表.
+type Replicas []*gorm.DB
+
+// Option 定义 datastore 的可选配置.
+type Option func(*datastore)
+
+// WithReplicas 设置只读副本，Get、List 等读操作会以轮询的方式发送到健康的只读副本.
+func WithReplicas(replicas ...*gorm.DB) Option {
+	return func(store *datastore) {
+		for _, db := range replicas {
+			r := &replica{db: db}
+			r.healthy.Store(true)
+			store.replicas = append(store.replicas, r)
+		}
+	}
+}
+
+// WithHealthCheckInterval 设置检查只读副本健康状态的间隔，为 0 时不检查.
+func WithHealthCheckInterval(interval time.Duration) Option {
+	return func(store *datastore) {
+		store.healthCheckInterval = interval
+	}
+}
+
+// WithReadYourWrites 设置用户写入数据之后，其读请求固定发送到主库的时间窗口，为 0 时不开启.
+func WithReadYourWrites(window time.Duration) Option {
+	return func(store *datastore) {
+		store.writes = newWriteTracker(window)
+	}
+}
+
+// primaryKey 用于在 context.Context 中标记读请求需要发送到主库.
+type primaryKey struct{}
+
+// WithPrimary 返回一个新的上下文，使用该上下文的读请求总是发送到主库.
+// 适用于刚写入数据、不能容忍复制延迟的请求.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// replica 是一个只读副本.
+type replica struct {
+	db      *gorm.DB
+	healthy atomic.Bool
+}
+
+// readDB 返回读操作使用的数据库实例，并叠加传入的查询条件.
+// 事务中的读操作、标记为读主库的请求以及在写入窗口内的用户读请求会发送到主库，
+// 否则以轮询的方式选择一个健康的只读副本，没有健康的副本时使用主库.
+func (store *datastore) readDB(ctx context.Context, wheres ...where.Where) *gorm.DB {
+	if _, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return store.DB(ctx, wheres...)
+	}
+	if pinned, _ := ctx.Value(primaryKey{}).(bool); pinned || store.writes.recent(contextx.UserID(ctx)) {
+		return store.DB(ctx, wheres...)
+	}
+
+	r := store.pickReplica()
+	if r == nil {
+		return store.DB(ctx, wheres...)
+	}
+	return store.scoped(ctx, r.db, wheres...)
+}
+
+// pickReplica 以轮询的方式返回一个健康的只读副本，没有健康的副本时返回 nil.
+func (store *datastore) pickReplica() *replica {
+	n := uint64(len(store.replicas))
+	if n == 0 {
+		return nil
+	}
+
+	start := store.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if r := store.replicas[(start+i)%n]; r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
+}
+
+// startHealthCheck 定期检查只读副本的健康状态，不健康的副本会被移出负载均衡，恢复后重新加入.
+func (store *datastore) startHealthCheck() {
+	if len(store.replicas) == 0 || store.healthCheckInterval <= 0 {
+		return
+	}
+
+	store.stop = make(chan struct{})
+	store.wg.Add(1)
+	go func() {
+		defer store.wg.Done()
+
+		ticker := time.NewTicker(store.healthCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-store.stop:
+				return
+			case <-ticker.C:
+				for i, r := range store.replicas {
+					healthy := ping(r.db, store.healthCheckInterval) == nil
+					if r.healthy.Swap(healthy) != healthy {
+						log.Warnw("Database replica health changed", "replica", i, "healthy", healthy)
+					}
+				}
+			}
+		}
+	}()
+}
+
+// stopHealthCheck 停止健康检查并等待检查协程退出.
+func (store *datastore) stopHealthCheck() {
+	if store.stop != nil {
+		close(store.stop)
+		store.wg.Wait()
+		store.stop = nil
+	}
+}
+
+// ping 在超时时间内检查数据库连接是否可用.
+func ping(db *gorm.DB, timeout time.Duration) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return sqlDB.PingContext(ctx)
+}
+
+// writeTracker 记录用户最近一次写入数据的时间，用于实现读己之写（read your writes）.
+// 记录只保存在当前进程中，多个 apiserver 实例之间不共享.
+type writeTracker struct {
+	window time.Duration
+
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+// newWriteTracker 创建 writeTracker 实例，window 小于等于 0 时返回 nil，表示不开启.
+func newWriteTracker(window time.Duration) *writeTracker {
+	if window <= 0 {
+		return nil
+	}
+	return &writeTracker{window: window, last: make(map[string]time.Time)}
+}
+
+// record 记录用户在当前时间写入了数据.
+func (w *writeTracker) record(userID string) {
+	if w == nil || userID == "" {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now()
+	if len(w.last) >= maxTrackedWriters {
+		for id, t := range w.last {
+			if now.Sub(t) >= w.window {
+				delete(w.last, id)
+			}
+		}
+	}
+	w.last[userID] = now
+}
+
+// recent 判断用户是否在时间窗口内写入过数据.
+func (w *writeTracker) recent(userID string) bool {
+	if w == nil || userID == "" {
+		return false
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	t, ok := w.last[userID]
+	return ok && time.Since(t) < w.window
+}
+
+// registerWriteCallbacks 在主库上注册记录用户写入的 GORM 回调. 同一个 db 重复注册时直接返回.
+func registerWriteCallbacks(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if callbacks.Create().Get("miniblog:record_create") != nil {
+		return nil
+	}
+
+	if err := callbacks.Create().After("gorm:create").Register("miniblog:record_create", recordWrite); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("miniblog:record_update", recordWrite); err != nil {
+		return err
+	}
+	return callbacks.Delete().After("gorm:delete").Register("miniblog:record_delete", recordWrite)
+}
+
+// recordWrite 在写入成功之后记录当前用户的写入时间.
+func recordWrite(db *gorm.DB) {
+	if db.Error != nil || db.RowsAffected == 0 {
+		return
+	}
+
+	if w, ok := db.Get(writeTrackerKey); ok {
+		w.(*writer).record()
+	}
+}
+
+// writer 是执行写操作的用户，由 datastore.DB 传递给记录写入的回调.
+type writer struct {
+	tracker *writeTracker
+	userID  string
+}
+
+// record 记录用户在当前时间写入了数据.
+func (w *writer) record() {
+	w.tracker.record(w.userID)
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// 测试中的主库和只读副本是相互独立的数据库，没有数据复制，
+// 因此只写入主库的数据只能通过主库读取，可以据此判断读请求发送到了哪个数据库.
+func newReplicatedStore(t *testing.T, n int, opts ...store.Option) (store.IStore, []*gorm.DB) {
+	t.Helper()
+
+	replicas := make([]*gorm.DB, 0, n)
+	for i := 0; i < n; i++ {
+		replicas = append(replicas, newTestDB(t))
+	}
+
+	s, err := store.New(newTestDB(t), append(opts, store.WithReplicas(replicas...))...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	return s, replicas
+}
+
+func TestReadWriteSplitting(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newReplicatedStore(t, 1)
+
+	require.NoError(t, s.Organization().Create(ctx, &model.OrganizationM{Slug: "acme", Name: "Acme", OwnerID: "user-1"}))
+
+	// 读请求默认发送到只读副本
+	_, err := s.Organization().Get(ctx, where.F("slug", "acme"))
+	assert.ErrorIs(t, err, errno.ErrOrganizationNotFound)
+	count, _, err := s.Organization().List(ctx, where.F("slug", "acme"))
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// 显式指定读主库
+	_, err = s.Organization().Get(store.WithPrimary(ctx), where.F("slug", "acme"))
+	assert.NoError(t, err)
+
+	// 事务中的读请求发送到主库
+	err = s.TX(ctx, func(ctx context.Context) error {
+		_, err := s.Organization().Get(ctx, where.F("slug", "acme"))
+		return err
+	})
+	assert.NoError(t, err)
+}
+
+func TestReadYourWrites(t *testing.T) {
+	s, _ := newReplicatedStore(t, 1, store.WithReadYourWrites(100*time.Millisecond))
+
+	ctx := contextx.WithUserID(context.Background(), "user-1")
+	require.NoError(t, s.Organization().Create(ctx, &model.OrganizationM{Slug: "acme", Name: "Acme", OwnerID: "user-1"}))
+
+	// 写入之后的时间窗口内，同一用户的读请求发送到主库
+	_, err := s.Organization().Get(ctx, where.F("slug", "acme"))
+	assert.NoError(t, err)
+
+	otherCtx := contextx.WithUserID(context.Background(), "user-2")
+	_, err = s.Organization().Get(otherCtx, where.F("slug", "acme"))
+	assert.ErrorIs(t, err, errno.ErrOrganizationNotFound)
+
+	// 时间窗口过后重新读只读副本
+	time.Sleep(150 * time.Millisecond)
+	_, err = s.Organization().Get(ctx, where.F("slug", "acme"))
+	assert.ErrorIs(t, err, errno.ErrOrganizationNotFound)
+}
+
+func TestReplicaRoundRobinAndEjection(t *testing.T) {
+	ctx := context.Background()
+	s, replicas := newReplicatedStore(t, 2, store.WithHealthCheckInterval(10*time.Millisecond))
+
+	for i, slug := range []string{"replica-0", "replica-1"} {
+		require.NoError(t, replicas[i].Create(&model.OrganizationM{OrgID: slug, Slug: slug, Name: slug, OwnerID: "user-1"}).Error)
+	}
+	readSlugs := func(n int) map[string]int {
+		slugs := make(map[string]int)
+		for i := 0; i < n; i++ {
+			_, orgList, err := s.Organization().List(ctx, where.F("slug", []string{"replica-0", "replica-1"}))
+			require.NoError(t, err)
+			require.Len(t, orgList, 1)
+			slugs[orgList[0].Slug]++
+		}
+		return slugs
+	}
+
+	assert.Equal(t, map[string]int{"replica-0": 2, "replica-1": 2}, readSlugs(4))
+
+	// 不健康的只读副本被移出负载均衡
+	sqlDB, err := replicas[1].DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+	require.Eventually(t, func() bool {
+		_, orgList, err := s.Organization().List(ctx, where.F("slug", "replica-1"))
+		return err == nil && len(orgList) == 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, map[string]int{"replica-0": 4}, readSlugs(4))
+}
@@ -8,22 +8,32 @@ package store
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/database"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
 	"github.com/google/wire"
 	"github.com/onexstack/onexstack/pkg/store/where"
 	"gorm.io/gorm"
 )
 
 // ProviderSet 是一个 Wire 的 Provider 集合，用于声明依赖注入的规则.
-// 包含 NewStore 构造函数，用于生成 datastore 实例.
-// wire.Bind 用于将接口 IStore 与具体实现 *datastore 绑定,
-// 从而在依赖 IStore 的地方，能够自动注入 *datastore 实例
-var ProviderSet = wire.NewSet(NewStore, wire.Bind(new(IStore), new(*datastore)))
+// 包含 ProvideStore 构造函数，用于生成 datastore 实例，并在应用退出时关闭数据库连接.
+// ProvideCachedStore 在开启缓存时为 datastore 添加用户和博客查询缓存,
+// 从而在依赖 IStore 的地方，能够自动注入带缓存的 IStore 实例
+var ProviderSet = wire.NewSet(ProvideStore, ProvideCachedStore)
 
 var (
-	once sync.Once
-	// 全局变量，方便其他包直接调用已初始化好的 datastore 实例.
+	mu sync.Mutex
+	// S 是最近一次调用 NewStore 创建的 datastore 实例.
+	//
+	// Deprecated: 全局实例无法支持多个数据库，请通过依赖注入传递 IStore.
 	S *datastore
 )
 
@@ -32,9 +42,17 @@ type IStore interface {
 	// 返回 Store 层的 *gorm.DB 实例，在少数场景下会被用到
 	DB(ctx context.Context, wheres ...where.Where) *gorm.DB
 	TX(ctx context.Context, fn func(ctx context.Context) error) error
+	// Close 释放 Store 持有的资源，关闭之后不能再使用
+	Close() error
 
 	User() UserStore
 	Post() PostStore
+	TOTP() TOTPStore
+	RecoveryCode() RecoveryCodeStore
+	PasswordHistory() PasswordHistoryStore
+	Identity() IdentityStore
+	Audit() AuditStore
+	Organization() OrganizationStore
 }
 
 // transactionKey 用于在 context.Context 中存储事务上下文的键.
@@ -44,24 +62,118 @@ type transactionKey struct{}
 type datastore struct {
 	core *gorm.DB
 
-	// 可以根据需要添加其他数据库的实例
-	// fake *gorm.DB
+	// replicas 是只读副本，读操作以轮询的方式发送到健康的副本
+	replicas []*replica
+	// next 是轮询选择只读副本的计数器
+	next                atomic.Uint64
+	healthCheckInterval time.Duration
+	// writes 记录用户最近的写入时间，为 nil 时不开启读己之写
+	writes *writeTracker
+	// postCountColumn 表示是否在用户表的 postCount 列中冗余保存用户的帖子数量
+	postCountColumn bool
+
+	stop chan struct{}
+	wg   sync.WaitGroup
 }
 
 // 确保 datastore 实现了 IStore 接口.
 var _ IStore = (*datastore)(nil)
 
-// NewStore 创建一个 IStore 类型的实例.
+// New 基于主库 db 创建一个新的 datastore 实例，每次调用都会返回独立的实例.
+// 除了在数据库实例上注册 GORM 回调之外没有其他副作用，datastore 接管主库和只读副本的生命周期，由 Close 关闭数据库连接.
+func New(db *gorm.DB, opts ...Option) (*datastore, error) {
+	store := &datastore{core: db}
+	for _, opt := range opts {
+		opt(store)
+	}
+
+	// 注册租户隔离回调，包含 TenantID 字段的数据表会自动按租户过滤
+	if err := registerTenantCallbacks(db); err != nil {
+		return nil, err
+	}
+	if err := registerWriteCallbacks(db); err != nil {
+		return nil, err
+	}
+	for _, r := range store.replicas {
+		if err := registerTenantCallbacks(r.db); err != nil {
+			return nil, err
+		}
+	}
+
+	store.startHealthCheck()
+	return store, nil
+}
+
+// ProvideStore 创建 datastore 实例，返回的清理函数用于关闭数据库连接，供 Wire 使用.
+// replicaOptions 为 nil 时不开启只读副本的健康检查和读己之写，opts 为 nil 时使用默认配置.
+func ProvideStore(db *gorm.DB, replicas Replicas, replicaOptions *database.ReplicaOptions, opts *Options) (*datastore, func(), error) {
+	if replicaOptions == nil {
+		replicaOptions = &database.ReplicaOptions{}
+	}
+	if opts == nil {
+		opts = NewOptions()
+	}
+
+	storeOpts := []Option{
+		WithReplicas(replicas...),
+		WithHealthCheckInterval(replicaOptions.HealthCheckInterval),
+		WithReadYourWrites(replicaOptions.ReadYourWritesWindow),
+	}
+	if opts.DenormalizedPostCount {
+		storeOpts = append(storeOpts, WithPostCountColumn())
+	}
+	store, err := New(db, storeOpts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanup := func() {
+		if err := store.Close(); err != nil {
+			log.Errorw("Failed to close datastore", "err", err)
+		}
+	}
+	return store, cleanup, nil
+}
+
+// NewStore 创建一个 IStore 类型的实例，并将其保存到 S 中.
+//
+// Deprecated: 使用 New 创建 datastore 实例，并通过依赖注入传递.
+// NewStore 不再缓存第一次创建的实例，每次调用都会返回基于传入 db 的新实例.
+// 注册租户隔离回调失败时直接 panic，不能返回没有租户隔离的实例.
 func NewStore(db *gorm.DB) *datastore {
-	// 确保 S 只被初始化一次
-	once.Do(func() {
-		S = &datastore{db}
-	})
+	store, err := New(db)
+	if err != nil {
+		panic(fmt.Sprintf("failed to create datastore: %v", err))
+	}
 
+	mu.Lock()
+	defer mu.Unlock()
+	S = store
 	return S
 }
 
-// 如果未传入任何条件，则返回上下文中的数据库实例（事务实例或核心数据库实例）.
+// Close 停止只读副本的健康检查，并关闭主库和只读副本的数据库连接.
+func (store *datastore) Close() error {
+	store.stopHealthCheck()
+
+	dbs := []*gorm.DB{store.core}
+	for _, r := range store.replicas {
+		dbs = append(dbs, r.db)
+	}
+
+	var errs []error
+	for _, db := range dbs {
+		sqlDB, err := db.DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// 如果未传入任何条件，则返回上下文中的数据库实例（事务实例或主库实例），写操作都需要使用该实例.
+// 上下文中包含租户 ID 时，包含 TenantID 字段的数据表会自动按租户过滤.
 func (store *datastore) DB(ctx context.Context, wheres ...where.Where) *gorm.DB {
 	db := store.core
 	// 从上下文中提取事务实例
@@ -69,6 +181,21 @@ func (store *datastore) DB(ctx context.Context, wheres ...where.Where) *gorm.DB
 		db = tx
 	}
 
+	// 将当前用户传递给记录写入的回调
+	if store.writes != nil {
+		db = db.Set(writeTrackerKey, &writer{tracker: store.writes, userID: contextx.UserID(ctx)})
+	}
+
+	return store.scoped(ctx, db, wheres...)
+}
+
+// scoped 在 db 上叠加租户条件和传入的查询条件.
+func (store *datastore) scoped(ctx context.Context, db *gorm.DB, wheres ...where.Where) *gorm.DB {
+	// 将租户 ID 传递给租户隔离回调
+	if tenantID := contextx.TenantID(ctx); tenantID != "" {
+		db = db.Set(tenantSettingKey, tenantID)
+	}
+
 	// 遍历所有传入的条件并逐一叠加到数据库查询对象上
 	for _, whr := range wheres {
 		db = whr.Where(db)
@@ -76,8 +203,46 @@ func (store *datastore) DB(ctx context.Context, wheres ...where.Where) *gorm.DB
 	return db
 }
 
+// findAndCount 按 id 倒序查询 opts 分页后的记录，并统计不分页时的记录总数.
+// 查询和统计分别从 db 创建独立的会话，统计总数的会话只叠加 opts 中的查询条件，不包含排序和分页，
+// PostgreSQL 不允许在 COUNT(*) 中使用 ORDER BY，在同一个会话上链式调用 Count 也会复用 Find 的语句状态.
+func findAndCount(db *gorm.DB, opts *where.Options, dest any) (int64, error) {
+	query := conditions(db.Session(&gorm.Session{}), opts)
+	if opts != nil {
+		query = query.Offset(opts.Offset).Limit(opts.Limit)
+	}
+	if err := query.Order("id desc").Find(dest).Error; err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := conditions(db.Session(&gorm.Session{}), opts).Model(dest).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// conditions 在 db 上叠加 opts 中的过滤条件、子句和查询，不包含分页条件.
+// where.Options.Where 会把 Queries 追加到 opts.Clauses 中，因此这里不修改 opts.
+func conditions(db *gorm.DB, opts *where.Options) *gorm.DB {
+	if opts == nil {
+		return db
+	}
+
+	clauses := slices.Clone(opts.Clauses)
+	for _, query := range opts.Queries {
+		clauses = append(clauses, db.Statement.BuildCondition(query.Query, query.Args...)...)
+	}
+	return db.Where(opts.Filters).Clauses(clauses...)
+}
+
 // TX 返回一个新的事务实例.
+// 嵌套调用时复用上下文中已有的事务，fn 中的修改与外层事务一起提交或回滚.
 func (store *datastore) TX(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
 	return store.core.WithContext(ctx).Transaction(
 		func(tx *gorm.DB) error {
 			ctx := context.WithValue(ctx, transactionKey{}, tx)
@@ -95,3 +260,33 @@ func (store *datastore) User() UserStore {
 func (store *datastore) Post() PostStore {
 	return newPostStore(store)
 }
+
+// TOTP 返回一个实现了 TOTPStore 接口的实例.
+func (store *datastore) TOTP() TOTPStore {
+	return newTOTPStore(store)
+}
+
+// RecoveryCode 返回一个实现了 RecoveryCodeStore 接口的实例.
+func (store *datastore) RecoveryCode() RecoveryCodeStore {
+	return newRecoveryCodeStore(store)
+}
+
+// PasswordHistory 返回一个实现了 PasswordHistoryStore 接口的实例.
+func (store *datastore) PasswordHistory() PasswordHistoryStore {
+	return newPasswordHistoryStore(store)
+}
+
+// Identity 返回一个实现了 IdentityStore 接口的实例.
+func (store *datastore) Identity() IdentityStore {
+	return newIdentityStore(store)
+}
+
+// Audit 返回一个实现了 AuditStore 接口的实例.
+func (store *datastore) Audit() AuditStore {
+	return newAuditStore(store)
+}
+
+// Organization 返回一个实现了 OrganizationStore 接口的实例.
+func (store *datastore) Organization() OrganizationStore {
+	return newOrganizationStore(store)
+}
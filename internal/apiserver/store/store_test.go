@@ -0,0 +1,74 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/migration"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store/storetest"
+	"github.com/TobyIcetea/miniblog/internal/pkg/database"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newTestDB 创建一个 SQLite 内存数据库，并执行所有数据库迁移.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	opts := database.NewSQLiteOptions()
+	opts.Path = database.MemoryPath
+	db, err := opts.NewDB()
+	require.NoError(t, err)
+
+	m, err := migration.New(db, migration.NewOptions())
+	require.NoError(t, err)
+	_, err = m.Up(context.Background(), 0)
+	require.NoError(t, err)
+
+	return db
+}
+
+// newTestStore 基于 SQLite 内存数据库创建一个独立的 IStore 实例.
+func newTestStore(t *testing.T) store.IStore {
+	t.Helper()
+
+	s, err := store.New(newTestDB(t))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+
+	return s
+}
+
+func TestDatastore(t *testing.T) {
+	storetest.Run(t, newTestStore)
+}
+
+// TestNewIndependentStores 确保多次创建的 datastore 实例互不影响.
+func TestNewIndependentStores(t *testing.T) {
+	ctx := context.Background()
+	s1, s2 := newTestStore(t), newTestStore(t)
+
+	require.NoError(t, s1.Organization().Create(ctx, &model.OrganizationM{Slug: "acme", Name: "Acme", OwnerID: "user-1"}))
+
+	_, err := s1.Organization().Get(ctx, where.F("slug", "acme"))
+	require.NoError(t, err)
+	_, err = s2.Organization().Get(ctx, where.F("slug", "acme"))
+	assert.ErrorIs(t, err, errno.ErrOrganizationNotFound)
+
+	require.NoError(t, s2.Close())
+	_, err = s2.Organization().Get(ctx, where.F("slug", "acme"))
+	assert.Error(t, err)
+	_, err = s1.Organization().Get(ctx, where.F("slug", "acme"))
+	assert.NoError(t, err)
+}
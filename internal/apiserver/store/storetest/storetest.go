@@ -0,0 +1,555 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package storetest 提供 store.IStore 实现的一致性测试用例.
+// 基于 GORM 的实现和内存实现都需要通过这些测试，以保证在单元测试中使用内存实现时的行为与生产环境一致.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/rid"
+	"github.com/TobyIcetea/miniblog/pkg/auth"
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/clause"
+)
+
+// notRoot 排除数据库迁移中初始化的 root 用户，内存实现中没有初始数据.
+var notRoot = clause.Neq{Column: "username", Value: "root"}
+
+// Run 对 newStore 创建的 IStore 实例执行所有一致性测试，每个子测试都会创建一个新的实例.
+func Run(t *testing.T, newStore func(t *testing.T) store.IStore) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s store.IStore)
+	}{
+		{"UserStore", testUserStore},
+		{"UserStoreHooks", testUserStoreHooks},
+		{"UserStoreUpdatePassword", testUserStoreUpdatePassword},
+		{"UniqueIndex", testUniqueIndex},
+		{"BatchCreate", testBatchCreate},
+		{"Upsert", testUpsert},
+		{"NotFound", testNotFound},
+		{"Conditions", testConditions},
+		{"Pagination", testPagination},
+		{"DeleteWithoutConditions", testDeleteWithoutConditions},
+		{"PostStoreTenantScope", testPostStoreTenantScope},
+		{"PostStoreCountByUserIDs", testPostStoreCountByUserIDs},
+		{"TX", testTX},
+		{"OrganizationStoreGetByIDOrSlug", testOrganizationStoreGetByIDOrSlug},
+		{"TOTPStoreConsumeStep", testTOTPStoreConsumeStep},
+		{"RecoveryCodeStoreConsume", testRecoveryCodeStoreConsume},
+		{"PasswordHistoryStorePrune", testPasswordHistoryStorePrune},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newStore(t))
+		})
+	}
+}
+
+// createUsers 创建 n 个用户，用户名为 user0、user1...
+func createUsers(t *testing.T, s store.IStore, n int) []*model.UserM {
+	t.Helper()
+
+	users := make([]*model.UserM, 0, n)
+	for i := 0; i < n; i++ {
+		userM := &model.UserM{
+			Username: fmt.Sprintf("user%d", i),
+			Password: "miniblog1234",
+			Nickname: "nick",
+			Email:    fmt.Sprintf("user%d@example.com", i),
+			Phone:    fmt.Sprintf("1880000000%d", i),
+		}
+		require.NoError(t, s.User().Create(context.Background(), userM))
+		users = append(users, userM)
+	}
+	return users
+}
+
+func testUserStore(t *testing.T, s store.IStore) {
+	ctx := context.Background()
+	createUsers(t, s, 3)
+
+	userM, err := s.User().Get(ctx, where.F("username", "user1"))
+	require.NoError(t, err)
+	assert.Equal(t, "user1@example.com", userM.Email)
+
+	userM.Nickname = "updated"
+	require.NoError(t, s.User().Update(ctx, userM))
+	userM, err = s.User().Get(ctx, where.F("userID", userM.UserID))
+	require.NoError(t, err)
+	assert.Equal(t, "updated", userM.Nickname)
+
+	// 分页查询时总数不受分页条件影响，结果按 id 倒序排列
+	count, userList, err := s.User().List(ctx, where.NewWhere().C(notRoot).O(1).L(1))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	require.Len(t, userList, 1)
+	assert.Equal(t, "user1", userList[0].Username)
+
+	require.NoError(t, s.User().Delete(ctx, where.F("username", "user0")))
+	count, _, err = s.User().List(ctx, where.NewWhere().C(notRoot))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	// 删除不存在的记录不返回错误
+	require.NoError(t, s.User().Delete(ctx, where.F("username", "user0")))
+}
+
+func testUserStoreHooks(t *testing.T, s store.IStore) {
+	ctx := context.Background()
+	userM := createUsers(t, s, 1)[0]
+
+	assert.True(t, strings.HasPrefix(userM.UserID, rid.UserID.String()+"-"), userM.UserID)
+	assert.NotZero(t, userM.CreatedAt)
+	assert.NoError(t, auth.Compare(userM.Password, "miniblog1234"))
+
+	got, err := s.User().Get(ctx, where.F("userID", userM.UserID))
+	require.NoError(t, err)
+	assert.Equal(t, userM.ID, got.ID)
+	assert.Equal(t, userM.Password, got.Password)
+
+	// 修改查询结果不影响已经保存的数据
+	got.Nickname = "changed"
+	got, err = s.User().Get(ctx, where.F("userID", userM.UserID))
+	require.NoError(t, err)
+	assert.Equal(t, "nick", got.Nickname)
+
+	postM := &model.PostM{UserID: userM.UserID, Title: "title", Content: "content"}
+	require.NoError(t, s.Post().Create(ctx, postM))
+	assert.True(t, strings.HasPrefix(postM.PostID, rid.PostID.String()+"-"), postM.PostID)
+
+	orgM := &model.OrganizationM{Slug: "acme", Name: "Acme", OwnerID: userM.UserID}
+	require.NoError(t, s.Organization().Create(ctx, orgM))
+	assert.True(t, strings.HasPrefix(orgM.OrgID, rid.OrgID.String()+"-"), orgM.OrgID)
+}
+
+func testUserStoreUpdatePassword(t *testing.T, s store.IStore) {
+	ctx := context.Background()
+	userM := createUsers(t, s, 1)[0]
+
+	// 密码已经被其他请求修改时不更新
+	ok, err := s.User().UpdatePassword(ctx, userM.UserID, "stale", "new-hash")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = s.User().UpdatePassword(ctx, userM.UserID, userM.Password, "new-hash")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = s.User().UpdatePassword(ctx, userM.UserID, userM.Password, "other-hash")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	got, err := s.User().Get(ctx, where.F("userID", userM.UserID))
+	require.NoError(t, err)
+	assert.Equal(t, "new-hash", got.Password)
+}
+
+func testUniqueIndex(t *testing.T, s store.IStore) {
+	ctx := context.Background()
+	createUsers(t, s, 1)
+
+	err := s.User().Create(ctx, &model.UserM{
+		Username: "user0",
+		Password: "miniblog1234",
+		Nickname: "nick",
+		Email:    "other@example.com",
+		Phone:    "18800000099",
+	})
+	assert.ErrorIs(t, err, errno.ErrDBWrite)
+
+	require.NoError(t, s.Organization().Create(ctx, &model.OrganizationM{Slug: "acme", Name: "Acme", OwnerID: "user-1"}))
+	err = s.Organization().Create(ctx, &model.OrganizationM{Slug: "acme", Name: "Other", OwnerID: "user-2"})
+	assert.ErrorIs(t, err, errno.ErrDBWrite)
+}
+
+func testBatchCreate(t *testing.T, s store.IStore) {
+	ctx := context.Background()
+	userList := []*model.UserM{
+		{Username: "user0", Password: "miniblog1234", Nickname: "nick", Email: "user0@example.com", Phone: "18800000000"},
+		{Username: "user1", Password: "miniblog1234", Nickname: "nick", Email: "user1@example.com", Phone: "18800000001"},
+	}
+	require.NoError(t, s.User().BatchCreate(ctx, userList))
+	for _, userM := range userList {
+		assert.True(t, strings.HasPrefix(userM.UserID, rid.UserID.String()+"-"), userM.UserID)
+		assert.NoError(t, auth.Compare(userM.Password, "miniblog1234"))
+	}
+	assert.NotEqual(t, userList[0].UserID, userList[1].UserID)
+
+	// 任意一条记录插入失败时不会插入任何记录
+	err := s.User().BatchCreate(ctx, []*model.UserM{
+		{Username: "user2", Password: "miniblog1234", Nickname: "nick", Email: "user2@example.com", Phone: "18800000002"},
+		{Username: "user0", Password: "miniblog1234", Nickname: "nick", Email: "other@example.com", Phone: "18800000003"},
+	})
+	assert.ErrorIs(t, err, errno.ErrDBWrite)
+	count, userList, err := s.User().List(ctx, where.NewWhere().C(notRoot))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.ElementsMatch(t, []string{"user0", "user1"}, usernames(userList))
+
+	require.NoError(t, s.User().BatchCreate(ctx, nil))
+
+	tenantCtx := contextx.WithTenantID(ctx, "org-a")
+	postList := []*model.PostM{{UserID: "user-1", Title: "a", Content: "content"}, {UserID: "user-1", Title: "b", Content: "content"}}
+	require.NoError(t, s.Post().BatchCreate(tenantCtx, postList))
+	for _, postM := range postList {
+		assert.True(t, strings.HasPrefix(postM.PostID, rid.PostID.String()+"-"), postM.PostID)
+		assert.Equal(t, "org-a", postM.TenantID)
+	}
+}
+
+func testUpsert(t *testing.T, s store.IStore) {
+	ctx := contextx.WithTenantID(context.Background(), "org-a")
+
+	// 主键为空时插入记录
+	postM := &model.PostM{UserID: "user-1", Title: "before", Content: "content"}
+	require.NoError(t, s.Post().Upsert(ctx, postM))
+	assert.True(t, strings.HasPrefix(postM.PostID, rid.PostID.String()+"-"), postM.PostID)
+	assert.Equal(t, "org-a", postM.TenantID)
+	created, err := s.Post().Get(ctx, where.F("postID", postM.PostID))
+	require.NoError(t, err)
+
+	// 主键已存在时更新记录，创建时间和资源 ID 保持不变
+	require.NoError(t, s.Post().Upsert(ctx, &model.PostM{ID: postM.ID, UserID: "user-1", Title: "after", Content: "content"}))
+	got, err := s.Post().Get(ctx, where.F("postID", postM.PostID))
+	require.NoError(t, err)
+	assert.Equal(t, "after", got.Title)
+	assert.Equal(t, created.CreatedAt.Unix(), got.CreatedAt.Unix())
+	count, _, err := s.Post().List(ctx, where.NewWhere())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// 主键不存在时按指定的主键插入记录
+	require.NoError(t, s.Post().Upsert(ctx, &model.PostM{ID: postM.ID + 100, UserID: "user-1", Title: "other", Content: "content"}))
+	got, err = s.Post().Get(ctx, where.F("postID", rid.PostID.New(uint64(postM.ID+100))))
+	require.NoError(t, err)
+	assert.Equal(t, "other", got.Title)
+
+	// 与 Create 一样执行创建钩子
+	userM := createUsers(t, s, 1)[0]
+	userM.Password = "miniblog5678"
+	userM.Nickname = "changed"
+	require.NoError(t, s.User().Upsert(ctx, userM))
+	got2, err := s.User().Get(ctx, where.F("userID", userM.UserID))
+	require.NoError(t, err)
+	assert.Equal(t, "changed", got2.Nickname)
+	assert.NoError(t, auth.Compare(got2.Password, "miniblog5678"))
+}
+
+func testNotFound(t *testing.T, s store.IStore) {
+	ctx := context.Background()
+	missing := where.F("userID", "user-missing")
+
+	_, err := s.User().Get(ctx, missing)
+	assert.ErrorIs(t, err, errno.ErrUserNotFound)
+	_, err = s.Post().Get(ctx, where.F("postID", "post-missing"))
+	assert.ErrorIs(t, err, errno.ErrPostNotFound)
+	_, err = s.TOTP().Get(ctx, missing)
+	assert.ErrorIs(t, err, errno.ErrTOTPNotEnrolled)
+	_, err = s.RecoveryCode().Get(ctx, missing)
+	assert.ErrorIs(t, err, errno.ErrRecoveryCodeInvalid)
+	_, err = s.Identity().Get(ctx, missing)
+	assert.ErrorIs(t, err, errno.ErrIdentityNotFound)
+	_, err = s.Organization().Get(ctx, where.F("orgID", "org-missing"))
+	assert.ErrorIs(t, err, errno.ErrOrganizationNotFound)
+
+	count, userList, err := s.User().List(ctx, missing)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Empty(t, userList)
+}
+
+func testConditions(t *testing.T, s store.IStore) {
+	ctx := context.Background()
+	users := createUsers(t, s, 4)
+
+	count, userList, err := s.User().List(ctx, where.F("userID", []string{users[0].UserID, users[2].UserID}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, []string{"user2", "user0"}, usernames(userList))
+
+	or := clause.Or(clause.Eq{Column: "username", Value: "user1"}, clause.Eq{Column: "email", Value: "user3@example.com"})
+	_, userList, err = s.User().List(ctx, where.NewWhere().C(or))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user3", "user1"}, usernames(userList))
+
+	_, userList, err = s.User().List(ctx, where.NewWhere().C(clause.Gte{Column: "id", Value: users[1].ID}, clause.Lt{Column: "id", Value: users[3].ID}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user2", "user1"}, usernames(userList))
+
+	_, userList, err = s.User().List(ctx, where.F("nickname", "nick").C(clause.Neq{Column: "username", Value: "user0"}, notRoot))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user3", "user2", "user1"}, usernames(userList))
+
+	// 模糊匹配时通配符按普通字符处理
+	now := time.Now()
+	for _, resource := range []string{"post", "user_totp", "usertotp"} {
+		require.NoError(t, s.Audit().Create(ctx, &model.AuditEventM{Action: "create", Resource: resource, Outcome: "success", CreatedAt: now}))
+	}
+	count, _, err = s.Audit().List(ctx, where.NewWhere().C(store.Contains("resource", "r_t")))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	count, _, err = s.Audit().List(ctx, where.NewWhere().C(store.Contains("resource", "totp"), clause.Lt{Column: "createdAt", Value: now.Add(time.Minute)}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func testPagination(t *testing.T, s store.IStore) {
+	ctx := context.Background()
+	createUsers(t, s, 5)
+
+	tests := []struct {
+		name string
+		opts *where.Options
+		want []string
+	}{
+		{"page 1", where.NewWhere().C(notRoot).P(1, 2), []string{"user4", "user3"}},
+		{"page 3", where.NewWhere().C(notRoot).P(3, 2), []string{"user0"}},
+		{"beyond last page", where.NewWhere().C(notRoot).P(4, 2), []string{}},
+		{"offset only", where.NewWhere().C(notRoot).O(3), []string{"user1", "user0"}},
+		{"limit only", where.NewWhere().C(notRoot).L(1), []string{"user4"}},
+	}
+	for _, tt := range tests {
+		count, userList, err := s.User().List(ctx, tt.opts)
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, int64(5), count, tt.name)
+		assert.Equal(t, tt.want, usernames(userList), tt.name)
+	}
+
+	// Get 返回 id 最小的记录
+	userM, err := s.User().Get(ctx, where.NewWhere().C(notRoot))
+	require.NoError(t, err)
+	assert.Equal(t, "user0", userM.Username)
+}
+
+func testDeleteWithoutConditions(t *testing.T, s store.IStore) {
+	ctx := context.Background()
+	createUsers(t, s, 1)
+
+	assert.Error(t, s.User().Delete(ctx, where.NewWhere()))
+	count, _, err := s.User().List(ctx, where.NewWhere().C(notRoot))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func testPostStoreTenantScope(t *testing.T, s store.IStore) {
+	for _, tenantID := range []string{"org-a", "org-a", "org-b"} {
+		ctx := contextx.WithTenantID(context.Background(), tenantID)
+		postM := &model.PostM{UserID: "user-1", Title: "title", Content: "content"}
+		require.NoError(t, s.Post().Create(ctx, postM))
+		assert.Equal(t, tenantID, postM.TenantID)
+	}
+
+	ctx := contextx.WithTenantID(context.Background(), "org-a")
+	count, postList, err := s.Post().List(ctx, where.F("userID", "user-1").L(1))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	require.Len(t, postList, 1)
+	assert.Equal(t, "org-a", postList[0].TenantID)
+
+	// 不能跨租户查询和删除博客
+	otherCtx := contextx.WithTenantID(context.Background(), "org-b")
+	_, err = s.Post().Get(otherCtx, where.F("postID", postList[0].PostID))
+	assert.ErrorIs(t, err, errno.ErrPostNotFound)
+	require.NoError(t, s.Post().Delete(otherCtx, where.F("postID", postList[0].PostID)))
+	postM, err := s.Post().Get(ctx, where.F("postID", postList[0].PostID))
+	require.NoError(t, err)
+
+	postM.Title = "updated"
+	require.NoError(t, s.Post().Update(ctx, postM))
+	postM, err = s.Post().Get(ctx, where.F("postID", postM.PostID))
+	require.NoError(t, err)
+	assert.Equal(t, "updated", postM.Title)
+
+	// 租户条件本身可以作为删除条件，只删除当前租户的数据
+	require.NoError(t, s.Post().Delete(otherCtx, where.NewWhere()))
+	count, _, err = s.Post().List(context.Background(), where.NewWhere())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	// 上下文中没有租户 ID 时不做租户隔离
+	count, _, err = s.Post().List(context.Background(), where.F("tenantID", []string{"org-a", "org-b"}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func testPostStoreCountByUserIDs(t *testing.T, s store.IStore) {
+	ctx := contextx.WithTenantID(context.Background(), "org-a")
+	for _, userID := range []string{"user-1", "user-1", "user-2", "user-3"} {
+		require.NoError(t, s.Post().Create(ctx, &model.PostM{UserID: userID, Title: "title", Content: "content"}))
+	}
+	otherCtx := contextx.WithTenantID(context.Background(), "org-b")
+	require.NoError(t, s.Post().Create(otherCtx, &model.PostM{UserID: "user-1", Title: "title", Content: "content"}))
+
+	// 没有帖子的用户和未指定的用户不包含在结果中，统计所有租户中的帖子
+	counts, err := s.Post().CountByUserIDs(ctx, []string{"user-1", "user-2", "user-4"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"user-1": 3, "user-2": 1}, counts)
+
+	counts, err = s.Post().CountByUserIDs(otherCtx, []string{"user-1", "user-2"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"user-1": 3, "user-2": 1}, counts)
+
+	counts, err = s.Post().CountByUserIDs(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+}
+
+func testTX(t *testing.T, s store.IStore) {
+	ctx := context.Background()
+	countOrgs := func() int64 {
+		count, _, err := s.Organization().List(ctx, where.F("slug", []string{"acme", "globex"}))
+		require.NoError(t, err)
+		return count
+	}
+
+	errRollback := errors.New("rollback")
+	err := s.TX(ctx, func(ctx context.Context) error {
+		if err := s.Organization().Create(ctx, &model.OrganizationM{Slug: "acme", Name: "Acme", OwnerID: "user-1"}); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+	assert.Zero(t, countOrgs())
+
+	assert.Panics(t, func() {
+		_ = s.TX(ctx, func(ctx context.Context) error {
+			_ = s.Organization().Create(ctx, &model.OrganizationM{Slug: "acme", Name: "Acme", OwnerID: "user-1"})
+			panic("boom")
+		})
+	})
+	assert.Zero(t, countOrgs())
+
+	// 嵌套事务与外层事务一起提交或回滚
+	err = s.TX(ctx, func(ctx context.Context) error {
+		if err := s.Organization().Create(ctx, &model.OrganizationM{Slug: "acme", Name: "Acme", OwnerID: "user-1"}); err != nil {
+			return err
+		}
+		return s.TX(ctx, func(ctx context.Context) error {
+			return s.Organization().Create(ctx, &model.OrganizationM{Slug: "globex", Name: "Globex", OwnerID: "user-1"})
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), countOrgs())
+
+	// 事务中的写操作失败时回滚之前的写操作
+	err = s.TX(ctx, func(ctx context.Context) error {
+		if err := s.Organization().Delete(ctx, where.F("slug", "globex")); err != nil {
+			return err
+		}
+		return s.Organization().Create(ctx, &model.OrganizationM{Slug: "acme", Name: "Acme", OwnerID: "user-1"})
+	})
+	require.Error(t, err)
+	assert.Equal(t, int64(2), countOrgs())
+}
+
+func testOrganizationStoreGetByIDOrSlug(t *testing.T, s store.IStore) {
+	ctx := context.Background()
+
+	orgM := &model.OrganizationM{Slug: "acme", Name: "Acme", OwnerID: "user-1"}
+	require.NoError(t, s.Organization().Create(ctx, orgM))
+
+	for _, tenant := range []string{orgM.OrgID, "acme"} {
+		whr := where.NewWhere().C(clause.Or(clause.Eq{Column: "orgID", Value: tenant}, clause.Eq{Column: "slug", Value: tenant}))
+		got, err := s.Organization().Get(ctx, whr)
+		require.NoError(t, err)
+		assert.Equal(t, orgM.OrgID, got.OrgID)
+	}
+}
+
+func testTOTPStoreConsumeStep(t *testing.T, s store.IStore) {
+	ctx := context.Background()
+
+	require.NoError(t, s.TOTP().Create(ctx, &model.UserTOTPM{UserID: "user-1", Secret: "secret", Enabled: true}))
+
+	tests := []struct {
+		step int64
+		want bool
+	}{
+		{step: 10, want: true},
+		{step: 10, want: false},
+		{step: 9, want: false},
+		{step: 11, want: true},
+	}
+	for _, tt := range tests {
+		ok, err := s.TOTP().ConsumeStep(ctx, "user-1", tt.step)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, ok, "step %d", tt.step)
+	}
+
+	totpM, err := s.TOTP().Get(ctx, where.F("userID", "user-1"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), totpM.LastUsedStep)
+
+	ok, err := s.TOTP().ConsumeStep(ctx, "user-2", 1)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func testRecoveryCodeStoreConsume(t *testing.T, s store.IStore) {
+	ctx := context.Background()
+
+	require.NoError(t, s.RecoveryCode().Create(ctx, &model.RecoveryCodeM{UserID: "user-1", CodeHash: "hash"}))
+
+	ok, err := s.RecoveryCode().Consume(ctx, "user-1", "hash")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = s.RecoveryCode().Consume(ctx, "user-1", "hash")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	codeM, err := s.RecoveryCode().Get(ctx, where.F("userID", "user-1"))
+	require.NoError(t, err)
+	assert.NotNil(t, codeM.UsedAt)
+
+	// 未使用的恢复码可以通过 usedAt IS NULL 条件查询
+	count, _, err := s.RecoveryCode().List(ctx, where.F("userID", "user-1").C(clause.Eq{Column: "usedAt", Value: nil}))
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func testPasswordHistoryStorePrune(t *testing.T, s store.IStore) {
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, s.PasswordHistory().Create(ctx, &model.PasswordHistoryM{UserID: "user-1", Password: fmt.Sprintf("hash-%d", i)}))
+	}
+	require.NoError(t, s.PasswordHistory().Create(ctx, &model.PasswordHistoryM{UserID: "user-2", Password: "hash"}))
+
+	require.NoError(t, s.PasswordHistory().Prune(ctx, "user-1", 2))
+
+	count, historyList, err := s.PasswordHistory().List(ctx, where.F("userID", "user-1"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, "hash-4", historyList[0].Password)
+
+	count, _, err = s.PasswordHistory().List(ctx, where.F("userID", "user-2"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+// usernames 返回用户列表中的用户名.
+func usernames(userList []*model.UserM) []string {
+	names := make([]string, 0, len(userList))
+	for _, userM := range userList {
+		names = append(names, userM.Username)
+	}
+	return names
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	// tenantField 是租户隔离的数据表中保存租户 ID 的模型字段名.
+	tenantField = "TenantID"
+	// tenantSettingKey 是 *gorm.DB 中保存当前租户 ID 的键，由 datastore.DB 设置.
+	tenantSettingKey = "miniblog:tenant_id"
+	// tenantScopedKey 标记当前语句已经添加过租户条件，避免链式调用时重复添加.
+	tenantScopedKey = "miniblog:tenant_scoped"
+)
+
+// registerTenantCallbacks 注册租户隔离的 GORM 回调.
+// 模型包含 TenantID 字段时，查询、更新和删除语句会自动添加租户条件，创建记录时会自动填充租户 ID.
+// 上下文中没有租户 ID 时（例如后台任务）不做任何处理. 同一个 db 重复注册时直接返回.
+func registerTenantCallbacks(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if callbacks.Create().Get("miniblog:tenant_create") != nil {
+		return nil
+	}
+	if err := callbacks.Create().Before("gorm:create").Register("miniblog:tenant_create", fillTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("miniblog:tenant_query", scopeTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("miniblog:tenant_row", scopeTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("miniblog:tenant_update", scopeTenant); err != nil {
+		return err
+	}
+	return callbacks.Delete().Before("gorm:delete").Register("miniblog:tenant_delete", scopeTenant)
+}
+
+// tenantOf 返回当前语句的租户 ID 和模型中的租户字段.
+func tenantOf(db *gorm.DB) (string, *schema.Field, bool) {
+	tenantID, ok := db.Get(tenantSettingKey)
+	if !ok || tenantID.(string) == "" || db.Statement.Schema == nil {
+		return "", nil, false
+	}
+
+	field := db.Statement.Schema.LookUpField(tenantField)
+	if field == nil {
+		return "", nil, false
+	}
+	return tenantID.(string), field, true
+}
+
+// fillTenant 在创建记录时填充租户 ID，已经指定租户 ID 的记录保持不变.
+func fillTenant(db *gorm.DB) {
+	tenantID, field, ok := tenantOf(db)
+	if !ok {
+		return
+	}
+
+	ctx := db.Statement.Context
+	switch db.Statement.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < db.Statement.ReflectValue.Len(); i++ {
+			rv := reflect.Indirect(db.Statement.ReflectValue.Index(i))
+			if _, zero := field.ValueOf(ctx, rv); zero {
+				db.AddError(field.Set(ctx, rv, tenantID))
+			}
+		}
+	case reflect.Struct:
+		if _, zero := field.ValueOf(ctx, db.Statement.ReflectValue); zero {
+			db.AddError(field.Set(ctx, db.Statement.ReflectValue, tenantID))
+		}
+	}
+}
+
+// scopeTenant 为查询、更新和删除语句添加租户条件.
+func scopeTenant(db *gorm.DB) {
+	tenantID, field, ok := tenantOf(db)
+	if !ok {
+		return
+	}
+	if _, scoped := db.InstanceGet(tenantScopedKey); scoped {
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: tenantID},
+	}})
+	db.InstanceSet(tenantScopedKey, true)
+}
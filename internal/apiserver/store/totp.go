@@ -0,0 +1,58 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package store
+
+import (
+	"context"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	"gorm.io/gorm/clause"
+)
+
+// TOTPStore 定义了 totp 模块在 store 层所实现的方法.
+type TOTPStore interface {
+	Repository[model.UserTOTPM]
+
+	TOTPExpansion
+}
+
+// TOTPExpansion 定义了 TOTP 配置操作的附加方法.
+type TOTPExpansion interface {
+	// ConsumeStep 原子地将 lastUsedStep 推进到 step，只有当 step 大于当前值时才会更新成功，
+	// 返回值表示是否更新成功，用于防止同一个动态码在并发请求中被重复使用.
+	ConsumeStep(ctx context.Context, userID string, step int64) (bool, error)
+}
+
+// totpStore 是 TOTPStore 接口的实现.
+type totpStore struct {
+	*Store[model.UserTOTPM]
+	store *datastore
+}
+
+// 确保 totpStore 实现了 TOTPStore 接口.
+var _ TOTPStore = (*totpStore)(nil)
+
+// newTOTPStore 创建 totpStore 的实例.
+func newTOTPStore(store *datastore) *totpStore {
+	return &totpStore{Store: newGenericStore[model.UserTOTPM](store, WithNotFound(errno.ErrTOTPNotEnrolled)), store: store}
+}
+
+// ConsumeStep 原子地推进用户最近一次使用的 TOTP 时间步.
+func (s *totpStore) ConsumeStep(ctx context.Context, userID string, step int64) (bool, error) {
+	db := s.store.DB(ctx).Model(new(model.UserTOTPM)).
+		Where(map[string]any{"userID": userID}).
+		Where(clause.Lt{Column: "lastUsedStep", Value: step}).
+		Update("lastUsedStep", step)
+	if err := db.Error; err != nil {
+		log.Errorw("Failed to update totp last used step", "err", err, "userID", userID)
+		return false, dbWriteError(err)
+	}
+
+	return db.RowsAffected == 1, nil
+}
@@ -8,31 +8,29 @@ package store
 
 import (
 	"context"
-	"errors"
 
 	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
 	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
 	"github.com/TobyIcetea/miniblog/internal/pkg/log"
-	"github.com/onexstack/onexstack/pkg/store/where"
-	"gorm.io/gorm"
 )
 
 // UserStore 定义了 user 模块在 store 层所实现的方法.
 type UserStore interface {
-	Create(ctx context.Context, obj *model.UserM) error
-	Update(ctx context.Context, obj *model.UserM) error
-	Delete(ctx context.Context, opts *where.Options) error
-	Get(ctx context.Context, opts *where.Options) (*model.UserM, error)
-	List(ctx context.Context, opts *where.Options) (int64, []*model.UserM, error)
+	Repository[model.UserM]
 
 	UserExpansion
 }
 
 // UserExpansion 定义了用户操作的附加方法.
-type UserExpansion interface{}
+type UserExpansion interface {
+	// UpdatePassword 仅当用户当前的密码密文为 oldPassword 时将其更新为 newPassword，
+	// 返回值表示是否更新成功，用于防止并发修改密码时覆盖其他请求设置的密码.
+	UpdatePassword(ctx context.Context, userID string, oldPassword string, newPassword string) (bool, error)
+}
 
 // userStore 是 UserStore 接口的实现.
 type userStore struct {
+	*Store[model.UserM]
 	store *datastore
 }
 
@@ -41,60 +39,18 @@ var _ UserStore = (*userStore)(nil)
 
 // newUserStore 创建 userStore 的实例.
 func newUserStore(store *datastore) *userStore {
-	return &userStore{store}
-}
-
-// Create 插入一条用户记录.
-func (s *userStore) Create(ctx context.Context, obj *model.UserM) error {
-	if err := s.store.DB(ctx).Create(obj).Error; err != nil {
-		log.Errorw("Failed tto insert user into database", "err", err, "user", obj)
-		return errno.ErrDBWrite.WithMessage(err.Error())
-	}
-
-	return nil
-}
-
-// Update 更新用户数据库记录.
-func (s *userStore) Update(ctx context.Context, obj *model.UserM) error {
-	if err := s.store.DB(ctx).Save(obj).Error; err != nil {
-		log.Errorw("Failed to update user in database", "err", err, "user", obj)
-		return errno.ErrDBWrite.WithMessage(err.Error())
-	}
-
-	return nil
-}
-
-// Delete 根据条件删除用户记录.
-func (s *userStore) Delete(ctx context.Context, opts *where.Options) error {
-	err := s.store.DB(ctx, opts).Delete(new(model.UserM)).Error
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		log.Errorw("Failed to delete user from database", "err", err, "conditions", opts)
-		return errno.ErrDBWrite.WithMessage(err.Error())
-	}
-
-	return nil
+	return &userStore{Store: newGenericStore[model.UserM](store, WithNotFound(errno.ErrUserNotFound)), store: store}
 }
 
-// Get 根据条件查询用户记录.
-func (s *userStore) Get(ctx context.Context, opts *where.Options) (*model.UserM, error) {
-	var obj model.UserM
-	if err := s.store.DB(ctx, opts).First(&obj).Error; err != nil {
-		log.Errorw("Failed to get user from database", "err", err, "conditions", opts)
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errno.ErrUserNotFound
-		}
-		return nil, errno.ErrDBRead.WithMessage(err.Error())
+// UpdatePassword 以条件更新的方式修改用户的密码密文.
+func (s *userStore) UpdatePassword(ctx context.Context, userID string, oldPassword string, newPassword string) (bool, error) {
+	db := s.store.DB(ctx).Model(new(model.UserM)).
+		Where(map[string]any{"userID": userID, "password": oldPassword}).
+		Update("password", newPassword)
+	if err := db.Error; err != nil {
+		log.Errorw("Failed to update user password", "err", err, "userID", userID)
+		return false, dbWriteError(err)
 	}
 
-	return &obj, nil
-}
-
-// List 返回用户列表和总数.
-func (s *userStore) List(ctx context.Context, opts *where.Options) (count int64, ret []*model.UserM, err error) {
-	err = s.store.DB(ctx, opts).Order("id desc").Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
-	if err != nil {
-		log.Errorw("Failed to list users from database", "err", err, "conditions", ret)
-		err = errno.ErrDBRead.WithMessage(err.Error())
-	}
-	return
+	return db.RowsAffected == 1, nil
 }
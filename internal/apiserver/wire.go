@@ -11,25 +11,49 @@ package apiserver
 import (
 	"github.com/TobyIcetea/miniblog/internal/apiserver/biz"
 	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/cache"
+	"github.com/TobyIcetea/miniblog/internal/pkg/captcha"
+	"github.com/TobyIcetea/miniblog/internal/pkg/lockout"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mail"
 	ginmw "github.com/TobyIcetea/miniblog/internal/pkg/middleware/gin"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mtls"
+	"github.com/TobyIcetea/miniblog/internal/pkg/oidc"
+	"github.com/TobyIcetea/miniblog/internal/pkg/password"
+	"github.com/TobyIcetea/miniblog/internal/pkg/ratelimit"
 	"github.com/TobyIcetea/miniblog/internal/pkg/server"
+	"github.com/TobyIcetea/miniblog/internal/pkg/tenant"
 	"github.com/TobyIcetea/miniblog/internal/pkg/validation"
 	"github.com/TobyIcetea/miniblog/pkg/auth"
 	"github.com/google/wire"
 )
 
-func InitializeWebServer(*Config) (server.Server, error) {
+// InitializeWebServer 创建服务器实例，返回的清理函数用于在服务器停止后释放数据库连接等资源.
+func InitializeWebServer(*Config) (server.Server, func(), error) {
 	wire.Build(
-		wire.NewSet(NewWebServer, wire.FieldsOf(new(*Config), "ServerMode")),
+		wire.NewSet(NewWebServer, wire.FieldsOf(new(*Config), "ServerMode", "ReplicaOptions", "StoreOptions", "MailOptions", "LockoutOptions", "OIDCOptions", "MTLSOptions", "TenantOptions", "PasswordOptions", "RateLimitOptions", "RedisOptions", "CacheOptions", "CaptchaOptions")),
 		wire.Struct(new(ServerConfig), "*"), // * 表示注入全部字段
 		wire.NewSet(store.ProviderSet, biz.ProviderSet),
-		ProvideDB, // 提供数据库实例
+		ProvideDB,       // 提供数据库实例
+		ProvideReplicas, // 提供只读副本数据库实例
 		validation.ProviderSet,
 		wire.NewSet(
 			wire.Struct(new(UserRetriever), "*"),
 			wire.Bind(new(ginmw.UserRetriever), new(*UserRetriever)),
+			wire.Struct(new(AuditRecorder), "*"),
+			wire.Bind(new(ginmw.Auditor), new(*AuditRecorder)),
+			wire.Struct(new(TenantRetriever), "*"),
+			wire.Bind(new(ginmw.TenantRetriever), new(*TenantRetriever)),
 		),
 		auth.ProviderSet,
+		mail.ProviderSet,
+		lockout.ProviderSet,
+		oidc.ProviderSet,
+		mtls.ProviderSet,
+		tenant.ProviderSet,
+		password.ProviderSet,
+		ratelimit.ProviderSet,
+		cache.ProviderSet,
+		captcha.ProviderSet,
 	)
-	return nil, nil
+	return nil, nil, nil
 }
@@ -9,40 +9,149 @@ package apiserver
 import (
 	"github.com/TobyIcetea/miniblog/internal/apiserver/biz"
 	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/cache"
+	"github.com/TobyIcetea/miniblog/internal/pkg/captcha"
+	"github.com/TobyIcetea/miniblog/internal/pkg/lockout"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mail"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mtls"
+	"github.com/TobyIcetea/miniblog/internal/pkg/oidc"
+	"github.com/TobyIcetea/miniblog/internal/pkg/password"
+	"github.com/TobyIcetea/miniblog/internal/pkg/ratelimit"
 	"github.com/TobyIcetea/miniblog/internal/pkg/server"
+	"github.com/TobyIcetea/miniblog/internal/pkg/tenant"
 	"github.com/TobyIcetea/miniblog/internal/pkg/validation"
 	"github.com/TobyIcetea/miniblog/pkg/auth"
 )
 
 // Injectors from wire.go:
 
-func InitializeWebServer(config *Config) (server.Server, error) {
+// InitializeWebServer 创建服务器实例，返回的清理函数用于在服务器停止后释放数据库连接等资源.
+func InitializeWebServer(config *Config) (server.Server, func(), error) {
 	string2 := config.ServerMode
-	db, err := ProvideDB(config)
+	db, cleanup, err := ProvideDB(config)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	datastore := store.NewStore(db)
+	replicas, cleanup2, err := ProvideReplicas(config)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	replicaOptions := config.ReplicaOptions
+	options := config.StoreOptions
+	datastore, cleanup3, err := store.ProvideStore(db, replicas, replicaOptions, options)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	cacheOptions := config.CacheOptions
+	redisOptions := config.RedisOptions
+	cacheCache, cleanup4, err := cache.NewCache(cacheOptions, redisOptions)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	iStore := store.ProvideCachedStore(datastore, cacheCache, cacheOptions)
 	v := auth.DefaultOptions()
 	authz, err := auth.NewAuthz(db, v...)
 	if err != nil {
-		return nil, err
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	mailOptions := config.MailOptions
+	mailer, err := mail.NewMailer(mailOptions)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	lockoutOptions := config.LockoutOptions
+	guard := lockout.NewGuard(lockoutOptions)
+	oidcOptions := config.OIDCOptions
+	manager := oidc.NewManager(oidcOptions)
+	passwordOptions := config.PasswordOptions
+	policy, err := password.NewPolicy(passwordOptions)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	captchaOptions := config.CaptchaOptions
+	captchaManager, err := captcha.NewManager(captchaOptions)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
 	}
-	bizBiz := biz.NewBiz(datastore, authz)
-	validator := validation.New(datastore)
+	bizBiz := biz.NewBiz(iStore, authz, mailer, mailOptions, guard, manager, policy, captchaManager)
+	validator := validation.New(iStore, policy, captchaManager)
 	userRetriever := &UserRetriever{
-		store: datastore,
+		store: iStore,
+	}
+	auditRecorder := &AuditRecorder{
+		store: iStore,
+	}
+	mtlsOptions := config.MTLSOptions
+	mapper, err := mtls.NewMapper(mtlsOptions)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	tenantOptions := config.TenantOptions
+	resolver := tenant.NewResolver(tenantOptions)
+	tenantRetriever := &TenantRetriever{
+		biz: bizBiz,
+	}
+	ratelimitOptions := config.RateLimitOptions
+	limiter, cleanup5, err := ratelimit.NewLimiter(ratelimitOptions, redisOptions)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
 	}
 	serverConfig := &ServerConfig{
-		cfg:       config,
-		biz:       bizBiz,
-		val:       validator,
-		retriever: userRetriever,
-		authz:     authz,
+		cfg:             config,
+		biz:             bizBiz,
+		val:             validator,
+		retriever:       userRetriever,
+		auditor:         auditRecorder,
+		authz:           authz,
+		mapper:          mapper,
+		tenants:         resolver,
+		tenantRetriever: tenantRetriever,
+		limiter:         limiter,
 	}
 	serverServer, err := NewWebServer(string2, serverConfig)
 	if err != nil {
-		return nil, err
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
 	}
-	return serverServer, nil
+	return serverServer, func() {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
 }
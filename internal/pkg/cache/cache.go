@@ -0,0 +1,54 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package cache 提供键值缓存，支持进程内 LRU 缓存和 Redis 缓存两种后端.
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/wire"
+	genericoptions "github.com/onexstack/onexstack/pkg/options"
+)
+
+// ProviderSet 是 cache 包的 Wire Provider 集合.
+var ProviderSet = wire.NewSet(NewCache)
+
+// 缓存的存储后端.
+const (
+	// BackendMemory 表示缓存保存在进程内存中，多副本部署时每个副本独立缓存.
+	BackendMemory = "memory"
+	// BackendRedis 表示缓存保存在 Redis 中，多副本共享缓存.
+	BackendRedis = "redis"
+)
+
+// Cache 定义键值缓存.
+type Cache interface {
+	// Get 返回 key 对应的值，第二个返回值表示 key 是否存在
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set 设置 key 的值，ttl 是过期时间
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete 删除 keys，key 不存在时不返回错误
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// NewCache 根据配置创建 Cache 实例，未开启缓存时返回 nil，使用 Redis 后端时会创建 Redis 客户端.
+// 返回的清理函数用于关闭 Redis 客户端.
+func NewCache(opts *Options, redisOptions *genericoptions.RedisOptions) (Cache, func(), error) {
+	switch {
+	case !opts.Enabled:
+		return nil, func() {}, nil
+	case opts.Backend == BackendRedis:
+		client, err := redisOptions.NewClient()
+		if err != nil {
+			return nil, nil, err
+		}
+		return NewRedisCache(client, opts.KeyPrefix), func() { _ = client.Close() }, nil
+	default:
+		return NewMemoryCache(opts.Size), func() {}, nil
+	}
+}
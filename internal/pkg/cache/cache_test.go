@@ -0,0 +1,80 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	mr := miniredis.RunT(t)
+	caches := map[string]Cache{
+		BackendMemory: NewMemoryCache(10),
+		BackendRedis:  NewRedisCache(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "test:"),
+	}
+
+	for name, c := range caches {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, ok, err := c.Get(ctx, "a")
+			require.NoError(t, err)
+			assert.False(t, ok)
+
+			require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+			require.NoError(t, c.Set(ctx, "b", []byte{}, time.Minute))
+			value, ok, err := c.Get(ctx, "a")
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("1"), value)
+
+			// 空值也是合法的缓存值
+			value, ok, err = c.Get(ctx, "b")
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Empty(t, value)
+
+			require.NoError(t, c.Delete(ctx, "a", "b", "missing"))
+			_, ok, err = c.Get(ctx, "a")
+			require.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+
+	assert.True(t, mr.Exists("test:b") == false)
+}
+
+func TestMemoryCacheEviction(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(2).(*memoryCache)
+	now := time.Unix(1700000000, 0)
+	c.now = func() time.Time { return now }
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, c.Set(ctx, "b", []byte("2"), time.Minute))
+	// 访问 a 之后，b 成为最久未使用的条目
+	_, _, _ = c.Get(ctx, "a")
+	require.NoError(t, c.Set(ctx, "c", []byte("3"), time.Minute))
+
+	_, ok, _ := c.Get(ctx, "b")
+	assert.False(t, ok)
+	_, ok, _ = c.Get(ctx, "a")
+	assert.True(t, ok)
+
+	// 过期的条目不再返回
+	now = now.Add(time.Minute)
+	_, ok, _ = c.Get(ctx, "a")
+	assert.False(t, ok)
+	assert.Equal(t, 1, c.ll.Len())
+}
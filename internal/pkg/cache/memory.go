@@ -0,0 +1,99 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// entry 是一个缓存条目.
+type entry struct {
+	key      string
+	value    []byte
+	expireAt time.Time
+}
+
+// memoryCache 是基于 LRU 淘汰策略和过期时间的进程内缓存.
+type memoryCache struct {
+	size int
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+
+	// now 用于在测试中替换当前时间
+	now func() time.Time
+}
+
+// 确保 memoryCache 实现了 Cache 接口.
+var _ Cache = (*memoryCache)(nil)
+
+// NewMemoryCache 创建一个最多保存 size 个条目的进程内缓存.
+func NewMemoryCache(size int) Cache {
+	return &memoryCache{size: max(size, 1), ll: list.New(), items: make(map[string]*list.Element), now: time.Now}
+}
+
+// Get 返回 key 对应的值，已经过期的条目会被删除.
+func (m *memoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	e := el.Value.(*entry)
+	if !m.now().Before(e.expireAt) {
+		m.remove(el)
+		return nil, false, nil
+	}
+
+	m.ll.MoveToFront(el)
+	return e.value, true, nil
+}
+
+// Set 设置 key 的值，条目数超过上限时淘汰最久未使用的条目.
+func (m *memoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expireAt := m.now().Add(ttl)
+	if el, ok := m.items[key]; ok {
+		e := el.Value.(*entry)
+		e.value, e.expireAt = value, expireAt
+		m.ll.MoveToFront(el)
+		return nil
+	}
+
+	m.items[key] = m.ll.PushFront(&entry{key: key, value: value, expireAt: expireAt})
+	for m.ll.Len() > m.size {
+		m.remove(m.ll.Back())
+	}
+	return nil
+}
+
+// Delete 删除 keys.
+func (m *memoryCache) Delete(_ context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := m.items[key]; ok {
+			m.remove(el)
+		}
+	}
+	return nil
+}
+
+// remove 删除一个条目，调用方需要持有锁.
+func (m *memoryCache) remove(el *list.Element) {
+	m.ll.Remove(el)
+	delete(m.items, el.Value.(*entry).key)
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Options 包含缓存相关的配置选项.
+type Options struct {
+	// Enabled 表示是否开启用户和博客查询缓存.
+	// 使用 memory 后端并部署多个副本时，其他副本上的修改最多在 TTL 之后才能被读到
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// Backend 是缓存的存储后端，可选值为 memory 和 redis，redis 后端使用 redis 配置项连接 Redis
+	Backend string `json:"backend" mapstructure:"backend"`
+	// KeyPrefix 是缓存在 Redis 中的键前缀
+	KeyPrefix string `json:"key-prefix" mapstructure:"key-prefix"`
+	// Size 是 memory 后端最多缓存的条目数，超过时淘汰最久未使用的条目
+	Size int `json:"size" mapstructure:"size"`
+	// TTL 是缓存条目的过期时间
+	TTL time.Duration `json:"ttl" mapstructure:"ttl"`
+	// NegativeTTL 是记录不存在的查询结果的过期时间，0 表示不缓存不存在的记录
+	NegativeTTL time.Duration `json:"negative-ttl" mapstructure:"negative-ttl"`
+}
+
+// NewOptions 创建带有默认值的 Options 实例.
+func NewOptions() *Options {
+	return &Options{
+		Enabled:     false,
+		Backend:     BackendMemory,
+		KeyPrefix:   "miniblog:cache:",
+		Size:        10000,
+		TTL:         5 * time.Minute,
+		NegativeTTL: 30 * time.Second,
+	}
+}
+
+// AddFlags 将缓存相关的选项绑定到命令行标志.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Enabled, "cache.enabled", o.Enabled, "Enable caching of user and post lookups.")
+	fs.StringVar(&o.Backend, "cache.backend", o.Backend, "Storage of cached entries, one of memory or redis. The redis backend uses the redis.* options.")
+	fs.StringVar(&o.KeyPrefix, "cache.key-prefix", o.KeyPrefix, "Prefix of cache keys in Redis.")
+	fs.IntVar(&o.Size, "cache.size", o.Size, "Maximum number of entries kept by the memory backend.")
+	fs.DurationVar(&o.TTL, "cache.ttl", o.TTL, "Time to live of cached entries.")
+	fs.DurationVar(&o.NegativeTTL, "cache.negative-ttl", o.NegativeTTL, "Time to live of cached not-found results, 0 disables negative caching.")
+}
+
+// Validate 校验缓存配置选项是否合法.
+func (o *Options) Validate() []error {
+	errs := []error{}
+
+	if o.Backend != BackendMemory && o.Backend != BackendRedis {
+		errs = append(errs, fmt.Errorf("cache.backend must be %s or %s", BackendMemory, BackendRedis))
+	}
+	if o.Backend == BackendMemory && o.Size <= 0 {
+		errs = append(errs, errors.New("cache.size must be greater than 0"))
+	}
+	if o.TTL <= 0 {
+		errs = append(errs, errors.New("cache.ttl must be greater than 0"))
+	}
+	if o.NegativeTTL < 0 {
+		errs = append(errs, errors.New("cache.negative-ttl cannot be negative"))
+	}
+
+	return errs
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package captcha 为注册和登录等公开接口提供可插拔的人机验证.
+//
+// 客户端先调用签发接口获取挑战，完成挑战后将挑战 ID 和答案随业务请求一起提交.
+// 每个操作可以配置为总是需要验证，或者只在同一客户端 IP 短时间内请求过多时才需要验证.
+// 内置的 pow 实现是工作量证明，不依赖任何外部服务；其他实现（例如第三方验证码服务）可以通过 Register 注册.
+//
+// 请求计数和已使用的挑战保存在进程内存中，多副本部署时每个副本独立计数.
+package captcha
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/google/wire"
+)
+
+// ProviderSet 是 captcha 包的 Wire Provider 集合.
+var ProviderSet = wire.NewSet(NewManager)
+
+// 需要人机验证的操作.
+const (
+	// ActionLogin 表示用户登录.
+	ActionLogin = "login"
+	// ActionRegister 表示用户注册.
+	ActionRegister = "register"
+)
+
+// 人机验证的触发方式.
+const (
+	// ModeOff 表示不需要人机验证.
+	ModeOff = "off"
+	// ModeAlways 表示每次请求都需要人机验证.
+	ModeAlways = "always"
+	// ModeSuspicious 表示同一客户端 IP 请求次数超过阈值后才需要人机验证.
+	ModeSuspicious = "suspicious"
+)
+
+// sweepInterval 是清理过期请求计数的最小时间间隔.
+const sweepInterval = time.Minute
+
+// Challenge 是签发给客户端的挑战.
+type Challenge struct {
+	// ID 是挑战的唯一标识，客户端提交答案时需要一并提交
+	ID string
+	// Provider 是签发挑战的实现名称，客户端根据它选择求解方式
+	Provider string
+	// Difficulty 是工作量证明要求的哈希前导零比特数，其他实现为 0
+	Difficulty int
+	// ExpireAt 是挑战的过期时间
+	ExpireAt time.Time
+}
+
+// Verifier 定义人机验证的实现.
+type Verifier interface {
+	// Issue 为操作 action 签发一个挑战
+	Issue(ctx context.Context, action string) (*Challenge, error)
+	// Verify 校验挑战 id 的答案 solution，每个挑战只能通过一次校验
+	Verify(ctx context.Context, action string, id string, solution string) error
+}
+
+// Factory 根据配置创建一个 Verifier.
+type Factory func(opts *Options) (Verifier, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = map[string]Factory{}
+)
+
+// Register 注册一个人机验证实现，通过配置项 captcha.provider 选择使用哪个实现.
+func Register(name string, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	factories[name] = factory
+}
+
+// registered 判断实现 name 是否已经注册.
+func registered(name string) bool {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	_, ok := factories[name]
+	return ok
+}
+
+// counter 记录一个客户端 IP 在时间窗口内的请求次数.
+type counter struct {
+	count int
+	start time.Time
+}
+
+// Manager 根据配置决定请求是否需要人机验证，并校验客户端提交的答案.
+type Manager struct {
+	opts     *Options
+	verifier Verifier
+
+	mu        sync.Mutex
+	counters  map[string]*counter
+	lastSweep time.Time
+
+	// now 用于在测试中替换当前时间
+	now func() time.Time
+}
+
+// NewManager 创建一个 Manager 实例.
+func NewManager(opts *Options) (*Manager, error) {
+	factoriesMu.RLock()
+	factory, ok := factories[opts.Provider]
+	factoriesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown captcha provider %q", opts.Provider)
+	}
+
+	verifier, err := factory(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		opts:     opts,
+		verifier: verifier,
+		counters: make(map[string]*counter),
+		now:      time.Now,
+	}, nil
+}
+
+// Issue 为客户端 ip 的操作 action 签发一个挑战，并返回该客户端当前是否需要人机验证.
+func (m *Manager) Issue(ctx context.Context, action string, ip string) (*Challenge, bool, error) {
+	challenge, err := m.verifier.Issue(ctx, action)
+	if err != nil {
+		return nil, false, err
+	}
+	return challenge, m.Required(action, ip), nil
+}
+
+// Required 判断客户端 ip 的操作 action 当前是否需要人机验证.
+func (m *Manager) Required(action string, ip string) bool {
+	switch m.opts.mode(action) {
+	case ModeAlways:
+		return true
+	case ModeSuspicious:
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		c := m.get(action+":"+ip, m.now())
+		return c != nil && c.count >= m.opts.Threshold
+	default:
+		return false
+	}
+}
+
+// Check 记录客户端 ip 的一次 action 请求，并在需要人机验证时校验挑战的答案.
+// 客户端主动提交的答案即使当前不需要验证也会被校验，避免答案错误的请求被静默放行.
+func (m *Manager) Check(ctx context.Context, action string, ip string, id string, solution string) error {
+	if m.opts.mode(action) == ModeOff {
+		return nil
+	}
+
+	required := m.Required(action, ip)
+	m.record(action+":"+ip, m.now())
+
+	if id == "" {
+		if required {
+			return errno.ErrCaptchaRequired
+		}
+		return nil
+	}
+	return m.verifier.Verify(ctx, action, id, solution)
+}
+
+// record 将 key 的请求次数加 1.
+func (m *Manager) record(key string, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sweep(now)
+	c := m.get(key, now)
+	if c == nil {
+		c = &counter{start: now}
+		m.counters[key] = c
+	}
+	c.count++
+}
+
+// get 返回 key 在当前时间窗口内的请求计数，计数已经过期时返回 nil.
+func (m *Manager) get(key string, now time.Time) *counter {
+	c, ok := m.counters[key]
+	if !ok || now.Sub(c.start) >= m.opts.Window {
+		return nil
+	}
+	return c
+}
+
+// sweep 删除已经过期的请求计数.
+func (m *Manager) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	m.lastSweep = now
+
+	for key := range m.counters {
+		if m.get(key, now) == nil {
+			delete(m.counters, key)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package captcha
+
+import (
+	"context"
+	"crypto/sha256"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagerCheck(t *testing.T) {
+	ctx := context.Background()
+	opts := NewOptions()
+	opts.Difficulty = 8
+	opts.LoginMode = ModeSuspicious
+	opts.RegisterMode = ModeAlways
+	m, err := NewManager(opts)
+	require.NoError(t, err)
+
+	// 注册总是需要人机验证
+	assert.ErrorIs(t, m.Check(ctx, ActionRegister, "10.0.0.1", "", ""), errno.ErrCaptchaRequired)
+	challenge, required, err := m.Issue(ctx, ActionRegister, "10.0.0.1")
+	require.NoError(t, err)
+	assert.True(t, required)
+	solution := Solve(challenge.ID, challenge.Difficulty)
+	assert.NoError(t, m.Check(ctx, ActionRegister, "10.0.0.1", challenge.ID, solution))
+	// 同一个挑战不能重复使用
+	assert.ErrorIs(t, m.Check(ctx, ActionRegister, "10.0.0.1", challenge.ID, solution), errno.ErrCaptchaInvalid)
+
+	// 挑战只能用于签发时指定的操作
+	challenge, _, err = m.Issue(ctx, ActionRegister, "10.0.0.1")
+	require.NoError(t, err)
+	assert.ErrorIs(t, m.Check(ctx, ActionLogin, "10.0.0.1", challenge.ID, Solve(challenge.ID, challenge.Difficulty)), errno.ErrCaptchaInvalid)
+
+	// 登录请求超过阈值后才需要人机验证
+	for range opts.Threshold {
+		assert.NoError(t, m.Check(ctx, ActionLogin, "10.0.0.2", "", ""))
+	}
+	assert.ErrorIs(t, m.Check(ctx, ActionLogin, "10.0.0.2", "", ""), errno.ErrCaptchaRequired)
+	assert.NoError(t, m.Check(ctx, ActionLogin, "10.0.0.3", "", ""))
+
+	// 时间窗口过去之后计数清零
+	now := time.Now().Add(opts.Window)
+	m.now = func() time.Time { return now }
+	assert.False(t, m.Required(ActionLogin, "10.0.0.2"))
+}
+
+func TestPoWVerifier(t *testing.T) {
+	ctx := context.Background()
+	opts := NewOptions()
+	opts.Difficulty = 8
+	opts.Secret = "secret"
+	verifier, err := newPoWVerifier(opts)
+	require.NoError(t, err)
+	p := verifier.(*powVerifier)
+
+	challenge, err := p.Issue(ctx, ActionLogin)
+	require.NoError(t, err)
+	solution := Solve(challenge.ID, challenge.Difficulty)
+
+	// 答案错误、签名被篡改或者挑战已经过期时校验失败
+	wrong := 0
+	for leadingZeroBits(sha256.Sum256([]byte(challenge.ID+strconv.Itoa(wrong)))) >= challenge.Difficulty {
+		wrong++
+	}
+	assert.ErrorIs(t, p.Verify(ctx, ActionLogin, challenge.ID, strconv.Itoa(wrong)), errno.ErrCaptchaInvalid)
+	assert.ErrorIs(t, p.Verify(ctx, ActionLogin, challenge.ID+"x", solution), errno.ErrCaptchaInvalid)
+
+	other, err := newPoWVerifier(&Options{Secret: "other", Difficulty: 8, TTL: time.Minute})
+	require.NoError(t, err)
+	assert.ErrorIs(t, other.Verify(ctx, ActionLogin, challenge.ID, solution), errno.ErrCaptchaInvalid)
+
+	p.now = func() time.Time { return challenge.ExpireAt }
+	assert.ErrorIs(t, p.Verify(ctx, ActionLogin, challenge.ID, solution), errno.ErrCaptchaInvalid)
+
+	p.now = time.Now
+	assert.NoError(t, p.Verify(ctx, ActionLogin, challenge.ID, solution))
+}
+
+func TestOptionsValidate(t *testing.T) {
+	opts := NewOptions()
+	assert.Empty(t, opts.Validate())
+
+	opts.Provider = "recaptcha"
+	opts.LoginMode = "sometimes"
+	opts.Difficulty = 64
+	assert.Len(t, opts.Validate(), 3)
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package captcha
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Options 包含人机验证相关的配置选项.
+type Options struct {
+	// Provider 是人机验证的实现，默认为内置的工作量证明 pow
+	Provider string `json:"provider" mapstructure:"provider"`
+	// LoginMode 是登录接口的触发方式，可选值为 off、always 和 suspicious
+	LoginMode string `json:"login-mode" mapstructure:"login-mode"`
+	// RegisterMode 是注册接口的触发方式，可选值为 off、always 和 suspicious
+	RegisterMode string `json:"register-mode" mapstructure:"register-mode"`
+	// Threshold 是 suspicious 模式下，同一客户端 IP 在 Window 内不需要人机验证的最大请求次数
+	Threshold int `json:"threshold" mapstructure:"threshold"`
+	// Window 是 suspicious 模式下统计请求次数的时间窗口
+	Window time.Duration `json:"window" mapstructure:"window"`
+	// Difficulty 是工作量证明要求的哈希前导零比特数，每增加 1 客户端的平均计算量翻倍
+	Difficulty int `json:"difficulty" mapstructure:"difficulty"`
+	// TTL 是挑战的有效期
+	TTL time.Duration `json:"ttl" mapstructure:"ttl"`
+	// Secret 是签发挑战使用的密钥，为空时在启动时随机生成，多副本部署时需要配置为相同的值
+	Secret string `json:"secret" mapstructure:"secret"`
+}
+
+// NewOptions 创建带有默认值的 Options 实例.
+func NewOptions() *Options {
+	return &Options{
+		Provider:     ProviderPoW,
+		LoginMode:    ModeSuspicious,
+		RegisterMode: ModeAlways,
+		Threshold:    3,
+		Window:       15 * time.Minute,
+		Difficulty:   18,
+		TTL:          5 * time.Minute,
+	}
+}
+
+// AddFlags 将人机验证相关的选项绑定到命令行标志.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Provider, "captcha.provider", o.Provider, "Challenge provider used by login and registration.")
+	fs.StringVar(&o.LoginMode, "captcha.login-mode", o.LoginMode, "When login requires a challenge, one of off, always or suspicious.")
+	fs.StringVar(&o.RegisterMode, "captcha.register-mode", o.RegisterMode, "When registration requires a challenge, one of off, always or suspicious.")
+	fs.IntVar(&o.Threshold, "captcha.threshold", o.Threshold, "Requests per client IP within the window allowed without a challenge in suspicious mode.")
+	fs.DurationVar(&o.Window, "captcha.window", o.Window, "Window used to count requests in suspicious mode.")
+	fs.IntVar(&o.Difficulty, "captcha.difficulty", o.Difficulty, "Number of leading zero bits required by the proof-of-work challenge.")
+	fs.DurationVar(&o.TTL, "captcha.ttl", o.TTL, "How long an issued challenge stays valid.")
+	fs.StringVar(&o.Secret, "captcha.secret", o.Secret, "Key used to sign challenges. A random key is generated if empty.")
+}
+
+// Validate 校验人机验证配置选项是否合法.
+func (o *Options) Validate() []error {
+	errs := []error{}
+
+	if !registered(o.Provider) {
+		errs = append(errs, fmt.Errorf("captcha.provider: unknown provider %q", o.Provider))
+	}
+	for name, mode := range map[string]string{"captcha.login-mode": o.LoginMode, "captcha.register-mode": o.RegisterMode} {
+		switch mode {
+		case ModeOff, ModeAlways, ModeSuspicious:
+		default:
+			errs = append(errs, fmt.Errorf("%s must be one of %s, %s or %s", name, ModeOff, ModeAlways, ModeSuspicious))
+		}
+	}
+	if o.Threshold < 0 || o.Window <= 0 {
+		errs = append(errs, errors.New("captcha.threshold must not be negative and captcha.window must be positive"))
+	}
+	if o.Difficulty < 1 || o.Difficulty > 32 {
+		errs = append(errs, errors.New("captcha.difficulty must be between 1 and 32"))
+	}
+	if o.TTL <= 0 {
+		errs = append(errs, errors.New("captcha.ttl must be positive"))
+	}
+
+	return errs
+}
+
+// mode 返回操作对应的触发方式.
+func (o *Options) mode(action string) string {
+	switch action {
+	case ActionLogin:
+		return o.LoginMode
+	case ActionRegister:
+		return o.RegisterMode
+	default:
+		return ModeOff
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package captcha
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+)
+
+// ProviderPoW 是内置的工作量证明实现的名称.
+//
+// 挑战 ID 是经过签名的 "action.过期时间.难度.随机数"，服务端不需要保存已签发的挑战.
+// 客户端需要找到一个答案 solution，使得 SHA-256(ID + solution) 的前 Difficulty 个比特都为 0.
+const ProviderPoW = "pow"
+
+func init() {
+	Register(ProviderPoW, newPoWVerifier)
+}
+
+// powVerifier 是基于工作量证明的 Verifier 实现.
+type powVerifier struct {
+	secret     []byte
+	difficulty int
+	ttl        time.Duration
+
+	// used 记录已经通过校验的挑战及其过期时间，防止同一个答案被重复使用
+	mu        sync.Mutex
+	used      map[string]time.Time
+	lastSweep time.Time
+
+	// now 用于在测试中替换当前时间
+	now func() time.Time
+}
+
+// newPoWVerifier 创建一个工作量证明 Verifier.
+func newPoWVerifier(opts *Options) (Verifier, error) {
+	secret := []byte(opts.Secret)
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+	}
+
+	return &powVerifier{
+		secret:     secret,
+		difficulty: opts.Difficulty,
+		ttl:        opts.TTL,
+		used:       make(map[string]time.Time),
+		now:        time.Now,
+	}, nil
+}
+
+// Issue 签发一个工作量证明挑战.
+func (p *powVerifier) Issue(_ context.Context, action string) (*Challenge, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	expireAt := p.now().Add(p.ttl)
+	payload := strings.Join([]string{action, strconv.FormatInt(expireAt.Unix(), 10), strconv.Itoa(p.difficulty), hex.EncodeToString(nonce)}, ".")
+	id := base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + base64.RawURLEncoding.EncodeToString(p.sign(payload))
+
+	return &Challenge{ID: id, Provider: ProviderPoW, Difficulty: p.difficulty, ExpireAt: expireAt}, nil
+}
+
+// Verify 校验工作量证明挑战的答案.
+func (p *powVerifier) Verify(_ context.Context, action string, id string, solution string) error {
+	encoded, sig, ok := strings.Cut(id, ".")
+	if !ok {
+		return errno.ErrCaptchaInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return errno.ErrCaptchaInvalid
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, p.sign(string(payload))) {
+		return errno.ErrCaptchaInvalid
+	}
+
+	// 挑战只能用于签发时指定的操作
+	fields := strings.Split(string(payload), ".")
+	if len(fields) != 4 || fields[0] != action {
+		return errno.ErrCaptchaInvalid
+	}
+	expires, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return errno.ErrCaptchaInvalid
+	}
+	difficulty, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return errno.ErrCaptchaInvalid
+	}
+
+	now := p.now()
+	expireAt := time.Unix(expires, 0)
+	if !now.Before(expireAt) {
+		return captchaInvalid("Challenge has expired, please request a new one")
+	}
+	if leadingZeroBits(sha256.Sum256([]byte(id+solution))) < difficulty {
+		return errno.ErrCaptchaInvalid
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sweep(now)
+	if _, ok := p.used[id]; ok {
+		return captchaInvalid("Challenge has already been used, please request a new one")
+	}
+	p.used[id] = expireAt
+	return nil
+}
+
+// sign 计算 payload 的 HMAC-SHA256 签名.
+func (p *powVerifier) sign(payload string) []byte {
+	h := hmac.New(sha256.New, p.secret)
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
+
+// sweep 删除已经过期的挑战记录，过期的挑战无法通过签名校验之后的过期检查.
+func (p *powVerifier) sweep(now time.Time) {
+	if now.Sub(p.lastSweep) < sweepInterval {
+		return
+	}
+	p.lastSweep = now
+
+	for id, expireAt := range p.used {
+		if !now.Before(expireAt) {
+			delete(p.used, id)
+		}
+	}
+}
+
+// captchaInvalid 返回带有具体原因的 ErrCaptchaInvalid.
+func captchaInvalid(message string) error {
+	return errno.WithMessage(errno.ErrCaptchaInvalid, "%s", message)
+}
+
+// leadingZeroBits 返回哈希值的前导零比特数.
+func leadingZeroBits(sum [sha256.Size]byte) int {
+	n := 0
+	for _, b := range sum {
+		if b != 0 {
+			return n + bits.LeadingZeros8(b)
+		}
+		n += 8
+	}
+	return n
+}
+
+// Solve 求解工作量证明挑战，返回满足难度要求的答案，主要用于测试和命令行客户端.
+func Solve(id string, difficulty int) string {
+	for i := 0; ; i++ {
+		solution := strconv.Itoa(i)
+		if leadingZeroBits(sha256.Sum256([]byte(id+solution))) >= difficulty {
+			return solution
+		}
+	}
+}
@@ -18,6 +18,12 @@ type (
 	accessTokenKey struct{}
 	// requestIDKey 定义请求 ID 的上下文键.
 	requestIDKey struct{}
+	// clientIPKey 定义客户端 IP 的上下文键.
+	clientIPKey struct{}
+	// actorIDKey 定义模拟登录时实际操作者用户 ID 的上下文键.
+	actorIDKey struct{}
+	// tenantIDKey 定义租户 ID 的上下文键.
+	tenantIDKey struct{}
 )
 
 // WithUserID 将用户 ID 存放到上下文中.
@@ -63,3 +69,41 @@ func RequestID(ctx context.Context) string {
 	requestID, _ := ctx.Value(requestIDKey{}).(string)
 	return requestID
 }
+
+// WithClientIP 将客户端 IP 存放到上下文中.
+func WithClientIP(ctx context.Context, clientIP string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, clientIP)
+}
+
+// ClientIP 从上下文中提取客户端 IP.
+func ClientIP(ctx context.Context) string {
+	clientIP, _ := ctx.Value(clientIPKey{}).(string)
+	return clientIP
+}
+
+// WithActorID 将模拟登录时实际操作者的用户 ID 存放到上下文中.
+func WithActorID(ctx context.Context, actorID string) context.Context {
+	return context.WithValue(ctx, actorIDKey{}, actorID)
+}
+
+// ActorID 从上下文中提取模拟登录时实际操作者的用户 ID，非模拟登录请求返回空字符串.
+func ActorID(ctx context.Context) string {
+	actorID, _ := ctx.Value(actorIDKey{}).(string)
+	return actorID
+}
+
+// Impersonating 判断当前请求是否为模拟登录请求.
+func Impersonating(ctx context.Context) bool {
+	return ActorID(ctx) != ""
+}
+
+// WithTenantID 将请求所在的租户 ID 存放到上下文中.
+func WithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDKey{}, tenantID)
+}
+
+// TenantID 从上下文中提取租户 ID，没有经过租户解析的请求返回空字符串.
+func TenantID(ctx context.Context) string {
+	tenantID, _ := ctx.Value(tenantIDKey{}).(string)
+	return tenantID
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package database 提供 miniblog 支持的数据库驱动及其配置选项.
+package database
+
+import "slices"
+
+// 定义支持的数据库驱动.
+const (
+	// DriverMySQL 使用 MySQL 数据库，也是默认的数据库驱动.
+	DriverMySQL = "mysql"
+	// DriverPostgreSQL 使用 PostgreSQL 数据库.
+	DriverPostgreSQL = "postgresql"
+	// DriverSQLite 使用 SQLite 数据库（纯 Go 实现，不依赖 CGO），适合本地开发和测试.
+	DriverSQLite = "sqlite"
+)
+
+// Drivers 返回所有支持的数据库驱动名称.
+func Drivers() []string {
+	return []string{DriverMySQL, DriverPostgreSQL, DriverSQLite}
+}
+
+// IsValidDriver 判断给定的数据库驱动是否受支持.
+func IsValidDriver(driver string) bool {
+	return slices.Contains(Drivers(), driver)
+}
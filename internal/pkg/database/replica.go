@@ -0,0 +1,61 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// ReplicaOptions 包含只读副本（读写分离）相关的配置选项.
+type ReplicaOptions struct {
+	// Addrs 是只读副本的地址列表，副本使用与主库相同的用户名、密码和数据库名.
+	// 使用 SQLite 时为副本的数据库文件路径. 为空时所有查询都发送到主库
+	Addrs []string `json:"addrs" mapstructure:"addrs"`
+	// HealthCheckInterval 是检查只读副本健康状态的间隔，不健康的副本会被暂时移出读负载均衡
+	HealthCheckInterval time.Duration `json:"health-check-interval" mapstructure:"health-check-interval"`
+	// ReadYourWritesWindow 是用户写入数据之后，其读请求固定发送到主库的时间窗口，用于避免读到复制延迟前的旧数据.
+	// 为 0 时不开启
+	ReadYourWritesWindow time.Duration `json:"read-your-writes-window" mapstructure:"read-your-writes-window"`
+}
+
+// NewReplicaOptions 创建带有默认值的 ReplicaOptions 实例.
+func NewReplicaOptions() *ReplicaOptions {
+	return &ReplicaOptions{
+		HealthCheckInterval:  5 * time.Second,
+		ReadYourWritesWindow: 5 * time.Second,
+	}
+}
+
+// AddFlags 将只读副本相关的选项绑定到命令行标志.
+func (o *ReplicaOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&o.Addrs, "replica.addrs", o.Addrs, "Addresses of read-only database replicas (file paths for SQLite). Reads are sent to the primary when empty.")
+	fs.DurationVar(&o.HealthCheckInterval, "replica.health-check-interval", o.HealthCheckInterval, "Interval between health checks of read-only replicas.")
+	fs.DurationVar(&o.ReadYourWritesWindow, "replica.read-your-writes-window", o.ReadYourWritesWindow, "Duration for which a user's reads are pinned to the primary after the user writes, 0 to disable.")
+}
+
+// Validate 校验只读副本配置选项是否合法.
+func (o *ReplicaOptions) Validate() []error {
+	errs := []error{}
+
+	for _, addr := range o.Addrs {
+		if addr == "" {
+			errs = append(errs, errors.New("replica.addrs cannot contain empty addresses"))
+			break
+		}
+	}
+	if len(o.Addrs) > 0 && o.HealthCheckInterval <= 0 {
+		errs = append(errs, errors.New("replica.health-check-interval must be greater than 0"))
+	}
+	if o.ReadYourWritesWindow < 0 {
+		errs = append(errs, errors.New("replica.read-your-writes-window cannot be negative"))
+	}
+
+	return errs
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package database
+
+import (
+	"errors"
+
+	"github.com/glebarez/sqlite"
+	"github.com/onexstack/onexstack/pkg/log"
+	"github.com/spf13/pflag"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// MemoryPath 表示使用内存数据库，进程退出后数据会丢失.
+const MemoryPath = ":memory:"
+
+// SQLiteOptions 包含 SQLite 数据库相关的配置选项.
+type SQLiteOptions struct {
+	// Path 是数据库文件路径，为 :memory: 时使用内存数据库
+	Path string `json:"path" mapstructure:"path"`
+	// LogLevel 是 GORM 的日志级别
+	LogLevel int `json:"log-level" mapstructure:"log-level"`
+}
+
+// NewSQLiteOptions 创建带有默认值的 SQLiteOptions 实例.
+func NewSQLiteOptions() *SQLiteOptions {
+	return &SQLiteOptions{
+		Path:     "miniblog.db",
+		LogLevel: 1, // Silent
+	}
+}
+
+// AddFlags 将 SQLite 相关的选项绑定到命令行标志.
+func (o *SQLiteOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Path, "sqlite.path", o.Path, "Path of the SQLite database file, use :memory: for an in-memory database.")
+	fs.IntVar(&o.LogLevel, "sqlite.log-mode", o.LogLevel, "Specify gorm log level.")
+}
+
+// Validate 校验 SQLite 配置选项是否合法.
+func (o *SQLiteOptions) Validate() []error {
+	errs := []error{}
+
+	if o.Path == "" {
+		errs = append(errs, errors.New("sqlite.path cannot be empty"))
+	}
+
+	return errs
+}
+
+// NewDB 使用给定的配置创建 SQLite 数据库实例.
+func (o *SQLiteOptions) NewDB() (*gorm.DB, error) {
+	// 文件数据库开启 WAL 并设置忙等待，减少并发写入时的 database is locked 错误
+	dsn := "file:" + o.Path + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	if o.Path == MemoryPath {
+		dsn = MemoryPath
+	}
+
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: log.Default().LogMode(gormlogger.LogLevel(o.LogLevel)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if o.Path == MemoryPath {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		// 内存数据库的数据只存在于单个连接中，这里限制只使用一个连接，并且该连接不会被回收
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetMaxIdleConns(1)
+		sqlDB.SetConnMaxLifetime(0)
+		sqlDB.SetConnMaxIdleTime(0)
+	}
+
+	return db, nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package devauth 实现仅用于本地开发的认证模式.
+//
+// 开发认证模式下，服务器不再校验令牌，而是信任请求头中的用户 ID，任何能访问服务器的人都可以冒充任意用户.
+// 所以开启该模式时，服务器只允许监听本地回环地址，否则拒绝启动.
+// 授权仍然正常生效，请求身份对应的用户需要具有相应的权限.
+package devauth
+
+import (
+	"fmt"
+	"net"
+)
+
+// CheckLoopback 检查监听地址是否只能从本机访问.
+// 主机部分为空（例如 :5555）或者为 0.0.0.0 时会监听所有网卡，不是回环地址.
+func CheckLoopback(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid listen address %q: %w", addr, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("dev-auth requires a loopback listen address, got %q", addr)
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package devauth
+
+import (
+	"testing"
+)
+
+func TestCheckLoopback(t *testing.T) {
+	tests := []struct {
+		addr string
+		ok   bool
+	}{
+		{"127.0.0.1:5555", true},
+		{"127.0.0.2:5555", true},
+		{"localhost:5555", true},
+		{"[::1]:6666", true},
+		{":5555", false},
+		{"0.0.0.0:5555", false},
+		{"[::]:5555", false},
+		{"192.168.1.10:5555", false},
+		{"example.com:5555", false},
+		{"127.0.0.1", false},
+	}
+	for _, tt := range tests {
+		if err := CheckLoopback(tt.addr); (err == nil) != tt.ok {
+			t.Errorf("CheckLoopback(%q) = %v, want ok %v", tt.addr, err, tt.ok)
+		}
+	}
+}
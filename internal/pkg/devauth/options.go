@@ -0,0 +1,48 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package devauth
+
+import (
+	"errors"
+	"net/textproto"
+
+	"github.com/spf13/pflag"
+)
+
+// Options 包含开发认证模式相关的配置选项.
+type Options struct {
+	// Enabled 表示是否开启开发认证模式，开启后不再校验令牌，直接使用请求头中的用户 ID 作为请求身份
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// Header 是携带用户 ID 的请求头名称
+	Header string `json:"header" mapstructure:"header"`
+}
+
+// NewOptions 创建带有默认值的 Options 实例.
+func NewOptions() *Options {
+	return &Options{
+		Enabled: false,
+		Header:  "X-Dev-User-ID",
+	}
+}
+
+// AddFlags 将开发认证模式相关的选项绑定到命令行标志.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Enabled, "dev-auth.enabled", o.Enabled, "Trust the user ID in the dev-auth header instead of verifying tokens. "+
+		"Only for local development, the server refuses to start unless all listen addresses are loopback.")
+	fs.StringVar(&o.Header, "dev-auth.header", o.Header, "Request header carrying the user ID in dev-auth mode.")
+}
+
+// Validate 校验开发认证模式配置选项是否合法.
+func (o *Options) Validate() []error {
+	errs := []error{}
+
+	if o.Enabled && (o.Header == "" || textproto.TrimString(o.Header) != o.Header) {
+		errs = append(errs, errors.New("dev-auth.header must be a valid header name when dev-auth is enabled"))
+	}
+
+	return errs
+}
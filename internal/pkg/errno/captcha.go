@@ -0,0 +1,29 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package errno
+
+import (
+	"net/http"
+
+	"github.com/onexstack/onexstack/pkg/errorsx"
+)
+
+var (
+	// ErrCaptchaRequired 表示请求需要先完成人机验证.
+	ErrCaptchaRequired = &errorsx.ErrorX{
+		Code:    http.StatusBadRequest,
+		Reason:  "InvalidArgument.CaptchaRequired",
+		Message: "Challenge is required, please request a challenge and submit its solution",
+	}
+
+	// ErrCaptchaInvalid 表示人机验证的挑战无效、已过期、已被使用或者答案错误.
+	ErrCaptchaInvalid = &errorsx.ErrorX{
+		Code:    http.StatusBadRequest,
+		Reason:  "InvalidArgument.CaptchaInvalid",
+		Message: "Challenge solution is invalid",
+	}
+)
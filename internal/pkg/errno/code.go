@@ -57,4 +57,15 @@ var (
 
 	// ErrRemoveRole 表示在删除角色时发生错误.
 	ErrRemoveRole = &errorsx.ErrorX{Code: http.StatusInternalServerError, Reason: "InternalError.RemoveRole", Message: "Error occurred while removing the role."}
+
+	// ErrTooManyRequests 表示请求过于频繁，被限流拒绝.
+	ErrTooManyRequests = &errorsx.ErrorX{Code: http.StatusTooManyRequests, Reason: "ResourceExhausted.TooManyRequests", Message: "Too many requests, please try again later."}
 )
+
+// WithMessage 返回 err 的副本，并使用 format 和 args 设置副本的错误信息.
+// errno 中定义的错误是全局共享的，直接调用其 WithMessage 方法会修改全局变量，并发请求之间会互相覆盖错误信息，
+// 因此设置错误信息时统一使用该函数.
+func WithMessage(err *errorsx.ErrorX, format string, args ...any) *errorsx.ErrorX {
+	e := *err
+	return e.WithMessage(format, args...)
+}
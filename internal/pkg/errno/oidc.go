@@ -0,0 +1,50 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package errno
+
+import (
+	"net/http"
+
+	"github.com/onexstack/onexstack/pkg/errorsx"
+)
+
+var (
+	// ErrOIDCProviderNotFound 表示请求的身份提供方不存在.
+	ErrOIDCProviderNotFound = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.OIDCProviderNotFound",
+		Message: "OIDC provider not found",
+	}
+
+	// ErrOIDCProviderUnavailable 表示身份提供方服务发现失败.
+	ErrOIDCProviderUnavailable = &errorsx.ErrorX{
+		Code:    http.StatusServiceUnavailable,
+		Reason:  "Unavailable.OIDCProviderUnavailable",
+		Message: "OIDC provider is unavailable",
+	}
+
+	// ErrOIDCSessionInvalid 表示登录会话无效、已过期或与回调参数不匹配.
+	ErrOIDCSessionInvalid = &errorsx.ErrorX{
+		Code:    http.StatusBadRequest,
+		Reason:  "InvalidArgument.OIDCSessionInvalid",
+		Message: "OIDC login session is invalid or has expired",
+	}
+
+	// ErrOIDCLoginFailed 表示授权码换取令牌或 ID Token 校验失败.
+	ErrOIDCLoginFailed = &errorsx.ErrorX{
+		Code:    http.StatusUnauthorized,
+		Reason:  "Unauthenticated.OIDCLoginFailed",
+		Message: "OIDC login failed",
+	}
+
+	// ErrIdentityNotFound 表示第三方登录身份不存在.
+	ErrIdentityNotFound = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.IdentityNotFound",
+		Message: "Identity not found",
+	}
+)
@@ -0,0 +1,64 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package errno
+
+import (
+	"net/http"
+
+	"github.com/onexstack/onexstack/pkg/errorsx"
+)
+
+var (
+	// ErrOrganizationNotFound 表示未找到指定的组织.
+	ErrOrganizationNotFound = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.OrganizationNotFound",
+		Message: "Organization not found.",
+	}
+
+	// ErrOrganizationAlreadyExists 表示组织的短名称已经被占用.
+	ErrOrganizationAlreadyExists = &errorsx.ErrorX{
+		Code:    http.StatusConflict,
+		Reason:  "AlreadyExists.OrganizationAlreadyExists",
+		Message: "Organization slug already exists.",
+	}
+
+	// ErrOrganizationReserved 表示默认组织不允许被修改或删除.
+	ErrOrganizationReserved = &errorsx.ErrorX{
+		Code:    http.StatusForbidden,
+		Reason:  "PermissionDenied.OrganizationReserved",
+		Message: "The default organization cannot be modified.",
+	}
+
+	// ErrNotOrganizationMember 表示当前用户不是组织的成员.
+	ErrNotOrganizationMember = &errorsx.ErrorX{
+		Code:    http.StatusForbidden,
+		Reason:  "PermissionDenied.NotOrganizationMember",
+		Message: "You are not a member of the organization.",
+	}
+
+	// ErrOrganizationMemberNotFound 表示用户不是组织的成员.
+	ErrOrganizationMemberNotFound = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.OrganizationMemberNotFound",
+		Message: "Organization member not found.",
+	}
+
+	// ErrLastOrganizationOwner 表示不能移除或者降级组织的最后一个所有者.
+	ErrLastOrganizationOwner = &errorsx.ErrorX{
+		Code:    http.StatusBadRequest,
+		Reason:  "FailedPrecondition.LastOrganizationOwner",
+		Message: "An organization must have at least one owner.",
+	}
+
+	// ErrUpdateOrganizationMember 表示修改组织成员时发生错误.
+	ErrUpdateOrganizationMember = &errorsx.ErrorX{
+		Code:    http.StatusInternalServerError,
+		Reason:  "InternalError.UpdateOrganizationMember",
+		Message: "Error occurred while updating the organization member.",
+	}
+)
@@ -0,0 +1,71 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package errno
+
+import (
+	"net/http"
+
+	"github.com/onexstack/onexstack/pkg/errorsx"
+)
+
+var (
+	// ErrPolicyAlreadyExists 表示访问控制策略已经存在.
+	ErrPolicyAlreadyExists = &errorsx.ErrorX{
+		Code:    http.StatusConflict,
+		Reason:  "AlreadyExists.PolicyAlreadyExists",
+		Message: "Policy already exists.",
+	}
+
+	// ErrPolicyNotFound 表示未找到指定的访问控制策略.
+	ErrPolicyNotFound = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.PolicyNotFound",
+		Message: "Policy not found.",
+	}
+
+	// ErrRoleAlreadyExists 表示角色已经存在.
+	ErrRoleAlreadyExists = &errorsx.ErrorX{
+		Code:    http.StatusConflict,
+		Reason:  "AlreadyExists.RoleAlreadyExists",
+		Message: "Role already exists.",
+	}
+
+	// ErrRoleNotFound 表示未找到指定的角色.
+	ErrRoleNotFound = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.RoleNotFound",
+		Message: "Role not found.",
+	}
+
+	// ErrRoleReserved 表示内置角色不允许被删除.
+	ErrRoleReserved = &errorsx.ErrorX{
+		Code:    http.StatusForbidden,
+		Reason:  "PermissionDenied.RoleReserved",
+		Message: "Built-in roles cannot be deleted.",
+	}
+
+	// ErrRoleAssignmentAlreadyExists 表示主体已经拥有该角色.
+	ErrRoleAssignmentAlreadyExists = &errorsx.ErrorX{
+		Code:    http.StatusConflict,
+		Reason:  "AlreadyExists.RoleAssignmentAlreadyExists",
+		Message: "Role has already been assigned to the subject.",
+	}
+
+	// ErrRoleAssignmentNotFound 表示主体没有被分配该角色.
+	ErrRoleAssignmentNotFound = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.RoleAssignmentNotFound",
+		Message: "Role assignment not found.",
+	}
+
+	// ErrUpdatePolicy 表示修改访问控制策略时发生错误.
+	ErrUpdatePolicy = &errorsx.ErrorX{
+		Code:    http.StatusInternalServerError,
+		Reason:  "InternalError.UpdatePolicy",
+		Message: "Error occurred while updating the policy.",
+	}
+)
@@ -14,3 +14,6 @@ import (
 
 // ErrPostNotFound 表示未找到指定的博客.
 var ErrPostNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.PostNotFound", Message: "Post not found."}
+
+// ErrPostShareNotFound 表示博客没有分享给指定的用户.
+var ErrPostShareNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.PostShareNotFound", Message: "Post is not shared with the user."}
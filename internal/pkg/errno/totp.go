@@ -0,0 +1,50 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package errno
+
+import (
+	"net/http"
+
+	"github.com/onexstack/onexstack/pkg/errorsx"
+)
+
+var (
+	// ErrTOTPNotEnrolled 表示用户尚未注册 TOTP 二次验证.
+	ErrTOTPNotEnrolled = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.TOTPNotEnrolled",
+		Message: "TOTP is not enrolled",
+	}
+
+	// ErrTOTPAlreadyEnabled 表示用户已经启用了 TOTP 二次验证.
+	ErrTOTPAlreadyEnabled = &errorsx.ErrorX{
+		Code:    http.StatusBadRequest,
+		Reason:  "AlreadyExist.TOTPAlreadyEnabled",
+		Message: "TOTP is already enabled",
+	}
+
+	// ErrTOTPCodeInvalid 表示 TOTP 动态码或恢复码无效.
+	ErrTOTPCodeInvalid = &errorsx.ErrorX{
+		Code:    http.StatusUnauthorized,
+		Reason:  "Unauthenticated.TOTPCodeInvalid",
+		Message: "Verification code is invalid or has already been used",
+	}
+
+	// ErrRecoveryCodeInvalid 表示恢复码不存在或已被使用.
+	ErrRecoveryCodeInvalid = &errorsx.ErrorX{
+		Code:    http.StatusUnauthorized,
+		Reason:  "Unauthenticated.RecoveryCodeInvalid",
+		Message: "Recovery code is invalid or has already been used",
+	}
+
+	// ErrMFAChallengeInvalid 表示二次验证挑战令牌无效或已过期.
+	ErrMFAChallengeInvalid = &errorsx.ErrorX{
+		Code:    http.StatusUnauthorized,
+		Reason:  "Unauthenticated.MFAChallengeInvalid",
+		Message: "MFA challenge token is invalid or has expired",
+	}
+)
@@ -27,6 +27,13 @@ var (
 		Message: "Password is incorrect",
 	}
 
+	// ErrPasswordReused 表示新密码与最近使用过的密码重复.
+	ErrPasswordReused = &errorsx.ErrorX{
+		Code:    http.StatusBadRequest,
+		Reason:  "InvalidArgument.PasswordReused",
+		Message: "The new password must not be the same as a recently used password",
+	}
+
 	// ErrUserAlreadyExists 表示用户已存在.
 	ErrUserAlreadyExists = &errorsx.ErrorX{
 		Code:    http.StatusBadRequest,
@@ -40,4 +47,53 @@ var (
 		Reason:  "NotFound.UserNotFound",
 		Message: "User not found",
 	}
+
+	// ErrPasswordResetTokenInvalid 表示密码重置令牌无效、已过期或已被使用.
+	ErrPasswordResetTokenInvalid = &errorsx.ErrorX{
+		Code:    http.StatusBadRequest,
+		Reason:  "InvalidArgument.PasswordResetTokenInvalid",
+		Message: "Password reset token is invalid, expired or has already been used",
+	}
+
+	// ErrEmailVerificationTokenInvalid 表示邮箱验证令牌无效或已过期.
+	ErrEmailVerificationTokenInvalid = &errorsx.ErrorX{
+		Code:    http.StatusBadRequest,
+		Reason:  "InvalidArgument.EmailVerificationTokenInvalid",
+		Message: "Email verification token is invalid or expired",
+	}
+
+	// ErrEmailAlreadyVerified 表示用户邮箱已经通过验证.
+	ErrEmailAlreadyVerified = &errorsx.ErrorX{
+		Code:    http.StatusBadRequest,
+		Reason:  "FailedPrecondition.EmailAlreadyVerified",
+		Message: "Email has already been verified",
+	}
+
+	// ErrSendMail 表示发送邮件失败.
+	ErrSendMail = &errorsx.ErrorX{
+		Code:    http.StatusInternalServerError,
+		Reason:  "InternalError.SendMail",
+		Message: "Failed to send mail",
+	}
+
+	// ErrInvalidCredentials 表示用户名或密码错误，登录时不区分用户不存在和密码错误，避免用户名被枚举.
+	ErrInvalidCredentials = &errorsx.ErrorX{
+		Code:    http.StatusUnauthorized,
+		Reason:  "Unauthenticated.InvalidCredentials",
+		Message: "Username or password is incorrect",
+	}
+
+	// ErrTooManyLoginAttempts 表示登录失败次数过多，需要等待一段时间后重试.
+	ErrTooManyLoginAttempts = &errorsx.ErrorX{
+		Code:    http.StatusTooManyRequests,
+		Reason:  "ResourceExhausted.TooManyLoginAttempts",
+		Message: "Too many failed login attempts, please try again later",
+	}
+
+	// ErrImpersonationForbidden 表示模拟登录时不允许执行该操作.
+	ErrImpersonationForbidden = &errorsx.ErrorX{
+		Code:    http.StatusForbidden,
+		Reason:  "PermissionDenied.ImpersonationForbidden",
+		Message: "This operation is not allowed while impersonating another user.",
+	}
 )
@@ -0,0 +1,146 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package fieldmask 实现基于 google.protobuf.FieldMask 的部分更新（AIP-134）和部分读取（AIP-157）.
+// 目前只支持顶层字段.
+package fieldmask
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+)
+
+// Wildcard 表示掩码包含所有允许的字段.
+const Wildcard = "*"
+
+// Paths 校验 mask 中的路径，并返回 md 中对应的 proto 字段名，结果按 mask 中的顺序排列并去重.
+// 路径可以是 proto 字段名、JSON 字段名，或者 protojson 解码 FieldMask 时生成的 snake_case 名称，
+// 例如 createdAt 和 created_at 都对应 createdAt.
+// allowed 不为空时只允许其中的字段，通配符 * 会展开为 allowed，allowed 为空时展开为 md 的所有字段.
+// mask 为 nil 或者不包含任何路径时返回 nil.
+func Paths(mask *fieldmaskpb.FieldMask, md protoreflect.MessageDescriptor, allowed ...string) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		return nil, nil
+	}
+
+	fields := md.Fields()
+	if len(allowed) == 0 {
+		for i := range fields.Len() {
+			allowed = append(allowed, string(fields.Get(i).Name()))
+		}
+	}
+
+	if len(mask.GetPaths()) == 1 && strings.TrimSpace(mask.GetPaths()[0]) == Wildcard {
+		return allowed, nil
+	}
+
+	paths := make([]string, 0, len(mask.GetPaths()))
+	seen := make(map[string]struct{}, len(mask.GetPaths()))
+	for _, path := range mask.GetPaths() {
+		path = strings.TrimSpace(path)
+		switch {
+		case path == Wildcard:
+			return nil, invalidPath("wildcard %q must be used alone", Wildcard)
+		case strings.Contains(path, "."):
+			return nil, invalidPath("nested field %q is not supported", path)
+		}
+
+		fd := lookup(fields, path)
+		if fd == nil {
+			return nil, invalidPath("unknown field %q", path)
+		}
+		name := string(fd.Name())
+		if !slices.Contains(allowed, name) {
+			return nil, invalidPath("field %q is not allowed", name)
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		paths = append(paths, name)
+	}
+
+	return paths, nil
+}
+
+// Prune 清空 msg 中不在 paths 中的顶层字段，paths 为空时不修改 msg.
+// paths 应当是 Paths 返回的 proto 字段名.
+func Prune(msg proto.Message, paths []string) {
+	if len(paths) == 0 || msg == nil {
+		return
+	}
+
+	m := msg.ProtoReflect()
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if !slices.Contains(paths, string(fd.Name())) {
+			m.Clear(fd)
+		}
+		return true
+	})
+}
+
+// Contains 判断 paths 中是否包含 name，paths 为空时表示包含所有字段.
+func Contains(paths []string, name string) bool {
+	return len(paths) == 0 || slices.Contains(paths, name)
+}
+
+// Parse 将查询参数中的字段列表转换为 FieldMask，每个值可以是逗号分隔的多个字段.
+// 没有任何字段时返回 nil.
+func Parse(values ...string) *fieldmaskpb.FieldMask {
+	var paths []string
+	for _, value := range values {
+		for _, path := range strings.Split(value, ",") {
+			if path = strings.TrimSpace(path); path != "" {
+				paths = append(paths, path)
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	return &fieldmaskpb.FieldMask{Paths: paths}
+}
+
+// lookup 按 proto 字段名、JSON 字段名和 snake_case 名称查找字段.
+func lookup(fields protoreflect.FieldDescriptors, path string) protoreflect.FieldDescriptor {
+	if fd := fields.ByName(protoreflect.Name(path)); fd != nil {
+		return fd
+	}
+	if fd := fields.ByJSONName(path); fd != nil {
+		return fd
+	}
+	for i := range fields.Len() {
+		if fd := fields.Get(i); snakeCase(string(fd.Name())) == path {
+			return fd
+		}
+	}
+	return nil
+}
+
+// snakeCase 按照 protojson 解码 FieldMask 的规则将驼峰命名转换为 snake_case，例如 postID 转换为 post_i_d.
+func snakeCase(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsUpper(r) {
+			b.WriteByte('_')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// invalidPath 返回字段掩码路径不合法的错误.
+func invalidPath(format string, args ...any) error {
+	return errno.WithMessage(errno.ErrInvalidArgument, "field mask: "+format, args...)
+}
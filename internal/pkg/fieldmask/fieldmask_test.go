@@ -0,0 +1,78 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package fieldmask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+)
+
+func TestPaths(t *testing.T) {
+	md := (&apiv1.Post{}).ProtoReflect().Descriptor()
+	tests := []struct {
+		name    string
+		paths   []string
+		allowed []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty", want: nil},
+		{name: "proto names", paths: []string{"title", "createdAt"}, want: []string{"title", "createdAt"}},
+		{name: "snake case", paths: []string{"created_at", "post_i_d"}, want: []string{"createdAt", "postID"}},
+		{name: "duplicates", paths: []string{"title", " title"}, want: []string{"title"}},
+		{name: "wildcard", paths: []string{"*"}, allowed: []string{"title", "content"}, want: []string{"title", "content"}},
+		{name: "wildcard with other paths", paths: []string{"*", "title"}, wantErr: true},
+		{name: "unknown field", paths: []string{"author"}, wantErr: true},
+		{name: "nested field", paths: []string{"createdAt.seconds"}, wantErr: true},
+		{name: "not allowed", paths: []string{"postID"}, allowed: []string{"title", "content"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Paths(&fieldmaskpb.FieldMask{Paths: tt.paths}, md, tt.allowed...)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errno.ErrInvalidArgument)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPathsFromProtoJSON(t *testing.T) {
+	var rq apiv1.UpdatePostRequest
+	assert.NoError(t, protojson.Unmarshal([]byte(`{"postID":"post-1","updateMask":"title,content"}`), &rq))
+
+	got, err := Paths(rq.GetUpdateMask(), rq.ProtoReflect().Descriptor(), "title", "content")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"title", "content"}, got)
+}
+
+func TestPrune(t *testing.T) {
+	post := &apiv1.Post{PostID: "post-1", Title: "title", Content: "content", CreatedAt: timestamppb.Now()}
+
+	Prune(post, []string{"postID", "title"})
+	assert.True(t, proto.Equal(&apiv1.Post{PostID: "post-1", Title: "title"}, post))
+
+	Prune(post, nil)
+	assert.Equal(t, "title", post.GetTitle())
+}
+
+func TestParse(t *testing.T) {
+	assert.Nil(t, Parse())
+	assert.Nil(t, Parse("", " , "))
+	assert.Equal(t, []string{"title", "content", "postID"}, Parse("title, content", "postID").GetPaths())
+}
@@ -6,6 +6,8 @@
 
 package known
 
+import "time"
+
 // 定义 HTTP/gRPC Header
 // gRPC 底层使用了 HTTP/2 作为传输协议，而 HTTP/2 的规范
 // 规定 Header 的键必须是小写的。因此，在 gRPC 中，所有的 Header 键都会被强制转换为小写，
@@ -23,13 +25,87 @@ const (
 
 	// XUsername 用来定义上下文的键，代表请求用户名.
 	XUsername = "x-username"
+
+	// XActorID 用来定义上下文的键，代表模拟登录时实际发起请求的管理员用户 ID.
+	XActorID = "x-actor-id"
+
+	// XTenantID 用来定义上下文的键，代表请求所在的租户（组织）ID.
+	XTenantID = "x-tenant-id"
 )
 
 // 定义其他常量.
 const (
 	// Admin 用户名.
 	AdminUsername = "root"
+)
+
+// 定义二次验证相关常量.
+const (
+	// TOTPIssuer 是 otpauth URI 中的签发方名称，会显示在身份验证器 App 中.
+	TOTPIssuer = "miniblog"
+
+	// MFAChallengeScope 是二次验证挑战令牌的用途.
+	MFAChallengeScope = "mfa-challenge"
+
+	// MFAChallengeExpiration 是二次验证挑战令牌的有效期.
+	MFAChallengeExpiration = 5 * time.Minute
+
+	// RecoveryCodeCount 是启用二次验证时生成的恢复码数量.
+	RecoveryCodeCount = 10
+)
+
+// 定义密码重置和邮箱验证相关常量.
+const (
+	// PasswordResetScope 是密码重置令牌的用途.
+	PasswordResetScope = "password-reset"
+
+	// PasswordResetExpiration 是密码重置令牌的有效期.
+	PasswordResetExpiration = 30 * time.Minute
+
+	// EmailVerificationScope 是邮箱验证令牌的用途.
+	EmailVerificationScope = "email-verification"
+
+	// EmailVerificationExpiration 是邮箱验证令牌的有效期.
+	EmailVerificationExpiration = 24 * time.Hour
+)
+
+// 定义第三方登录相关常量.
+const (
+	// OIDCSessionScope 是 OIDC 登录会话令牌的用途.
+	OIDCSessionScope = "oidc-session"
+)
+
+// 定义模拟登录相关常量.
+const (
+	// ImpersonationExpiration 是模拟登录令牌的有效期.
+	ImpersonationExpiration = 15 * time.Minute
+)
+
+// 定义审计日志相关常量.
+const (
+	// AuditOutcomeSuccess 表示操作成功.
+	AuditOutcomeSuccess = "success"
+
+	// AuditOutcomeFailure 表示操作失败.
+	AuditOutcomeFailure = "failure"
+)
+
+const (
+	// DefaultTenantID 是默认租户的 ID，未指定租户的请求都属于默认租户，所有用户都是默认租户的成员.
+	DefaultTenantID = "default"
+)
+
+// 定义批量接口相关常量.
+const (
+	// MaxBatchSize 是批量接口一次最多可以处理的条目数.
+	MaxBatchSize = 100
+)
+
+// 定义字段掩码相关变量，列表中是 proto 字段名.
+var (
+	// PostUpdatableFields 是 UpdatePostRequest 的 update_mask 中可以使用的字段.
+	PostUpdatableFields = []string{"title", "content"}
 
-	// 根据场景需求，可以调整该值大小.
-	MaxErrGroupConcurrency = 1000
+	// UserUpdatableFields 是 UpdateUserRequest 的 update_mask 中可以使用的字段.
+	UserUpdatableFields = []string{"username", "nickname", "email", "phone"}
 )
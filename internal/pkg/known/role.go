@@ -11,4 +11,46 @@ const (
 	RoleUser = "role::user"
 	// Role for administrators.
 	RoleAdmin = "role::admin"
+
+	// RolePostViewer 是博客的查看者角色，可以查看被分享的博客.
+	RolePostViewer = "role::post-viewer"
+	// RolePostEditor 是博客的编辑者角色，可以查看和修改被分享的博客.
+	RolePostEditor = "role::post-editor"
+
+	// RoleTenantOwner 是租户的所有者角色，可以管理租户成员和删除租户.
+	RoleTenantOwner = "role::tenant-owner"
+	// RoleTenantAdmin 是租户的管理员角色，可以管理租户成员，以及查看和修改租户内的所有博客.
+	RoleTenantAdmin = "role::tenant-admin"
+	// RoleTenantMember 是租户的普通成员角色.
+	RoleTenantMember = "role::tenant-member"
+)
+
+// 定义资源级别访问控制的资源前缀和操作.
+const (
+	// ResourcePostPrefix 是博客资源的前缀，完整的资源名称为 post:<postID>.
+	ResourcePostPrefix = "post:"
+	// ResourceUserPrefix 是用户资源的前缀，完整的资源名称为 user:<userID>.
+	ResourceUserPrefix = "user:"
+	// ResourceOrganizationPrefix 是组织资源的前缀，完整的资源名称为 organization:<orgID>.
+	ResourceOrganizationPrefix = "organization:"
+
+	// ActionRead 表示读取资源.
+	ActionRead = "read"
+	// ActionWrite 表示修改资源.
+	ActionWrite = "write"
+	// ActionShare 表示将资源分享给其他用户.
+	ActionShare = "share"
+)
+
+const (
+	// RolePrefix 是所有角色名称的前缀，用于区分角色和用户 ID.
+	RolePrefix = "role::"
+
+	// ServicePrincipalPrefix 是通过客户端证书认证的服务主体的前缀，用于区分服务主体和用户 ID.
+	ServicePrincipalPrefix = "service::"
+
+	// EffectAllow 表示策略允许访问.
+	EffectAllow = "allow"
+	// EffectDeny 表示策略拒绝访问.
+	EffectDeny = "deny"
 )
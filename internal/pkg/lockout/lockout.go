@@ -0,0 +1,182 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package lockout 实现登录失败次数限制，按账号和客户端 IP 分别统计连续失败次数，
+// 超过免费次数后按指数退避拒绝登录，达到上限后临时锁定.
+//
+// 失败记录保存在进程内存中，多副本部署时每个副本独立计数.
+package lockout
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/wire"
+)
+
+// ProviderSet 是 lockout 包的 Wire Provider 集合.
+var ProviderSet = wire.NewSet(NewGuard)
+
+// sweepInterval 是清理过期失败记录的最小时间间隔.
+const sweepInterval = time.Minute
+
+// entry 记录一个账号或 IP 的失败信息.
+type entry struct {
+	failures    int
+	lastFailure time.Time
+	blockedTill time.Time
+}
+
+// Guard 按账号和客户端 IP 统计登录失败次数.
+type Guard struct {
+	opts *Options
+
+	mu        sync.Mutex
+	entries   map[string]*entry
+	lastSweep time.Time
+
+	// now 用于在测试中替换当前时间
+	now func() time.Time
+}
+
+// NewGuard 创建一个 Guard 实例.
+func NewGuard(opts *Options) *Guard {
+	return &Guard{
+		opts:    opts,
+		entries: make(map[string]*entry),
+		now:     time.Now,
+	}
+}
+
+// Check 判断账号 account 和客户端 ip 当前是否允许尝试登录，不允许时返回需要等待的时间.
+// ip 为空时只检查账号.
+func (g *Guard) Check(account string, ip string) (time.Duration, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	var wait time.Duration
+	for _, key := range keys(account, ip) {
+		if e := g.get(key, now); e != nil && e.blockedTill.After(now) {
+			wait = max(wait, e.blockedTill.Sub(now))
+		}
+	}
+
+	return wait, wait == 0
+}
+
+// Fail 记录一次登录失败，并根据失败次数计算退避或锁定时间.
+func (g *Guard) Fail(account string, ip string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	g.sweep(now)
+
+	g.fail(accountKey(account), g.opts.MaxAccountFailures, now)
+	if ip != "" {
+		g.fail(ipKey(ip), g.opts.MaxIPFailures, now)
+	}
+}
+
+// Succeed 记录一次登录成功，清除账号的失败记录.
+// IP 的失败记录不会被清除，否则攻击者可以使用自己的账号周期性登录来重置 IP 计数.
+func (g *Guard) Succeed(account string) {
+	g.Unlock(account)
+}
+
+// Unlock 清除账号的失败记录，解除锁定.
+func (g *Guard) Unlock(account string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.entries, accountKey(account))
+}
+
+// Locked 判断账号当前是否处于退避或锁定状态.
+func (g *Guard) Locked(account string) bool {
+	_, ok := g.Check(account, "")
+	return !ok
+}
+
+// fail 增加 key 的失败次数，调用方需要持有锁.
+func (g *Guard) fail(key string, limit int, now time.Time) {
+	e := g.get(key, now)
+	if e == nil {
+		e = &entry{}
+		g.entries[key] = e
+	}
+
+	e.failures++
+	e.lastFailure = now
+
+	switch {
+	case e.failures >= limit:
+		e.blockedTill = now.Add(g.opts.LockoutDuration)
+	case e.failures > g.opts.FreeAttempts:
+		e.blockedTill = now.Add(g.backoff(e.failures - g.opts.FreeAttempts))
+	}
+}
+
+// backoff 返回第 n 次超出免费次数的失败对应的退避时间：BaseDelay * 2^(n-1)，不超过 MaxDelay.
+func (g *Guard) backoff(n int) time.Duration {
+	delay := g.opts.BaseDelay
+	for i := 1; i < n; i++ {
+		delay *= 2
+		if delay >= g.opts.MaxDelay {
+			return g.opts.MaxDelay
+		}
+	}
+	return min(delay, g.opts.MaxDelay)
+}
+
+// get 返回 key 对应的未过期失败记录，调用方需要持有锁.
+func (g *Guard) get(key string, now time.Time) *entry {
+	e, ok := g.entries[key]
+	if !ok {
+		return nil
+	}
+	if g.expired(e, now) {
+		delete(g.entries, key)
+		return nil
+	}
+	return e
+}
+
+// expired 判断失败记录是否已经过期：不再处于锁定状态，并且最后一次失败已经超出统计窗口.
+func (g *Guard) expired(e *entry, now time.Time) bool {
+	return !e.blockedTill.After(now) && now.Sub(e.lastFailure) > g.opts.Window
+}
+
+// sweep 周期性地清理过期的失败记录，避免大量不同的用户名或 IP 占用内存，调用方需要持有锁.
+func (g *Guard) sweep(now time.Time) {
+	if now.Sub(g.lastSweep) < sweepInterval {
+		return
+	}
+	g.lastSweep = now
+
+	for key, e := range g.entries {
+		if g.expired(e, now) {
+			delete(g.entries, key)
+		}
+	}
+}
+
+// keys 返回需要检查的所有 key.
+func keys(account string, ip string) []string {
+	if ip == "" {
+		return []string{accountKey(account)}
+	}
+	return []string{accountKey(account), ipKey(ip)}
+}
+
+func accountKey(account string) string {
+	return "account:" + account
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
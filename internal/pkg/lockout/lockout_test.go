@@ -0,0 +1,105 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package lockout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestGuard 创建一个使用可控时钟的 Guard.
+func newTestGuard() (*Guard, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	g := NewGuard(&Options{
+		FreeAttempts:       2,
+		MaxAccountFailures: 5,
+		MaxIPFailures:      8,
+		BaseDelay:          time.Second,
+		MaxDelay:           4 * time.Second,
+		LockoutDuration:    time.Minute,
+		Window:             time.Hour,
+	})
+	g.now = func() time.Time { return now }
+	return g, &now
+}
+
+func TestGuard_Backoff(t *testing.T) {
+	g, now := newTestGuard()
+
+	// 免费次数内不受限制
+	for i := 0; i < 2; i++ {
+		g.Fail("colin", "10.0.0.1")
+		_, ok := g.Check("colin", "10.0.0.1")
+		assert.True(t, ok)
+	}
+
+	// 超过免费次数后指数退避：1s、2s、4s（达到上限）
+	for _, want := range []time.Duration{time.Second, 2 * time.Second} {
+		g.Fail("colin", "10.0.0.1")
+		wait, ok := g.Check("colin", "10.0.0.1")
+		assert.False(t, ok)
+		assert.Equal(t, want, wait)
+
+		*now = now.Add(wait)
+		_, ok = g.Check("colin", "10.0.0.1")
+		assert.True(t, ok)
+	}
+
+	// 达到最大失败次数后锁定
+	g.Fail("colin", "10.0.0.1")
+	wait, ok := g.Check("colin", "10.0.0.1")
+	assert.False(t, ok)
+	assert.Equal(t, time.Minute, wait)
+	assert.True(t, g.Locked("colin"))
+
+	// 其他账号不受影响（IP 尚未达到上限，但处于退避中）
+	_, ok = g.Check("bob", "")
+	assert.True(t, ok)
+
+	// 管理员解锁
+	g.Unlock("colin")
+	assert.False(t, g.Locked("colin"))
+}
+
+func TestGuard_IPLimit(t *testing.T) {
+	g, now := newTestGuard()
+
+	// 同一个 IP 尝试不同的用户名
+	for i := 0; i < 8; i++ {
+		*now = now.Add(10 * time.Second)
+		g.Fail(string(rune('a'+i)), "10.0.0.2")
+	}
+
+	wait, ok := g.Check("someone", "10.0.0.2")
+	assert.False(t, ok)
+	assert.Equal(t, time.Minute, wait)
+
+	// 其他 IP 不受影响
+	_, ok = g.Check("someone", "10.0.0.3")
+	assert.True(t, ok)
+
+	// 登录成功不会清除 IP 的失败记录
+	g.Succeed("someone")
+	_, ok = g.Check("someone", "10.0.0.2")
+	assert.False(t, ok)
+}
+
+func TestGuard_Window(t *testing.T) {
+	g, now := newTestGuard()
+
+	g.Fail("colin", "")
+	g.Fail("colin", "")
+
+	// 超出统计窗口后失败次数清零
+	*now = now.Add(2 * time.Hour)
+	g.Fail("colin", "")
+	_, ok := g.Check("colin", "")
+	assert.True(t, ok)
+	assert.Len(t, g.entries, 1)
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package lockout
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Options 包含登录失败限制相关的配置选项.
+type Options struct {
+	// FreeAttempts 是不受任何限制的连续失败次数，超过后开始指数退避
+	FreeAttempts int `json:"free-attempts" mapstructure:"free-attempts"`
+	// MaxAccountFailures 是单个账号允许的最大连续失败次数，达到后账号被临时锁定
+	MaxAccountFailures int `json:"max-account-failures" mapstructure:"max-account-failures"`
+	// MaxIPFailures 是单个 IP 允许的最大失败次数，达到后该 IP 被临时锁定
+	MaxIPFailures int `json:"max-ip-failures" mapstructure:"max-ip-failures"`
+	// BaseDelay 是指数退避的初始等待时间
+	BaseDelay time.Duration `json:"base-delay" mapstructure:"base-delay"`
+	// MaxDelay 是指数退避的最大等待时间
+	MaxDelay time.Duration `json:"max-delay" mapstructure:"max-delay"`
+	// LockoutDuration 是达到最大失败次数后的锁定时长
+	LockoutDuration time.Duration `json:"lockout-duration" mapstructure:"lockout-duration"`
+	// Window 是失败记录的保留时长，超过该时长没有新的失败时计数清零
+	Window time.Duration `json:"window" mapstructure:"window"`
+}
+
+// NewOptions 创建带有默认值的 Options 实例.
+func NewOptions() *Options {
+	return &Options{
+		FreeAttempts:       3,
+		MaxAccountFailures: 10,
+		MaxIPFailures:      50,
+		BaseDelay:          time.Second,
+		MaxDelay:           5 * time.Minute,
+		LockoutDuration:    15 * time.Minute,
+		Window:             time.Hour,
+	}
+}
+
+// AddFlags 将登录失败限制相关的选项绑定到命令行标志.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&o.FreeAttempts, "lockout.free-attempts", o.FreeAttempts, "Number of consecutive failed logins allowed before backoff starts.")
+	fs.IntVar(&o.MaxAccountFailures, "lockout.max-account-failures", o.MaxAccountFailures, "Number of consecutive failed logins after which an account is locked.")
+	fs.IntVar(&o.MaxIPFailures, "lockout.max-ip-failures", o.MaxIPFailures, "Number of failed logins after which a client IP is locked.")
+	fs.DurationVar(&o.BaseDelay, "lockout.base-delay", o.BaseDelay, "Initial backoff delay after free attempts are used up.")
+	fs.DurationVar(&o.MaxDelay, "lockout.max-delay", o.MaxDelay, "Upper bound of the backoff delay.")
+	fs.DurationVar(&o.LockoutDuration, "lockout.duration", o.LockoutDuration, "How long an account or IP stays locked.")
+	fs.DurationVar(&o.Window, "lockout.window", o.Window, "Failures older than this window are forgotten.")
+}
+
+// Validate 校验登录失败限制配置选项是否合法.
+func (o *Options) Validate() []error {
+	errs := []error{}
+
+	if o.FreeAttempts < 0 {
+		errs = append(errs, errors.New("lockout.free-attempts must not be negative"))
+	}
+	if o.MaxAccountFailures <= o.FreeAttempts || o.MaxIPFailures <= o.FreeAttempts {
+		errs = append(errs, errors.New("lockout.max-account-failures and lockout.max-ip-failures must be greater than lockout.free-attempts"))
+	}
+	if o.BaseDelay <= 0 || o.MaxDelay < o.BaseDelay {
+		errs = append(errs, errors.New("lockout.base-delay must be positive and not greater than lockout.max-delay"))
+	}
+	if o.LockoutDuration <= 0 || o.Window <= 0 {
+		errs = append(errs, errors.New("lockout.duration and lockout.window must be positive"))
+	}
+
+	return errs
+}
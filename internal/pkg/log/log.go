@@ -199,6 +199,8 @@ func (l *zapLogger) W(ctx context.Context) Logger {
 	contextExtractors := map[string]func(context.Context) string{
 		known.XRequestID: contextx.RequestID, // 提取请求 ID
 		known.XUserID:    contextx.UserID,    // 提取用户 ID
+		known.XActorID:   contextx.ActorID,   // 提取模拟登录时的实际操作者 ID
+		known.XTenantID:  contextx.TenantID,  // 提取租户 ID
 	}
 
 	// 遍历映射，从 context 中提取值并添加到日志中
@@ -0,0 +1,84 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+)
+
+// logMailer 将邮件内容打印到日志中，不会真正发送邮件.
+type logMailer struct {
+	from string
+}
+
+// fileMailer 将邮件以 .eml 文件的形式写入指定目录，可以直接用邮件客户端打开查看.
+type fileMailer struct {
+	from string
+	dir  string
+}
+
+// 确保 logMailer 和 fileMailer 实现了 Mailer 接口.
+var (
+	_ Mailer = (*logMailer)(nil)
+	_ Mailer = (*fileMailer)(nil)
+)
+
+// NewLogMailer 创建一个将邮件打印到日志中的 Mailer.
+func NewLogMailer(from string) Mailer {
+	return &logMailer{from: from}
+}
+
+// NewFileMailer 创建一个将邮件写入 dir 目录的 Mailer.
+func NewFileMailer(from string, dir string) (Mailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &fileMailer{from: from, dir: dir}, nil
+}
+
+// Send 实现 Mailer 接口中的 Send 方法.
+func (m *logMailer) Send(ctx context.Context, msg *Message) error {
+	log.W(ctx).Infow("Mail delivered to log", "from", m.from, "to", msg.To, "subject", msg.Subject, "text", msg.Text)
+	return nil
+}
+
+// Send 实现 Mailer 接口中的 Send 方法.
+func (m *fileMailer) Send(ctx context.Context, msg *Message) error {
+	data, err := msg.Bytes(m.from)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102150405.000000000"), sanitize(strings.Join(msg.To, "_")))
+	path := filepath.Join(m.dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return err
+	}
+
+	log.W(ctx).Infow("Mail written to file", "to", msg.To, "subject", msg.Subject, "path", path)
+	return nil
+}
+
+// sanitize 将收件人地址转换为可以安全用作文件名的字符串.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '@', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
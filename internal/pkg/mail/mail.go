@@ -0,0 +1,57 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package mail 提供邮件发送能力，支持 SMTP 以及用于开发环境的日志/文件两种实现.
+package mail
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/wire"
+)
+
+// ProviderSet 是 mail 包的 Wire Provider 集合.
+var ProviderSet = wire.NewSet(NewMailer)
+
+// 定义支持的邮件发送驱动.
+const (
+	// DriverSMTP 通过 SMTP 服务器发送邮件.
+	DriverSMTP = "smtp"
+	// DriverLog 将邮件内容打印到日志中，适用于开发环境.
+	DriverLog = "log"
+	// DriverFile 将邮件以 .eml 文件的形式写入目录，适用于开发和测试环境.
+	DriverFile = "file"
+)
+
+// Message 表示一封待发送的邮件.
+type Message struct {
+	To      []string
+	Subject string
+	// Text 是纯文本格式的正文
+	Text string
+	// HTML 是 HTML 格式的正文，为空时只发送纯文本正文
+	HTML string
+}
+
+// Mailer 定义了邮件发送器需要实现的方法.
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewMailer 根据配置创建对应驱动的 Mailer.
+func NewMailer(opts *Options) (Mailer, error) {
+	switch opts.Driver {
+	case DriverSMTP:
+		return NewSMTPMailer(opts), nil
+	case DriverFile:
+		return NewFileMailer(opts.From, opts.Dir)
+	case DriverLog, "":
+		return NewLogMailer(opts.From), nil
+	default:
+		return nil, fmt.Errorf("unsupported mail driver: %s", opts.Driver)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package mail
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// 定义支持的驱动集合.
+var availableDrivers = sets.New(DriverSMTP, DriverLog, DriverFile)
+
+// Options 包含邮件发送相关的配置选项.
+type Options struct {
+	// Driver 指定邮件发送驱动：smtp、log、file
+	Driver string `json:"driver" mapstructure:"driver"`
+	// From 是发件人地址
+	From string `json:"from" mapstructure:"from"`
+	// Host 是 SMTP 服务器地址
+	Host string `json:"host" mapstructure:"host"`
+	// Port 是 SMTP 服务器端口
+	Port int `json:"port" mapstructure:"port"`
+	// Username 是 SMTP 认证用户名，为空时不进行认证
+	Username string `json:"username" mapstructure:"username"`
+	// Password 是 SMTP 认证密码
+	Password string `json:"password" mapstructure:"password"`
+	// ImplicitTLS 为 true 时直接建立 TLS 连接（通常为 465 端口），否则在服务器支持时使用 STARTTLS
+	ImplicitTLS bool `json:"implicit-tls" mapstructure:"implicit-tls"`
+	// InsecureSkipVerify 为 true 时不校验 SMTP 服务器证书，仅用于测试环境
+	InsecureSkipVerify bool `json:"insecure-skip-verify" mapstructure:"insecure-skip-verify"`
+	// Timeout 是单次发送邮件的超时时间
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+	// Dir 是 file 驱动写入邮件的目录
+	Dir string `json:"dir" mapstructure:"dir"`
+	// LinkBaseURL 是邮件中链接的前缀，例如 https://miniblog.example.com
+	LinkBaseURL string `json:"link-base-url" mapstructure:"link-base-url"`
+}
+
+// NewOptions 创建带有默认值的 Options 实例.
+func NewOptions() *Options {
+	return &Options{
+		Driver:      DriverLog,
+		From:        "miniblog <no-reply@miniblog.local>",
+		Host:        "127.0.0.1",
+		Port:        25,
+		Timeout:     10 * time.Second,
+		Dir:         "_output/mails",
+		LinkBaseURL: "http://127.0.0.1:5555",
+	}
+}
+
+// AddFlags 将邮件相关的选项绑定到命令行标志.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Driver, "mail.driver", o.Driver, fmt.Sprintf("Mail driver, available options: %v", sets.List(availableDrivers)))
+	fs.StringVar(&o.From, "mail.from", o.From, "Sender address of outgoing mails.")
+	fs.StringVar(&o.Host, "mail.host", o.Host, "SMTP server host.")
+	fs.IntVar(&o.Port, "mail.port", o.Port, "SMTP server port.")
+	fs.StringVar(&o.Username, "mail.username", o.Username, "SMTP username, leave empty to disable authentication.")
+	fs.StringVar(&o.Password, "mail.password", o.Password, "SMTP password.")
+	fs.BoolVar(&o.ImplicitTLS, "mail.implicit-tls", o.ImplicitTLS, "Use implicit TLS (usually port 465) instead of STARTTLS.")
+	fs.BoolVar(&o.InsecureSkipVerify, "mail.insecure-skip-verify", o.InsecureSkipVerify, "Skip verifying SMTP server certificate. For testing only.")
+	fs.DurationVar(&o.Timeout, "mail.timeout", o.Timeout, "Timeout for sending a single mail.")
+	fs.StringVar(&o.Dir, "mail.dir", o.Dir, "Directory to write mails into when mail.driver is file.")
+	fs.StringVar(&o.LinkBaseURL, "mail.link-base-url", o.LinkBaseURL, "Base URL used to build links in mails.")
+}
+
+// Validate 校验邮件配置选项是否合法.
+func (o *Options) Validate() []error {
+	errs := []error{}
+
+	if !availableDrivers.Has(o.Driver) {
+		errs = append(errs, fmt.Errorf("invalid mail driver: %s", o.Driver))
+	}
+
+	if o.From == "" {
+		errs = append(errs, fmt.Errorf("mail.from must not be empty"))
+	}
+
+	if o.Driver == DriverSMTP && (o.Host == "" || o.Port <= 0) {
+		errs = append(errs, fmt.Errorf("mail.host and mail.port are required when mail.driver is smtp"))
+	}
+
+	if o.Driver == DriverFile && o.Dir == "" {
+		errs = append(errs, fmt.Errorf("mail.dir is required when mail.driver is file"))
+	}
+
+	if _, err := url.ParseRequestURI(o.LinkBaseURL); err != nil {
+		errs = append(errs, fmt.Errorf("invalid mail.link-base-url: %w", err))
+	}
+
+	return errs
+}
+
+// Link 基于 LinkBaseURL 生成邮件中使用的链接.
+func (o *Options) Link(path string, query url.Values) string {
+	link := strings.TrimRight(o.LinkBaseURL, "/") + "/" + strings.TrimLeft(path, "/")
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	return link
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// smtpMailer 是通过 SMTP 服务器发送邮件的 Mailer 实现.
+type smtpMailer struct {
+	opts *Options
+}
+
+// 确保 smtpMailer 实现了 Mailer 接口.
+var _ Mailer = (*smtpMailer)(nil)
+
+// NewSMTPMailer 创建一个通过 SMTP 服务器发送邮件的 Mailer.
+func NewSMTPMailer(opts *Options) Mailer {
+	return &smtpMailer{opts: opts}
+}
+
+// Send 实现 Mailer 接口中的 Send 方法.
+func (m *smtpMailer) Send(ctx context.Context, msg *Message) error {
+	if len(msg.To) == 0 {
+		return errors.New("mail: no recipients")
+	}
+
+	from, err := envelopeAddress(m.opts.From)
+	if err != nil {
+		return err
+	}
+
+	data, err := msg.Bytes(m.opts.From)
+	if err != nil {
+		return err
+	}
+
+	if m.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.opts.Timeout)
+		defer cancel()
+	}
+
+	conn, err := m.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// net/smtp 不支持 context，这里通过连接的截止时间控制整个会话的超时
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, m.opts.Host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if !m.opts.ImplicitTLS {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(m.tlsConfig()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if m.opts.Username != "" {
+		// smtp.PlainAuth 会拒绝在非 TLS 连接上向非本机地址发送密码
+		if err := c.Auth(smtp.PlainAuth("", m.opts.Username, m.opts.Password, m.opts.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		rcpt, err := envelopeAddress(to)
+		if err != nil {
+			return err
+		}
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// dial 建立到 SMTP 服务器的连接，ImplicitTLS 为 true 时直接建立 TLS 连接.
+func (m *smtpMailer) dial(ctx context.Context) (net.Conn, error) {
+	addr := net.JoinHostPort(m.opts.Host, strconv.Itoa(m.opts.Port))
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+
+	if m.opts.ImplicitTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: m.tlsConfig()}
+		return tlsDialer.DialContext(ctx, "tcp", addr)
+	}
+
+	return dialer.DialContext(ctx, "tcp", addr)
+}
+
+// tlsConfig 返回与 SMTP 服务器建立 TLS 连接时使用的配置.
+func (m *smtpMailer) tlsConfig() *tls.Config {
+	return &tls.Config{
+		ServerName:         m.opts.Host,
+		InsecureSkipVerify: m.opts.InsecureSkipVerify, //nolint:gosec
+		MinVersion:         tls.VersionTLS12,
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package mail
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSMTPServer 是一个最小化的 SMTP 服务端，只实现发送一封邮件所需的命令.
+type fakeSMTPServer struct {
+	ln     net.Listener
+	auth   string
+	from   string
+	rcpts  []string
+	data   string
+	closed chan struct{}
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	s := &fakeSMTPServer{ln: ln, closed: make(chan struct{})}
+	go s.serve()
+	t.Cleanup(func() { _ = ln.Close() })
+	return s
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve() {
+	defer close(s.closed)
+
+	conn, err := s.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 localhost ESMTP fake")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			_ = tp.PrintfLine("250-localhost")
+			_ = tp.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			s.auth = strings.TrimPrefix(line, "AUTH PLAIN ")
+			_ = tp.PrintfLine("235 2.7.0 Authentication successful")
+		case "MAIL":
+			s.from = line
+			_ = tp.PrintfLine("250 OK")
+		case "RCPT":
+			s.rcpts = append(s.rcpts, line)
+			_ = tp.PrintfLine("250 OK")
+		case "DATA":
+			_ = tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.data = string(data)
+			_ = tp.PrintfLine("250 OK")
+		case "QUIT":
+			_ = tp.PrintfLine("221 Bye")
+			return
+		default:
+			_ = tp.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	srv := newFakeSMTPServer(t)
+
+	opts := NewOptions()
+	opts.Driver = DriverSMTP
+	opts.Host = "127.0.0.1"
+	opts.Port = srv.port()
+	opts.Username = "miniblog"
+	opts.Password = "secret"
+	opts.Timeout = 5 * time.Second
+
+	mailer, err := NewMailer(opts)
+	assert.NoError(t, err)
+
+	msg, err := Render(TemplatePasswordReset, map[string]string{
+		"Username":  "colin",
+		"Link":      "http://127.0.0.1:5555/reset-password?token=abc",
+		"ExpiresIn": "30m0s",
+	}, "colin <colin@example.com>")
+	assert.NoError(t, err)
+
+	assert.NoError(t, mailer.Send(context.Background(), msg))
+	<-srv.closed
+
+	auth, err := base64.StdEncoding.DecodeString(srv.auth)
+	assert.NoError(t, err)
+	assert.Equal(t, "\x00miniblog\x00secret", string(auth))
+	assert.Equal(t, "MAIL FROM:<no-reply@miniblog.local>", strings.SplitN(srv.from, " BODY", 2)[0])
+	assert.Equal(t, []string{"RCPT TO:<colin@example.com>"}, srv.rcpts)
+
+	assert.Contains(t, srv.data, "To: colin <colin@example.com>\n")
+	assert.Contains(t, srv.data, "Subject: =?utf-8?q?")
+	assert.Contains(t, srv.data, "multipart/alternative")
+	assert.Contains(t, srv.data, "Content-Type: text/html; charset=utf-8")
+	assert.Contains(t, srv.data, "token=3Dabc")
+}
+
+func TestSMTPMailer_NoRecipients(t *testing.T) {
+	mailer := NewSMTPMailer(&Options{Host: "127.0.0.1", Port: 1, From: "no-reply@miniblog.local"})
+	assert.Error(t, mailer.Send(context.Background(), &Message{Subject: "hi", Text: "hi"}))
+}
+
+func TestRender_EscapesHTML(t *testing.T) {
+	msg, err := Render(TemplateEmailVerification, map[string]string{
+		"Username":  "<b>colin</b>",
+		"Email":     "colin@example.com",
+		"Link":      "http://127.0.0.1:5555/verify-email?token=abc",
+		"ExpiresIn": "24h0m0s",
+	}, "colin@example.com")
+	assert.NoError(t, err)
+
+	// 纯文本正文保留原样，HTML 正文需要转义
+	assert.Contains(t, msg.Text, "<b>colin</b>")
+	assert.Contains(t, msg.HTML, "&lt;b&gt;colin&lt;/b&gt;")
+	assert.Equal(t, "验证您的 miniblog 邮箱地址", msg.Subject)
+
+	_, err = Render("not-exist", nil)
+	assert.Error(t, err)
+}
+
+func TestNewMailer_InvalidDriver(t *testing.T) {
+	_, err := NewMailer(&Options{Driver: "pigeon"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+// 定义内置的邮件模板名称.
+const (
+	// TemplatePasswordReset 是密码重置邮件模板.
+	TemplatePasswordReset = "password_reset"
+	// TemplateEmailVerification 是邮箱验证邮件模板.
+	TemplateEmailVerification = "email_verification"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// mailTemplate 是一个邮件模板文件解析后的结果.
+// 每个模板文件中都需要定义 subject、text、html 三个模板块，
+// subject 和 text 使用 text/template 渲染，html 使用 html/template 渲染以便对数据进行转义.
+type mailTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// templates 保存所有内置模板，由于各模板文件中的模板块同名，所以需要分别解析.
+var templates = mustLoadTemplates()
+
+// Render 使用名为 name 的模板渲染邮件，name 不包含 .tmpl 后缀.
+func Render(name string, data any, to ...string) (*Message, error) {
+	tmpl, ok := templates[name]
+	if !ok {
+		return nil, fmt.Errorf("mail template %q not found", name)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := tmpl.text.ExecuteTemplate(&text, "text", data); err != nil {
+		return nil, err
+	}
+	if err := tmpl.html.ExecuteTemplate(&html, "html", data); err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()),
+		HTML:    strings.TrimSpace(html.String()),
+	}, nil
+}
+
+// mustLoadTemplates 解析 templates 目录下的所有模板文件.
+func mustLoadTemplates() map[string]*mailTemplate {
+	files, err := fs.Glob(templateFS, "templates/*.tmpl")
+	if err != nil {
+		panic(err)
+	}
+
+	result := make(map[string]*mailTemplate, len(files))
+	for _, file := range files {
+		name := strings.TrimSuffix(path.Base(file), ".tmpl")
+		result[name] = &mailTemplate{
+			text: texttemplate.Must(texttemplate.ParseFS(templateFS, file)),
+			html: htmltemplate.Must(htmltemplate.ParseFS(templateFS, file)),
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package gin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/redact"
+	"github.com/gin-gonic/gin"
+)
+
+// maxAuditBodySize 是审计日志中记录的请求体和错误响应体的最大字节数.
+const maxAuditBodySize = 64 << 10
+
+// Auditor 用于记录审计事件，请求 ID、操作者和客户端 IP 等信息由实现从上下文中补全.
+type Auditor interface {
+	Record(ctx context.Context, event *model.AuditEventM)
+}
+
+// AuditMiddleware 是一个 Gin 中间件，用于为请求记录审计事件.
+// 只读请求（GET、HEAD）不会被记录，模拟登录期间的请求除外.
+// 审计事件包括操作者、路由、目标资源 ID、请求 ID、客户端 IP、请求结果和错误原因，
+// 请求体中的密码、令牌等敏感字段会被脱敏后记录.
+func AuditMiddleware(auditor Auditor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			c.Next()
+			return
+		}
+
+		// 只记录长度已知且不超过上限的请求体，避免截断请求体影响后续处理
+		var body []byte
+		if c.Request.ContentLength > 0 && c.Request.ContentLength <= maxAuditBodySize {
+			body, _ = io.ReadAll(c.Request.Body)
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		writer := &auditResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		// 认证中间件会替换请求的上下文，所以需要在请求处理完成之后再获取
+		ctx := c.Request.Context()
+		if readOnly(c.Request.Method) && !contextx.Impersonating(ctx) {
+			return
+		}
+
+		payload, _ := redact.JSON(body).(map[string]any)
+		event := &model.AuditEventM{
+			Action:   c.Request.Method + " " + route,
+			Resource: auditTargets(c.Params, payload),
+			Outcome:  known.AuditOutcomeSuccess,
+		}
+
+		code := writer.Status()
+		if code >= http.StatusBadRequest {
+			event.Outcome = known.AuditOutcomeFailure
+			var errResp struct {
+				Reason string `json:"reason"`
+			}
+			_ = json.Unmarshal(writer.body.Bytes(), &errResp)
+			event.Reason = errResp.Reason
+		}
+
+		detail, _ := json.Marshal(map[string]any{"code": code, "request": payload})
+		event.Detail = string(detail)
+
+		auditor.Record(ctx, event)
+	}
+}
+
+// auditResponseWriter 在写入响应的同时缓存错误响应体，用于提取错误原因.
+type auditResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write 写入响应体，状态码表示请求失败时同时缓存响应体.
+func (w *auditResponseWriter) Write(data []byte) (int, error) {
+	if w.Status() >= http.StatusBadRequest && w.body.Len()+len(data) <= maxAuditBodySize {
+		w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// readOnly 判断 HTTP 方法是否为只读方法.
+func readOnly(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// auditTargets 从路由参数和请求体中提取目标资源 ID，格式为 user:<userID>,post:<postID>.
+func auditTargets(params gin.Params, payload map[string]any) string {
+	var targets []string
+	add := func(prefix string, value any) {
+		switch typed := value.(type) {
+		case string:
+			if typed != "" {
+				targets = append(targets, prefix+typed)
+			}
+		case []any:
+			for _, v := range typed {
+				if id, ok := v.(string); ok && id != "" {
+					targets = append(targets, prefix+id)
+				}
+			}
+		}
+	}
+
+	for _, target := range []struct{ key, prefix string }{
+		{"userID", known.ResourceUserPrefix},
+		{"postID", known.ResourcePostPrefix},
+		{"postIDs", known.ResourcePostPrefix},
+	} {
+		if value, ok := params.Get(target.key); ok {
+			add(target.prefix, value)
+		} else {
+			add(target.prefix, payload[target.key])
+		}
+	}
+
+	resource := strings.Join(targets, ",")
+	if len(resource) > 255 {
+		resource = resource[:255]
+	}
+	return resource
+}
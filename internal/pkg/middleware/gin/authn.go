@@ -8,6 +8,7 @@ package gin
 
 import (
 	"context"
+	"crypto/tls"
 
 	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
 	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
@@ -24,28 +25,58 @@ type UserRetriever interface {
 	GetUser(ctx context.Context, userID string) (*model.UserM, error)
 }
 
+// PrincipalMapper 用于将客户端证书映射为服务主体的接口.
+type PrincipalMapper interface {
+	// Principal 返回 TLS 连接中客户端证书对应的服务主体
+	Principal(state *tls.ConnectionState) (string, bool)
+}
+
 // AuthnMiddleware 是一个认证中间件，用于从 gin.Context 中提取 token 并验证 token 是否有效.
-func AuthnMiddleware(retriever UserRetriever) gin.HandlerFunc {
+// 请求没有携带令牌，但客户端证书可以映射为服务主体时，以服务主体的身份继续处理请求.
+func AuthnMiddleware(retriever UserRetriever, mapper PrincipalMapper) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if principal, ok := mapper.Principal(c.Request.TLS); ok {
+				log.Debugw("Client certificate authentication successful", "principal", principal)
+
+				ctx := contextx.WithUserID(c.Request.Context(), principal)
+				ctx = contextx.WithUsername(ctx, principal)
+				c.Request = c.Request.WithContext(ctx)
+
+				c.Next()
+				return
+			}
+		}
+
 		// 解析 JWT Token
-		userID, err := token.ParseRequest(c)
+		userID, actorID, err := token.ParseRequestWithActor(c)
 		if err != nil {
-			core.WriteResponse(c, nil, errno.ErrTokenInvalid.WithMessage(err.Error()))
+			core.WriteResponse(c, nil, errno.WithMessage(errno.ErrTokenInvalid, "%v", err))
 			c.Abort()
 			return
 		}
 
-		log.Debugw("Token parsing successful", "userID", userID)
+		log.Debugw("Token parsing successful", "userID", userID, "actorID", actorID)
 
 		user, err := retriever.GetUser(c, userID)
 		if err != nil {
-			core.WriteResponse(c, nil, errno.ErrUserNotFound.WithMessage(err.Error()))
+			core.WriteResponse(c, nil, errno.WithMessage(errno.ErrUserNotFound, "%v", err))
 			c.Abort()
 			return
 		}
 
 		ctx := contextx.WithUserID(c.Request.Context(), user.UserID)
 		ctx = contextx.WithUsername(ctx, user.Username)
+
+		// 模拟登录令牌需要确认实际操作者仍然存在
+		if actorID != "" {
+			if _, err := retriever.GetUser(c, actorID); err != nil {
+				core.WriteResponse(c, nil, errno.WithMessage(errno.ErrUserNotFound, "%v", err))
+				c.Abort()
+				return
+			}
+			ctx = contextx.WithActorID(ctx, actorID)
+		}
 		c.Request = c.Request.WithContext(ctx)
 
 		c.Next()
@@ -7,8 +7,12 @@
 package gin
 
 import (
+	"strings"
+
 	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
 	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/permission"
 	"github.com/gin-gonic/gin"
 	"github.com/onexstack/onexstack/pkg/core"
 	"github.com/onexstack/onexstack/pkg/log"
@@ -16,23 +20,46 @@ import (
 
 // Authorizer 用于定义授权接口的实现.
 type Authorizer interface {
-	Authorize(subject, object, action string) (bool, error)
+	Authorize(subject, domain, object, action string) (bool, error)
+	// AuthorizeExplicit 默认拒绝，只有命中 allow 策略时才允许，用于服务主体
+	AuthorizeExplicit(subject, domain, object, action string) (bool, error)
+}
+
+// PermissionResolver 用于将 HTTP 路由解析为权限标识.
+type PermissionResolver interface {
+	ForRoute(method, path string) (string, bool)
 }
 
 // AuthzMiddleware 是一个 Gin 中间件，用于进行请求授权.
-func AuthzMiddleware(authorizer Authorizer) gin.HandlerFunc {
+// 请求的 HTTP 方法和路由模板会被解析为权限标识，与 gRPC 请求使用同一套策略，请求所在的租户作为策略的租户.
+// 没有注册权限标识的路由一律拒绝访问，避免新增路由时遗漏授权.
+func AuthzMiddleware(authorizer Authorizer, resolver PermissionResolver) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		subject := contextx.UserID(c.Request.Context())
-		object := c.Request.URL.Path
-		action := c.Request.Method
+		domain := contextx.TenantID(c.Request.Context())
+		action := permission.Action
+		object, ok := resolver.ForRoute(c.Request.Method, c.FullPath())
+		if !ok {
+			log.Warnw("No permission registered for route", "method", c.Request.Method, "route", c.FullPath())
+			core.WriteResponse(c, nil, errno.WithMessage(errno.ErrPermissionDenied,
+				"access denied: no permission registered for %s %s", c.Request.Method, c.FullPath()))
+			c.Abort()
+			return
+		}
 
 		// 记录授权上下文信息
-		log.Debugw("Build authorize context", "subject", subject, "object", object, "action", action)
+		log.Debugw("Build authorize context", "subject", subject, "domain", domain, "object", object, "action", action)
 		// 调用授权接口进行验证
-		if allowed, err := authorizer.Authorize(subject, object, action); err != nil || !allowed {
-			core.WriteResponse(c, nil, errno.ErrPermissionDenied.WithMessage(
-				"access denied: subject=%s, object=%s, action=%s, reason=%v",
+		// 服务主体默认没有任何权限，需要为其（或者其角色）配置 allow 策略
+		authorize := authorizer.Authorize
+		if strings.HasPrefix(subject, known.ServicePrincipalPrefix) {
+			authorize = authorizer.AuthorizeExplicit
+		}
+		if allowed, err := authorize(subject, domain, object, action); err != nil || !allowed {
+			core.WriteResponse(c, nil, errno.WithMessage(errno.ErrPermissionDenied,
+				"access denied: subject=%s, domain=%s, object=%s, action=%s, reason=%v",
 				subject,
+				domain,
 				object,
 				action,
 				err,
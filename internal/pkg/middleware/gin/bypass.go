@@ -8,27 +8,37 @@ package gin
 
 import (
 	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
-	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
 	"github.com/gin-gonic/gin"
+	"github.com/onexstack/onexstack/pkg/core"
 	"github.com/onexstack/onexstack/pkg/log"
 )
 
-// 用于从 gin.Context 的 Header 中提取用户 ID，模拟所有请求认证通过.
-func AuthnBypassMiddleware() gin.HandlerFunc {
+// AuthnBypassMiddleware 是开发认证模式使用的认证中间件，不校验令牌，直接信任请求头 header 中的用户 ID.
+// 该中间件只能用于本地开发，由 devauth 选项开启，服务器只允许监听本地回环地址.
+func AuthnBypassMiddleware(header string, retriever UserRetriever) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// 从 Header 中提取用户 ID，假设请求头名称为 "X-User-ID"
-		userID := "user-000001" // 默认用户 ID
-		if val := c.GetHeader(known.XUserID); val != "" {
-			userID = val
+		userID := c.GetHeader(header)
+		if userID == "" {
+			core.WriteResponse(c, nil, errno.WithMessage(errno.ErrUnauthenticated, "dev-auth: missing %s header", header))
+			c.Abort()
+			return
 		}
 
-		log.Debugw("Simulated authentication bypass", "userID", userID)
+		user, err := retriever.GetUser(c, userID)
+		if err != nil {
+			core.WriteResponse(c, nil, errno.WithMessage(errno.ErrUserNotFound, "%v", err))
+			c.Abort()
+			return
+		}
+
+		log.Debugw("Dev authentication bypass", "userID", user.UserID, "username", user.Username)
 
 		// 将用户 ID 和用户名注入到上下文中
-		ctx := contextx.WithUserID(c.Request.Context(), userID)
+		ctx := contextx.WithUserID(c.Request.Context(), user.UserID)
+		ctx = contextx.WithUsername(ctx, user.Username)
 		c.Request = c.Request.WithContext(ctx)
 
-		// 继续后续的操作
 		c.Next()
 	}
 }
@@ -0,0 +1,23 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package gin
+
+import (
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/gin-gonic/gin"
+)
+
+// ClientIPMiddleware 将客户端 IP 保存到 context.Context 中.
+// 客户端 IP 由 gin 根据可信代理配置解析 X-Forwarded-For 等请求头得到.
+func ClientIPMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := contextx.WithClientIP(c.Request.Context(), c.ClientIP())
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
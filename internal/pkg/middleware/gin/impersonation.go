@@ -0,0 +1,39 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package gin
+
+import (
+	"slices"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/gin-gonic/gin"
+	"github.com/onexstack/onexstack/pkg/core"
+	"github.com/onexstack/onexstack/pkg/log"
+)
+
+// ImpersonationMiddleware 是一个 Gin 中间件，用于约束模拟登录请求.
+// 模拟登录时禁止访问 forbidden 中列出的权限标识对应的路由，非模拟登录请求直接放行.
+// 模拟登录期间的每个请求都会由审计中间件记录.
+func ImpersonationMiddleware(resolver PermissionResolver, forbidden []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if !contextx.Impersonating(ctx) {
+			c.Next()
+			return
+		}
+
+		if object, _ := resolver.ForRoute(c.Request.Method, c.FullPath()); slices.Contains(forbidden, object) {
+			log.Warnw("Blocked sensitive operation while impersonating", "actorID", contextx.ActorID(ctx), "userID", contextx.UserID(ctx), "route", c.FullPath())
+			core.WriteResponse(c, nil, errno.ErrImpersonationForbidden)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package gin
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/onexstack/onexstack/pkg/core"
+	"github.com/onexstack/onexstack/pkg/log"
+)
+
+// RateLimiter 用于对请求进行限流的接口.
+type RateLimiter interface {
+	Allow(ctx context.Context, permission string, id ratelimit.Identity) (*ratelimit.Result, error)
+}
+
+// RateLimitMiddleware 是一个 Gin 中间件，用于按权限标识对请求进行限流.
+// 中间件需要在认证中间件之后加载，才能按用户 ID 计数；不需要认证的接口按客户端 IP 或者 API 令牌计数.
+// 限流后端出错时放行请求，避免限流组件故障导致服务不可用.
+func RateLimitMiddleware(limiter RateLimiter, resolver PermissionResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		perm, ok := resolver.ForRoute(c.Request.Method, c.FullPath())
+		if !ok {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		result, err := limiter.Allow(ctx, perm, ratelimit.Identity{
+			IP:     contextx.ClientIP(ctx),
+			UserID: contextx.UserID(ctx),
+			Token:  c.GetHeader("Authorization"),
+		})
+		if err != nil {
+			log.Errorw(err, "Failed to check rate limit, request is allowed", "permission", perm)
+			c.Next()
+			return
+		}
+		if result == nil {
+			c.Next()
+			return
+		}
+
+		for key, value := range result.Headers() {
+			c.Header(key, value)
+		}
+		if !result.Allowed {
+			log.Warnw("Request rejected by rate limiter", "permission", perm, "clientIP", contextx.ClientIP(ctx), "userID", contextx.UserID(ctx))
+			// 复制一份错误再设置元数据，避免并发修改全局错误变量
+			err := *errno.ErrTooManyRequests
+			core.WriteResponse(c, nil, err.KV("retryAfter", strconv.Itoa(result.RetryAfterSeconds())))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
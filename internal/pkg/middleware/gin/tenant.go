@@ -0,0 +1,54 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package gin
+
+import (
+	"context"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/gin-gonic/gin"
+	"github.com/onexstack/onexstack/pkg/core"
+	"github.com/onexstack/onexstack/pkg/log"
+)
+
+// TenantResolver 用于从请求中解析租户.
+type TenantResolver interface {
+	// Header 返回用于指定租户的请求头名称
+	Header() string
+	// Resolve 根据请求头的值和主机名解析租户，返回空字符串表示使用默认租户
+	Resolve(headerValue, host string) string
+}
+
+// TenantRetriever 用于将请求中指定的租户转换为组织 ID，并校验用户是否可以访问该租户.
+type TenantRetriever interface {
+	GetTenant(ctx context.Context, userID, tenant string) (string, error)
+}
+
+// TenantMiddleware 是一个 Gin 中间件，用于解析请求所在的租户.
+// 需要在认证中间件之后、授权中间件之前执行，授权和数据访问都会使用解析出的租户.
+// 请求没有指定租户时使用默认租户.
+func TenantMiddleware(resolver TenantResolver, retriever TenantRetriever) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		tenantID := known.DefaultTenantID
+		if tenant := resolver.Resolve(c.GetHeader(resolver.Header()), c.Request.Host); tenant != "" {
+			orgID, err := retriever.GetTenant(ctx, contextx.UserID(ctx), tenant)
+			if err != nil {
+				log.Warnw("Failed to resolve tenant", "tenant", tenant, "userID", contextx.UserID(ctx), "err", err)
+				core.WriteResponse(c, nil, err)
+				c.Abort()
+				return
+			}
+			tenantID = orgID
+		}
+
+		c.Request = c.Request.WithContext(contextx.WithTenantID(ctx, tenantID))
+		c.Next()
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/redact"
+	"github.com/onexstack/onexstack/pkg/errorsx"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Auditor 用于记录审计事件，请求 ID、操作者和客户端 IP 等信息由实现从上下文中补全.
+type Auditor interface {
+	Record(ctx context.Context, event *model.AuditEventM)
+}
+
+// ReadOnlyResolver 用于判断 gRPC 方法是否为只读方法.
+type ReadOnlyResolver interface {
+	ReadOnly(fullMethod string) bool
+}
+
+// AuditInterceptor 是一个 gRPC 拦截器，用于为请求记录审计事件.
+// 只读方法不会被记录，模拟登录期间的请求除外. 拦截器需要放在认证拦截器之后，才能获取到操作者.
+// 审计事件包括操作者、方法名、目标资源 ID、请求 ID、客户端 IP、请求结果和错误原因，
+// 请求中的密码、令牌等敏感字段会被脱敏后记录.
+func AuditInterceptor(auditor Auditor, resolver ReadOnlyResolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+
+		if resolver.ReadOnly(info.FullMethod) && !contextx.Impersonating(ctx) {
+			return resp, err
+		}
+
+		var payload map[string]any
+		if msg, ok := req.(proto.Message); ok {
+			data, _ := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+			payload, _ = redact.JSON(data).(map[string]any)
+		}
+
+		event := &model.AuditEventM{
+			Action:   info.FullMethod,
+			Resource: auditTargets(payload),
+			Outcome:  known.AuditOutcomeSuccess,
+		}
+
+		code := http.StatusOK
+		if err != nil {
+			errx := errorsx.FromError(err)
+			code = errx.Code
+			event.Outcome = known.AuditOutcomeFailure
+			event.Reason = errx.Reason
+		}
+
+		detail, _ := json.Marshal(map[string]any{"code": code, "request": payload})
+		event.Detail = string(detail)
+
+		auditor.Record(ctx, event)
+
+		return resp, err
+	}
+}
+
+// auditTargets 从请求中提取目标资源 ID，格式为 user:<userID>,post:<postID>.
+func auditTargets(payload map[string]any) string {
+	var targets []string
+	for _, target := range []struct{ key, prefix string }{
+		{"userID", known.ResourceUserPrefix},
+		{"postID", known.ResourcePostPrefix},
+		{"postIDs", known.ResourcePostPrefix},
+	} {
+		switch typed := payload[target.key].(type) {
+		case string:
+			if typed != "" {
+				targets = append(targets, target.prefix+typed)
+			}
+		case []any:
+			for _, v := range typed {
+				if id, ok := v.(string); ok && id != "" {
+					targets = append(targets, target.prefix+id)
+				}
+			}
+		}
+	}
+
+	resource := strings.Join(targets, ",")
+	if len(resource) > 255 {
+		resource = resource[:255]
+	}
+	return resource
+}
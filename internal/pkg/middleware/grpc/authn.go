@@ -8,6 +8,7 @@ package grpc
 
 import (
 	"context"
+	"crypto/tls"
 
 	"github.com/TobyIcetea/miniblog/internal/apiserver/model"
 	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
@@ -16,6 +17,9 @@ import (
 	"github.com/TobyIcetea/miniblog/pkg/token"
 	"github.com/go-kratos/kratos/v2/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 // UserRetriever 用于根据用户名获取用户信息的接口.
@@ -24,21 +28,44 @@ type UserRetriever interface {
 	GetUser(ctx context.Context, usreID string) (*model.UserM, error)
 }
 
+// PrincipalMapper 用于将客户端证书映射为服务主体的接口.
+type PrincipalMapper interface {
+	// Principal 返回 TLS 连接中客户端证书对应的服务主体
+	Principal(state *tls.ConnectionState) (string, bool)
+}
+
 // AuthnInterceptor 是一个 gRPC 拦截器，用于进行认证.
-func AuthnInterceptor(retriever UserRetriever) grpc.UnaryServerInterceptor {
+// 请求没有携带令牌，但客户端证书可以映射为服务主体时，以服务主体的身份继续处理请求.
+func AuthnInterceptor(retriever UserRetriever, mapper PrincipalMapper) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if principal, ok := servicePrincipal(ctx, mapper); ok {
+			log.Debugw("Client certificate authentication successful", "principal", principal)
+
+			ctx = contextx.WithUserID(ctx, principal)
+			ctx = contextx.WithUsername(ctx, principal)
+			return handler(ctx, req)
+		}
+
 		// 解析 JWT Token
-		userID, err := token.ParseRequest(ctx)
+		userID, actorID, err := token.ParseRequestWithActor(ctx)
 		if err != nil {
 			log.Errorw("Failed to parse request", "err", err)
-			return nil, errno.ErrTokenInvalid.WithMessage(err.Error())
+			return nil, errno.WithMessage(errno.ErrTokenInvalid, "%v", err)
 		}
 
-		log.Debugw("Token parsing successful", "userID", userID)
+		log.Debugw("Token parsing successful", "userID", userID, "actorID", actorID)
 
 		user, err := retriever.GetUser(ctx, userID)
 		if err != nil {
-			return nil, errno.ErrUnauthenticated.WithMessage(err.Error())
+			return nil, errno.WithMessage(errno.ErrUnauthenticated, "%v", err)
+		}
+
+		// 模拟登录令牌需要确认实际操作者仍然存在
+		if actorID != "" {
+			if _, err := retriever.GetUser(ctx, actorID); err != nil {
+				return nil, errno.WithMessage(errno.ErrUnauthenticated, "%v", err)
+			}
+			ctx = contextx.WithActorID(ctx, actorID)
 		}
 
 		// 将用户信息存入上下文
@@ -53,3 +80,21 @@ func AuthnInterceptor(retriever UserRetriever) grpc.UnaryServerInterceptor {
 		return handler(ctx, req)
 	}
 }
+
+// servicePrincipal 在请求没有携带令牌时，返回客户端证书对应的服务主体.
+func servicePrincipal(ctx context.Context, mapper PrincipalMapper) (string, bool) {
+	if md, _ := metadata.FromIncomingContext(ctx); len(md.Get("authorization")) > 0 {
+		return "", false
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return "", false
+	}
+
+	return mapper.Principal(&tlsInfo.State)
+}
@@ -8,33 +8,56 @@ package grpc
 
 import (
 	"context"
+	"strings"
 
 	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
 	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/permission"
 	"github.com/go-kratos/kratos/v2/log"
 	"google.golang.org/grpc"
 )
 
 // Authorizer 用于定义授权接口的实现.
 type Authorizer interface {
-	Authorize(subject, object, action string) (bool, error)
+	Authorize(subject, domain, object, action string) (bool, error)
+	// AuthorizeExplicit 默认拒绝，只有命中 allow 策略时才允许，用于服务主体
+	AuthorizeExplicit(subject, domain, object, action string) (bool, error)
+}
+
+// PermissionResolver 用于将 gRPC 方法解析为权限标识.
+type PermissionResolver interface {
+	ForMethod(fullMethod string) (string, bool)
 }
 
 // AuthzInterceptor 是一个 gRPC 拦截器，用于进行请求授权.
-func AuthzInterceptor(authorizer Authorizer) grpc.UnaryServerInterceptor {
+// 请求的方法会被解析为权限标识，与 HTTP 请求使用同一套策略，请求所在的租户作为策略的租户.
+// 没有注册权限标识的方法一律拒绝访问，避免新增方法时遗漏授权.
+func AuthzInterceptor(authorizer Authorizer, resolver PermissionResolver) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		subject := contextx.UserID(ctx) // 获取用户 ID
-		object := info.FullMethod       // 获取请求资源
-		action := "CALL"                // 默认操作
+		subject := contextx.UserID(ctx)  // 获取用户 ID
+		domain := contextx.TenantID(ctx) // 获取租户 ID
+		action := permission.Action      // 默认操作
+		object, ok := resolver.ForMethod(info.FullMethod)
+		if !ok {
+			log.Warnw("No permission registered for method", "method", info.FullMethod)
+			return nil, errno.WithMessage(errno.ErrPermissionDenied, "access denied: no permission registered for %s", info.FullMethod)
+		}
 
 		// 记录授权上下文信息
-		log.Debugw("Build authorize context", "subject", subject, "object", object, "action", action)
+		log.Debugw("Build authorize context", "subject", subject, "domain", domain, "object", object, "action", action)
 
 		// 调用授权接口进行验证
-		if allowed, err := authorizer.Authorize(subject, object, action); err != nil || !allowed {
-			return nil, errno.ErrPermissionDenied.WithMessage(
-				"access denied: subject=%s, object=%s, action=%s, reason=%v",
+		// 服务主体默认没有任何权限，需要为其（或者其角色）配置 allow 策略
+		authorize := authorizer.Authorize
+		if strings.HasPrefix(subject, known.ServicePrincipalPrefix) {
+			authorize = authorizer.AuthorizeExplicit
+		}
+		if allowed, err := authorize(subject, domain, object, action); err != nil || !allowed {
+			return nil, errno.WithMessage(errno.ErrPermissionDenied,
+				"access denied: subject=%s, domain=%s, object=%s, action=%s, reason=%v",
 				subject,
+				domain,
 				object,
 				action,
 				err,
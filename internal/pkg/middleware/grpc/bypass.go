@@ -8,35 +8,46 @@ package grpc
 
 import (
 	"context"
+	"strings"
 
 	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
 	"github.com/TobyIcetea/miniblog/internal/pkg/known"
 	"github.com/go-kratos/kratos/v2/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
-// AuthnBypassInterceptor 是一个 gRPC 拦截器，模拟所有请求都通过认证.
-func AuthnBypassInterceptor() grpc.UnaryServerInterceptor {
+// AuthnBypassInterceptor 是开发认证模式使用的认证拦截器，不校验令牌，直接信任元数据 header 中的用户 ID.
+// 该拦截器只能用于本地开发，由 devauth 选项开启，服务器只允许监听本地回环地址.
+func AuthnBypassInterceptor(header string, retriever UserRetriever) grpc.UnaryServerInterceptor {
+	// gRPC 元数据的键都是小写的
+	header = strings.ToLower(header)
+
 	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		// 从请求头中获取用户 ID
-		userID := "user-000001" // 默认用户 ID
-		if md, ok := metadata.FromIncomingContext(ctx); ok {
-			// 获取 header 中指定的用户 ID，假设 Header 名为 "x-user-id"
-			if values := md.Get(known.XUserID); len(values) > 0 {
-				userID = values[0]
-			}
+		var userID string
+		if values := metadata.ValueFromIncomingContext(ctx, header); len(values) > 0 {
+			userID = values[0]
+		}
+		if userID == "" {
+			return nil, errno.WithMessage(errno.ErrUnauthenticated, "dev-auth: missing %s metadata", header)
+		}
+
+		user, err := retriever.GetUser(ctx, userID)
+		if err != nil {
+			return nil, errno.WithMessage(errno.ErrUnauthenticated, "%v", err)
 		}
 
-		log.Debugw("Simulated authentication bypass", "userID", userID)
+		log.Debugw("Dev authentication bypass", "userID", user.UserID, "username", user.Username)
 
-		// 将默认的用户信息存入上下文
-		ctx = context.WithValue(ctx, known.XUserID, userID)
+		// 将用户信息存入上下文
+		ctx = context.WithValue(ctx, known.XUsername, user.Username)
+		ctx = context.WithValue(ctx, known.XUserID, user.UserID)
 
-		// 为 log 和 contextx 提供用户上下文支持
-		ctx = contextx.WithUserID(ctx, userID)
+		// 供 log 和 contextx 使用
+		ctx = contextx.WithUserID(ctx, user.UserID)
+		ctx = contextx.WithUsername(ctx, user.Username)
 
-		// 继续处理请求
 		return handler(ctx, req)
 	}
 }
@@ -0,0 +1,99 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// xForwardedFor 是 grpc-gateway 转发请求时携带客户端地址的元数据键.
+const xForwardedFor = "x-forwarded-for"
+
+// ClientIPInterceptor 是一个 gRPC 拦截器，用于将客户端 IP 保存到上下文中.
+// 只有当请求来自本机（即同进程的 grpc-gateway）时才信任 x-forwarded-for，
+// 避免外部 gRPC 客户端伪造来源地址.
+// grpc-gateway 会把它看到的 HTTP 对端地址追加到 x-forwarded-for 末尾，之前的地址都由 HTTP 客户端提供，
+// 因此从最后一个地址开始向前查找，跳过 trustedProxies 中的可信代理，返回第一个不可信的地址.
+// trustedProxies 中的每一项是 IP 或者 CIDR，不合法的项会被忽略，为空时只使用最后一个地址.
+func ClientIPInterceptor(trustedProxies ...string) grpc.UnaryServerInterceptor {
+	trusted := parseTrustedProxies(trustedProxies)
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(contextx.WithClientIP(ctx, clientIP(ctx, trusted)), req)
+	}
+}
+
+// clientIP 从 gRPC 上下文中解析客户端 IP.
+func clientIP(ctx context.Context, trusted []*net.IPNet) string {
+	var ip string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+
+	if parsed := net.ParseIP(ip); parsed == nil || !parsed.IsLoopback() {
+		return ip
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(xForwardedFor)
+	if len(values) == 0 {
+		return ip
+	}
+
+	// x-forwarded-for 的格式为 "client, proxy1, proxy2"，只有最后一个地址是 grpc-gateway 追加的
+	forwarded := strings.Split(strings.Join(values, ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		addr := net.ParseIP(strings.TrimSpace(forwarded[i]))
+		if addr == nil {
+			break
+		}
+		ip = addr.String()
+		if i == 0 || !isTrusted(addr, trusted) {
+			break
+		}
+	}
+
+	return ip
+}
+
+// parseTrustedProxies 将 IP 或者 CIDR 转换为网段，单个 IP 转换为只包含该地址的网段.
+func parseTrustedProxies(proxies []string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if ip := net.ParseIP(proxy); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		if _, ipNet, err := net.ParseCIDR(proxy); err == nil {
+			nets = append(nets, ipNet)
+		}
+	}
+	return nets
+}
+
+// isTrusted 判断 ip 是否属于可信代理.
+func isTrusted(ip net.IP, trusted []*net.IPNet) bool {
+	for _, ipNet := range trusted {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
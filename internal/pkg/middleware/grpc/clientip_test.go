@@ -0,0 +1,49 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name    string
+		peer    string
+		xff     []string
+		trusted []string
+		want    string
+	}{
+		{name: "remote peer ignores forwarded", peer: "203.0.113.7:4000", xff: []string{"198.51.100.1"}, want: "203.0.113.7"},
+		{name: "gateway without forwarded", peer: "127.0.0.1:4000", want: "127.0.0.1"},
+		{name: "gateway appended address", peer: "127.0.0.1:4000", xff: []string{"198.51.100.1"}, want: "198.51.100.1"},
+		{name: "spoofed forwarded uses last address", peer: "127.0.0.1:4000", xff: []string{"1.2.3.4, 198.51.100.1"}, want: "198.51.100.1"},
+		{name: "spoofed forwarded through untrusted proxy", peer: "[::1]:4000", xff: []string{"1.2.3.4, 198.51.100.1, 10.0.0.2"}, want: "10.0.0.2"},
+		{name: "trusted proxy", peer: "127.0.0.1:4000", xff: []string{"1.2.3.4, 198.51.100.1, 10.0.0.2"}, trusted: []string{"10.0.0.0/8"}, want: "198.51.100.1"},
+		{name: "all trusted", peer: "127.0.0.1:4000", xff: []string{"10.0.0.3, 10.0.0.2"}, trusted: []string{"10.0.0.2", "10.0.0.3"}, want: "10.0.0.3"},
+		{name: "invalid forwarded", peer: "127.0.0.1:4000", xff: []string{"not-an-ip"}, want: "127.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := net.ResolveTCPAddr("tcp", tt.peer)
+			assert.NoError(t, err)
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+			if len(tt.xff) > 0 {
+				ctx = metadata.NewIncomingContext(ctx, metadata.MD{xForwardedFor: tt.xff})
+			}
+
+			assert.Equal(t, tt.want, clientIP(ctx, parseTrustedProxies(tt.trusted)))
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package grpc
+
+import (
+	"context"
+	"slices"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc"
+)
+
+// ImpersonationInterceptor 是一个 gRPC 拦截器，用于约束模拟登录请求.
+// 模拟登录时禁止调用 forbidden 中列出的权限标识对应的方法，非模拟登录请求直接放行.
+// 模拟登录期间的每个请求都会由审计拦截器记录.
+func ImpersonationInterceptor(resolver PermissionResolver, forbidden []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !contextx.Impersonating(ctx) {
+			return handler(ctx, req)
+		}
+
+		if object, _ := resolver.ForMethod(info.FullMethod); slices.Contains(forbidden, object) {
+			log.Warnw("Blocked sensitive operation while impersonating", "actorID", contextx.ActorID(ctx), "userID", contextx.UserID(ctx), "method", info.FullMethod)
+			return nil, errno.ErrImpersonationForbidden
+		}
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package grpc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/ratelimit"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RateLimiter 用于对请求进行限流的接口.
+type RateLimiter interface {
+	Allow(ctx context.Context, permission string, id ratelimit.Identity) (*ratelimit.Result, error)
+}
+
+// MethodResolver 用于将 gRPC 方法解析为权限标识.
+type MethodResolver interface {
+	ForMethod(fullMethod string) (string, bool)
+}
+
+// RateLimitInterceptor 是一个 gRPC 一元拦截器，用于按权限标识对请求进行限流.
+// 拦截器需要在认证拦截器之后加载，才能按用户 ID 计数.
+func RateLimitInterceptor(limiter RateLimiter, resolver MethodResolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := checkRateLimit(ctx, limiter, resolver, info.FullMethod, func(md metadata.MD) error {
+			return grpc.SetHeader(ctx, md)
+		}); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// RateLimitStreamInterceptor 是一个 gRPC 流式拦截器，在建立流时按权限标识进行限流.
+func RateLimitStreamInterceptor(limiter RateLimiter, resolver MethodResolver) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkRateLimit(ss.Context(), limiter, resolver, info.FullMethod, ss.SetHeader); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// checkRateLimit 检查请求是否被限流，并通过 setHeader 返回限流相关的响应头.
+// 限流后端出错时放行请求，避免限流组件故障导致服务不可用.
+func checkRateLimit(ctx context.Context, limiter RateLimiter, resolver MethodResolver, fullMethod string, setHeader func(metadata.MD) error) error {
+	perm, ok := resolver.ForMethod(fullMethod)
+	if !ok {
+		return nil
+	}
+
+	// 流式请求不经过一元拦截器，上下文中可能没有客户端 IP
+	ip := contextx.ClientIP(ctx)
+	if ip == "" {
+		ip = clientIP(ctx, nil)
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	result, err := limiter.Allow(ctx, perm, ratelimit.Identity{
+		IP:     ip,
+		UserID: contextx.UserID(ctx),
+		Token:  strings.Join(md.Get("authorization"), ","),
+	})
+	if err != nil {
+		log.Errorw("Failed to check rate limit, request is allowed", "permission", perm, "err", err)
+		return nil
+	}
+	if result == nil {
+		return nil
+	}
+
+	header := metadata.MD{}
+	for key, value := range result.Headers() {
+		header.Set(key, value)
+	}
+	_ = setHeader(header)
+
+	if !result.Allowed {
+		log.Warnw("Request rejected by rate limiter", "permission", perm, "clientIP", ip, "userID", contextx.UserID(ctx))
+		return tooManyRequests(result)
+	}
+	return nil
+}
+
+// tooManyRequests 返回 ResourceExhausted 错误，并在错误详情中携带重试信息.
+func tooManyRequests(result *ratelimit.Result) error {
+	retryAfter := strconv.Itoa(result.RetryAfterSeconds())
+	st, err := status.New(codes.ResourceExhausted, errno.ErrTooManyRequests.Message).WithDetails(
+		&errdetails.ErrorInfo{Reason: errno.ErrTooManyRequests.Reason, Metadata: map[string]string{"retryAfter": retryAfter}},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(result.RetryAfter)},
+	)
+	if err != nil {
+		err := *errno.ErrTooManyRequests
+		return err.KV("retryAfter", retryAfter)
+	}
+	return st.Err()
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TenantResolver 用于从请求中解析租户.
+type TenantResolver interface {
+	// Header 返回用于指定租户的请求头名称
+	Header() string
+	// Resolve 根据请求头的值和主机名解析租户，返回空字符串表示使用默认租户
+	Resolve(headerValue, host string) string
+}
+
+// TenantRetriever 用于将请求中指定的租户转换为组织 ID，并校验用户是否可以访问该租户.
+type TenantRetriever interface {
+	GetTenant(ctx context.Context, userID, tenant string) (string, error)
+}
+
+// TenantInterceptor 是一个 gRPC 拦截器，用于解析请求所在的租户.
+// 需要在认证拦截器之后、授权拦截器之前执行，授权和数据访问都会使用解析出的租户.
+// 经过 gRPC-Gateway 转发的请求使用 x-forwarded-host 作为主机名，请求没有指定租户时使用默认租户.
+func TenantInterceptor(resolver TenantResolver, retriever TenantRetriever) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		tenantID := known.DefaultTenantID
+		if tenant := resolver.Resolve(firstValue(md, strings.ToLower(resolver.Header())), requestHost(md)); tenant != "" {
+			orgID, err := retriever.GetTenant(ctx, contextx.UserID(ctx), tenant)
+			if err != nil {
+				log.Warnw("Failed to resolve tenant", "tenant", tenant, "userID", contextx.UserID(ctx), "err", err)
+				return nil, err
+			}
+			tenantID = orgID
+		}
+
+		return handler(contextx.WithTenantID(ctx, tenantID), req)
+	}
+}
+
+// requestHost 返回请求的主机名，优先使用 gRPC-Gateway 设置的 x-forwarded-host.
+func requestHost(md metadata.MD) string {
+	if host := firstValue(md, "x-forwarded-host"); host != "" {
+		return host
+	}
+	return firstValue(md, ":authority")
+}
+
+// firstValue 返回元数据中指定键的第一个值.
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
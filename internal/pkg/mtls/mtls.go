@@ -0,0 +1,84 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package mtls 提供双向 TLS 认证的配置，以及将客户端证书映射为服务主体的功能.
+// 服务主体以 service:: 为前缀，可以像用户 ID 一样出现在 casbin 策略中，用于授权服务间调用.
+package mtls
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/google/wire"
+)
+
+// ProviderSet 是 mtls 包的 Wire Provider 集合.
+var ProviderSet = wire.NewSet(NewMapper)
+
+// Mapper 根据配置将通过校验的客户端证书映射为服务主体.
+type Mapper struct {
+	principals map[string]string
+	// gateway 是 gRPC-Gateway 自身客户端证书的 DER 编码，该证书不会被映射为服务主体
+	gateway []byte
+}
+
+// NewMapper 创建一个 Mapper 实例.
+// 配置了 gRPC-Gateway 的客户端证书时，网关转发的所有请求都会携带该证书，
+// 如果将其映射为服务主体，匿名的 HTTP 请求就会以该主体的身份执行，因此该证书始终不会被映射.
+func NewMapper(opts *Options) (*Mapper, error) {
+	m := &Mapper{principals: opts.Principals}
+	if opts.ClientCert == "" {
+		return m, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(opts.ClientCert, opts.ClientKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+	m.gateway = cert.Certificate[0]
+	return m, nil
+}
+
+// Principal 返回 TLS 连接中客户端证书对应的服务主体.
+// 只有通过 CA 校验的证书才会被映射，依次匹配 URI、DNS、EMAIL 类型的 SAN 和证书的 CN.
+// gRPC-Gateway 自身的客户端证书不会被映射.
+func (m *Mapper) Principal(state *tls.ConnectionState) (string, bool) {
+	if m == nil || len(m.principals) == 0 || state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+
+	leaf := state.VerifiedChains[0][0]
+	if m.gateway != nil && bytes.Equal(leaf.Raw, m.gateway) {
+		return "", false
+	}
+
+	for _, key := range certificateKeys(leaf) {
+		if principal, ok := m.principals[key]; ok {
+			return principal, true
+		}
+	}
+	return "", false
+}
+
+// certificateKeys 按匹配优先级返回证书可以用于映射的键.
+func certificateKeys(cert *x509.Certificate) []string {
+	var keys []string
+	for _, uri := range cert.URIs {
+		keys = append(keys, "URI:"+uri.String())
+	}
+	for _, name := range cert.DNSNames {
+		keys = append(keys, "DNS:"+name)
+	}
+	for _, email := range cert.EmailAddresses {
+		keys = append(keys, "EMAIL:"+email)
+	}
+	if cert.Subject.CommonName != "" {
+		keys = append(keys, "CN:"+cert.Subject.CommonName)
+	}
+	return keys
+}
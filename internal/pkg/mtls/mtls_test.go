@@ -0,0 +1,129 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package mtls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	genericoptions "github.com/onexstack/onexstack/pkg/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapperPrincipal(t *testing.T) {
+	spiffe, _ := url.Parse("spiffe://miniblog/reporter")
+	cert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "reporter"},
+		DNSNames: []string{"reporter.internal"},
+		URIs:     []*url.URL{spiffe},
+	}
+	verified := &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+
+	tests := []struct {
+		name       string
+		principals map[string]string
+		state      *tls.ConnectionState
+		want       string
+		wantOK     bool
+	}{
+		{"uri first", map[string]string{"URI:spiffe://miniblog/reporter": "service::uri", "CN:reporter": "service::cn"}, verified, "service::uri", true},
+		{"dns", map[string]string{"DNS:reporter.internal": "service::dns"}, verified, "service::dns", true},
+		{"common name", map[string]string{"CN:reporter": "service::cn"}, verified, "service::cn", true},
+		{"unmapped", map[string]string{"CN:other": "service::other"}, verified, "", false},
+		{"unverified", map[string]string{"CN:reporter": "service::cn"}, &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}, "", false},
+		{"no tls", map[string]string{"CN:reporter": "service::cn"}, nil, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMapper(&Options{Principals: tt.principals})
+			require.NoError(t, err)
+			got, ok := m.Principal(tt.state)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	opts := NewOptions()
+	assert.Empty(t, opts.Validate())
+
+	opts.VerifyMode = VerifyModeRequire
+	opts.Principals = map[string]string{"CN:reporter": "reporter", "SERIAL:1": "service::reporter"}
+	opts.ClientCert = "client.crt"
+	assert.Len(t, opts.Validate(), 4)
+}
+
+func TestMapperIgnoresGatewayCertificate(t *testing.T) {
+	gateway, certFile, keyFile := writeTestCertificate(t, "gateway")
+	other, _, _ := writeTestCertificate(t, "gateway")
+
+	m, err := NewMapper(&Options{Principals: map[string]string{"CN:gateway": "service::gateway"}, ClientCert: certFile, ClientKey: keyFile})
+	require.NoError(t, err)
+
+	// 网关自身的证书不会被映射，即使其 CN 配置了服务主体
+	_, ok := m.Principal(&tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{gateway}}})
+	assert.False(t, ok)
+
+	got, ok := m.Principal(&tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{other}}})
+	assert.True(t, ok)
+	assert.Equal(t, "service::gateway", got)
+
+	_, err = NewMapper(&Options{ClientCert: filepath.Join(t.TempDir(), "missing.crt"), ClientKey: keyFile})
+	assert.Error(t, err)
+}
+
+func TestOptionsValidateTLS(t *testing.T) {
+	tlsOptions := genericoptions.NewTLSOptions()
+	opts := NewOptions()
+	assert.Empty(t, opts.ValidateTLS(tlsOptions))
+
+	opts.VerifyMode = VerifyModeRequire
+	assert.Len(t, opts.ValidateTLS(tlsOptions), 1)
+
+	tlsOptions.UseTLS = true
+	assert.Empty(t, opts.ValidateTLS(tlsOptions))
+}
+
+// writeTestCertificate 生成一个自签名证书，并将证书和私钥写入临时目录.
+func writeTestCertificate(t *testing.T, commonName string) (*x509.Certificate, string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return cert, certFile, keyFile
+}
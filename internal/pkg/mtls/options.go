@@ -0,0 +1,191 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	genericoptions "github.com/onexstack/onexstack/pkg/options"
+	"github.com/spf13/pflag"
+)
+
+// 客户端证书的校验模式.
+const (
+	// VerifyModeNone 表示不请求客户端证书.
+	VerifyModeNone = "none"
+	// VerifyModeOptional 表示客户端可以不提供证书，但提供的证书必须通过校验.
+	VerifyModeOptional = "optional"
+	// VerifyModeRequire 表示客户端必须提供通过校验的证书.
+	VerifyModeRequire = "require"
+)
+
+// principalKeyPrefixes 是证书到服务主体映射中支持的匹配字段.
+var principalKeyPrefixes = []string{"URI:", "DNS:", "EMAIL:", "CN:"}
+
+// Options 包含双向 TLS 认证相关的配置选项.
+type Options struct {
+	// ClientCA 是用于校验客户端证书的 CA 证书文件路径
+	ClientCA string `json:"client-ca" mapstructure:"client-ca"`
+	// VerifyMode 是客户端证书的校验模式，可选值为 none、optional 和 require
+	VerifyMode string `json:"verify-mode" mapstructure:"verify-mode"`
+	// Principals 将客户端证书映射为服务主体，键的格式为 CN:<common name>、DNS:<SAN>、URI:<SAN> 或 EMAIL:<SAN>，
+	// 值为服务主体名称，例如 service::reporter，可以在 casbin 策略中作为主体使用
+	Principals map[string]string `json:"principals" mapstructure:"principals"`
+	// ClientCert 是 gRPC-Gateway 访问 gRPC 服务器时使用的客户端证书文件路径
+	ClientCert string `json:"client-cert" mapstructure:"client-cert"`
+	// ClientKey 是 gRPC-Gateway 访问 gRPC 服务器时使用的客户端私钥文件路径
+	ClientKey string `json:"client-key" mapstructure:"client-key"`
+	// ServerName 是 gRPC-Gateway 校验 gRPC 服务器证书时使用的主机名，为空时使用拨号地址中的主机名
+	ServerName string `json:"server-name" mapstructure:"server-name"`
+}
+
+// NewOptions 创建带有默认值的 Options 实例.
+func NewOptions() *Options {
+	return &Options{
+		VerifyMode: VerifyModeNone,
+		Principals: map[string]string{},
+	}
+}
+
+// AddFlags 将双向 TLS 认证相关的选项绑定到命令行标志.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.ClientCA, "mtls.client-ca", o.ClientCA, "Path to the CA bundle used to verify client certificates.")
+	fs.StringVar(&o.VerifyMode, "mtls.verify-mode", o.VerifyMode, "Client certificate verification mode of the gRPC and Gin servers, available options: none, optional, require.")
+	fs.StringToStringVar(&o.Principals, "mtls.principals", o.Principals, "Map client certificates to service principals, e.g. CN:reporter=service::reporter,URI:spiffe://miniblog/reporter=service::reporter.")
+	fs.StringVar(&o.ClientCert, "mtls.client-cert", o.ClientCert, "Path to the client certificate presented by the gRPC gateway to the gRPC server.")
+	fs.StringVar(&o.ClientKey, "mtls.client-key", o.ClientKey, "Path to the client private key used by the gRPC gateway.")
+	fs.StringVar(&o.ServerName, "mtls.server-name", o.ServerName, "Server name used by the gRPC gateway to verify the gRPC server certificate.")
+}
+
+// Validate 校验双向 TLS 认证配置选项是否合法.
+func (o *Options) Validate() []error {
+	errs := []error{}
+
+	switch o.VerifyMode {
+	case VerifyModeNone:
+	case VerifyModeOptional, VerifyModeRequire:
+		if o.ClientCA == "" {
+			errs = append(errs, fmt.Errorf("mtls.client-ca is required when mtls.verify-mode is %s", o.VerifyMode))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid mtls.verify-mode: %s", o.VerifyMode))
+	}
+
+	if (o.ClientCert == "") != (o.ClientKey == "") {
+		errs = append(errs, errors.New("mtls.client-cert and mtls.client-key must be set together"))
+	}
+
+	for key, principal := range o.Principals {
+		if !hasPrincipalKeyPrefix(key) {
+			errs = append(errs, fmt.Errorf("invalid mtls.principals key %q, must start with one of %v", key, principalKeyPrefixes))
+		}
+		if !strings.HasPrefix(principal, known.ServicePrincipalPrefix) || principal == known.ServicePrincipalPrefix {
+			errs = append(errs, fmt.Errorf("invalid mtls.principals value %q, must start with %s", principal, known.ServicePrincipalPrefix))
+		}
+	}
+
+	return errs
+}
+
+// ValidateTLS 校验双向 TLS 认证配置与服务器 TLS 配置是否匹配.
+// 服务器没有启用 TLS 时无法校验客户端证书，此时不允许开启客户端证书校验.
+func (o *Options) ValidateTLS(tlsOptions *genericoptions.TLSOptions) []error {
+	if o.VerifyMode == VerifyModeNone || (tlsOptions != nil && tlsOptions.UseTLS) {
+		return nil
+	}
+	return []error{fmt.Errorf("mtls.verify-mode %s requires tls.use-tls to be enabled", o.VerifyMode)}
+}
+
+// ServerTLSConfig 在 base 的基础上添加客户端证书校验配置，返回新的 TLS 配置.
+// base 为 nil 表示服务器没有启用 TLS，此时直接返回 nil.
+func (o *Options) ServerTLSConfig(base *tls.Config) (*tls.Config, error) {
+	if base == nil {
+		return nil, nil
+	}
+
+	tlsConfig := base.Clone()
+	switch o.VerifyMode {
+	case VerifyModeOptional:
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	case VerifyModeRequire:
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		tlsConfig.ClientAuth = tls.NoClientCert
+		return tlsConfig, nil
+	}
+
+	pool, err := loadCertPool(o.ClientCA)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.ClientCAs = pool
+
+	return tlsConfig, nil
+}
+
+// ClientTLSConfig 返回 gRPC-Gateway 访问 gRPC 服务器时使用的 TLS 配置.
+// 服务器证书使用 tlsOptions.CaCert 校验，配置了客户端证书时会在握手时提供给服务器.
+// tlsOptions 没有启用 TLS 时返回 nil.
+func (o *Options) ClientTLSConfig(tlsOptions *genericoptions.TLSOptions) (*tls.Config, error) {
+	if tlsOptions == nil || !tlsOptions.UseTLS {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         o.ServerName,
+		InsecureSkipVerify: tlsOptions.InsecureSkipVerify, //nolint:gosec
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if tlsOptions.CaCert != "" {
+		pool, err := loadCertPool(tlsOptions.CaCert)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if o.ClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(o.ClientCert, o.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// loadCertPool 从 PEM 文件中加载 CA 证书.
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no valid certificates found in %s", path)
+	}
+	return pool, nil
+}
+
+// hasPrincipalKeyPrefix 判断映射的键是否使用了支持的匹配字段.
+func hasPrincipalKeyPrefix(key string) bool {
+	for _, prefix := range principalKeyPrefixes {
+		if strings.HasPrefix(key, prefix) && len(key) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
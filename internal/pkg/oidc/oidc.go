@@ -0,0 +1,171 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package oidc 实现基于 OpenID Connect 授权码模式（PKCE）的第三方登录.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/google/wire"
+	"golang.org/x/oauth2"
+)
+
+// ProviderSet 是 oidc 包的 Wire Provider 集合.
+var ProviderSet = wire.NewSet(NewManager)
+
+// ErrProviderNotFound 表示请求的身份提供方没有配置.
+var ErrProviderNotFound = errors.New("oidc provider not found")
+
+// Identity 是从 ID Token 中解析出的用户身份信息.
+type Identity struct {
+	// Provider 是身份提供方的名称
+	Provider string
+	// Subject 是用户在身份提供方中的唯一标识
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	PreferredUsername string
+	Name              string
+}
+
+// Manager 管理所有配置的身份提供方.
+// 服务发现在第一次使用时才进行，这样身份提供方暂时不可用时不会影响服务启动.
+type Manager struct {
+	opts *Options
+
+	mu        sync.Mutex
+	providers map[string]*Provider
+}
+
+// Provider 表示一个已经完成服务发现的身份提供方.
+type Provider struct {
+	name     string
+	config   oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+// NewManager 创建一个 Manager 实例.
+func NewManager(opts *Options) *Manager {
+	return &Manager{opts: opts, providers: make(map[string]*Provider)}
+}
+
+// SessionExpiration 返回登录会话的有效期.
+func (m *Manager) SessionExpiration() time.Duration {
+	return m.opts.SessionExpiration
+}
+
+// Provider 返回名为 name 的身份提供方，首次调用时会进行服务发现.
+func (m *Manager) Provider(ctx context.Context, name string) (*Provider, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if p, ok := m.providers[name]; ok {
+		return p, nil
+	}
+
+	idx := slices.IndexFunc(m.opts.Providers, func(p ProviderOptions) bool { return p.Name == name })
+	if idx < 0 {
+		return nil, ErrProviderNotFound
+	}
+	opts := m.opts.Providers[idx]
+
+	discovered, err := gooidc.NewProvider(ctx, opts.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("discover oidc provider %q: %w", name, err)
+	}
+
+	scopes := []string{gooidc.ScopeOpenID}
+	for _, scope := range append([]string{"profile", "email"}, opts.Scopes...) {
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	p := &Provider{
+		name: name,
+		config: oauth2.Config{
+			ClientID:     opts.ClientID,
+			ClientSecret: opts.ClientSecret,
+			RedirectURL:  opts.RedirectURL,
+			Endpoint:     discovered.Endpoint(),
+			Scopes:       scopes,
+		},
+		verifier: discovered.Verifier(&gooidc.Config{ClientID: opts.ClientID}),
+	}
+	m.providers[name] = p
+
+	return p, nil
+}
+
+// Name 返回身份提供方的名称.
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// AuthCodeURL 返回身份提供方的授权地址，使用 S256 方式携带 PKCE 校验码.
+func (p *Provider) AuthCodeURL(state string, nonce string, verifier string) string {
+	return p.config.AuthCodeURL(state, gooidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier))
+}
+
+// Exchange 使用授权码和 PKCE 校验码换取令牌，校验 ID Token 的签名、签发方、受众、有效期以及 nonce，
+// 校验通过后返回用户身份信息.
+func (p *Provider) Exchange(ctx context.Context, code string, verifier string, nonce string) (*Identity, error) {
+	oauth2Token, err := p.config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.New("no id_token in token response")
+	}
+
+	// Verify 会使用身份提供方 JWKS 中的公钥校验签名
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("verify id_token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.New("id_token nonce mismatch")
+	}
+
+	var claims struct {
+		Email             string `json:"email"`
+		EmailVerified     *bool  `json:"email_verified"`
+		PreferredUsername string `json:"preferred_username"`
+		Name              string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("parse id_token claims: %w", err)
+	}
+
+	return &Identity{
+		Provider:          p.name,
+		Subject:           idToken.Subject,
+		Email:             claims.Email,
+		EmailVerified:     claims.EmailVerified != nil && *claims.EmailVerified,
+		PreferredUsername: claims.PreferredUsername,
+		Name:              claims.Name,
+	}, nil
+}
+
+// NewVerifier 生成一个 PKCE 校验码.
+func NewVerifier() string {
+	return oauth2.GenerateVerifier()
+}
+
+// NewState 生成一个随机字符串，用作 state 或 nonce.
+func NewState() string {
+	return rand.Text()
+}
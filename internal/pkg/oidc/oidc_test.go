@@ -0,0 +1,117 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/url"
+	"testing"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/oidc/oidctest"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// startLogin 发起登录并模拟用户在身份提供方完成授权，返回授权码、PKCE 校验码和 nonce.
+func startLogin(t *testing.T, issuer *oidctest.Issuer, p *Provider, claims jwt.MapClaims) (string, string, string) {
+	verifier, nonce := NewVerifier(), NewState()
+
+	authURL, err := url.Parse(p.AuthCodeURL("state", nonce, verifier))
+	assert.NoError(t, err)
+	query := authURL.Query()
+	assert.Equal(t, issuer.URL+"/authorize", authURL.Scheme+"://"+authURL.Host+authURL.Path)
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.Equal(t, "state", query.Get("state"))
+	assert.Contains(t, query.Get("scope"), "openid")
+
+	code := issuer.Authorize(query.Get("code_challenge"), query.Get("nonce"), claims)
+	return code, verifier, nonce
+}
+
+func newTestManager(t *testing.T) (*oidctest.Issuer, *Provider) {
+	issuer := oidctest.NewIssuer(t, "miniblog")
+	m := NewManager(&Options{Providers: []ProviderOptions{{
+		Name:        "mock",
+		Issuer:      issuer.URL,
+		ClientID:    "miniblog",
+		RedirectURL: "http://127.0.0.1:5555/oidc/mock/callback",
+	}}})
+
+	p, err := m.Provider(context.Background(), "mock")
+	assert.NoError(t, err)
+	return issuer, p
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	issuer, p := newTestManager(t)
+
+	code, verifier, nonce := startLogin(t, issuer, p, jwt.MapClaims{
+		"sub":                "subject-1",
+		"email":              "colin@example.com",
+		"email_verified":     true,
+		"preferred_username": "colin",
+	})
+
+	identity, err := p.Exchange(context.Background(), code, verifier, nonce)
+	assert.NoError(t, err)
+	assert.Equal(t, &Identity{
+		Provider:          "mock",
+		Subject:           "subject-1",
+		Email:             "colin@example.com",
+		EmailVerified:     true,
+		PreferredUsername: "colin",
+	}, identity)
+
+	// 授权码只能使用一次
+	_, err = p.Exchange(context.Background(), code, verifier, nonce)
+	assert.Error(t, err)
+}
+
+func TestProvider_ExchangeRejectsWrongVerifier(t *testing.T) {
+	issuer, p := newTestManager(t)
+
+	code, _, nonce := startLogin(t, issuer, p, jwt.MapClaims{"sub": "subject-1"})
+	_, err := p.Exchange(context.Background(), code, NewVerifier(), nonce)
+	assert.Error(t, err)
+}
+
+func TestProvider_ExchangeRejectsWrongNonce(t *testing.T) {
+	issuer, p := newTestManager(t)
+
+	code, verifier, _ := startLogin(t, issuer, p, jwt.MapClaims{"sub": "subject-1"})
+	_, err := p.Exchange(context.Background(), code, verifier, NewState())
+	assert.Error(t, err)
+}
+
+func TestProvider_ExchangeRejectsForgedSignature(t *testing.T) {
+	issuer, p := newTestManager(t)
+
+	// 使用不在 JWKS 中的私钥签发 ID Token
+	forged, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	issuer.SignKey = forged
+
+	code, verifier, nonce := startLogin(t, issuer, p, jwt.MapClaims{"sub": "subject-1"})
+	_, err = p.Exchange(context.Background(), code, verifier, nonce)
+	assert.Error(t, err)
+}
+
+func TestProvider_ExchangeRejectsWrongAudience(t *testing.T) {
+	issuer, p := newTestManager(t)
+
+	code, verifier, nonce := startLogin(t, issuer, p, jwt.MapClaims{"sub": "subject-1", "aud": "another-client"})
+	_, err := p.Exchange(context.Background(), code, verifier, nonce)
+	assert.Error(t, err)
+}
+
+func TestManager_ProviderNotFound(t *testing.T) {
+	m := NewManager(NewOptions())
+	_, err := m.Provider(context.Background(), "unknown")
+	assert.ErrorIs(t, err, ErrProviderNotFound)
+}
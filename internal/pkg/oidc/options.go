@@ -0,0 +1,89 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package oidc
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ProviderOptions 包含单个 OIDC 身份提供方的配置.
+type ProviderOptions struct {
+	// Name 是身份提供方的名称，用于 API 路径中，例如 google、keycloak
+	Name string `json:"name" mapstructure:"name"`
+	// Issuer 是身份提供方的 Issuer URL，会通过 {Issuer}/.well-known/openid-configuration 进行服务发现
+	Issuer string `json:"issuer" mapstructure:"issuer"`
+	// ClientID 是在身份提供方注册的客户端 ID
+	ClientID string `json:"client-id" mapstructure:"client-id"`
+	// ClientSecret 是在身份提供方注册的客户端密钥，公共客户端可以为空
+	ClientSecret string `json:"client-secret" mapstructure:"client-secret"`
+	// RedirectURL 是授权完成后身份提供方回调的地址
+	RedirectURL string `json:"redirect-url" mapstructure:"redirect-url"`
+	// Scopes 是额外申请的权限范围，openid 会被自动添加
+	Scopes []string `json:"scopes" mapstructure:"scopes"`
+}
+
+// Options 包含 OIDC 登录相关的配置选项.
+// 身份提供方列表只能通过配置文件设置，例如：
+//
+//	oidc:
+//	  providers:
+//	    - name: keycloak
+//	      issuer: https://sso.example.com/realms/miniblog
+//	      client-id: miniblog
+//	      client-secret: xxx
+//	      redirect-url: https://miniblog.example.com/oidc/keycloak/callback
+type Options struct {
+	// Providers 是所有可用的身份提供方
+	Providers []ProviderOptions `json:"providers" mapstructure:"providers"`
+	// SessionExpiration 是从发起登录到完成回调允许的最长时间
+	SessionExpiration time.Duration `json:"session-expiration" mapstructure:"session-expiration"`
+}
+
+// NewOptions 创建带有默认值的 Options 实例.
+func NewOptions() *Options {
+	return &Options{
+		SessionExpiration: 10 * time.Minute,
+	}
+}
+
+// AddFlags 将 OIDC 相关的选项绑定到命令行标志.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&o.SessionExpiration, "oidc.session-expiration", o.SessionExpiration, "Maximum time allowed between starting an OIDC login and completing the callback.")
+}
+
+// Validate 校验 OIDC 配置选项是否合法.
+func (o *Options) Validate() []error {
+	errs := []error{}
+
+	if o.SessionExpiration <= 0 {
+		errs = append(errs, fmt.Errorf("oidc.session-expiration must be positive"))
+	}
+
+	names := sets.New[string]()
+	for _, p := range o.Providers {
+		if p.Name == "" || names.Has(p.Name) {
+			errs = append(errs, fmt.Errorf("oidc provider name must be unique and not empty: %q", p.Name))
+		}
+		names.Insert(p.Name)
+
+		if p.ClientID == "" {
+			errs = append(errs, fmt.Errorf("oidc provider %q: client-id is required", p.Name))
+		}
+		for _, u := range []string{p.Issuer, p.RedirectURL} {
+			if _, err := url.ParseRequestURI(u); err != nil {
+				errs = append(errs, fmt.Errorf("oidc provider %q: invalid url %q: %w", p.Name, u, err))
+			}
+		}
+	}
+
+	return errs
+}
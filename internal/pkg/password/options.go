@@ -0,0 +1,135 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package password
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/TobyIcetea/miniblog/pkg/auth"
+	"github.com/spf13/pflag"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcryptMaxLength 是 bcrypt 能够处理的最大密码字节数.
+const bcryptMaxLength = 72
+
+// Options 包含密码策略和密码加密相关的配置选项.
+type Options struct {
+	// MinLength 是密码的最小长度
+	MinLength int `json:"min-length" mapstructure:"min-length"`
+	// MaxLength 是密码的最大长度，使用 bcrypt 时不能超过 72
+	MaxLength int `json:"max-length" mapstructure:"max-length"`
+	// RequireLetter 表示密码必须包含字母
+	RequireLetter bool `json:"require-letter" mapstructure:"require-letter"`
+	// RequireUpper 表示密码必须包含大写字母
+	RequireUpper bool `json:"require-upper" mapstructure:"require-upper"`
+	// RequireLower 表示密码必须包含小写字母
+	RequireLower bool `json:"require-lower" mapstructure:"require-lower"`
+	// RequireDigit 表示密码必须包含数字
+	RequireDigit bool `json:"require-digit" mapstructure:"require-digit"`
+	// RequireSymbol 表示密码必须包含特殊字符
+	RequireSymbol bool `json:"require-symbol" mapstructure:"require-symbol"`
+	// Denylist 是禁止使用的密码列表，比较时不区分大小写，内置的常见弱密码总是生效
+	Denylist []string `json:"denylist" mapstructure:"denylist"`
+	// DenylistFile 是禁止使用的密码文件路径，每行一个密码
+	DenylistFile string `json:"denylist-file" mapstructure:"denylist-file"`
+	// DisallowUsername 表示密码不能包含用户名
+	DisallowUsername bool `json:"disallow-username" mapstructure:"disallow-username"`
+	// HistorySize 是修改密码时不能与之重复的历史密码个数（包括当前密码），0 表示不检查
+	HistorySize int `json:"history-size" mapstructure:"history-size"`
+
+	// Algorithm 是加密新密码时使用的算法，可选值为 bcrypt 和 argon2id
+	Algorithm string `json:"algorithm" mapstructure:"algorithm"`
+	// BcryptCost 是 bcrypt 的计算成本
+	BcryptCost int `json:"bcrypt-cost" mapstructure:"bcrypt-cost"`
+	// Argon2Memory 是 argon2id 使用的内存大小，单位为 KiB
+	Argon2Memory uint32 `json:"argon2-memory" mapstructure:"argon2-memory"`
+	// Argon2Iterations 是 argon2id 的迭代次数
+	Argon2Iterations uint32 `json:"argon2-iterations" mapstructure:"argon2-iterations"`
+	// Argon2Parallelism 是 argon2id 的并行度
+	Argon2Parallelism uint8 `json:"argon2-parallelism" mapstructure:"argon2-parallelism"`
+}
+
+// NewOptions 创建带有默认值的 Options 实例，默认的复杂度要求与之前保持一致.
+func NewOptions() *Options {
+	hashOpts := auth.DefaultHashOptions()
+	return &Options{
+		MinLength:         8,
+		MaxLength:         64,
+		RequireLetter:     true,
+		RequireDigit:      true,
+		DisallowUsername:  true,
+		HistorySize:       5,
+		Algorithm:         hashOpts.Algorithm,
+		BcryptCost:        hashOpts.BcryptCost,
+		Argon2Memory:      hashOpts.Argon2Memory,
+		Argon2Iterations:  hashOpts.Argon2Iterations,
+		Argon2Parallelism: hashOpts.Argon2Parallelism,
+	}
+}
+
+// AddFlags 将密码策略相关的选项绑定到命令行标志.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&o.MinLength, "password.min-length", o.MinLength, "Minimum length of a password.")
+	fs.IntVar(&o.MaxLength, "password.max-length", o.MaxLength, "Maximum length of a password. Must not exceed 72 when bcrypt is used.")
+	fs.BoolVar(&o.RequireLetter, "password.require-letter", o.RequireLetter, "Require at least one letter in a password.")
+	fs.BoolVar(&o.RequireUpper, "password.require-upper", o.RequireUpper, "Require at least one upper case letter in a password.")
+	fs.BoolVar(&o.RequireLower, "password.require-lower", o.RequireLower, "Require at least one lower case letter in a password.")
+	fs.BoolVar(&o.RequireDigit, "password.require-digit", o.RequireDigit, "Require at least one digit in a password.")
+	fs.BoolVar(&o.RequireSymbol, "password.require-symbol", o.RequireSymbol, "Require at least one symbol in a password.")
+	fs.StringSliceVar(&o.Denylist, "password.denylist", o.Denylist, "Passwords that are not allowed in addition to the built-in common passwords.")
+	fs.StringVar(&o.DenylistFile, "password.denylist-file", o.DenylistFile, "Path to a file of passwords that are not allowed, one per line.")
+	fs.BoolVar(&o.DisallowUsername, "password.disallow-username", o.DisallowUsername, "Reject passwords that contain the username.")
+	fs.IntVar(&o.HistorySize, "password.history-size", o.HistorySize, "Number of previous passwords, including the current one, that cannot be reused. 0 disables the check.")
+	fs.StringVar(&o.Algorithm, "password.algorithm", o.Algorithm, "Hash algorithm for new passwords, one of bcrypt or argon2id. Existing hashes are upgraded on login.")
+	fs.IntVar(&o.BcryptCost, "password.bcrypt-cost", o.BcryptCost, "Cost of the bcrypt hash algorithm.")
+	fs.Uint32Var(&o.Argon2Memory, "password.argon2-memory", o.Argon2Memory, "Memory in KiB used by the argon2id hash algorithm.")
+	fs.Uint32Var(&o.Argon2Iterations, "password.argon2-iterations", o.Argon2Iterations, "Number of iterations of the argon2id hash algorithm.")
+	fs.Uint8Var(&o.Argon2Parallelism, "password.argon2-parallelism", o.Argon2Parallelism, "Degree of parallelism of the argon2id hash algorithm.")
+}
+
+// Validate 校验密码策略配置选项是否合法.
+func (o *Options) Validate() []error {
+	errs := []error{}
+
+	if o.MinLength <= 0 || o.MaxLength < o.MinLength {
+		errs = append(errs, errors.New("password.min-length must be positive and not greater than password.max-length"))
+	}
+	if o.HistorySize < 0 {
+		errs = append(errs, errors.New("password.history-size must not be negative"))
+	}
+
+	switch o.Algorithm {
+	case auth.HashAlgorithmBcrypt:
+		if o.BcryptCost < bcrypt.MinCost || o.BcryptCost > bcrypt.MaxCost {
+			errs = append(errs, fmt.Errorf("password.bcrypt-cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost))
+		}
+		if o.MaxLength > bcryptMaxLength {
+			errs = append(errs, fmt.Errorf("password.max-length must not exceed %d when bcrypt is used", bcryptMaxLength))
+		}
+	case auth.HashAlgorithmArgon2id:
+		if o.Argon2Memory < 8*uint32(o.Argon2Parallelism) || o.Argon2Iterations == 0 || o.Argon2Parallelism == 0 {
+			errs = append(errs, errors.New("password.argon2-iterations and password.argon2-parallelism must be positive, and password.argon2-memory must be at least 8 KiB per thread"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("password.algorithm must be %s or %s", auth.HashAlgorithmBcrypt, auth.HashAlgorithmArgon2id))
+	}
+
+	return errs
+}
+
+// HashOptions 返回密码加密参数.
+func (o *Options) HashOptions() auth.HashOptions {
+	return auth.HashOptions{
+		Algorithm:         o.Algorithm,
+		BcryptCost:        o.BcryptCost,
+		Argon2Memory:      o.Argon2Memory,
+		Argon2Iterations:  o.Argon2Iterations,
+		Argon2Parallelism: o.Argon2Parallelism,
+	}
+}
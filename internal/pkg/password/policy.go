@@ -0,0 +1,141 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package password 实现可配置的密码策略，包括长度、字符类型、弱密码黑名单、
+// 禁止包含用户名以及历史密码检查.
+package password
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/google/wire"
+)
+
+// ProviderSet 是 password 包的 Wire Provider 集合.
+var ProviderSet = wire.NewSet(NewPolicy)
+
+// commonPasswords 是内置的常见弱密码列表.
+//
+//go:embed denylist.txt
+var commonPasswords []byte
+
+// Policy 根据配置校验密码是否符合要求.
+type Policy struct {
+	opts     *Options
+	denylist map[string]struct{}
+}
+
+// NewPolicy 创建一个 Policy 实例，并加载内置和配置的密码黑名单.
+func NewPolicy(opts *Options) (*Policy, error) {
+	p := &Policy{opts: opts, denylist: make(map[string]struct{})}
+
+	p.loadDenylist(bytes.NewReader(commonPasswords))
+	for _, password := range opts.Denylist {
+		p.addDenylist(password)
+	}
+
+	if opts.DenylistFile != "" {
+		file, err := os.Open(opts.DenylistFile)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		if err := p.loadDenylist(file); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// HistorySize 返回修改密码时不能与之重复的历史密码个数.
+func (p *Policy) HistorySize() int {
+	return p.opts.HistorySize
+}
+
+// Check 校验密码是否符合密码策略，username 为空时不检查密码是否包含用户名.
+func (p *Policy) Check(password string, username string) error {
+	if password == "" {
+		return errno.WithMessage(errno.ErrInvalidArgument, "password cannot be empty")
+	}
+
+	length := utf8.RuneCountInString(password)
+	if length < p.opts.MinLength {
+		return errno.WithMessage(errno.ErrInvalidArgument, "password must be at least %d characters long", p.opts.MinLength)
+	}
+	if length > p.opts.MaxLength {
+		return errno.WithMessage(errno.ErrInvalidArgument, "password must be at most %d characters long", p.opts.MaxLength)
+	}
+
+	var hasLetter, hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasLetter, hasUpper = true, true
+		case unicode.IsLower(r):
+			hasLetter, hasLower = true, true
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	if p.opts.RequireLetter && !hasLetter {
+		return errno.WithMessage(errno.ErrInvalidArgument, "password must contain at least one letter")
+	}
+	if p.opts.RequireUpper && !hasUpper {
+		return errno.WithMessage(errno.ErrInvalidArgument, "password must contain at least one upper case letter")
+	}
+	if p.opts.RequireLower && !hasLower {
+		return errno.WithMessage(errno.ErrInvalidArgument, "password must contain at least one lower case letter")
+	}
+	if p.opts.RequireDigit && !hasDigit {
+		return errno.WithMessage(errno.ErrInvalidArgument, "password must contain at least one number")
+	}
+	if p.opts.RequireSymbol && !hasSymbol {
+		return errno.WithMessage(errno.ErrInvalidArgument, "password must contain at least one symbol")
+	}
+
+	if _, ok := p.denylist[strings.ToLower(password)]; ok {
+		return errno.WithMessage(errno.ErrInvalidArgument, "password is too common, please choose another one")
+	}
+
+	if p.opts.DisallowUsername && username != "" && strings.Contains(strings.ToLower(password), strings.ToLower(username)) {
+		return errno.WithMessage(errno.ErrInvalidArgument, "password must not contain the username")
+	}
+
+	return nil
+}
+
+// loadDenylist 从 r 中逐行读取禁止使用的密码，忽略空行和以 # 开头的注释行.
+func (p *Policy) loadDenylist(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p.addDenylist(line)
+	}
+	return scanner.Err()
+}
+
+// addDenylist 将密码加入黑名单，比较时不区分大小写.
+func (p *Policy) addDenylist(password string) {
+	p.denylist[strings.ToLower(password)] = struct{}{}
+}
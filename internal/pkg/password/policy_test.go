@@ -0,0 +1,75 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package password
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	denylistFile := filepath.Join(t.TempDir(), "denylist.txt")
+	require.NoError(t, os.WriteFile(denylistFile, []byte("# company passwords\nAcme2025!\n"), 0o600))
+
+	opts := NewOptions()
+	opts.RequireUpper = true
+	opts.RequireSymbol = true
+	opts.Denylist = []string{"Blog@2025"}
+	opts.DenylistFile = denylistFile
+	policy, err := NewPolicy(opts)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		password string
+		username string
+		wantErr  bool
+	}{
+		{"valid", "Correct-Horse-9", "colin", false},
+		{"empty", "", "colin", true},
+		{"too short", "Ab1!", "colin", true},
+		{"too long", "Aa1!" + string(make([]byte, 64)), "colin", true},
+		{"no upper", "correct-horse-9", "colin", true},
+		{"no digit", "Correct-Horse", "colin", true},
+		{"no symbol", "CorrectHorse9", "colin", true},
+		{"built-in denylist", "P@ssw0rd", "colin", true},
+		{"configured denylist", "blog@2025", "colin", true},
+		{"denylist file", "ACME2025!", "colin", true},
+		{"contains username", "Colin-Horse-9", "colin", true},
+		{"username unknown", "Colin-Horse-9", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(tt.password, tt.username)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	opts := NewOptions()
+	assert.Empty(t, opts.Validate())
+
+	opts.MaxLength = 100
+	opts.BcryptCost = 1
+	assert.Len(t, opts.Validate(), 2)
+
+	opts.Algorithm = "md5"
+	assert.Len(t, opts.Validate(), 1)
+
+	opts.Algorithm = "argon2id"
+	assert.Empty(t, opts.Validate())
+}
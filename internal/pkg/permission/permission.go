@@ -0,0 +1,191 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package permission 维护 API 方法与权限标识之间的对应关系.
+// 权限标识来自 proto 方法选项 (miniblog.v1.permission)，HTTP 和 gRPC 请求在授权时
+// 都会被解析为同一个权限标识，所以同一个操作在所有服务器模式下只需要配置一条策略.
+package permission
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/casbin/casbin/v2/util"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Action 是接口级别访问控制使用的操作，所有权限标识都使用该操作.
+const Action = "CALL"
+
+// Default 是 MiniBlog 服务的权限注册表.
+var Default = NewRegistry(apiv1.File_apiserver_v1_apiserver_proto.Services().ByName("MiniBlog"))
+
+// pathParamRegex 匹配 google.api.http 路径模板中的参数，例如 {userID}.
+var pathParamRegex = regexp.MustCompile(`\{([^}=]+)(=[^}]*)?\}`)
+
+// Route 表示一个 HTTP 路由与权限标识的对应关系.
+type Route struct {
+	// Method 表示 HTTP 方法，例如 GET.
+	Method string
+	// Path 表示 Gin 风格的路由模板，例如 /v1/users/:userID.
+	Path string
+	// FullMethod 表示对应的 gRPC 完整方法名.
+	FullMethod string
+	// Permission 表示权限标识.
+	Permission string
+}
+
+// Registry 保存 gRPC 方法、HTTP 路由与权限标识之间的对应关系.
+type Registry struct {
+	methods  map[string]string
+	routes   map[string]string
+	readOnly map[string]bool
+	list     []Route
+}
+
+// NewRegistry 从服务描述中读取每个方法的权限标识和 HTTP 映射，创建权限注册表.
+func NewRegistry(services ...protoreflect.ServiceDescriptor) *Registry {
+	r := &Registry{methods: map[string]string{}, routes: map[string]string{}, readOnly: map[string]bool{}}
+
+	for _, sd := range services {
+		methods := sd.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			md := methods.Get(i)
+			perm, _ := proto.GetExtension(md.Options(), apiv1.E_Permission).(string)
+			if perm == "" {
+				continue
+			}
+
+			fullMethod := "/" + string(sd.FullName()) + "/" + string(md.Name())
+			r.methods[fullMethod] = perm
+
+			rule, _ := proto.GetExtension(md.Options(), annotations.E_Http).(*annotations.HttpRule)
+			if rule == nil {
+				continue
+			}
+			// 主 HTTP 映射使用 GET 方法的接口视为只读接口
+			r.readOnly[fullMethod] = rule.GetGet() != ""
+			for _, binding := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+				method, path := httpPattern(binding)
+				if method == "" {
+					continue
+				}
+				route := Route{Method: method, Path: path, FullMethod: fullMethod, Permission: perm}
+				r.routes[routeKey(method, path)] = perm
+				r.list = append(r.list, route)
+			}
+		}
+	}
+
+	return r
+}
+
+// ForMethod 返回 gRPC 完整方法名对应的权限标识.
+func (r *Registry) ForMethod(fullMethod string) (string, bool) {
+	perm, ok := r.methods[fullMethod]
+	return perm, ok
+}
+
+// ForRoute 返回 HTTP 方法和 Gin 路由模板对应的权限标识.
+func (r *Registry) ForRoute(method, path string) (string, bool) {
+	perm, ok := r.routes[routeKey(method, path)]
+	return perm, ok
+}
+
+// ReadOnly 判断 gRPC 完整方法名对应的接口是否为只读接口，即主 HTTP 映射使用 GET 方法.
+func (r *Registry) ReadOnly(fullMethod string) bool {
+	return r.readOnly[fullMethod]
+}
+
+// Routes 返回所有 HTTP 路由与权限标识的对应关系.
+func (r *Registry) Routes() []Route {
+	return slices.Clone(r.list)
+}
+
+// Methods 返回所有 gRPC 方法与权限标识的对应关系.
+func (r *Registry) Methods() map[string]string {
+	methods := make(map[string]string, len(r.methods))
+	for k, v := range r.methods {
+		methods[k] = v
+	}
+	return methods
+}
+
+// Convert 将旧格式的策略对象和操作转换为权限标识.
+// 旧格式包括 HTTP 路径加方法（例如 /v1/users/* + DELETE），以及 gRPC 方法名加 CALL
+// （例如 /v1.MiniBlog/DeleteUser + CALL，包名写错的方法名也能被识别）.
+// 路径中的 keyMatch 通配符会被展开为所有匹配的权限标识. 无法识别的策略返回 false.
+func (r *Registry) Convert(object, action string) ([]string, bool) {
+	var perms []string
+
+	if action == Action && strings.HasPrefix(object, "/") {
+		if _, name, found := strings.Cut(strings.TrimPrefix(object, "/"), "/"); found {
+			for fullMethod, perm := range r.methods {
+				if util.KeyMatch(methodName(fullMethod), name) {
+					perms = append(perms, perm)
+				}
+			}
+		}
+	}
+
+	for _, route := range r.list {
+		if route.Method == action && util.KeyMatch(samplePath(route.Path), object) {
+			perms = append(perms, route.Permission)
+		}
+	}
+
+	slices.Sort(perms)
+	perms = slices.Compact(perms)
+	return perms, len(perms) > 0
+}
+
+// httpPattern 返回 HttpRule 中的 HTTP 方法和 Gin 风格的路由模板.
+func httpPattern(rule *annotations.HttpRule) (string, string) {
+	var method, path string
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		method, path = "GET", pattern.Get
+	case *annotations.HttpRule_Post:
+		method, path = "POST", pattern.Post
+	case *annotations.HttpRule_Put:
+		method, path = "PUT", pattern.Put
+	case *annotations.HttpRule_Delete:
+		method, path = "DELETE", pattern.Delete
+	case *annotations.HttpRule_Patch:
+		method, path = "PATCH", pattern.Patch
+	case *annotations.HttpRule_Custom:
+		method, path = pattern.Custom.GetKind(), pattern.Custom.GetPath()
+	default:
+		return "", ""
+	}
+
+	return method, pathParamRegex.ReplaceAllString(path, ":$1")
+}
+
+// samplePath 将路由模板中的参数替换为示例值，用于和旧策略中的 keyMatch 通配符匹配.
+func samplePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "x"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// methodName 返回 gRPC 完整方法名中的方法名部分.
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+// routeKey 返回路由在注册表中的键.
+func routeKey(method, path string) string {
+	return method + " " + path
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package permission
+
+import (
+	"slices"
+	"testing"
+
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+)
+
+func TestEveryMethodHasPermission(t *testing.T) {
+	methods := apiv1.File_apiserver_v1_apiserver_proto.Services().ByName("MiniBlog").Methods()
+	for i := 0; i < methods.Len(); i++ {
+		fullMethod := "/miniblog.v1.MiniBlog/" + string(methods.Get(i).Name())
+		if _, ok := Default.ForMethod(fullMethod); !ok {
+			t.Errorf("method %s has no (miniblog.v1.permission) option", fullMethod)
+		}
+	}
+}
+
+func TestResolveSamePermission(t *testing.T) {
+	tests := []struct {
+		fullMethod string
+		method     string
+		route      string
+		want       string
+	}{
+		{apiv1.MiniBlog_DeleteUser_FullMethodName, "DELETE", "/v1/users/:userID", "users.delete"},
+		{apiv1.MiniBlog_ListUser_FullMethodName, "GET", "/v1/users", "users.list"},
+		{apiv1.MiniBlog_ChangePassword_FullMethodName, "PUT", "/v1/users/:userID/change-password", "users.password.update"},
+		{apiv1.MiniBlog_UnsharePost_FullMethodName, "DELETE", "/v1/posts/:postID/shares/:userID", "posts.unshare"},
+	}
+	for _, tt := range tests {
+		byMethod, ok := Default.ForMethod(tt.fullMethod)
+		if !ok || byMethod != tt.want {
+			t.Errorf("ForMethod(%s) = %q, %v, want %q", tt.fullMethod, byMethod, ok, tt.want)
+		}
+		byRoute, ok := Default.ForRoute(tt.method, tt.route)
+		if !ok || byRoute != tt.want {
+			t.Errorf("ForRoute(%s, %s) = %q, %v, want %q", tt.method, tt.route, byRoute, ok, tt.want)
+		}
+	}
+
+	if _, ok := Default.ForRoute("GET", "/v1/unknown"); ok {
+		t.Errorf("ForRoute should not resolve unknown routes")
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		object, action string
+		want           []string
+	}{
+		{"/v1/users/*", "DELETE", []string{"users.batch-delete", "users.delete"}},
+		{"/v1/users", "GET", []string{"users.list"}},
+		{"/v1/lockouts/*", "DELETE", []string{"lockouts.delete"}},
+		{"/v1/roles/*", "DELETE", []string{"roles.delete"}},
+		{"/v1/posts/*", "PUT", []string{"posts.update"}},
+		{"/v1.MiniBlog/DeleteUser", "CALL", []string{"users.delete"}},
+		{"/miniblog.v1.MiniBlog/ListUser", "CALL", []string{"users.list"}},
+		{"/v1.MiniBlog/List*", "CALL", []string{"audit-events.list", "organizations.list", "organizations.members.list", "policies.list", "posts.list", "role-assignments.list", "roles.list", "users.list"}},
+		{"/v1/nothing", "GET", nil},
+		{"*", "*", nil},
+	}
+	for _, tt := range tests {
+		got, ok := Default.Convert(tt.object, tt.action)
+		if ok != (len(tt.want) > 0) || !slices.Equal(got, tt.want) {
+			t.Errorf("Convert(%s, %s) = %v, %v, want %v", tt.object, tt.action, got, ok, tt.want)
+		}
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	if !Default.ReadOnly(apiv1.MiniBlog_ListUser_FullMethodName) {
+		t.Errorf("ListUser should be read-only")
+	}
+	if Default.ReadOnly(apiv1.MiniBlog_DeleteUser_FullMethodName) {
+		t.Errorf("DeleteUser should not be read-only")
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval 是清理已经恢复满的令牌桶的最小时间间隔.
+const sweepInterval = time.Minute
+
+// bucket 记录一个令牌桶的状态.
+type bucket struct {
+	tokens   float64
+	last     time.Time
+	capacity int
+	rate     float64
+}
+
+// refill 按照经过的时间补充令牌.
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(float64(b.capacity), b.tokens+elapsed*b.rate)
+	}
+	b.last = now
+}
+
+// memoryBackend 将令牌桶保存在进程内存中.
+type memoryBackend struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// 确保 memoryBackend 实现了 Backend 接口.
+var _ Backend = (*memoryBackend)(nil)
+
+// NewMemoryBackend 创建一个基于进程内存的 Backend.
+func NewMemoryBackend() Backend {
+	return &memoryBackend{buckets: make(map[string]*bucket)}
+}
+
+// Take 从令牌桶中取出一个令牌.
+func (m *memoryBackend) Take(_ context.Context, key string, capacity int, rate float64, now time.Time) (bool, float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sweep(now)
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(capacity), last: now}
+		m.buckets[key] = b
+	}
+	// 规则可能在运行期间被修改，以最新的规则为准
+	b.capacity, b.rate = capacity, rate
+	b.refill(now)
+
+	if b.tokens < 1 {
+		return false, b.tokens, nil
+	}
+	b.tokens--
+	return true, b.tokens, nil
+}
+
+// sweep 删除已经恢复满的令牌桶，这些令牌桶与不存在时的效果相同.
+func (m *memoryBackend) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	m.lastSweep = now
+
+	for key, b := range m.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.capacity) {
+			delete(m.buckets, key)
+		}
+	}
+}
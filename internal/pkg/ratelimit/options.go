@@ -0,0 +1,132 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package ratelimit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/permission"
+	"github.com/spf13/pflag"
+)
+
+// Rule 定义一条令牌桶限流规则.
+type Rule struct {
+	// Limit 是每个周期内补充的令牌数，0 表示不限流
+	Limit int `json:"limit" mapstructure:"limit"`
+	// Period 是补充 Limit 个令牌的周期
+	Period time.Duration `json:"period" mapstructure:"period"`
+	// Burst 是令牌桶的容量，即允许的最大突发请求数，0 表示与 Limit 相同
+	Burst int `json:"burst" mapstructure:"burst"`
+	// Key 是计数使用的键类型，可选值为 ip、user 和 token，默认为 ip
+	Key string `json:"key" mapstructure:"key"`
+}
+
+// bucket 返回令牌桶的容量和每秒补充的令牌数.
+func (r Rule) bucket() (int, float64) {
+	capacity := r.Burst
+	if capacity <= 0 {
+		capacity = r.Limit
+	}
+	return capacity, float64(r.Limit) / r.Period.Seconds()
+}
+
+// validate 校验限流规则是否合法.
+func (r Rule) validate(name string) error {
+	if r.Limit < 0 || r.Burst < 0 {
+		return fmt.Errorf("%s: limit and burst must not be negative", name)
+	}
+	if r.Limit > 0 && r.Period <= 0 {
+		return fmt.Errorf("%s: period must be positive", name)
+	}
+	switch r.Key {
+	case "", KeyIP, KeyUser, KeyToken:
+	default:
+		return fmt.Errorf("%s: key must be one of %s, %s or %s", name, KeyIP, KeyUser, KeyToken)
+	}
+	return nil
+}
+
+// Options 包含请求限流相关的配置选项.
+type Options struct {
+	// Enabled 表示是否开启请求限流
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// Backend 是令牌桶状态的存储后端，可选值为 memory 和 redis，redis 后端使用 redis 配置项连接 Redis
+	Backend string `json:"backend" mapstructure:"backend"`
+	// KeyPrefix 是令牌桶在存储后端中的键前缀
+	KeyPrefix string `json:"key-prefix" mapstructure:"key-prefix"`
+	// Default 是没有单独配置规则的接口使用的默认规则
+	Default Rule `json:"default" mapstructure:"default"`
+	// Rules 是按权限标识（例如 auth.login）配置的限流规则，会覆盖默认规则
+	Rules map[string]Rule `json:"rules" mapstructure:"rules"`
+}
+
+// NewOptions 创建带有默认值的 Options 实例.
+// 默认只对登录、注册等不需要认证的接口限流，其他接口不限流.
+func NewOptions() *Options {
+	return &Options{
+		Enabled:   true,
+		Backend:   BackendMemory,
+		KeyPrefix: "miniblog:ratelimit:",
+		Rules: map[string]Rule{
+			"auth.login":            {Limit: 10, Period: time.Minute, Key: KeyIP},
+			"auth.verify":           {Limit: 10, Period: time.Minute, Key: KeyIP},
+			"users.create":          {Limit: 5, Period: time.Hour, Key: KeyIP},
+			"password-reset.create": {Limit: 5, Period: time.Hour, Key: KeyIP},
+		},
+	}
+}
+
+// AddFlags 将请求限流相关的选项绑定到命令行标志.
+// 按接口配置的规则只能通过配置文件中的 ratelimit.rules 设置.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Enabled, "ratelimit.enabled", o.Enabled, "Enable request rate limiting.")
+	fs.StringVar(&o.Backend, "ratelimit.backend", o.Backend, "Storage of token buckets, one of memory or redis. The redis backend uses the redis.* options.")
+	fs.StringVar(&o.KeyPrefix, "ratelimit.key-prefix", o.KeyPrefix, "Prefix of token bucket keys in the storage backend.")
+	fs.IntVar(&o.Default.Limit, "ratelimit.default-limit", o.Default.Limit, "Requests allowed per period for APIs without a specific rule. 0 disables the default rule.")
+	fs.DurationVar(&o.Default.Period, "ratelimit.default-period", o.Default.Period, "Period of the default rule.")
+	fs.IntVar(&o.Default.Burst, "ratelimit.default-burst", o.Default.Burst, "Maximum burst of the default rule. 0 means the same as the limit.")
+	fs.StringVar(&o.Default.Key, "ratelimit.default-key", o.Default.Key, "Key of the default rule, one of ip, user or token.")
+}
+
+// Validate 校验请求限流配置选项是否合法.
+func (o *Options) Validate() []error {
+	errs := []error{}
+
+	if o.Backend != BackendMemory && o.Backend != BackendRedis {
+		errs = append(errs, fmt.Errorf("ratelimit.backend must be %s or %s", BackendMemory, BackendRedis))
+	}
+	if err := o.Default.validate("ratelimit.default"); err != nil {
+		errs = append(errs, err)
+	}
+
+	// 规则名称必须是已注册的权限标识，避免拼写错误导致规则不生效
+	known := map[string]bool{}
+	for _, perm := range permission.Default.Methods() {
+		known[perm] = true
+	}
+	for name, rule := range o.Rules {
+		if !known[name] {
+			errs = append(errs, fmt.Errorf("ratelimit.rules: unknown permission %q", name))
+			continue
+		}
+		if err := rule.validate("ratelimit.rules." + name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// rule 返回权限标识对应的限流规则.
+func (o *Options) rule(permission string) (Rule, bool) {
+	rule, ok := o.Rules[permission]
+	if !ok {
+		rule = o.Default
+	}
+	return rule, rule.Limit > 0
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package ratelimit 实现基于令牌桶的请求限流.
+//
+// 限流规则按权限标识配置，HTTP 和 gRPC 请求都会被解析为同一个权限标识，所以同一条规则在所有服务器模式下生效.
+// 每条规则可以选择按客户端 IP、用户 ID 或者 API 令牌分别计数，令牌桶的状态保存在进程内存或 Redis 中.
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/google/wire"
+	genericoptions "github.com/onexstack/onexstack/pkg/options"
+)
+
+// ProviderSet 是 ratelimit 包的 Wire Provider 集合.
+var ProviderSet = wire.NewSet(NewLimiter)
+
+// 限流计数使用的键类型.
+const (
+	// KeyIP 表示按客户端 IP 计数.
+	KeyIP = "ip"
+	// KeyUser 表示按用户 ID 计数，未认证的请求按客户端 IP 计数.
+	KeyUser = "user"
+	// KeyToken 表示按请求携带的 API 令牌计数，没有令牌的请求按客户端 IP 计数.
+	KeyToken = "token"
+)
+
+// 令牌桶状态的存储后端.
+const (
+	// BackendMemory 表示令牌桶保存在进程内存中，多副本部署时每个副本独立计数.
+	BackendMemory = "memory"
+	// BackendRedis 表示令牌桶保存在 Redis 中，多副本共享计数.
+	BackendRedis = "redis"
+)
+
+// 限流相关的响应头，参考 IETF RateLimit header fields 草案.
+const (
+	HeaderLimit      = "RateLimit-Limit"
+	HeaderRemaining  = "RateLimit-Remaining"
+	HeaderReset      = "RateLimit-Reset"
+	HeaderRetryAfter = "Retry-After"
+)
+
+// Backend 定义令牌桶状态的存储后端.
+type Backend interface {
+	// Take 按 rate（每秒补充的令牌数）补充令牌后，尝试从 key 对应的容量为 capacity 的令牌桶中取出一个令牌，
+	// 返回是否取到令牌以及桶中剩余的令牌数.
+	Take(ctx context.Context, key string, capacity int, rate float64, now time.Time) (bool, float64, error)
+}
+
+// Identity 包含用于限流计数的请求方信息.
+type Identity struct {
+	// IP 是客户端 IP
+	IP string
+	// UserID 是认证后的用户 ID，未认证时为空
+	UserID string
+	// Token 是请求携带的 API 令牌，没有令牌时为空
+	Token string
+}
+
+// Result 是一次限流检查的结果.
+type Result struct {
+	// Allowed 表示请求是否被允许
+	Allowed bool
+	// Limit 是令牌桶的容量
+	Limit int
+	// Remaining 是剩余可用的请求数
+	Remaining int
+	// Reset 是令牌桶恢复到满的时间
+	Reset time.Duration
+	// RetryAfter 是请求被拒绝时，需要等待的时间
+	RetryAfter time.Duration
+}
+
+// Headers 返回需要写入响应的限流相关头.
+func (r *Result) Headers() map[string]string {
+	headers := map[string]string{
+		HeaderLimit:     strconv.Itoa(r.Limit),
+		HeaderRemaining: strconv.Itoa(r.Remaining),
+		HeaderReset:     strconv.Itoa(seconds(r.Reset)),
+	}
+	if !r.Allowed {
+		headers[HeaderRetryAfter] = strconv.Itoa(r.RetryAfterSeconds())
+	}
+	return headers
+}
+
+// RetryAfterSeconds 返回向上取整后的等待秒数.
+func (r *Result) RetryAfterSeconds() int {
+	return seconds(r.RetryAfter)
+}
+
+// Limiter 根据配置的规则对请求进行限流.
+type Limiter struct {
+	opts    *Options
+	backend Backend
+
+	// now 用于在测试中替换当前时间
+	now func() time.Time
+}
+
+// NewLimiter 创建一个 Limiter 实例，使用 Redis 后端时会创建 Redis 客户端.
+// 返回的清理函数用于关闭 Redis 客户端.
+func NewLimiter(opts *Options, redisOptions *genericoptions.RedisOptions) (*Limiter, func(), error) {
+	var backend Backend
+	cleanup := func() {}
+	switch {
+	case !opts.Enabled:
+	case opts.Backend == BackendRedis:
+		client, err := redisOptions.NewClient()
+		if err != nil {
+			return nil, nil, err
+		}
+		backend = NewRedisBackend(client)
+		cleanup = func() { _ = client.Close() }
+	default:
+		backend = NewMemoryBackend()
+	}
+
+	return &Limiter{opts: opts, backend: backend, now: time.Now}, cleanup, nil
+}
+
+// Allow 检查权限标识为 permission 的请求是否被允许，没有适用的限流规则时返回 nil.
+func (l *Limiter) Allow(ctx context.Context, permission string, id Identity) (*Result, error) {
+	if l == nil || l.backend == nil {
+		return nil, nil
+	}
+
+	rule, ok := l.opts.rule(permission)
+	if !ok {
+		return nil, nil
+	}
+
+	capacity, rate := rule.bucket()
+	key := l.opts.KeyPrefix + permission + ":" + bucketKey(rule.Key, id)
+	allowed, tokens, err := l.backend.Take(ctx, key, capacity, rate, l.now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Allowed:   allowed,
+		Limit:     capacity,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     duration((float64(capacity) - tokens) / rate),
+	}
+	if !allowed {
+		result.RetryAfter = duration((1 - tokens) / rate)
+	}
+	return result, nil
+}
+
+// bucketKey 根据键类型返回令牌桶的键，API 令牌只保存摘要，避免令牌明文出现在存储后端中.
+func bucketKey(keyType string, id Identity) string {
+	switch {
+	case keyType == KeyUser && id.UserID != "":
+		return KeyUser + ":" + id.UserID
+	case keyType == KeyToken && id.Token != "":
+		sum := sha256.Sum256([]byte(id.Token))
+		return KeyToken + ":" + hex.EncodeToString(sum[:])
+	default:
+		return KeyIP + ":" + id.IP
+	}
+}
+
+// duration 将秒数转换为 time.Duration.
+func duration(seconds float64) time.Duration {
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// seconds 将 time.Duration 向上取整为秒数.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
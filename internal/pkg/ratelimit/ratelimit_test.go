@@ -0,0 +1,109 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLimiter 创建一个使用指定后端和可控时钟的 Limiter.
+func newTestLimiter(backend Backend) (*Limiter, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	l := &Limiter{
+		opts: &Options{
+			Enabled:   true,
+			KeyPrefix: "test:",
+			Default:   Rule{Limit: 1, Period: time.Second, Key: KeyUser},
+			Rules: map[string]Rule{
+				"auth.login":  {Limit: 2, Period: time.Minute, Burst: 3, Key: KeyIP},
+				"healthz.get": {},
+			},
+		},
+		backend: backend,
+	}
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestLimiter(t *testing.T) {
+	mr := miniredis.RunT(t)
+	backends := map[string]Backend{
+		BackendMemory: NewMemoryBackend(),
+		BackendRedis:  NewRedisBackend(redis.NewClient(&redis.Options{Addr: mr.Addr()})),
+	}
+
+	for name, backend := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			l, now := newTestLimiter(backend)
+			client := Identity{IP: "10.0.0.1"}
+
+			// 令牌桶容量为 3，前 3 个请求被允许
+			for i := 2; i >= 0; i-- {
+				result, err := l.Allow(ctx, "auth.login", client)
+				require.NoError(t, err)
+				assert.True(t, result.Allowed)
+				assert.Equal(t, 3, result.Limit)
+				assert.Equal(t, i, result.Remaining)
+			}
+
+			// 令牌用完后被拒绝，每分钟补充 2 个令牌，需要等待 30 秒
+			result, err := l.Allow(ctx, "auth.login", client)
+			require.NoError(t, err)
+			assert.False(t, result.Allowed)
+			assert.Equal(t, 30, result.RetryAfterSeconds())
+			assert.Equal(t, "30", result.Headers()[HeaderRetryAfter])
+			assert.Equal(t, "90", result.Headers()[HeaderReset])
+
+			// 其他 IP 不受影响
+			result, err = l.Allow(ctx, "auth.login", Identity{IP: "10.0.0.2"})
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+
+			// 30 秒后补充了 1 个令牌
+			*now = now.Add(30 * time.Second)
+			result, err = l.Allow(ctx, "auth.login", client)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 0, result.Remaining)
+
+			// 默认规则按用户计数，未认证时按 IP 计数
+			result, err = l.Allow(ctx, "posts.list", Identity{IP: "10.0.0.1", UserID: "user-000001"})
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			result, err = l.Allow(ctx, "posts.list", Identity{IP: "10.0.0.3", UserID: "user-000001"})
+			require.NoError(t, err)
+			assert.False(t, result.Allowed)
+			result, err = l.Allow(ctx, "posts.list", Identity{IP: "10.0.0.3"})
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+
+			// 单独配置为不限流的接口
+			result, err = l.Allow(ctx, "healthz.get", client)
+			require.NoError(t, err)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	opts := NewOptions()
+	assert.Empty(t, opts.Validate())
+
+	opts.Backend = "memcached"
+	opts.Default = Rule{Limit: 10}
+	opts.Rules["auth.logn"] = Rule{Limit: 1, Period: time.Second}
+	opts.Rules["users.create"] = Rule{Limit: 1, Period: time.Second, Key: "email"}
+	assert.Len(t, opts.Validate(), 4)
+}
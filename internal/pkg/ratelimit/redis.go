@@ -0,0 +1,84 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript 在 Redis 中原子地补充令牌并取出一个令牌.
+// 令牌桶保存为一个 hash，tokens 是剩余令牌数，ts 是上次更新的毫秒时间戳.
+// 令牌桶恢复满之后就不再需要保存，所以过期时间设置为恢复满所需的时间.
+var takeScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = capacity
+  ts = now
+end
+
+if now > ts then
+  tokens = math.min(capacity, tokens + (now - ts) * rate / 1000)
+  ts = now
+end
+
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(ts))
+redis.call('PEXPIRE', KEYS[1], ttl)
+return {allowed, tostring(tokens)}
+`)
+
+// redisBackend 将令牌桶保存在 Redis 中，多个副本共享同一个令牌桶.
+type redisBackend struct {
+	client redis.UniversalClient
+}
+
+// 确保 redisBackend 实现了 Backend 接口.
+var _ Backend = (*redisBackend)(nil)
+
+// NewRedisBackend 创建一个基于 Redis 的 Backend.
+func NewRedisBackend(client redis.UniversalClient) Backend {
+	return &redisBackend{client: client}
+}
+
+// Take 从令牌桶中取出一个令牌.
+// 当前时间由调用方传入，各副本之间的时钟误差会影响令牌补充的精度，但不会影响正确性.
+func (r *redisBackend) Take(ctx context.Context, key string, capacity int, rate float64, now time.Time) (bool, float64, error) {
+	ttl := int64(math.Ceil(float64(capacity)/rate*1000)) + 1000
+	ret, err := takeScript.Run(ctx, r.client, []string{key}, capacity, strconv.FormatFloat(rate, 'f', -1, 64), now.UnixMilli(), ttl).Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	if len(ret) != 2 {
+		return false, 0, fmt.Errorf("unexpected rate limit script result: %v", ret)
+	}
+
+	allowed, _ := ret[0].(int64)
+	tokensStr, _ := ret[1].(string)
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return false, 0, err
+	}
+	return allowed == 1, tokens, nil
+}
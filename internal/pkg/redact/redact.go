@@ -0,0 +1,69 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package redact 用于在记录请求内容之前脱敏其中的密码、令牌等敏感字段.
+package redact
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Mask 是敏感字段被替换后的值.
+const Mask = "******"
+
+// sensitiveKeywords 是敏感字段名中包含的关键字，字段名匹配时不区分大小写.
+var sensitiveKeywords = []string{"password", "token", "secret", "authorization", "verifier"}
+
+// sensitiveKeys 是需要完整匹配的敏感字段名，例如二次验证动态码、OIDC 授权码和恢复码.
+var sensitiveKeys = map[string]struct{}{
+	"code":          {},
+	"recoverycodes": {},
+	"nonce":         {},
+}
+
+// IsSensitive 判断字段名是否为敏感字段.
+func IsSensitive(key string) bool {
+	key = strings.ToLower(key)
+	if _, ok := sensitiveKeys[key]; ok {
+		return true
+	}
+	for _, keyword := range sensitiveKeywords {
+		if strings.Contains(key, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value 递归地替换 v 中敏感字段的值，v 通常由 encoding/json 解码得到.
+// 传入的 map 和 slice 会被原地修改.
+func Value(v any) any {
+	switch typed := v.(type) {
+	case map[string]any:
+		for key, val := range typed {
+			if IsSensitive(key) {
+				typed[key] = Mask
+				continue
+			}
+			typed[key] = Value(val)
+		}
+	case []any:
+		for i, val := range typed {
+			typed[i] = Value(val)
+		}
+	}
+	return v
+}
+
+// JSON 解析 data 并替换其中敏感字段的值，data 为空或者不是合法的 JSON 时返回 nil.
+func JSON(data []byte) any {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil
+	}
+	return Value(v)
+}
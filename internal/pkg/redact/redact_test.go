@@ -0,0 +1,38 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package redact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSON(t *testing.T) {
+	got := JSON([]byte(`{
+		"username": "colin",
+		"password": "miniblog1234",
+		"oldPassword": "old",
+		"challengeToken": "jwt",
+		"code": "123456",
+		"items": [{"refreshToken": "jwt", "postID": "post-1"}]
+	}`))
+
+	assert.Equal(t, map[string]any{
+		"username":       "colin",
+		"password":       Mask,
+		"oldPassword":    Mask,
+		"challengeToken": Mask,
+		"code":           Mask,
+		"items":          []any{map[string]any{"refreshToken": Mask, "postID": "post-1"}},
+	}, got)
+}
+
+func TestJSONInvalid(t *testing.T) {
+	assert.Nil(t, JSON(nil))
+	assert.Nil(t, JSON([]byte("not json")))
+}
@@ -17,6 +17,8 @@ const (
 	UserID ResourceID = "user"
 	// PostID 定义博文资源标识符.
 	PostID ResourceID = "post"
+	// OrgID 定义组织资源标识符.
+	OrgID ResourceID = "org"
 )
 
 // String 将资源标识符转换为字符串.
@@ -11,6 +11,7 @@ import (
 	"net"
 
 	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mtls"
 	genericoptions "github.com/onexstack/onexstack/pkg/options"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -26,9 +27,11 @@ type GRPCServer struct {
 }
 
 // NewGRPCServer 创建一个新的 GRPC 服务器实例.
+// 启用 TLS 时，会根据 mtlsOptions 的配置校验客户端证书.
 func NewGRPCServer(
 	grpcOptions *genericoptions.GRPCOptions,
 	tlsOptions *genericoptions.TLSOptions,
+	mtlsOptions *mtls.Options,
 	serverOptions []grpc.ServerOption,
 	registerServer func(grpc.ServiceRegistrar),
 ) (*GRPCServer, error) {
@@ -39,7 +42,11 @@ func NewGRPCServer(
 	}
 
 	if tlsOptions != nil && tlsOptions.UseTLS {
-		tlsConfig := tlsOptions.MustTLSConfig()
+		tlsConfig, err := mtlsOptions.ServerTLSConfig(tlsOptions.MustTLSConfig())
+		if err != nil {
+			log.Errorw("Failed to build GRPC server TLS config", "err", err)
+			return nil, err
+		}
 		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
 	}
 
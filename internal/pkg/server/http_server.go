@@ -13,6 +13,7 @@ import (
 	"net/http"
 
 	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mtls"
 	genericoptions "github.com/onexstack/onexstack/pkg/options"
 )
 
@@ -22,10 +23,21 @@ type HTTPServer struct {
 }
 
 // NewHTTPServer 创建一个新的 HTTP 服务器实例.
-func NewHTTPServer(httpOptions *genericoptions.HTTPOptions, tlsOptions *genericoptions.TLSOptions, handler http.Handler) *HTTPServer {
+// 启用 TLS 时，会根据 mtlsOptions 的配置校验客户端证书.
+func NewHTTPServer(
+	httpOptions *genericoptions.HTTPOptions,
+	tlsOptions *genericoptions.TLSOptions,
+	mtlsOptions *mtls.Options,
+	handler http.Handler,
+) (*HTTPServer, error) {
 	var tlsConfig *tls.Config
 	if tlsOptions != nil && tlsOptions.UseTLS {
-		tlsConfig = tlsOptions.MustTLSConfig()
+		var err error
+		tlsConfig, err = mtlsOptions.ServerTLSConfig(tlsOptions.MustTLSConfig())
+		if err != nil {
+			log.Errorw("Failed to build HTTP server TLS config", "err", err)
+			return nil, err
+		}
 	}
 
 	return &HTTPServer{
@@ -34,7 +46,7 @@ func NewHTTPServer(httpOptions *genericoptions.HTTPOptions, tlsOptions *generico
 			Handler:   handler,
 			TLSConfig: tlsConfig,
 		},
-	}
+	}, nil
 }
 
 // RunOrDir 启动 HTTP 服务器并在出错时记录致命错误.
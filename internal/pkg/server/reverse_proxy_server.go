@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/TobyIcetea/miniblog/internal/pkg/log"
+	"github.com/TobyIcetea/miniblog/internal/pkg/mtls"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	genericoptions "github.com/onexstack/onexstack/pkg/options"
 	"google.golang.org/grpc"
@@ -29,16 +30,25 @@ type GRPCGatewayServer struct {
 }
 
 // NewGRPCGatewayServer 创建一个新的 GRPC 网关服务器实例.
+// 网关访问 gRPC 服务器时会校验服务器证书，并使用 mtlsOptions 中配置的客户端证书完成双向认证.
+// 网关对外提供的 HTTP 服务只使用服务端 TLS，调用方通过 JWT 认证.
 func NewGRPCGatewayServer(
 	httpOptions *genericoptions.HTTPOptions,
 	grpcOptions *genericoptions.GRPCOptions,
 	tlsOptions *genericoptions.TLSOptions,
+	mtlsOptions *mtls.Options,
 	registerHandler func(mux *runtime.ServeMux, conn *grpc.ClientConn) error,
+	muxOptions ...runtime.ServeMuxOption,
 ) (*GRPCGatewayServer, error) {
 	var tlsConfig *tls.Config
 	if tlsOptions != nil && tlsOptions.UseTLS {
 		tlsConfig = tlsOptions.MustTLSConfig()
-		tlsConfig.InsecureSkipVerify = true
+	}
+
+	clientTLSConfig, err := mtlsOptions.ClientTLSConfig(tlsOptions)
+	if err != nil {
+		log.Errorw("Failed to build GRPC client TLS config", "err", err)
+		return nil, err
 	}
 
 	dialOptions := []grpc.DialOption{
@@ -48,8 +58,8 @@ func NewGRPCGatewayServer(
 		}),
 	}
 
-	if tlsConfig != nil {
-		dialOptions = append(dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if clientTLSConfig != nil {
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(clientTLSConfig)))
 	} else {
 		dialOptions = append(dialOptions, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
@@ -60,13 +70,13 @@ func NewGRPCGatewayServer(
 		return nil, err
 	}
 
-	gwmux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+	gwmux := runtime.NewServeMux(append([]runtime.ServeMuxOption{runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
 		MarshalOptions: protojson.MarshalOptions{
 			// 设置序列化 protobuf 数据时，枚举类型的字段以数字格式输出
 			// 否则，默认会以字符串格式输出，跟枚举类型定义不一致，带来理解成本
 			UseEnumNumbers: true,
 		},
-	}))
+	})}, muxOptions...)...)
 
 	if err := registerHandler(gwmux, conn); err != nil {
 		log.Errorw("Failed to register handler", "err", err)
@@ -0,0 +1,49 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package tenant
+
+import (
+	"errors"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/net/http/httpguts"
+)
+
+// Options 包含多租户相关的配置选项.
+type Options struct {
+	// Header 是用于指定租户的请求头名称，值可以是组织 ID 或者组织短名称
+	Header string `json:"header" mapstructure:"header"`
+	// BaseDomain 是用于子域名解析的基础域名，例如 blog.example.com，
+	// 此时 acme.blog.example.com 会被解析为短名称为 acme 的组织，为空时不使用子域名解析
+	BaseDomain string `json:"base-domain" mapstructure:"base-domain"`
+}
+
+// NewOptions 创建带有默认值的 Options 实例.
+func NewOptions() *Options {
+	return &Options{
+		Header: "X-Tenant-ID",
+	}
+}
+
+// AddFlags 将多租户相关的选项绑定到命令行标志.
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Header, "tenant.header", o.Header, "Request header used to select the organization (tenant) by ID or slug.")
+	fs.StringVar(&o.BaseDomain, "tenant.base-domain", o.BaseDomain, "Base domain used to resolve the organization from the subdomain, e.g. blog.example.com. Empty disables subdomain resolution.")
+}
+
+// Validate 校验多租户配置选项是否合法.
+func (o *Options) Validate() []error {
+	errs := []error{}
+
+	if o.Header == "" {
+		errs = append(errs, errors.New("tenant.header cannot be empty"))
+	} else if !httpguts.ValidHeaderFieldName(o.Header) {
+		errs = append(errs, errors.New("tenant.header is not a valid header name"))
+	}
+
+	return errs
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Package tenant 提供从请求中解析租户（组织）的功能.
+// 租户可以通过请求头指定组织 ID 或者短名称，也可以通过 <slug>.<base-domain> 形式的子域名指定.
+package tenant
+
+import (
+	"net"
+	"strings"
+
+	"github.com/google/wire"
+)
+
+// ProviderSet 是 tenant 包的 Wire Provider 集合.
+var ProviderSet = wire.NewSet(NewResolver)
+
+// Resolver 根据配置从请求头或者主机名中解析租户.
+type Resolver struct {
+	header     string
+	baseDomain string
+}
+
+// NewResolver 创建一个 Resolver 实例.
+func NewResolver(opts *Options) *Resolver {
+	return &Resolver{
+		header:     opts.Header,
+		baseDomain: strings.ToLower(strings.Trim(opts.BaseDomain, ".")),
+	}
+}
+
+// Header 返回用于指定租户的请求头名称.
+func (r *Resolver) Header() string {
+	return r.header
+}
+
+// Resolve 根据请求头的值和请求的主机名解析租户，返回组织 ID 或者组织短名称.
+// 请求头优先于子域名，两者都没有指定租户时返回空字符串，表示使用默认租户.
+func (r *Resolver) Resolve(headerValue, host string) string {
+	if tenant := strings.TrimSpace(headerValue); tenant != "" {
+		return tenant
+	}
+	if r.baseDomain == "" || host == "" {
+		return ""
+	}
+
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	// 只取基础域名的下一级子域名，例如 acme.blog.example.com 中的 acme
+	sub, ok := strings.CutSuffix(host, "."+r.baseDomain)
+	if !ok || sub == "" || strings.Contains(sub, ".") {
+		return ""
+	}
+	return sub
+}
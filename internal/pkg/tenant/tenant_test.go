@@ -0,0 +1,38 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package tenant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolverResolve(t *testing.T) {
+	resolver := NewResolver(&Options{Header: "X-Tenant-ID", BaseDomain: "blog.example.com."})
+
+	tests := []struct {
+		name   string
+		header string
+		host   string
+		want   string
+	}{
+		{"header first", "org-abc", "acme.blog.example.com", "org-abc"},
+		{"subdomain", "", "acme.blog.example.com", "acme"},
+		{"subdomain with port", "", "Acme.blog.example.com:8080", "acme"},
+		{"base domain", "", "blog.example.com", ""},
+		{"nested subdomain", "", "a.acme.blog.example.com", ""},
+		{"other domain", "", "acme.example.org", ""},
+		{"no tenant", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resolver.Resolve(tt.header, tt.host))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package validation
+
+import (
+	"context"
+	"time"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
+)
+
+// ValidateAuditRules 校验审计日志相关字段的有效性.
+func (v *Validator) ValidateAuditRules() genericvalidation.Rules {
+	// 通用的时间校验函数，空字符串表示不限制
+	validateTime := func(name string) genericvalidation.ValidatorFunc {
+		return func(value any) error {
+			if value.(string) == "" {
+				return nil
+			}
+			if _, err := time.Parse(time.RFC3339, value.(string)); err != nil {
+				return errno.WithMessage(errno.ErrInvalidArgument, "%s must be in RFC3339 format, e.g. 2025-01-01T00:00:00Z", name)
+			}
+			return nil
+		}
+	}
+
+	// 定义各字段的校验逻辑，通过一个 map 实现模块化和简化
+	return genericvalidation.Rules{
+		"Limit": func(value any) error {
+			if value.(int64) <= 0 {
+				return errno.WithMessage(errno.ErrInvalidArgument, "limit must be greater than 0")
+			}
+			return nil
+		},
+		"Offset": func(value any) error {
+			return nil
+		},
+		"Outcome": func(value any) error {
+			switch value.(string) {
+			case "", known.AuditOutcomeSuccess, known.AuditOutcomeFailure:
+				return nil
+			}
+			return errno.WithMessage(errno.ErrInvalidArgument, "outcome must be either %s or %s", known.AuditOutcomeSuccess, known.AuditOutcomeFailure)
+		},
+		"StartTime": validateTime("startTime"),
+		"EndTime":   validateTime("endTime"),
+	}
+}
+
+// ValidateListAuditEventsRequest 校验 ListAuditEventsRequest 结构体的有效性.
+func (v *Validator) ValidateListAuditEventsRequest(ctx context.Context, rq *apiv1.ListAuditEventsRequest) error {
+	if err := genericvalidation.ValidateAllFields(rq, v.ValidateAuditRules()); err != nil {
+		return err
+	}
+
+	if rq.GetStartTime() != "" && rq.GetEndTime() != "" {
+		startTime, _ := time.Parse(time.RFC3339, rq.GetStartTime())
+		endTime, _ := time.Parse(time.RFC3339, rq.GetEndTime())
+		if !startTime.Before(endTime) {
+			return errno.WithMessage(errno.ErrInvalidArgument, "startTime must be before endTime")
+		}
+	}
+	return nil
+}
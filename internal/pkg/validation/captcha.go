@@ -0,0 +1,41 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package validation
+
+import (
+	"context"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/captcha"
+	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
+)
+
+// ValidateCaptchaRules 返回人机验证相关请求的校验规则.
+func (v *Validator) ValidateCaptchaRules() genericvalidation.Rules {
+	return genericvalidation.Rules{
+		"Action": func(value any) error {
+			switch value.(string) {
+			case captcha.ActionLogin, captcha.ActionRegister:
+				return nil
+			default:
+				return errno.WithMessage(errno.ErrInvalidArgument, "action must be %s or %s", captcha.ActionLogin, captcha.ActionRegister)
+			}
+		},
+	}
+}
+
+// ValidateCreateCaptchaRequest 校验 CreateCaptchaRequest 结构体的有效性.
+func (v *Validator) ValidateCreateCaptchaRequest(ctx context.Context, rq *apiv1.CreateCaptchaRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateCaptchaRules())
+}
+
+// checkCaptcha 记录一次 action 请求，并在需要人机验证时校验请求携带的挑战答案.
+func (v *Validator) checkCaptcha(ctx context.Context, action string, answer *apiv1.Captcha) error {
+	return v.captcha.Check(ctx, action, contextx.ClientIP(ctx), answer.GetCaptchaID(), answer.GetSolution())
+}
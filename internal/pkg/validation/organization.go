@@ -0,0 +1,115 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package validation
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
+)
+
+// slugRegex 校验组织短名称，短名称会作为子域名使用，因此需要符合 DNS label 的规则.
+var slugRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// ValidateOrganizationRules 校验组织相关字段的有效性.
+func (v *Validator) ValidateOrganizationRules() genericvalidation.Rules {
+	// 定义各字段的校验逻辑，通过一个 map 实现模块化和简化
+	return genericvalidation.Rules{
+		"OrgID": func(value any) error {
+			if value.(string) == "" {
+				return errno.WithMessage(errno.ErrInvalidArgument, "orgID cannot be empty")
+			}
+			return nil
+		},
+		"Slug": func(value any) error {
+			slug := value.(string)
+			if !slugRegex.MatchString(slug) {
+				return errno.WithMessage(errno.ErrInvalidArgument, "slug must consist of lower case alphanumeric characters or '-', and be at most 63 characters")
+			}
+			// 组织 ID 以 org- 开头，禁止使用该前缀避免短名称和组织 ID 混淆
+			if slug == known.DefaultTenantID || strings.HasPrefix(slug, "org-") {
+				return errno.WithMessage(errno.ErrOrganizationReserved, "slug %s is reserved", slug)
+			}
+			return nil
+		},
+		"Name": func(value any) error {
+			name := value.(string)
+			if name == "" {
+				return errno.WithMessage(errno.ErrInvalidArgument, "name cannot be empty")
+			}
+			if len(name) > 255 {
+				return errno.WithMessage(errno.ErrInvalidArgument, "name must be at most 255 characters")
+			}
+			return nil
+		},
+		"UserID": func(value any) error {
+			if value.(string) == "" {
+				return errno.WithMessage(errno.ErrInvalidArgument, "userID cannot be empty")
+			}
+			return nil
+		},
+		"Role": func(value any) error {
+			switch value.(string) {
+			case "", "owner", "admin", "member":
+				return nil
+			}
+			return errno.WithMessage(errno.ErrInvalidArgument, "role must be owner, admin or member")
+		},
+		"Limit": func(value any) error {
+			if value.(int64) <= 0 {
+				return errno.WithMessage(errno.ErrInvalidArgument, "limit must be greater than 0")
+			}
+			return nil
+		},
+		"Offset": func(value any) error {
+			if value.(int64) < 0 {
+				return errno.WithMessage(errno.ErrInvalidArgument, "offset must be greater than or equal to 0")
+			}
+			return nil
+		},
+	}
+}
+
+// ValidateCreateOrganizationRequest 校验 CreateOrganizationRequest 结构体的有效性.
+func (v *Validator) ValidateCreateOrganizationRequest(ctx context.Context, rq *apiv1.CreateOrganizationRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateOrganizationRules())
+}
+
+// ValidateDeleteOrganizationRequest 校验 DeleteOrganizationRequest 结构体的有效性.
+func (v *Validator) ValidateDeleteOrganizationRequest(ctx context.Context, rq *apiv1.DeleteOrganizationRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateOrganizationRules())
+}
+
+// ValidateGetOrganizationRequest 校验 GetOrganizationRequest 结构体的有效性.
+func (v *Validator) ValidateGetOrganizationRequest(ctx context.Context, rq *apiv1.GetOrganizationRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateOrganizationRules())
+}
+
+// ValidateListOrganizationsRequest 校验 ListOrganizationsRequest 结构体的有效性.
+func (v *Validator) ValidateListOrganizationsRequest(ctx context.Context, rq *apiv1.ListOrganizationsRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateOrganizationRules())
+}
+
+// ValidateListOrganizationMembersRequest 校验 ListOrganizationMembersRequest 结构体的有效性.
+func (v *Validator) ValidateListOrganizationMembersRequest(ctx context.Context, rq *apiv1.ListOrganizationMembersRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateOrganizationRules())
+}
+
+// ValidateAddOrganizationMemberRequest 校验 AddOrganizationMemberRequest 结构体的有效性.
+func (v *Validator) ValidateAddOrganizationMemberRequest(ctx context.Context, rq *apiv1.AddOrganizationMemberRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateOrganizationRules())
+}
+
+// ValidateRemoveOrganizationMemberRequest 校验 RemoveOrganizationMemberRequest 结构体的有效性.
+func (v *Validator) ValidateRemoveOrganizationMemberRequest(ctx context.Context, rq *apiv1.RemoveOrganizationMemberRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateOrganizationRules())
+}
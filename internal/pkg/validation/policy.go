@@ -0,0 +1,189 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package validation
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
+)
+
+// casbinFieldMaxLength 是 casbin_rule 表中 v0 ~ v5 字段的最大长度.
+const casbinFieldMaxLength = 100
+
+// roleRegex 校验角色名称，例如 role::editor.
+var roleRegex = regexp.MustCompile(`^role::[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// ValidatePolicyRules 校验访问控制策略相关字段的有效性.
+func (v *Validator) ValidatePolicyRules() genericvalidation.Rules {
+	// 通用的策略字段校验函数
+	validateField := func(name string) genericvalidation.ValidatorFunc {
+		return func(value any) error {
+			return isValidCasbinField(name, value.(string))
+		}
+	}
+
+	// 定义各字段的校验逻辑，通过一个 map 实现模块化和简化
+	return genericvalidation.Rules{
+		"Subject": func(value any) error {
+			subject := value.(string)
+			if strings.HasPrefix(subject, known.RolePrefix) {
+				return isValidRole(subject)
+			}
+			return isValidCasbinField("subject", subject)
+		},
+		"Domain": func(value any) error {
+			// 空字符串表示全局（所有租户）
+			if value.(string) == "" {
+				return nil
+			}
+			return isValidCasbinField("domain", value.(string))
+		},
+		"Object": validateField("object"),
+		"Action": validateField("action"),
+		"Effect": func(value any) error {
+			return isValidEffect(value.(string))
+		},
+		"Role": func(value any) error {
+			return isValidRole(value.(string))
+		},
+		"Inherits": func(value any) error {
+			for _, role := range value.([]string) {
+				if err := isValidRole(role); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		"Policies": func(value any) error {
+			for _, policy := range value.([]*apiv1.Policy) {
+				if policy.GetDomain() != "" {
+					if err := isValidCasbinField("domain", policy.GetDomain()); err != nil {
+						return err
+					}
+				}
+				if err := isValidCasbinField("object", policy.GetObject()); err != nil {
+					return err
+				}
+				if err := isValidCasbinField("action", policy.GetAction()); err != nil {
+					return err
+				}
+				if err := isValidEffect(policy.GetEffect()); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		"Limit": func(value any) error {
+			if value.(int64) <= 0 {
+				return errno.WithMessage(errno.ErrInvalidArgument, "limit must be greater than 0")
+			}
+			return nil
+		},
+		"Offset": func(value any) error {
+			if value.(int64) < 0 {
+				return errno.WithMessage(errno.ErrInvalidArgument, "offset must be greater than or equal to 0")
+			}
+			return nil
+		},
+	}
+}
+
+// ValidateListPoliciesRequest 校验 ListPoliciesRequest 结构体的有效性.
+func (v *Validator) ValidateListPoliciesRequest(ctx context.Context, rq *apiv1.ListPoliciesRequest) error {
+	return genericvalidation.ValidateSelectedFields(rq, v.ValidatePolicyRules(), "Offset", "Limit")
+}
+
+// ValidateCreatePolicyRequest 校验 CreatePolicyRequest 结构体的有效性.
+func (v *Validator) ValidateCreatePolicyRequest(ctx context.Context, rq *apiv1.CreatePolicyRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidatePolicyRules())
+}
+
+// ValidateDeletePolicyRequest 校验 DeletePolicyRequest 结构体的有效性.
+func (v *Validator) ValidateDeletePolicyRequest(ctx context.Context, rq *apiv1.DeletePolicyRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidatePolicyRules())
+}
+
+// ValidateListRoleAssignmentsRequest 校验 ListRoleAssignmentsRequest 结构体的有效性.
+func (v *Validator) ValidateListRoleAssignmentsRequest(ctx context.Context, rq *apiv1.ListRoleAssignmentsRequest) error {
+	return genericvalidation.ValidateSelectedFields(rq, v.ValidatePolicyRules(), "Offset", "Limit")
+}
+
+// ValidateAssignRoleRequest 校验 AssignRoleRequest 结构体的有效性.
+func (v *Validator) ValidateAssignRoleRequest(ctx context.Context, rq *apiv1.AssignRoleRequest) error {
+	if rq.GetSubject() == rq.GetRole() {
+		return errno.WithMessage(errno.ErrInvalidArgument, "a role cannot be assigned to itself")
+	}
+	return genericvalidation.ValidateAllFields(rq, v.ValidatePolicyRules())
+}
+
+// ValidateRevokeRoleRequest 校验 RevokeRoleRequest 结构体的有效性.
+func (v *Validator) ValidateRevokeRoleRequest(ctx context.Context, rq *apiv1.RevokeRoleRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidatePolicyRules())
+}
+
+// ValidateListRolesRequest 校验 ListRolesRequest 结构体的有效性.
+func (v *Validator) ValidateListRolesRequest(ctx context.Context, rq *apiv1.ListRolesRequest) error {
+	return nil
+}
+
+// ValidateCreateRoleRequest 校验 CreateRoleRequest 结构体的有效性.
+func (v *Validator) ValidateCreateRoleRequest(ctx context.Context, rq *apiv1.CreateRoleRequest) error {
+	if len(rq.GetInherits()) == 0 && len(rq.GetPolicies()) == 0 {
+		return errno.WithMessage(errno.ErrInvalidArgument, "a role must inherit another role or define at least one policy")
+	}
+	for _, role := range rq.GetInherits() {
+		if role == rq.GetRole() {
+			return errno.WithMessage(errno.ErrInvalidArgument, "a role cannot inherit itself")
+		}
+	}
+	return genericvalidation.ValidateAllFields(rq, v.ValidatePolicyRules())
+}
+
+// ValidateDeleteRoleRequest 校验 DeleteRoleRequest 结构体的有效性.
+func (v *Validator) ValidateDeleteRoleRequest(ctx context.Context, rq *apiv1.DeleteRoleRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidatePolicyRules())
+}
+
+// ValidateCheckPermissionRequest 校验 CheckPermissionRequest 结构体的有效性.
+func (v *Validator) ValidateCheckPermissionRequest(ctx context.Context, rq *apiv1.CheckPermissionRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidatePolicyRules())
+}
+
+// isValidCasbinField 校验策略字段非空，并且不超过 casbin_rule 表的字段长度.
+func isValidCasbinField(name, value string) error {
+	if value == "" {
+		return errno.WithMessage(errno.ErrInvalidArgument, "%s cannot be empty", name)
+	}
+	if len(value) > casbinFieldMaxLength {
+		return errno.WithMessage(errno.ErrInvalidArgument, "%s must be at most %d characters", name, casbinFieldMaxLength)
+	}
+	return nil
+}
+
+// isValidRole 校验角色名称是否合法.
+func isValidRole(role string) error {
+	if !roleRegex.MatchString(role) {
+		return errno.WithMessage(errno.ErrInvalidArgument, "role must match %s", roleRegex.String())
+	}
+	return nil
+}
+
+// isValidEffect 校验策略效果是否合法，空值表示 allow.
+func isValidEffect(effect string) error {
+	switch effect {
+	case "", known.EffectAllow, known.EffectDeny:
+		return nil
+	default:
+		return errno.WithMessage(errno.ErrInvalidArgument, "effect must be %q or %q", known.EffectAllow, known.EffectDeny)
+	}
+}
@@ -10,9 +10,11 @@ import (
 	"context"
 
 	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
 	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/onexstack/onexstack/pkg/errorsx"
 	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
 )
 
@@ -22,19 +24,31 @@ func (v *Validator) ValidatePostRules() genericvalidation.Rules {
 	return genericvalidation.Rules{
 		"PostID": func(value any) error {
 			if value.(string) == "" {
-				return errno.ErrInvalidArgument.WithMessage("postID cannot be empty")
+				return errno.WithMessage(errno.ErrInvalidArgument, "postID cannot be empty")
 			}
 			return nil
 		},
 		"Title": func(value any) error {
 			if value.(string) == "" {
-				return errno.ErrInvalidArgument.WithMessage("title cannot be empty")
+				return errno.WithMessage(errno.ErrInvalidArgument, "title cannot be empty")
+			}
+			return nil
+		},
+		"UserID": func(value any) error {
+			if value.(string) == "" {
+				return errno.WithMessage(errno.ErrInvalidArgument, "userID cannot be empty")
+			}
+			return nil
+		},
+		"Role": func(value any) error {
+			if value.(string) != "viewer" && value.(string) != "editor" {
+				return errno.WithMessage(errno.ErrInvalidArgument, "role must be viewer or editor")
 			}
 			return nil
 		},
 		"Content": func(value any) error {
 			if value.(string) == "" {
-				return errno.ErrInvalidArgument.WithMessage("content cannot be empty")
+				return errno.WithMessage(errno.ErrInvalidArgument, "content cannot be empty")
 			}
 			return nil
 		},
@@ -48,7 +62,10 @@ func (v *Validator) ValidateCreatePostRequest(ctx context.Context, rq *apiv1.Cre
 
 // ValidateUpdatePostRequest 校验更新用户请求.
 func (v *Validator) ValidateUpdatePostRequest(ctx context.Context, rq *apiv1.UpdatePostRequest) error {
-	return genericvalidation.ValidateAllFields(rq, v.ValidatePostRules())
+	if err := genericvalidation.ValidateAllFields(rq, v.ValidatePostRules()); err != nil {
+		return err
+	}
+	return validateUpdateMask(rq, rq.GetUpdateMask(), v.ValidatePostRules(), known.PostUpdatableFields...)
 }
 
 // ValidateDeletePostRequest 校验 DeletePostRequest 结构体的有效性.
@@ -58,13 +75,48 @@ func (v *Validator) ValidateDeletePostRequest(ctx context.Context, rq *apiv1.Del
 
 // ValidateGetPostRequest 校验 GetPostRequest 结构体的有效性.
 func (v *Validator) ValidateGetPostRequest(ctx context.Context, rq *apiv1.GetPostRequest) error {
-	return genericvalidation.ValidateAllFields(rq, v.ValidatePostRules())
+	if err := genericvalidation.ValidateAllFields(rq, v.ValidatePostRules()); err != nil {
+		return err
+	}
+	return validateReadMask(rq.GetReadMask(), &apiv1.Post{})
+}
+
+// ValidateBatchGetPostsRequest 校验 BatchGetPostsRequest 结构体的有效性.
+func (v *Validator) ValidateBatchGetPostsRequest(ctx context.Context, rq *apiv1.BatchGetPostsRequest) error {
+	return validateBatchIDs("postIDs", rq.GetPostIDs())
+}
+
+// ValidateBatchCreatePostsRequest 校验 BatchCreatePostsRequest 结构体的有效性.
+// 部分创建模式下也会校验所有文章，参数错误的请求不会创建任何文章.
+func (v *Validator) ValidateBatchCreatePostsRequest(ctx context.Context, rq *apiv1.BatchCreatePostsRequest) error {
+	if err := validateBatchSize("posts", len(rq.GetPosts())); err != nil {
+		return err
+	}
+	for i, post := range rq.GetPosts() {
+		if err := genericvalidation.ValidateAllFields(post, v.ValidatePostRules()); err != nil {
+			return errno.WithMessage(errno.ErrInvalidArgument, "posts[%d]: %s", i, errorsx.FromError(err).Message)
+		}
+	}
+	return nil
 }
 
 // ValidateListPostRequest 校验 ListPostRequest 结构体的有效性.
 func (v *Validator) ValidateListPostRequest(ctx context.Context, rq *apiv1.ListPostRequest) error {
 	if err := validation.Validate(rq.GetTitle(), validation.Length(5, 100), is.URL); err != nil {
-		return errno.ErrInvalidArgument.WithMessage(err.Error())
+		return errno.WithMessage(errno.ErrInvalidArgument, "%v", err)
+	}
+	if err := genericvalidation.ValidateSelectedFields(rq, v.ValidatePostRules(), "Offset", "Limit"); err != nil {
+		return err
 	}
-	return genericvalidation.ValidateSelectedFields(rq, v.ValidatePostRules(), "Offset", "Limit")
+	return validateReadMask(rq.GetReadMask(), &apiv1.Post{})
+}
+
+// ValidateSharePostRequest 校验 SharePostRequest 结构体的有效性.
+func (v *Validator) ValidateSharePostRequest(ctx context.Context, rq *apiv1.SharePostRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidatePostRules())
+}
+
+// ValidateUnsharePostRequest 校验 UnsharePostRequest 结构体的有效性.
+func (v *Validator) ValidateUnsharePostRequest(ctx context.Context, rq *apiv1.UnsharePostRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidatePostRules())
 }
@@ -9,28 +9,36 @@ package validation
 import (
 	"context"
 
+	"github.com/TobyIcetea/miniblog/internal/pkg/captcha"
 	"github.com/TobyIcetea/miniblog/internal/pkg/contextx"
 	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
 	apiv1 "github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1"
+	"github.com/onexstack/onexstack/pkg/store/where"
 	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
 )
 
 func (v *Validator) ValidateUserRules() genericvalidation.Rules {
-	// 通用的密码校验函数
-	validatePassword := func() genericvalidation.ValidatorFunc {
+	// 登录和校验旧密码时只要求密码非空，避免密码策略变更后已有用户无法登录
+	requirePassword := func(name string) genericvalidation.ValidatorFunc {
 		return func(value any) error {
-			return isValidPassword(value.(string))
+			if value.(string) == "" {
+				return errno.WithMessage(errno.ErrInvalidArgument, "%s cannot be empty", name)
+			}
+			return nil
 		}
 	}
 
 	// 定义各字段的校验逻辑，通过一个 map 实现模块化和简化
 	return genericvalidation.Rules{
-		"Password":    validatePassword(),
-		"OldPassword": validatePassword(),
-		"NewPassword": validatePassword(),
+		"Password":    requirePassword("password"),
+		"OldPassword": requirePassword("oldPassword"),
+		"NewPassword": func(value any) error {
+			return v.policy.Check(value.(string), "")
+		},
 		"UserID": func(value any) error {
 			if value.(string) == "" {
-				return errno.ErrInvalidArgument.WithMessage("userID cannot be empty")
+				return errno.WithMessage(errno.ErrInvalidArgument, "userID cannot be empty")
 			}
 			return nil
 		},
@@ -42,7 +50,7 @@ func (v *Validator) ValidateUserRules() genericvalidation.Rules {
 		},
 		"Nickname": func(value any) error {
 			if len(value.(string)) >= 30 {
-				return errno.ErrInvalidArgument.WithMessage("nickname must be less than 30 characters")
+				return errno.WithMessage(errno.ErrInvalidArgument, "nickname must be less than 30 characters")
 			}
 			return nil
 		},
@@ -54,40 +62,168 @@ func (v *Validator) ValidateUserRules() genericvalidation.Rules {
 		},
 		"Limit": func(value any) error {
 			if value.(int64) <= 0 {
-				return errno.ErrInvalidArgument.WithMessage("limit must be greater than 0")
+				return errno.WithMessage(errno.ErrInvalidArgument, "limit must be greater than 0")
 			}
 			return nil
 		},
 		"Offset": func(value any) error {
 			return nil
 		},
+		"Code": func(value any) error {
+			if value.(string) == "" {
+				return errno.WithMessage(errno.ErrInvalidArgument, "code cannot be empty")
+			}
+			return nil
+		},
+		"ChallengeToken": func(value any) error {
+			if value.(string) == "" {
+				return errno.WithMessage(errno.ErrInvalidArgument, "challengeToken cannot be empty")
+			}
+			return nil
+		},
+		"Provider": func(value any) error {
+			if value.(string) == "" {
+				return errno.WithMessage(errno.ErrInvalidArgument, "provider cannot be empty")
+			}
+			return nil
+		},
+		"State": func(value any) error {
+			if value.(string) == "" {
+				return errno.WithMessage(errno.ErrInvalidArgument, "state cannot be empty")
+			}
+			return nil
+		},
+		"SessionToken": func(value any) error {
+			if value.(string) == "" {
+				return errno.WithMessage(errno.ErrInvalidArgument, "sessionToken cannot be empty")
+			}
+			return nil
+		},
+		"Reason": func(value any) error {
+			if len(value.(string)) > 255 {
+				return errno.WithMessage(errno.ErrInvalidArgument, "reason must be at most 255 characters")
+			}
+			return nil
+		},
+		"Token": func(value any) error {
+			if value.(string) == "" {
+				return errno.WithMessage(errno.ErrInvalidArgument, "token cannot be empty")
+			}
+			return nil
+		},
 	}
 }
 
 // ValidateLoginRequest 校验登录请求.
 func (v *Validator) ValidateLoginRequest(ctx context.Context, rq *apiv1.LoginRequest) error {
+	if err := genericvalidation.ValidateAllFields(rq, v.ValidateUserRules()); err != nil {
+		return err
+	}
+	return v.checkCaptcha(ctx, captcha.ActionLogin, rq.GetCaptcha())
+}
+
+// ValidateLoginVerifyRequest 校验二次验证登录请求.
+func (v *Validator) ValidateLoginVerifyRequest(ctx context.Context, rq *apiv1.LoginVerifyRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateOIDCAuthorizeRequest 校验 OIDCAuthorizeRequest 结构体的有效性.
+func (v *Validator) ValidateOIDCAuthorizeRequest(ctx context.Context, rq *apiv1.OIDCAuthorizeRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateOIDCCallbackRequest 校验 OIDCCallbackRequest 结构体的有效性.
+func (v *Validator) ValidateOIDCCallbackRequest(ctx context.Context, rq *apiv1.OIDCCallbackRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateEnrollTOTPRequest 校验 EnrollTOTPRequest 结构体的有效性.
+func (v *Validator) ValidateEnrollTOTPRequest(ctx context.Context, rq *apiv1.EnrollTOTPRequest) error {
+	if rq.GetUserID() != contextx.UserID(ctx) {
+		return errno.WithMessage(errno.ErrPermissionDenied, "The logged-in user `%s` does not match request user `%s`", contextx.UserID(ctx), rq.GetUserID())
+	}
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateEnableTOTPRequest 校验 EnableTOTPRequest 结构体的有效性.
+func (v *Validator) ValidateEnableTOTPRequest(ctx context.Context, rq *apiv1.EnableTOTPRequest) error {
+	if rq.GetUserID() != contextx.UserID(ctx) {
+		return errno.WithMessage(errno.ErrPermissionDenied, "The logged-in user `%s` does not match request user `%s`", contextx.UserID(ctx), rq.GetUserID())
+	}
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateRequestPasswordResetRequest 校验 RequestPasswordResetRequest 结构体的有效性.
+func (v *Validator) ValidateRequestPasswordResetRequest(ctx context.Context, rq *apiv1.RequestPasswordResetRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateResetPasswordRequest 校验 ResetPasswordRequest 结构体的有效性.
+func (v *Validator) ValidateResetPasswordRequest(ctx context.Context, rq *apiv1.ResetPasswordRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateSendVerificationEmailRequest 校验 SendVerificationEmailRequest 结构体的有效性.
+func (v *Validator) ValidateSendVerificationEmailRequest(ctx context.Context, rq *apiv1.SendVerificationEmailRequest) error {
+	if rq.GetUserID() != contextx.UserID(ctx) {
+		return errno.WithMessage(errno.ErrPermissionDenied, "The logged-in user `%s` does not match request user `%s`", contextx.UserID(ctx), rq.GetUserID())
+	}
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateVerifyEmailRequest 校验 VerifyEmailRequest 结构体的有效性.
+func (v *Validator) ValidateVerifyEmailRequest(ctx context.Context, rq *apiv1.VerifyEmailRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateUnlockUserRequest 校验 UnlockUserRequest 结构体的有效性.
+func (v *Validator) ValidateUnlockUserRequest(ctx context.Context, rq *apiv1.UnlockUserRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateImpersonateRequest 校验 ImpersonateRequest 结构体的有效性.
+func (v *Validator) ValidateImpersonateRequest(ctx context.Context, rq *apiv1.ImpersonateRequest) error {
 	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
 }
 
 // ValidateChangePasswordRequest 校验 ChangePasswordRequest 结构体的有效性.
 func (v *Validator) ValidateChangePasswordRequest(ctx context.Context, rq *apiv1.ChangePasswordRequest) error {
 	if rq.GetUserID() != contextx.UserID(ctx) {
-		return errno.ErrPermissionDenied.WithMessage("The logged-in user `%s` does not match request user `%s`", contextx.UserID(ctx), rq.GetUserID())
+		return errno.WithMessage(errno.ErrPermissionDenied, "The logged-in user `%s` does not match request user `%s`", contextx.UserID(ctx), rq.GetUserID())
 	}
-	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+	if err := genericvalidation.ValidateAllFields(rq, v.ValidateUserRules()); err != nil {
+		return err
+	}
+
+	// 新密码不能包含用户名，需要查询数据库获取用户名
+	userM, err := v.store.User().Get(ctx, where.F("userID", rq.GetUserID()))
+	if err != nil {
+		return err
+	}
+	return v.policy.Check(rq.GetNewPassword(), userM.Username)
 }
 
 // ValidateCreateUserRequest 校验 CreateUserRequest 结构的有效性.
 func (v *Validator) ValidateCreateUserRequest(ctx context.Context, rq *apiv1.CreateUserRequest) error {
-	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+	if err := genericvalidation.ValidateAllFields(rq, v.ValidateUserRules()); err != nil {
+		return err
+	}
+	if err := v.policy.Check(rq.GetPassword(), rq.GetUsername()); err != nil {
+		return err
+	}
+	// 人机验证放在最后，避免参数错误的请求消耗掉客户端已经完成的挑战
+	return v.checkCaptcha(ctx, captcha.ActionRegister, rq.GetCaptcha())
 }
 
 // ValidateUpdateUserRequest 校验更新用户请求.
 func (v *Validator) ValidateUpdateUserRequest(ctx context.Context, rq *apiv1.UpdateUserRequest) error {
 	if rq.GetUserID() != contextx.UserID(ctx) {
-		return errno.ErrPermissionDenied.WithMessage("The logged-in user `%s` does not match request user `%s`", contextx.UserID(ctx), rq.GetUserID())
+		return errno.WithMessage(errno.ErrPermissionDenied, "The logged-in user `%s` does not match request user `%s`", contextx.UserID(ctx), rq.GetUserID())
 	}
-	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+	if err := genericvalidation.ValidateAllFields(rq, v.ValidateUserRules()); err != nil {
+		return err
+	}
+	return validateUpdateMask(rq, rq.GetUpdateMask(), v.ValidateUserRules(), known.UserUpdatableFields...)
 }
 
 // ValidateDateUsrRequest 校验 DeleteUserRequest 结构体的有效性.
@@ -95,15 +231,26 @@ func (v *Validator) ValidateDeleteUserRequest(ctx context.Context, rq *apiv1.Del
 	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
 }
 
+// ValidateBatchDeleteUsersRequest 校验 BatchDeleteUsersRequest 结构体的有效性.
+func (v *Validator) ValidateBatchDeleteUsersRequest(ctx context.Context, rq *apiv1.BatchDeleteUsersRequest) error {
+	return validateBatchIDs("userIDs", rq.GetUserIDs())
+}
+
 // ValidateGetUsreRequest 校验 GetUserRequest 结构体的有效性.
 func (v *Validator) ValidateGetUsreRequest(ctx context.Context, rq *apiv1.GetUserRequest) error {
 	if rq.GetUserID() != contextx.UserID(ctx) {
-		return errno.ErrPermissionDenied.WithMessage("The logged-in user `%s` does not match request user `%s`", contextx.UserID(ctx), rq.GetUserID())
+		return errno.WithMessage(errno.ErrPermissionDenied, "The logged-in user `%s` does not match request user `%s`", contextx.UserID(ctx), rq.GetUserID())
 	}
-	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+	if err := genericvalidation.ValidateAllFields(rq, v.ValidateUserRules()); err != nil {
+		return err
+	}
+	return validateReadMask(rq.GetReadMask(), &apiv1.User{})
 }
 
 // ValidateListUserRequest 校验 ListUserRequest 结构体的有效性.
 func (v *Validator) ValidateListUserRequest(ctx context.Context, rq *apiv1.ListUserRequest) error {
-	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+	if err := genericvalidation.ValidateAllFields(rq, v.ValidateUserRules()); err != nil {
+		return err
+	}
+	return validateReadMask(rq.GetReadMask(), &apiv1.User{})
 }
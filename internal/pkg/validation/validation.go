@@ -8,10 +8,19 @@ package validation
 
 import (
 	"regexp"
+	"strings"
 
 	"github.com/TobyIcetea/miniblog/internal/apiserver/store"
+	"github.com/TobyIcetea/miniblog/internal/pkg/captcha"
 	"github.com/TobyIcetea/miniblog/internal/pkg/errno"
+	"github.com/TobyIcetea/miniblog/internal/pkg/fieldmask"
+	"github.com/TobyIcetea/miniblog/internal/pkg/known"
+	"github.com/TobyIcetea/miniblog/internal/pkg/password"
 	"github.com/google/wire"
+	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 // Validator 是验证逻辑的实现结构体.
@@ -20,14 +29,16 @@ type Validator struct {
 	// 这里只是一个举例，如果验证时，有其他依赖的客户端/服务/资源等,
 	// 都可以一并注入进来
 	store store.IStore
+	// policy 是可配置的密码策略
+	policy *password.Policy
+	// captcha 用于校验注册和登录请求的人机验证
+	captcha *captcha.Manager
 }
 
 // 使用预编译的全局正则表达式，避免重复创建和编译.
 var (
 	lengthRegex = regexp.MustCompile(`^.{3,20}$`)                                        // 长度在 3 到 20 个字符之间
 	validRegex  = regexp.MustCompile(`^[A-Za-z0-9_]+$`)                                  // 仅包含字母、数字和下划线
-	letterRegex = regexp.MustCompile(`[A-Za-z]`)                                         // 至少包含一个字母
-	numberRegex = regexp.MustCompile(`\d`)                                               // 至少包含一个数字
 	emailRegex  = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`) // 邮箱格式
 	phoneRegex  = regexp.MustCompile(`^1[3-9]\d{9}$`)                                    // 中国手机号
 )
@@ -37,8 +48,8 @@ var (
 var ProviderSet = wire.NewSet(New)
 
 // New 创建一个新的 Validator 实例.
-func New(store store.IStore) *Validator {
-	return &Validator{store: store}
+func New(store store.IStore, policy *password.Policy, captcha *captcha.Manager) *Validator {
+	return &Validator{store: store, policy: policy, captcha: captcha}
 }
 
 // isValidUsername 校验用户名是否合法.
@@ -55,41 +66,16 @@ func isValidUsername(username string) bool {
 	return true
 }
 
-// isValidPassword 判断密码是否符合复杂度要求.
-func isValidPassword(password string) error {
-	// 检查新密码是否为空
-	if password == "" {
-		return errno.ErrInvalidArgument.WithMessage("password cannot be empty")
-	}
-
-	// 检查新密码的长度要求
-	if len(password) < 8 {
-		return errno.ErrInvalidArgument.WithMessage("password must be at least 8 characters long")
-	}
-
-	// 使用正则表达式检查是否至少包含一个字母
-	if !letterRegex.MatchString(password) {
-		return errno.ErrInvalidArgument.WithMessage("password must contain at least one letter")
-	}
-
-	// 使用正则表达式检查是否至少包含一个数字
-	if !numberRegex.MatchString(password) {
-		return errno.ErrInvalidArgument.WithMessage("password must contain at least one number")
-	}
-
-	return nil
-}
-
 // isValidEmail 判断电子邮件是否合法.
 func isValidEmail(email string) error {
 	// 检查电子邮件地址格式
 	if email == "" {
-		return errno.ErrInvalidArgument.WithMessage("email cannot be empty")
+		return errno.WithMessage(errno.ErrInvalidArgument, "email cannot be empty")
 	}
 
 	// 使用正则表达式校验电子邮件格式
 	if !emailRegex.MatchString(email) {
-		return errno.ErrInvalidArgument.WithMessage("invalid email format")
+		return errno.WithMessage(errno.ErrInvalidArgument, "invalid email format")
 	}
 
 	return nil
@@ -99,13 +85,63 @@ func isValidEmail(email string) error {
 func isValidPhone(phone string) error {
 	// 检查手机号码格式
 	if phone == "" {
-		return errno.ErrInvalidArgument.WithMessage("phone cannot be empty")
+		return errno.WithMessage(errno.ErrInvalidArgument, "phone cannot be empty")
 	}
 
 	// 使用正则表达式校验手机号码格式（假设是中国手机号，11 位数字）
 	if !phoneRegex.MatchString(phone) {
-		return errno.ErrInvalidArgument.WithMessage("invalid phone format")
+		return errno.WithMessage(errno.ErrInvalidArgument, "invalid phone format")
+	}
+
+	return nil
+}
+
+// validateBatchSize 校验批量请求的条目数，条目数必须在 1 到 known.MaxBatchSize 之间.
+func validateBatchSize(name string, size int) error {
+	if size == 0 {
+		return errno.WithMessage(errno.ErrInvalidArgument, "%s cannot be empty", name)
+	}
+	if size > known.MaxBatchSize {
+		return errno.WithMessage(errno.ErrInvalidArgument, "%s must contain at most %d items, got %d", name, known.MaxBatchSize, size)
+	}
+	return nil
+}
+
+// validateBatchIDs 校验批量请求中的资源 ID 列表，ID 列表的长度受 known.MaxBatchSize 限制，并且每个 ID 都不能为空.
+func validateBatchIDs(name string, ids []string) error {
+	if err := validateBatchSize(name, len(ids)); err != nil {
+		return err
 	}
+	for i, id := range ids {
+		if id == "" {
+			return errno.WithMessage(errno.ErrInvalidArgument, "%s[%d] cannot be empty", name, i)
+		}
+	}
+	return nil
+}
 
+// validateUpdateMask 校验 update_mask 中的字段，并按照更新之后的值校验这些字段.
+// 掩码中没有设置的字段会被清空，所以按零值校验，避免通过掩码清空必填字段.
+func validateUpdateMask(rq proto.Message, mask *fieldmaskpb.FieldMask, rules genericvalidation.Rules, allowed ...string) error {
+	m := rq.ProtoReflect()
+	paths, err := fieldmask.Paths(mask, m.Descriptor(), allowed...)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		rule, ok := rules[strings.ToUpper(path[:1])+path[1:]]
+		if !ok {
+			continue
+		}
+		if err := rule(m.Get(m.Descriptor().Fields().ByName(protoreflect.Name(path))).Interface()); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// validateReadMask 校验 read_mask 中的字段，字段必须是 resource 的顶层字段.
+func validateReadMask(mask *fieldmaskpb.FieldMask, resource proto.Message) error {
+	_, err := fieldmask.Paths(mask, resource.ProtoReflect().Descriptor())
+	return err
+}
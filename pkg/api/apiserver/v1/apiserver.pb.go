@@ -35,114 +35,342 @@ var File_apiserver_v1_apiserver_proto protoreflect.FileDescriptor
 
 const file_apiserver_v1_apiserver_proto_rawDesc = "" +
 	"\n" +
-	"\x1capiserver/v1/apiserver.proto\x12\vminiblog.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1aapiserver/v1/healthz.proto\x1a\x17apiserver/v1/post.proto\x1a\x17apiserver/v1/user.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\x9a\x10\n" +
-	"\bMiniBlog\x12\x7f\n" +
-	"\aHealthz\x12\x16.google.protobuf.Empty\x1a\x1c.miniblog.v1.HealthzResponse\">\x92A+\n" +
-	"\f服务治理\x12\x12服务健康检查*\aHealthz\x82\xd3\xe4\x93\x02\n" +
-	"\x12\b/healthz\x12w\n" +
-	"\x05Login\x12\x19.miniblog.v1.LoginRequest\x1a\x1a.miniblog.v1.LoginResponse\"7\x92A#\n" +
-	"\f用户管理\x12\f用户登录*\x05Login\x82\xd3\xe4\x93\x02\v:\x01*\"\x06/login\x12\x9b\x01\n" +
-	"\fRefreshToken\x12 .miniblog.v1.RefreshTokenRequest\x1a!.miniblog.v1.RefreshTokenResponse\"F\x92A*\n" +
-	"\f用户管理\x12\f刷新令牌*\fRefreshToken\x82\xd3\xe4\x93\x02\x13:\x01*\x1a\x0e/refresh-token\x12\xb7\x01\n" +
-	"\x0eChangePassword\x12\".miniblog.v1.ChangePasswordRequest\x1a#.miniblog.v1.ChangePasswordResponse\"\\\x92A,\n" +
-	"\f用户管理\x12\f修改密码*\x0eChangePassword\x82\xd3\xe4\x93\x02':\x01*\x1a\"/v1/users/{userID}/change-password\x12\x8e\x01\n" +
+	"\x1capiserver/v1/apiserver.proto\x12\vminiblog.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x18apiserver/v1/audit.proto\x1a\x1aapiserver/v1/healthz.proto\x1a\x1fapiserver/v1/organization.proto\x1a\x17apiserver/v1/post.proto\x1a\x19apiserver/v1/policy.proto\x1a\x1dapiserver/v1/permission.proto\x1a\x17apiserver/v1/user.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\xa8U\n" +
+	"\bMiniBlog\x12\x8e\x01\n" +
+	"\aHealthz\x12\x16.google.protobuf.Empty\x1a\x1c.miniblog.v1.HealthzResponse\"M\x92A+\n" +
+	"\f服务治理\x12\x12服务健康检查*\aHealthz\x8a\xb5\x18\vhealthz.get\x82\xd3\xe4\x93\x02\n" +
+	"\x12\b/healthz\x12\xb1\x02\n" +
+	"\rCreateCaptcha\x12!.miniblog.v1.CreateCaptchaRequest\x1a\".miniblog.v1.CreateCaptchaResponse\"\xd8\x01\x92A\xaf\x01\n" +
+	"\f用户管理\x12\x18签发人机验证挑战\x1av登录和注册需要人机验证时，先调用该接口获取挑战，并将挑战 ID 和答案随请求一起提交*\rCreateCaptcha\x8a\xb5\x18\x0ecaptcha.create\x82\xd3\xe4\x93\x02\r:\x01*\"\b/captcha\x12\x85\x01\n" +
+	"\x05Login\x12\x19.miniblog.v1.LoginRequest\x1a\x1a.miniblog.v1.LoginResponse\"E\x92A#\n" +
+	"\f用户管理\x12\f用户登录*\x05Login\x8a\xb5\x18\n" +
+	"auth.login\x82\xd3\xe4\x93\x02\v:\x01*\"\x06/login\x12\xab\x01\n" +
+	"\vLoginVerify\x12\x1f.miniblog.v1.LoginVerifyRequest\x1a .miniblog.v1.LoginVerifyResponse\"Y\x92A/\n" +
+	"\f用户管理\x12\x12二次验证登录*\vLoginVerify\x8a\xb5\x18\vauth.verify\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/login/verify\x12\x8f\x02\n" +
+	"\rOIDCAuthorize\x12!.miniblog.v1.OIDCAuthorizeRequest\x1a\".miniblog.v1.OIDCAuthorizeResponse\"\xb6\x01\x92A|\n" +
+	"\f用户管理\x12\x12发起 OIDC 登录\x1aI客户端需要保存返回的 sessionToken，并在回调时原样传回*\rOIDCAuthorize\x8a\xb5\x18\x0eoidc.authorize\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/oidc/{provider}/authorize\x12\xbd\x01\n" +
+	"\fOIDCCallback\x12 .miniblog.v1.OIDCCallbackRequest\x1a!.miniblog.v1.OIDCCallbackResponse\"h\x92A0\n" +
+	"\f用户管理\x12\x12完成 OIDC 登录*\fOIDCCallback\x8a\xb5\x18\roidc.callback\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/oidc/{provider}/callback\x12\xab\x01\n" +
+	"\fRefreshToken\x12 .miniblog.v1.RefreshTokenRequest\x1a!.miniblog.v1.RefreshTokenResponse\"V\x92A*\n" +
+	"\f用户管理\x12\f刷新令牌*\fRefreshToken\x8a\xb5\x18\fauth.refresh\x82\xd3\xe4\x93\x02\x13:\x01*\x1a\x0e/refresh-token\x12\xd0\x01\n" +
+	"\x0eChangePassword\x12\".miniblog.v1.ChangePasswordRequest\x1a#.miniblog.v1.ChangePasswordResponse\"u\x92A,\n" +
+	"\f用户管理\x12\f修改密码*\x0eChangePassword\x8a\xb5\x18\x15users.password.update\x82\xd3\xe4\x93\x02':\x01*\x1a\"/v1/users/{userID}/change-password\x12\xbd\x01\n" +
 	"\n" +
-	"CreateUser\x12\x1e.miniblog.v1.CreateUserRequest\x1a\x1f.miniblog.v1.CreateUserResponse\"?\x92A(\n" +
+	"EnrollTOTP\x12\x1e.miniblog.v1.EnrollTOTPRequest\x1a\x1f.miniblog.v1.EnrollTOTPResponse\"n\x92A4\n" +
+	"\f用户管理\x12\x18注册 TOTP 二次验证*\n" +
+	"EnrollTOTP\x8a\xb5\x18\x11users.totp.create\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/users/{userID}/totp\x12\xc4\x01\n" +
+	"\n" +
+	"EnableTOTP\x12\x1e.miniblog.v1.EnableTOTPRequest\x1a\x1f.miniblog.v1.EnableTOTPResponse\"u\x92A4\n" +
+	"\f用户管理\x12\x18启用 TOTP 二次验证*\n" +
+	"EnableTOTP\x8a\xb5\x18\x11users.totp.enable\x82\xd3\xe4\x93\x02#:\x01*\x1a\x1e/v1/users/{userID}/totp/enable\x12\xa3\x02\n" +
+	"\x14RequestPasswordReset\x12(.miniblog.v1.RequestPasswordResetRequest\x1a).miniblog.v1.RequestPasswordResetResponse\"\xb5\x01\x92A\x7f\n" +
+	"\f用户管理\x12\x12请求重置密码\x1aE无论邮箱是否存在都会返回成功，避免泄露用户信息*\x14RequestPasswordReset\x8a\xb5\x18\x15password-reset.create\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/password-reset\x12\xb9\x01\n" +
+	"\rResetPassword\x12!.miniblog.v1.ResetPasswordRequest\x1a\".miniblog.v1.ResetPasswordResponse\"a\x92A+\n" +
+	"\f用户管理\x12\f重置密码*\rResetPassword\x8a\xb5\x18\x15password-reset.update\x82\xd3\xe4\x93\x02\x14:\x01*\x1a\x0f/password-reset\x12\x84\x02\n" +
+	"\x15SendVerificationEmail\x12).miniblog.v1.SendVerificationEmailRequest\x1a*.miniblog.v1.SendVerificationEmailResponse\"\x93\x01\x92A?\n" +
+	"\f用户管理\x12\x18发送邮箱验证邮件*\x15SendVerificationEmail\x8a\xb5\x18\x1dusers.verification-email.send\x82\xd3\xe4\x93\x02*:\x01*\"%/v1/users/{userID}/verification-email\x12\xb3\x01\n" +
+	"\vVerifyEmail\x12\x1f.miniblog.v1.VerifyEmailRequest\x1a .miniblog.v1.VerifyEmailResponse\"a\x92A)\n" +
+	"\f用户管理\x12\f验证邮箱*\vVerifyEmail\x8a\xb5\x18\x19email-verification.verify\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/verify-email\x12\xca\x01\n" +
+	"\n" +
+	"UnlockUser\x12\x1e.miniblog.v1.UnlockUserRequest\x1a\x1f.miniblog.v1.UnlockUserResponse\"{\x92AH\n" +
+	"\f用户管理\x12\x12解除用户锁定\x1a\x18仅管理员可以调用*\n" +
+	"UnlockUser\x8a\xb5\x18\x0flockouts.delete\x82\xd3\xe4\x93\x02\x17*\x15/v1/lockouts/{userID}\x12\x9d\x02\n" +
+	"\vImpersonate\x12\x1f.miniblog.v1.ImpersonateRequest\x1a .miniblog.v1.ImpersonateResponse\"\xca\x01\x92A\x88\x01\n" +
+	"\f用户管理\x12\f模拟登录\x1a]仅管理员可以调用，模拟登录期间不能修改密码、删除用户等敏感操作*\vImpersonate\x8a\xb5\x18\x11users.impersonate\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/users/{userID}/impersonate\x12\x9e\x01\n" +
+	"\n" +
+	"CreateUser\x12\x1e.miniblog.v1.CreateUserRequest\x1a\x1f.miniblog.v1.CreateUserResponse\"O\x92A(\n" +
 	"\f用户管理\x12\f创建用户*\n" +
-	"CreateUser\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/users\x12\x9d\x01\n" +
+	"CreateUser\x8a\xb5\x18\fusers.create\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/users\x12\xad\x01\n" +
 	"\n" +
-	"UpdateUser\x12\x1e.miniblog.v1.UpdateUserRequest\x1a\x1f.miniblog.v1.UpdateUserResponse\"N\x92A.\n" +
+	"UpdateUser\x12\x1e.miniblog.v1.UpdateUserRequest\x1a\x1f.miniblog.v1.UpdateUserResponse\"^\x92A.\n" +
 	"\f用户管理\x12\x12更新用户信息*\n" +
-	"UpdateUser\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/users/{userID}\x12\x94\x01\n" +
+	"UpdateUser\x8a\xb5\x18\fusers.update\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/users/{userID}\x12\xa4\x01\n" +
 	"\n" +
-	"DeleteUser\x12\x1e.miniblog.v1.DeleteUserRequest\x1a\x1f.miniblog.v1.DeleteUserResponse\"E\x92A(\n" +
+	"DeleteUser\x12\x1e.miniblog.v1.DeleteUserRequest\x1a\x1f.miniblog.v1.DeleteUserResponse\"U\x92A(\n" +
 	"\f用户管理\x12\f删除用户*\n" +
-	"DeleteUser\x82\xd3\xe4\x93\x02\x14*\x12/v1/users/{userID}\x12\x8e\x01\n" +
-	"\aGetUser\x12\x1b.miniblog.v1.GetUserRequest\x1a\x1c.miniblog.v1.GetUserResponse\"H\x92A+\n" +
-	"\f用户管理\x12\x12获取用户信息*\aGetUser\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/users/{userID}\x12\x89\x01\n" +
-	"\bListUser\x12\x1c.miniblog.v1.ListUserRequest\x1a\x1d.miniblog.v1.ListUserResponse\"@\x92A,\n" +
-	"\f用户管理\x12\x12列出所有用户*\bListUser\x82\xd3\xe4\x93\x02\v\x12\t/v1/users\x12\x8e\x01\n" +
+	"DeleteUser\x8a\xb5\x18\fusers.delete\x82\xd3\xe4\x93\x02\x14*\x12/v1/users/{userID}\x12\x99\x02\n" +
+	"\x10BatchDeleteUsers\x12$.miniblog.v1.BatchDeleteUsersRequest\x1a%.miniblog.v1.BatchDeleteUsersResponse\"\xb7\x01\x92A\x83\x01\n" +
+	"\f用户管理\x12\x12批量删除用户\x1aM仅管理员可以调用，不存在的用户会在响应的 errors 中返回*\x10BatchDeleteUsers\x8a\xb5\x18\x12users.batch-delete\x82\xd3\xe4\x93\x02\x14:\x01**\x0f/v1/users/batch\x12\x9b\x01\n" +
+	"\aGetUser\x12\x1b.miniblog.v1.GetUserRequest\x1a\x1c.miniblog.v1.GetUserResponse\"U\x92A+\n" +
+	"\f用户管理\x12\x12获取用户信息*\aGetUser\x8a\xb5\x18\tusers.get\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/users/{userID}\x12\x97\x01\n" +
+	"\bListUser\x12\x1c.miniblog.v1.ListUserRequest\x1a\x1d.miniblog.v1.ListUserResponse\"N\x92A,\n" +
+	"\f用户管理\x12\x12列出所有用户*\bListUser\x8a\xb5\x18\n" +
+	"users.list\x82\xd3\xe4\x93\x02\v\x12\t/v1/users\x12\x9e\x01\n" +
 	"\n" +
-	"CreatePost\x12\x1e.miniblog.v1.CreatePostRequest\x1a\x1f.miniblog.v1.CreatePostResponse\"?\x92A(\n" +
+	"CreatePost\x12\x1e.miniblog.v1.CreatePostRequest\x1a\x1f.miniblog.v1.CreatePostResponse\"O\x92A(\n" +
 	"\f博客管理\x12\f创建文章*\n" +
-	"CreatePost\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/posts\x12\x97\x01\n" +
+	"CreatePost\x8a\xb5\x18\fposts.create\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/posts\x12\xa7\x01\n" +
 	"\n" +
-	"UpdatePost\x12\x1e.miniblog.v1.UpdatePostRequest\x1a\x1f.miniblog.v1.UpdatePostResponse\"H\x92A(\n" +
+	"UpdatePost\x12\x1e.miniblog.v1.UpdatePostRequest\x1a\x1f.miniblog.v1.UpdatePostResponse\"X\x92A(\n" +
 	"\f博客管理\x12\f更新文章*\n" +
-	"UpdatePost\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/posts/{postID}\x12\x8e\x01\n" +
+	"UpdatePost\x8a\xb5\x18\fposts.update\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/posts/{postID}\x12\x9e\x01\n" +
 	"\n" +
-	"DeletePost\x12\x1e.miniblog.v1.DeletePostRequest\x1a\x1f.miniblog.v1.DeletePostResponse\"?\x92A(\n" +
+	"DeletePost\x12\x1e.miniblog.v1.DeletePostRequest\x1a\x1f.miniblog.v1.DeletePostResponse\"O\x92A(\n" +
 	"\f博客管理\x12\f删除文章*\n" +
-	"DeletePost\x82\xd3\xe4\x93\x02\x0e:\x01**\t/v1/posts\x12\x8e\x01\n" +
-	"\aGetPost\x12\x1b.miniblog.v1.GetPostRequest\x1a\x1c.miniblog.v1.GetPostResponse\"H\x92A+\n" +
-	"\f博客管理\x12\x12获取文章信息*\aGetPost\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/posts/{postID}\x12\x89\x01\n" +
-	"\bListPost\x12\x1c.miniblog.v1.ListPostRequest\x1a\x1d.miniblog.v1.ListPostResponse\"@\x92A,\n" +
-	"\f博客管理\x12\x12列出所有文章*\bListPost\x82\xd3\xe4\x93\x02\v\x12\t/v1/postsB\x9b\x02\x92A\xdf\x01\x12\xb5\x01\n" +
+	"DeletePost\x8a\xb5\x18\fposts.delete\x82\xd3\xe4\x93\x02\x0e:\x01**\t/v1/posts\x12\x9b\x01\n" +
+	"\aGetPost\x12\x1b.miniblog.v1.GetPostRequest\x1a\x1c.miniblog.v1.GetPostResponse\"U\x92A+\n" +
+	"\f博客管理\x12\x12获取文章信息*\aGetPost\x8a\xb5\x18\tposts.get\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/posts/{postID}\x12\xb2\x02\n" +
+	"\rBatchGetPosts\x12!.miniblog.v1.BatchGetPostsRequest\x1a\".miniblog.v1.BatchGetPostsResponse\"\xd9\x01\x92A\xab\x01\n" +
+	"\f博客管理\x12\x18批量获取文章信息\x1ar按请求中的 ID 顺序返回文章，不存在或者没有权限查看的文章 ID 在 missingPostIDs 中返回*\rBatchGetPosts\x8a\xb5\x18\x0fposts.batch-get\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/posts/batch\x12\xaf\x02\n" +
+	"\x10BatchCreatePosts\x12$.miniblog.v1.BatchCreatePostsRequest\x1a%.miniblog.v1.BatchCreatePostsResponse\"\xcd\x01\x92A\x99\x01\n" +
+	"\f博客管理\x12\x12批量创建文章\x1acpartial 为 false 时在一个事务中创建所有文章，为 true 时跳过创建失败的文章*\x10BatchCreatePosts\x8a\xb5\x18\x12posts.batch-create\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/posts/batch\x12\x97\x01\n" +
+	"\bListPost\x12\x1c.miniblog.v1.ListPostRequest\x1a\x1d.miniblog.v1.ListPostResponse\"N\x92A,\n" +
+	"\f博客管理\x12\x12列出所有文章*\bListPost\x8a\xb5\x18\n" +
+	"posts.list\x82\xd3\xe4\x93\x02\v\x12\t/v1/posts\x12\x93\x02\n" +
+	"\tSharePost\x12\x1d.miniblog.v1.SharePostRequest\x1a\x1e.miniblog.v1.SharePostResponse\"\xc6\x01\x92A\x8f\x01\n" +
+	"\f博客管理\x12\f分享文章\x1af将文章以查看者或编辑者的身份分享给其他用户，只有文章的所有者可以分享*\tSharePost\x8a\xb5\x18\vposts.share\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/posts/{postID}/shares\x12\xbf\x01\n" +
+	"\vUnsharePost\x12\x1f.miniblog.v1.UnsharePostRequest\x1a .miniblog.v1.UnsharePostResponse\"m\x92A/\n" +
+	"\f博客管理\x12\x12取消分享文章*\vUnsharePost\x8a\xb5\x18\rposts.unshare\x82\xd3\xe4\x93\x02$*\"/v1/posts/{postID}/shares/{userID}\x12\xcd\x01\n" +
+	"\fListPolicies\x12 .miniblog.v1.ListPoliciesRequest\x1a!.miniblog.v1.ListPoliciesResponse\"x\x92AP\n" +
+	"\f权限管理\x12\x18列出访问控制策略\x1a\x18仅管理员可以调用*\fListPolicies\x8a\xb5\x18\rpolicies.list\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/policies\x12\xd2\x01\n" +
+	"\fCreatePolicy\x12 .miniblog.v1.CreatePolicyRequest\x1a!.miniblog.v1.CreatePolicyResponse\"}\x92AP\n" +
+	"\f权限管理\x12\x18添加访问控制策略\x1a\x18仅管理员可以调用*\fCreatePolicy\x8a\xb5\x18\x0fpolicies.create\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/policies\x12\xd2\x01\n" +
+	"\fDeletePolicy\x12 .miniblog.v1.DeletePolicyRequest\x1a!.miniblog.v1.DeletePolicyResponse\"}\x92AP\n" +
+	"\f权限管理\x12\x18删除访问控制策略\x1a\x18仅管理员可以调用*\fDeletePolicy\x8a\xb5\x18\x0fpolicies.delete\x82\xd3\xe4\x93\x02\x11:\x01**\f/v1/policies\x12\xf4\x01\n" +
+	"\x13ListRoleAssignments\x12'.miniblog.v1.ListRoleAssignmentsRequest\x1a(.miniblog.v1.ListRoleAssignmentsResponse\"\x89\x01\x92AQ\n" +
+	"\f权限管理\x12\x12列出角色分配\x1a\x18仅管理员可以调用*\x13ListRoleAssignments\x8a\xb5\x18\x15role-assignments.list\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/role-assignments\x12\xce\x01\n" +
+	"\n" +
+	"AssignRole\x12\x1e.miniblog.v1.AssignRoleRequest\x1a\x1f.miniblog.v1.AssignRoleResponse\"\x7f\x92AB\n" +
+	"\f权限管理\x12\f分配角色\x1a\x18仅管理员可以调用*\n" +
+	"AssignRole\x8a\xb5\x18\x17role-assignments.create\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/role-assignments\x12\xce\x01\n" +
+	"\n" +
+	"RevokeRole\x12\x1e.miniblog.v1.RevokeRoleRequest\x1a\x1f.miniblog.v1.RevokeRoleResponse\"\x7f\x92AB\n" +
+	"\f权限管理\x12\f撤销角色\x1a\x18仅管理员可以调用*\n" +
+	"RevokeRole\x8a\xb5\x18\x17role-assignments.delete\x82\xd3\xe4\x93\x02\x19:\x01**\x14/v1/role-assignments\x12\xb5\x01\n" +
+	"\tListRoles\x12\x1d.miniblog.v1.ListRolesRequest\x1a\x1e.miniblog.v1.ListRolesResponse\"i\x92AG\n" +
+	"\f权限管理\x12\x12列出所有角色\x1a\x18仅管理员可以调用*\tListRoles\x8a\xb5\x18\n" +
+	"roles.list\x82\xd3\xe4\x93\x02\v\x12\t/v1/roles\x12\xc1\x01\n" +
+	"\n" +
+	"CreateRole\x12\x1e.miniblog.v1.CreateRoleRequest\x1a\x1f.miniblog.v1.CreateRoleResponse\"r\x92AK\n" +
+	"\f权限管理\x12\x15定义自定义角色\x1a\x18仅管理员可以调用*\n" +
+	"CreateRole\x8a\xb5\x18\froles.create\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/roles\x12\xc5\x01\n" +
+	"\n" +
+	"DeleteRole\x12\x1e.miniblog.v1.DeleteRoleRequest\x1a\x1f.miniblog.v1.DeleteRoleResponse\"v\x92AK\n" +
+	"\f权限管理\x12\x15删除自定义角色\x1a\x18仅管理员可以调用*\n" +
+	"DeleteRole\x8a\xb5\x18\froles.delete\x82\xd3\xe4\x93\x02\x12*\x10/v1/roles/{role}\x12\x9b\x02\n" +
+	"\x0fCheckPermission\x12#.miniblog.v1.CheckPermissionRequest\x1a$.miniblog.v1.CheckPermissionResponse\"\xbc\x01\x92A\x83\x01\n" +
+	"\f权限管理\x12\x1e检查主体是否拥有权限\x1aB只做判断，不会修改任何策略。仅管理员可以调用*\x0fCheckPermission\x8a\xb5\x18\x11permissions.check\x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/permissions/check\x12\xa8\x02\n" +
+	"\x0fListAuditEvents\x12#.miniblog.v1.ListAuditEventsRequest\x1a$.miniblog.v1.ListAuditEventsResponse\"\xc9\x01\x92A\x98\x01\n" +
+	"\f审计日志\x12\x12列出审计事件\x1ac支持按操作者、操作类型、资源、结果和时间范围过滤。仅管理员可以调用*\x0fListAuditEvents\x8a\xb5\x18\x11audit-events.list\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/audit-events\x12\xf5\x01\n" +
+	"\x12CreateOrganization\x12&.miniblog.v1.CreateOrganizationRequest\x1a'.miniblog.v1.CreateOrganizationResponse\"\x8d\x01\x92AV\n" +
+	"\f组织管理\x12\f创建组织\x1a$创建者会成为组织的所有者*\x12CreateOrganization\x8a\xb5\x18\x14organizations.create\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/organizations\x12\x9b\x02\n" +
+	"\x12DeleteOrganization\x12&.miniblog.v1.DeleteOrganizationRequest\x1a'.miniblog.v1.DeleteOrganizationResponse\"\xb3\x01\x92Aw\n" +
+	"\f组织管理\x12\f删除组织\x1aE同时删除组织内的所有博客。仅组织所有者可以调用*\x12DeleteOrganization\x8a\xb5\x18\x14organizations.delete\x82\xd3\xe4\x93\x02\x1b*\x19/v1/organizations/{orgID}\x12\xe8\x01\n" +
+	"\x0fGetOrganization\x12#.miniblog.v1.GetOrganizationRequest\x1a$.miniblog.v1.GetOrganizationResponse\"\x89\x01\x92AP\n" +
+	"\f组织管理\x12\x12获取组织详情\x1a\x1b仅组织成员可以调用*\x0fGetOrganization\x8a\xb5\x18\x11organizations.get\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/organizations/{orgID}\x12\xec\x01\n" +
+	"\x11ListOrganizations\x12%.miniblog.v1.ListOrganizationsRequest\x1a&.miniblog.v1.ListOrganizationsResponse\"\x87\x01\x92AU\n" +
+	"\f组织管理\x12\f列出组织\x1a$只返回当前用户所属的组织*\x11ListOrganizations\x8a\xb5\x18\x12organizations.list\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/organizations\x12\x99\x02\n" +
+	"\x17ListOrganizationMembers\x12+.miniblog.v1.ListOrganizationMembersRequest\x1a,.miniblog.v1.ListOrganizationMembersResponse\"\xa2\x01\x92AX\n" +
+	"\f组织管理\x12\x12列出组织成员\x1a\x1b仅组织成员可以调用*\x17ListOrganizationMembers\x8a\xb5\x18\x1aorganizations.members.list\x82\xd3\xe4\x93\x02#\x12!/v1/organizations/{orgID}/members\x12\xfa\x02\n" +
+	"\x15AddOrganizationMember\x12).miniblog.v1.AddOrganizationMemberRequest\x1a*.miniblog.v1.AddOrganizationMemberResponse\"\x89\x02\x92A\xb9\x01\n" +
+	"\f组织管理\x12\x12添加组织成员\x1a~用户已经是成员时会修改其角色。仅组织所有者和管理员可以调用，只有所有者可以添加所有者*\x15AddOrganizationMember\x8a\xb5\x18\x1corganizations.members.create\x82\xd3\xe4\x93\x02&:\x01*\"!/v1/organizations/{orgID}/members\x12\xe2\x02\n" +
+	"\x18RemoveOrganizationMember\x12,.miniblog.v1.RemoveOrganizationMemberRequest\x1a-.miniblog.v1.RemoveOrganizationMemberResponse\"\xe8\x01\x92A\x92\x01\n" +
+	"\f组织管理\x12\x12移除组织成员\x1aT仅组织所有者和管理员可以调用，组织至少需要保留一个所有者*\x18RemoveOrganizationMember\x8a\xb5\x18\x1corganizations.members.delete\x82\xd3\xe4\x93\x02,**/v1/organizations/{orgID}/members/{userID}B\x9b\x02\x92A\xdf\x01\x12\xb5\x01\n" +
 	"\fminiblog API\"W\n" +
 	"\x18小而美的博客项目\x12&https://github.com/TobyIcetea/miniblog\x1a\x13x2406862525@163.com*G\n" +
 	"\vMIT License\x128https://github.com/TobyIcetea/miniblog/blob/main/LICENSE2\x031.0*\x01\x022\x10application/json:\x10application/jsonZ6github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1;v1b\x06proto3"
 
 var file_apiserver_v1_apiserver_proto_goTypes = []any{
-	(*emptypb.Empty)(nil),          // 0: google.protobuf.Empty
-	(*LoginRequest)(nil),           // 1: miniblog.v1.LoginRequest
-	(*RefreshTokenRequest)(nil),    // 2: miniblog.v1.RefreshTokenRequest
-	(*ChangePasswordRequest)(nil),  // 3: miniblog.v1.ChangePasswordRequest
-	(*CreateUserRequest)(nil),      // 4: miniblog.v1.CreateUserRequest
-	(*UpdateUserRequest)(nil),      // 5: miniblog.v1.UpdateUserRequest
-	(*DeleteUserRequest)(nil),      // 6: miniblog.v1.DeleteUserRequest
-	(*GetUserRequest)(nil),         // 7: miniblog.v1.GetUserRequest
-	(*ListUserRequest)(nil),        // 8: miniblog.v1.ListUserRequest
-	(*CreatePostRequest)(nil),      // 9: miniblog.v1.CreatePostRequest
-	(*UpdatePostRequest)(nil),      // 10: miniblog.v1.UpdatePostRequest
-	(*DeletePostRequest)(nil),      // 11: miniblog.v1.DeletePostRequest
-	(*GetPostRequest)(nil),         // 12: miniblog.v1.GetPostRequest
-	(*ListPostRequest)(nil),        // 13: miniblog.v1.ListPostRequest
-	(*HealthzResponse)(nil),        // 14: miniblog.v1.HealthzResponse
-	(*LoginResponse)(nil),          // 15: miniblog.v1.LoginResponse
-	(*RefreshTokenResponse)(nil),   // 16: miniblog.v1.RefreshTokenResponse
-	(*ChangePasswordResponse)(nil), // 17: miniblog.v1.ChangePasswordResponse
-	(*CreateUserResponse)(nil),     // 18: miniblog.v1.CreateUserResponse
-	(*UpdateUserResponse)(nil),     // 19: miniblog.v1.UpdateUserResponse
-	(*DeleteUserResponse)(nil),     // 20: miniblog.v1.DeleteUserResponse
-	(*GetUserResponse)(nil),        // 21: miniblog.v1.GetUserResponse
-	(*ListUserResponse)(nil),       // 22: miniblog.v1.ListUserResponse
-	(*CreatePostResponse)(nil),     // 23: miniblog.v1.CreatePostResponse
-	(*UpdatePostResponse)(nil),     // 24: miniblog.v1.UpdatePostResponse
-	(*DeletePostResponse)(nil),     // 25: miniblog.v1.DeletePostResponse
-	(*GetPostResponse)(nil),        // 26: miniblog.v1.GetPostResponse
-	(*ListPostResponse)(nil),       // 27: miniblog.v1.ListPostResponse
+	(*emptypb.Empty)(nil),                    // 0: google.protobuf.Empty
+	(*CreateCaptchaRequest)(nil),             // 1: miniblog.v1.CreateCaptchaRequest
+	(*LoginRequest)(nil),                     // 2: miniblog.v1.LoginRequest
+	(*LoginVerifyRequest)(nil),               // 3: miniblog.v1.LoginVerifyRequest
+	(*OIDCAuthorizeRequest)(nil),             // 4: miniblog.v1.OIDCAuthorizeRequest
+	(*OIDCCallbackRequest)(nil),              // 5: miniblog.v1.OIDCCallbackRequest
+	(*RefreshTokenRequest)(nil),              // 6: miniblog.v1.RefreshTokenRequest
+	(*ChangePasswordRequest)(nil),            // 7: miniblog.v1.ChangePasswordRequest
+	(*EnrollTOTPRequest)(nil),                // 8: miniblog.v1.EnrollTOTPRequest
+	(*EnableTOTPRequest)(nil),                // 9: miniblog.v1.EnableTOTPRequest
+	(*RequestPasswordResetRequest)(nil),      // 10: miniblog.v1.RequestPasswordResetRequest
+	(*ResetPasswordRequest)(nil),             // 11: miniblog.v1.ResetPasswordRequest
+	(*SendVerificationEmailRequest)(nil),     // 12: miniblog.v1.SendVerificationEmailRequest
+	(*VerifyEmailRequest)(nil),               // 13: miniblog.v1.VerifyEmailRequest
+	(*UnlockUserRequest)(nil),                // 14: miniblog.v1.UnlockUserRequest
+	(*ImpersonateRequest)(nil),               // 15: miniblog.v1.ImpersonateRequest
+	(*CreateUserRequest)(nil),                // 16: miniblog.v1.CreateUserRequest
+	(*UpdateUserRequest)(nil),                // 17: miniblog.v1.UpdateUserRequest
+	(*DeleteUserRequest)(nil),                // 18: miniblog.v1.DeleteUserRequest
+	(*BatchDeleteUsersRequest)(nil),          // 19: miniblog.v1.BatchDeleteUsersRequest
+	(*GetUserRequest)(nil),                   // 20: miniblog.v1.GetUserRequest
+	(*ListUserRequest)(nil),                  // 21: miniblog.v1.ListUserRequest
+	(*CreatePostRequest)(nil),                // 22: miniblog.v1.CreatePostRequest
+	(*UpdatePostRequest)(nil),                // 23: miniblog.v1.UpdatePostRequest
+	(*DeletePostRequest)(nil),                // 24: miniblog.v1.DeletePostRequest
+	(*GetPostRequest)(nil),                   // 25: miniblog.v1.GetPostRequest
+	(*BatchGetPostsRequest)(nil),             // 26: miniblog.v1.BatchGetPostsRequest
+	(*BatchCreatePostsRequest)(nil),          // 27: miniblog.v1.BatchCreatePostsRequest
+	(*ListPostRequest)(nil),                  // 28: miniblog.v1.ListPostRequest
+	(*SharePostRequest)(nil),                 // 29: miniblog.v1.SharePostRequest
+	(*UnsharePostRequest)(nil),               // 30: miniblog.v1.UnsharePostRequest
+	(*ListPoliciesRequest)(nil),              // 31: miniblog.v1.ListPoliciesRequest
+	(*CreatePolicyRequest)(nil),              // 32: miniblog.v1.CreatePolicyRequest
+	(*DeletePolicyRequest)(nil),              // 33: miniblog.v1.DeletePolicyRequest
+	(*ListRoleAssignmentsRequest)(nil),       // 34: miniblog.v1.ListRoleAssignmentsRequest
+	(*AssignRoleRequest)(nil),                // 35: miniblog.v1.AssignRoleRequest
+	(*RevokeRoleRequest)(nil),                // 36: miniblog.v1.RevokeRoleRequest
+	(*ListRolesRequest)(nil),                 // 37: miniblog.v1.ListRolesRequest
+	(*CreateRoleRequest)(nil),                // 38: miniblog.v1.CreateRoleRequest
+	(*DeleteRoleRequest)(nil),                // 39: miniblog.v1.DeleteRoleRequest
+	(*CheckPermissionRequest)(nil),           // 40: miniblog.v1.CheckPermissionRequest
+	(*ListAuditEventsRequest)(nil),           // 41: miniblog.v1.ListAuditEventsRequest
+	(*CreateOrganizationRequest)(nil),        // 42: miniblog.v1.CreateOrganizationRequest
+	(*DeleteOrganizationRequest)(nil),        // 43: miniblog.v1.DeleteOrganizationRequest
+	(*GetOrganizationRequest)(nil),           // 44: miniblog.v1.GetOrganizationRequest
+	(*ListOrganizationsRequest)(nil),         // 45: miniblog.v1.ListOrganizationsRequest
+	(*ListOrganizationMembersRequest)(nil),   // 46: miniblog.v1.ListOrganizationMembersRequest
+	(*AddOrganizationMemberRequest)(nil),     // 47: miniblog.v1.AddOrganizationMemberRequest
+	(*RemoveOrganizationMemberRequest)(nil),  // 48: miniblog.v1.RemoveOrganizationMemberRequest
+	(*HealthzResponse)(nil),                  // 49: miniblog.v1.HealthzResponse
+	(*CreateCaptchaResponse)(nil),            // 50: miniblog.v1.CreateCaptchaResponse
+	(*LoginResponse)(nil),                    // 51: miniblog.v1.LoginResponse
+	(*LoginVerifyResponse)(nil),              // 52: miniblog.v1.LoginVerifyResponse
+	(*OIDCAuthorizeResponse)(nil),            // 53: miniblog.v1.OIDCAuthorizeResponse
+	(*OIDCCallbackResponse)(nil),             // 54: miniblog.v1.OIDCCallbackResponse
+	(*RefreshTokenResponse)(nil),             // 55: miniblog.v1.RefreshTokenResponse
+	(*ChangePasswordResponse)(nil),           // 56: miniblog.v1.ChangePasswordResponse
+	(*EnrollTOTPResponse)(nil),               // 57: miniblog.v1.EnrollTOTPResponse
+	(*EnableTOTPResponse)(nil),               // 58: miniblog.v1.EnableTOTPResponse
+	(*RequestPasswordResetResponse)(nil),     // 59: miniblog.v1.RequestPasswordResetResponse
+	(*ResetPasswordResponse)(nil),            // 60: miniblog.v1.ResetPasswordResponse
+	(*SendVerificationEmailResponse)(nil),    // 61: miniblog.v1.SendVerificationEmailResponse
+	(*VerifyEmailResponse)(nil),              // 62: miniblog.v1.VerifyEmailResponse
+	(*UnlockUserResponse)(nil),               // 63: miniblog.v1.UnlockUserResponse
+	(*ImpersonateResponse)(nil),              // 64: miniblog.v1.ImpersonateResponse
+	(*CreateUserResponse)(nil),               // 65: miniblog.v1.CreateUserResponse
+	(*UpdateUserResponse)(nil),               // 66: miniblog.v1.UpdateUserResponse
+	(*DeleteUserResponse)(nil),               // 67: miniblog.v1.DeleteUserResponse
+	(*BatchDeleteUsersResponse)(nil),         // 68: miniblog.v1.BatchDeleteUsersResponse
+	(*GetUserResponse)(nil),                  // 69: miniblog.v1.GetUserResponse
+	(*ListUserResponse)(nil),                 // 70: miniblog.v1.ListUserResponse
+	(*CreatePostResponse)(nil),               // 71: miniblog.v1.CreatePostResponse
+	(*UpdatePostResponse)(nil),               // 72: miniblog.v1.UpdatePostResponse
+	(*DeletePostResponse)(nil),               // 73: miniblog.v1.DeletePostResponse
+	(*GetPostResponse)(nil),                  // 74: miniblog.v1.GetPostResponse
+	(*BatchGetPostsResponse)(nil),            // 75: miniblog.v1.BatchGetPostsResponse
+	(*BatchCreatePostsResponse)(nil),         // 76: miniblog.v1.BatchCreatePostsResponse
+	(*ListPostResponse)(nil),                 // 77: miniblog.v1.ListPostResponse
+	(*SharePostResponse)(nil),                // 78: miniblog.v1.SharePostResponse
+	(*UnsharePostResponse)(nil),              // 79: miniblog.v1.UnsharePostResponse
+	(*ListPoliciesResponse)(nil),             // 80: miniblog.v1.ListPoliciesResponse
+	(*CreatePolicyResponse)(nil),             // 81: miniblog.v1.CreatePolicyResponse
+	(*DeletePolicyResponse)(nil),             // 82: miniblog.v1.DeletePolicyResponse
+	(*ListRoleAssignmentsResponse)(nil),      // 83: miniblog.v1.ListRoleAssignmentsResponse
+	(*AssignRoleResponse)(nil),               // 84: miniblog.v1.AssignRoleResponse
+	(*RevokeRoleResponse)(nil),               // 85: miniblog.v1.RevokeRoleResponse
+	(*ListRolesResponse)(nil),                // 86: miniblog.v1.ListRolesResponse
+	(*CreateRoleResponse)(nil),               // 87: miniblog.v1.CreateRoleResponse
+	(*DeleteRoleResponse)(nil),               // 88: miniblog.v1.DeleteRoleResponse
+	(*CheckPermissionResponse)(nil),          // 89: miniblog.v1.CheckPermissionResponse
+	(*ListAuditEventsResponse)(nil),          // 90: miniblog.v1.ListAuditEventsResponse
+	(*CreateOrganizationResponse)(nil),       // 91: miniblog.v1.CreateOrganizationResponse
+	(*DeleteOrganizationResponse)(nil),       // 92: miniblog.v1.DeleteOrganizationResponse
+	(*GetOrganizationResponse)(nil),          // 93: miniblog.v1.GetOrganizationResponse
+	(*ListOrganizationsResponse)(nil),        // 94: miniblog.v1.ListOrganizationsResponse
+	(*ListOrganizationMembersResponse)(nil),  // 95: miniblog.v1.ListOrganizationMembersResponse
+	(*AddOrganizationMemberResponse)(nil),    // 96: miniblog.v1.AddOrganizationMemberResponse
+	(*RemoveOrganizationMemberResponse)(nil), // 97: miniblog.v1.RemoveOrganizationMemberResponse
 }
 var file_apiserver_v1_apiserver_proto_depIdxs = []int32{
 	0,  // 0: miniblog.v1.MiniBlog.Healthz:input_type -> google.protobuf.Empty
-	1,  // 1: miniblog.v1.MiniBlog.Login:input_type -> miniblog.v1.LoginRequest
-	2,  // 2: miniblog.v1.MiniBlog.RefreshToken:input_type -> miniblog.v1.RefreshTokenRequest
-	3,  // 3: miniblog.v1.MiniBlog.ChangePassword:input_type -> miniblog.v1.ChangePasswordRequest
-	4,  // 4: miniblog.v1.MiniBlog.CreateUser:input_type -> miniblog.v1.CreateUserRequest
-	5,  // 5: miniblog.v1.MiniBlog.UpdateUser:input_type -> miniblog.v1.UpdateUserRequest
-	6,  // 6: miniblog.v1.MiniBlog.DeleteUser:input_type -> miniblog.v1.DeleteUserRequest
-	7,  // 7: miniblog.v1.MiniBlog.GetUser:input_type -> miniblog.v1.GetUserRequest
-	8,  // 8: miniblog.v1.MiniBlog.ListUser:input_type -> miniblog.v1.ListUserRequest
-	9,  // 9: miniblog.v1.MiniBlog.CreatePost:input_type -> miniblog.v1.CreatePostRequest
-	10, // 10: miniblog.v1.MiniBlog.UpdatePost:input_type -> miniblog.v1.UpdatePostRequest
-	11, // 11: miniblog.v1.MiniBlog.DeletePost:input_type -> miniblog.v1.DeletePostRequest
-	12, // 12: miniblog.v1.MiniBlog.GetPost:input_type -> miniblog.v1.GetPostRequest
-	13, // 13: miniblog.v1.MiniBlog.ListPost:input_type -> miniblog.v1.ListPostRequest
-	14, // 14: miniblog.v1.MiniBlog.Healthz:output_type -> miniblog.v1.HealthzResponse
-	15, // 15: miniblog.v1.MiniBlog.Login:output_type -> miniblog.v1.LoginResponse
-	16, // 16: miniblog.v1.MiniBlog.RefreshToken:output_type -> miniblog.v1.RefreshTokenResponse
-	17, // 17: miniblog.v1.MiniBlog.ChangePassword:output_type -> miniblog.v1.ChangePasswordResponse
-	18, // 18: miniblog.v1.MiniBlog.CreateUser:output_type -> miniblog.v1.CreateUserResponse
-	19, // 19: miniblog.v1.MiniBlog.UpdateUser:output_type -> miniblog.v1.UpdateUserResponse
-	20, // 20: miniblog.v1.MiniBlog.DeleteUser:output_type -> miniblog.v1.DeleteUserResponse
-	21, // 21: miniblog.v1.MiniBlog.GetUser:output_type -> miniblog.v1.GetUserResponse
-	22, // 22: miniblog.v1.MiniBlog.ListUser:output_type -> miniblog.v1.ListUserResponse
-	23, // 23: miniblog.v1.MiniBlog.CreatePost:output_type -> miniblog.v1.CreatePostResponse
-	24, // 24: miniblog.v1.MiniBlog.UpdatePost:output_type -> miniblog.v1.UpdatePostResponse
-	25, // 25: miniblog.v1.MiniBlog.DeletePost:output_type -> miniblog.v1.DeletePostResponse
-	26, // 26: miniblog.v1.MiniBlog.GetPost:output_type -> miniblog.v1.GetPostResponse
-	27, // 27: miniblog.v1.MiniBlog.ListPost:output_type -> miniblog.v1.ListPostResponse
-	14, // [14:28] is the sub-list for method output_type
-	0,  // [0:14] is the sub-list for method input_type
+	1,  // 1: miniblog.v1.MiniBlog.CreateCaptcha:input_type -> miniblog.v1.CreateCaptchaRequest
+	2,  // 2: miniblog.v1.MiniBlog.Login:input_type -> miniblog.v1.LoginRequest
+	3,  // 3: miniblog.v1.MiniBlog.LoginVerify:input_type -> miniblog.v1.LoginVerifyRequest
+	4,  // 4: miniblog.v1.MiniBlog.OIDCAuthorize:input_type -> miniblog.v1.OIDCAuthorizeRequest
+	5,  // 5: miniblog.v1.MiniBlog.OIDCCallback:input_type -> miniblog.v1.OIDCCallbackRequest
+	6,  // 6: miniblog.v1.MiniBlog.RefreshToken:input_type -> miniblog.v1.RefreshTokenRequest
+	7,  // 7: miniblog.v1.MiniBlog.ChangePassword:input_type -> miniblog.v1.ChangePasswordRequest
+	8,  // 8: miniblog.v1.MiniBlog.EnrollTOTP:input_type -> miniblog.v1.EnrollTOTPRequest
+	9,  // 9: miniblog.v1.MiniBlog.EnableTOTP:input_type -> miniblog.v1.EnableTOTPRequest
+	10, // 10: miniblog.v1.MiniBlog.RequestPasswordReset:input_type -> miniblog.v1.RequestPasswordResetRequest
+	11, // 11: miniblog.v1.MiniBlog.ResetPassword:input_type -> miniblog.v1.ResetPasswordRequest
+	12, // 12: miniblog.v1.MiniBlog.SendVerificationEmail:input_type -> miniblog.v1.SendVerificationEmailRequest
+	13, // 13: miniblog.v1.MiniBlog.VerifyEmail:input_type -> miniblog.v1.VerifyEmailRequest
+	14, // 14: miniblog.v1.MiniBlog.UnlockUser:input_type -> miniblog.v1.UnlockUserRequest
+	15, // 15: miniblog.v1.MiniBlog.Impersonate:input_type -> miniblog.v1.ImpersonateRequest
+	16, // 16: miniblog.v1.MiniBlog.CreateUser:input_type -> miniblog.v1.CreateUserRequest
+	17, // 17: miniblog.v1.MiniBlog.UpdateUser:input_type -> miniblog.v1.UpdateUserRequest
+	18, // 18: miniblog.v1.MiniBlog.DeleteUser:input_type -> miniblog.v1.DeleteUserRequest
+	19, // 19: miniblog.v1.MiniBlog.BatchDeleteUsers:input_type -> miniblog.v1.BatchDeleteUsersRequest
+	20, // 20: miniblog.v1.MiniBlog.GetUser:input_type -> miniblog.v1.GetUserRequest
+	21, // 21: miniblog.v1.MiniBlog.ListUser:input_type -> miniblog.v1.ListUserRequest
+	22, // 22: miniblog.v1.MiniBlog.CreatePost:input_type -> miniblog.v1.CreatePostRequest
+	23, // 23: miniblog.v1.MiniBlog.UpdatePost:input_type -> miniblog.v1.UpdatePostRequest
+	24, // 24: miniblog.v1.MiniBlog.DeletePost:input_type -> miniblog.v1.DeletePostRequest
+	25, // 25: miniblog.v1.MiniBlog.GetPost:input_type -> miniblog.v1.GetPostRequest
+	26, // 26: miniblog.v1.MiniBlog.BatchGetPosts:input_type -> miniblog.v1.BatchGetPostsRequest
+	27, // 27: miniblog.v1.MiniBlog.BatchCreatePosts:input_type -> miniblog.v1.BatchCreatePostsRequest
+	28, // 28: miniblog.v1.MiniBlog.ListPost:input_type -> miniblog.v1.ListPostRequest
+	29, // 29: miniblog.v1.MiniBlog.SharePost:input_type -> miniblog.v1.SharePostRequest
+	30, // 30: miniblog.v1.MiniBlog.UnsharePost:input_type -> miniblog.v1.UnsharePostRequest
+	31, // 31: miniblog.v1.MiniBlog.ListPolicies:input_type -> miniblog.v1.ListPoliciesRequest
+	32, // 32: miniblog.v1.MiniBlog.CreatePolicy:input_type -> miniblog.v1.CreatePolicyRequest
+	33, // 33: miniblog.v1.MiniBlog.DeletePolicy:input_type -> miniblog.v1.DeletePolicyRequest
+	34, // 34: miniblog.v1.MiniBlog.ListRoleAssignments:input_type -> miniblog.v1.ListRoleAssignmentsRequest
+	35, // 35: miniblog.v1.MiniBlog.AssignRole:input_type -> miniblog.v1.AssignRoleRequest
+	36, // 36: miniblog.v1.MiniBlog.RevokeRole:input_type -> miniblog.v1.RevokeRoleRequest
+	37, // 37: miniblog.v1.MiniBlog.ListRoles:input_type -> miniblog.v1.ListRolesRequest
+	38, // 38: miniblog.v1.MiniBlog.CreateRole:input_type -> miniblog.v1.CreateRoleRequest
+	39, // 39: miniblog.v1.MiniBlog.DeleteRole:input_type -> miniblog.v1.DeleteRoleRequest
+	40, // 40: miniblog.v1.MiniBlog.CheckPermission:input_type -> miniblog.v1.CheckPermissionRequest
+	41, // 41: miniblog.v1.MiniBlog.ListAuditEvents:input_type -> miniblog.v1.ListAuditEventsRequest
+	42, // 42: miniblog.v1.MiniBlog.CreateOrganization:input_type -> miniblog.v1.CreateOrganizationRequest
+	43, // 43: miniblog.v1.MiniBlog.DeleteOrganization:input_type -> miniblog.v1.DeleteOrganizationRequest
+	44, // 44: miniblog.v1.MiniBlog.GetOrganization:input_type -> miniblog.v1.GetOrganizationRequest
+	45, // 45: miniblog.v1.MiniBlog.ListOrganizations:input_type -> miniblog.v1.ListOrganizationsRequest
+	46, // 46: miniblog.v1.MiniBlog.ListOrganizationMembers:input_type -> miniblog.v1.ListOrganizationMembersRequest
+	47, // 47: miniblog.v1.MiniBlog.AddOrganizationMember:input_type -> miniblog.v1.AddOrganizationMemberRequest
+	48, // 48: miniblog.v1.MiniBlog.RemoveOrganizationMember:input_type -> miniblog.v1.RemoveOrganizationMemberRequest
+	49, // 49: miniblog.v1.MiniBlog.Healthz:output_type -> miniblog.v1.HealthzResponse
+	50, // 50: miniblog.v1.MiniBlog.CreateCaptcha:output_type -> miniblog.v1.CreateCaptchaResponse
+	51, // 51: miniblog.v1.MiniBlog.Login:output_type -> miniblog.v1.LoginResponse
+	52, // 52: miniblog.v1.MiniBlog.LoginVerify:output_type -> miniblog.v1.LoginVerifyResponse
+	53, // 53: miniblog.v1.MiniBlog.OIDCAuthorize:output_type -> miniblog.v1.OIDCAuthorizeResponse
+	54, // 54: miniblog.v1.MiniBlog.OIDCCallback:output_type -> miniblog.v1.OIDCCallbackResponse
+	55, // 55: miniblog.v1.MiniBlog.RefreshToken:output_type -> miniblog.v1.RefreshTokenResponse
+	56, // 56: miniblog.v1.MiniBlog.ChangePassword:output_type -> miniblog.v1.ChangePasswordResponse
+	57, // 57: miniblog.v1.MiniBlog.EnrollTOTP:output_type -> miniblog.v1.EnrollTOTPResponse
+	58, // 58: miniblog.v1.MiniBlog.EnableTOTP:output_type -> miniblog.v1.EnableTOTPResponse
+	59, // 59: miniblog.v1.MiniBlog.RequestPasswordReset:output_type -> miniblog.v1.RequestPasswordResetResponse
+	60, // 60: miniblog.v1.MiniBlog.ResetPassword:output_type -> miniblog.v1.ResetPasswordResponse
+	61, // 61: miniblog.v1.MiniBlog.SendVerificationEmail:output_type -> miniblog.v1.SendVerificationEmailResponse
+	62, // 62: miniblog.v1.MiniBlog.VerifyEmail:output_type -> miniblog.v1.VerifyEmailResponse
+	63, // 63: miniblog.v1.MiniBlog.UnlockUser:output_type -> miniblog.v1.UnlockUserResponse
+	64, // 64: miniblog.v1.MiniBlog.Impersonate:output_type -> miniblog.v1.ImpersonateResponse
+	65, // 65: miniblog.v1.MiniBlog.CreateUser:output_type -> miniblog.v1.CreateUserResponse
+	66, // 66: miniblog.v1.MiniBlog.UpdateUser:output_type -> miniblog.v1.UpdateUserResponse
+	67, // 67: miniblog.v1.MiniBlog.DeleteUser:output_type -> miniblog.v1.DeleteUserResponse
+	68, // 68: miniblog.v1.MiniBlog.BatchDeleteUsers:output_type -> miniblog.v1.BatchDeleteUsersResponse
+	69, // 69: miniblog.v1.MiniBlog.GetUser:output_type -> miniblog.v1.GetUserResponse
+	70, // 70: miniblog.v1.MiniBlog.ListUser:output_type -> miniblog.v1.ListUserResponse
+	71, // 71: miniblog.v1.MiniBlog.CreatePost:output_type -> miniblog.v1.CreatePostResponse
+	72, // 72: miniblog.v1.MiniBlog.UpdatePost:output_type -> miniblog.v1.UpdatePostResponse
+	73, // 73: miniblog.v1.MiniBlog.DeletePost:output_type -> miniblog.v1.DeletePostResponse
+	74, // 74: miniblog.v1.MiniBlog.GetPost:output_type -> miniblog.v1.GetPostResponse
+	75, // 75: miniblog.v1.MiniBlog.BatchGetPosts:output_type -> miniblog.v1.BatchGetPostsResponse
+	76, // 76: miniblog.v1.MiniBlog.BatchCreatePosts:output_type -> miniblog.v1.BatchCreatePostsResponse
+	77, // 77: miniblog.v1.MiniBlog.ListPost:output_type -> miniblog.v1.ListPostResponse
+	78, // 78: miniblog.v1.MiniBlog.SharePost:output_type -> miniblog.v1.SharePostResponse
+	79, // 79: miniblog.v1.MiniBlog.UnsharePost:output_type -> miniblog.v1.UnsharePostResponse
+	80, // 80: miniblog.v1.MiniBlog.ListPolicies:output_type -> miniblog.v1.ListPoliciesResponse
+	81, // 81: miniblog.v1.MiniBlog.CreatePolicy:output_type -> miniblog.v1.CreatePolicyResponse
+	82, // 82: miniblog.v1.MiniBlog.DeletePolicy:output_type -> miniblog.v1.DeletePolicyResponse
+	83, // 83: miniblog.v1.MiniBlog.ListRoleAssignments:output_type -> miniblog.v1.ListRoleAssignmentsResponse
+	84, // 84: miniblog.v1.MiniBlog.AssignRole:output_type -> miniblog.v1.AssignRoleResponse
+	85, // 85: miniblog.v1.MiniBlog.RevokeRole:output_type -> miniblog.v1.RevokeRoleResponse
+	86, // 86: miniblog.v1.MiniBlog.ListRoles:output_type -> miniblog.v1.ListRolesResponse
+	87, // 87: miniblog.v1.MiniBlog.CreateRole:output_type -> miniblog.v1.CreateRoleResponse
+	88, // 88: miniblog.v1.MiniBlog.DeleteRole:output_type -> miniblog.v1.DeleteRoleResponse
+	89, // 89: miniblog.v1.MiniBlog.CheckPermission:output_type -> miniblog.v1.CheckPermissionResponse
+	90, // 90: miniblog.v1.MiniBlog.ListAuditEvents:output_type -> miniblog.v1.ListAuditEventsResponse
+	91, // 91: miniblog.v1.MiniBlog.CreateOrganization:output_type -> miniblog.v1.CreateOrganizationResponse
+	92, // 92: miniblog.v1.MiniBlog.DeleteOrganization:output_type -> miniblog.v1.DeleteOrganizationResponse
+	93, // 93: miniblog.v1.MiniBlog.GetOrganization:output_type -> miniblog.v1.GetOrganizationResponse
+	94, // 94: miniblog.v1.MiniBlog.ListOrganizations:output_type -> miniblog.v1.ListOrganizationsResponse
+	95, // 95: miniblog.v1.MiniBlog.ListOrganizationMembers:output_type -> miniblog.v1.ListOrganizationMembersResponse
+	96, // 96: miniblog.v1.MiniBlog.AddOrganizationMember:output_type -> miniblog.v1.AddOrganizationMemberResponse
+	97, // 97: miniblog.v1.MiniBlog.RemoveOrganizationMember:output_type -> miniblog.v1.RemoveOrganizationMemberResponse
+	49, // [49:98] is the sub-list for method output_type
+	0,  // [0:49] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -153,8 +381,12 @@ func file_apiserver_v1_apiserver_proto_init() {
 	if File_apiserver_v1_apiserver_proto != nil {
 		return
 	}
+	file_apiserver_v1_audit_proto_init()
 	file_apiserver_v1_healthz_proto_init()
+	file_apiserver_v1_organization_proto_init()
 	file_apiserver_v1_post_proto_init()
+	file_apiserver_v1_policy_proto_init()
+	file_apiserver_v1_permission_proto_init()
 	file_apiserver_v1_user_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
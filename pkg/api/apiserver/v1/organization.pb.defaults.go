@@ -0,0 +1,69 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Code generated by protoc-gen-defaults. DO NOT EDIT.
+
+package v1
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	_ *timestamppb.Timestamp
+	_ *durationpb.Duration
+	_ *wrapperspb.BoolValue
+)
+
+func (x *Organization) Default() {
+}
+
+func (x *OrganizationMember) Default() {
+}
+
+func (x *CreateOrganizationRequest) Default() {
+}
+
+func (x *CreateOrganizationResponse) Default() {
+}
+
+func (x *DeleteOrganizationRequest) Default() {
+}
+
+func (x *DeleteOrganizationResponse) Default() {
+}
+
+func (x *GetOrganizationRequest) Default() {
+}
+
+func (x *GetOrganizationResponse) Default() {
+}
+
+func (x *ListOrganizationsRequest) Default() {
+}
+
+func (x *ListOrganizationsResponse) Default() {
+}
+
+func (x *ListOrganizationMembersRequest) Default() {
+}
+
+func (x *ListOrganizationMembersResponse) Default() {
+}
+
+func (x *AddOrganizationMemberRequest) Default() {
+}
+
+func (x *AddOrganizationMemberResponse) Default() {
+}
+
+func (x *RemoveOrganizationMemberRequest) Default() {
+}
+
+func (x *RemoveOrganizationMemberResponse) Default() {
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: apiserver/v1/permission.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var file_apiserver_v1_permission_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         50001,
+		Name:          "miniblog.v1.permission",
+		Tag:           "bytes,50001,opt,name=permission",
+		Filename:      "apiserver/v1/permission.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// permission 表示调用该方法需要的权限标识，格式为 <资源>.<操作>，例如 users.delete。
+	// 无论请求来自 HTTP 还是 gRPC，授权时都使用该标识作为 casbin 策略的 object，
+	// 所以同一个操作在所有服务器模式下只需要配置一条策略。
+	//
+	// optional string permission = 50001;
+	E_Permission = &file_apiserver_v1_permission_proto_extTypes[0]
+)
+
+var File_apiserver_v1_permission_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_permission_proto_rawDesc = "" +
+	"\n" +
+	"\x1dapiserver/v1/permission.proto\x12\vminiblog.v1\x1a google/protobuf/descriptor.proto:@\n" +
+	"\n" +
+	"permission\x12\x1e.google.protobuf.MethodOptions\x18ц\x03 \x01(\tR\n" +
+	"permissionB8Z6github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1;v1b\x06proto3"
+
+var file_apiserver_v1_permission_proto_goTypes = []any{
+	(*descriptorpb.MethodOptions)(nil), // 0: google.protobuf.MethodOptions
+}
+var file_apiserver_v1_permission_proto_depIdxs = []int32{
+	0, // 0: miniblog.v1.permission:extendee -> google.protobuf.MethodOptions
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_permission_proto_init() }
+func file_apiserver_v1_permission_proto_init() {
+	if File_apiserver_v1_permission_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_permission_proto_rawDesc), len(file_apiserver_v1_permission_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_permission_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_permission_proto_depIdxs,
+		ExtensionInfos:    file_apiserver_v1_permission_proto_extTypes,
+	}.Build()
+	File_apiserver_v1_permission_proto = out.File
+	file_apiserver_v1_permission_proto_goTypes = nil
+	file_apiserver_v1_permission_proto_depIdxs = nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+// Code generated by protoc-gen-defaults. DO NOT EDIT.
+
+package v1
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	_ *timestamppb.Timestamp
+	_ *durationpb.Duration
+	_ *wrapperspb.BoolValue
+)
+
+func (x *Policy) Default() {
+}
+
+func (x *RoleAssignment) Default() {
+}
+
+func (x *ListPoliciesRequest) Default() {
+}
+
+func (x *ListPoliciesResponse) Default() {
+}
+
+func (x *CreatePolicyRequest) Default() {
+}
+
+func (x *CreatePolicyResponse) Default() {
+}
+
+func (x *DeletePolicyRequest) Default() {
+}
+
+func (x *DeletePolicyResponse) Default() {
+}
+
+func (x *ListRoleAssignmentsRequest) Default() {
+}
+
+func (x *ListRoleAssignmentsResponse) Default() {
+}
+
+func (x *AssignRoleRequest) Default() {
+}
+
+func (x *AssignRoleResponse) Default() {
+}
+
+func (x *RevokeRoleRequest) Default() {
+}
+
+func (x *RevokeRoleResponse) Default() {
+}
+
+func (x *ListRolesRequest) Default() {
+}
+
+func (x *ListRolesResponse) Default() {
+}
+
+func (x *CreateRoleRequest) Default() {
+}
+
+func (x *CreateRoleResponse) Default() {
+}
+
+func (x *DeleteRoleRequest) Default() {
+}
+
+func (x *DeleteRoleResponse) Default() {
+}
+
+func (x *CheckPermissionRequest) Default() {
+}
+
+func (x *CheckPermissionResponse) Default() {
+}
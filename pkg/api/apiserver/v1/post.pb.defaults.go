@@ -47,8 +47,32 @@ func (x *GetPostRequest) Default() {
 func (x *GetPostResponse) Default() {
 }
 
+func (x *BatchGetPostsRequest) Default() {
+}
+
+func (x *BatchGetPostsResponse) Default() {
+}
+
+func (x *BatchCreatePostsRequest) Default() {
+}
+
+func (x *BatchCreatePostsResponse) Default() {
+}
+
 func (x *ListPostRequest) Default() {
 }
 
 func (x *ListPostResponse) Default() {
 }
+
+func (x *SharePostRequest) Default() {
+}
+
+func (x *SharePostResponse) Default() {
+}
+
+func (x *UnsharePostRequest) Default() {
+}
+
+func (x *UnsharePostResponse) Default() {
+}
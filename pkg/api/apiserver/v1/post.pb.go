@@ -15,6 +15,7 @@ package v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -42,7 +43,9 @@ type Post struct {
 	// createdAt 表示博客创建时间
 	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
 	// updatedAt 表示博客最后更新时间
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	// tenantID 表示博客所属的组织 ID
+	TenantID      string `protobuf:"bytes,7,opt,name=tenantID,proto3" json:"tenantID,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -119,6 +122,13 @@ func (x *Post) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Post) GetTenantID() string {
+	if x != nil {
+		return x.TenantID
+	}
+	return ""
+}
+
 // CreatePostRequest 表示创建文章请求
 type CreatePostRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -224,11 +234,15 @@ func (x *CreatePostResponse) GetPostID() string {
 type UpdatePostRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// postID 表示要更新的文章 ID，对应 {postID}
-	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty"`
+	// @gotags: uri:"postID"
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty" uri:"postID"`
 	// title 表示更新后的博客标题
 	Title *string `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
 	// content 表示更新后的博客内容
-	Content       *string `protobuf:"bytes,3,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	Content *string `protobuf:"bytes,3,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	// update_mask 表示要更新的字段（AIP-134），为空时只更新请求中设置了的字段，
+	// 不为空时更新掩码中的所有字段，* 表示所有可以更新的字段
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -284,6 +298,13 @@ func (x *UpdatePostRequest) GetContent() string {
 	return ""
 }
 
+func (x *UpdatePostRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 // UpdatePostResponse 表示更新文章响应
 type UpdatePostResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -409,7 +430,10 @@ type GetPostRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// postID 表示要获取的文章 ID
 	// @gotags: uri:"postID"
-	PostID        string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty" uri:"postID"`
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty" uri:"postID"`
+	// read_mask 表示要返回的文章字段，为空时返回所有字段，对应查询参数 fields
+	// @gotags: form:"-"
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=fields,proto3" json:"read_mask,omitempty" form:"-"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -451,6 +475,13 @@ func (x *GetPostRequest) GetPostID() string {
 	return ""
 }
 
+func (x *GetPostRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
 // GetPostResponse 表示获取文章响应
 type GetPostResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -497,6 +528,219 @@ func (x *GetPostResponse) GetPost() *Post {
 	return nil
 }
 
+// BatchGetPostsRequest 表示批量获取文章请求
+type BatchGetPostsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postIDs 表示要获取的文章 ID 列表
+	// @gotags: form:"postIDs"
+	PostIDs       []string `protobuf:"bytes,1,rep,name=postIDs,proto3" json:"postIDs,omitempty" form:"postIDs"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetPostsRequest) Reset() {
+	*x = BatchGetPostsRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetPostsRequest) ProtoMessage() {}
+
+func (x *BatchGetPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetPostsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetPostsRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchGetPostsRequest) GetPostIDs() []string {
+	if x != nil {
+		return x.PostIDs
+	}
+	return nil
+}
+
+// BatchGetPostsResponse 表示批量获取文章响应
+type BatchGetPostsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// posts 表示查询到的文章，按请求中的 ID 顺序排列
+	Posts []*Post `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	// missingPostIDs 表示不存在或者没有权限查看的文章 ID，按请求中的顺序排列
+	MissingPostIDs []string `protobuf:"bytes,2,rep,name=missingPostIDs,proto3" json:"missingPostIDs,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BatchGetPostsResponse) Reset() {
+	*x = BatchGetPostsResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetPostsResponse) ProtoMessage() {}
+
+func (x *BatchGetPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetPostsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetPostsResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BatchGetPostsResponse) GetPosts() []*Post {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *BatchGetPostsResponse) GetMissingPostIDs() []string {
+	if x != nil {
+		return x.MissingPostIDs
+	}
+	return nil
+}
+
+// BatchCreatePostsRequest 表示批量创建文章请求
+type BatchCreatePostsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// posts 表示要创建的文章列表
+	Posts []*CreatePostRequest `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	// partial 为 true 时跳过创建失败的文章并在响应中返回错误，
+	// 为 false 时任意一篇文章创建失败都不会创建任何文章
+	Partial       bool `protobuf:"varint,2,opt,name=partial,proto3" json:"partial,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreatePostsRequest) Reset() {
+	*x = BatchCreatePostsRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreatePostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreatePostsRequest) ProtoMessage() {}
+
+func (x *BatchCreatePostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreatePostsRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreatePostsRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchCreatePostsRequest) GetPosts() []*CreatePostRequest {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *BatchCreatePostsRequest) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+// BatchCreatePostsResponse 表示批量创建文章响应
+type BatchCreatePostsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postIDs 表示创建的文章 ID，与请求中的文章一一对应，创建失败的文章 ID 为空
+	PostIDs []string `protobuf:"bytes,1,rep,name=postIDs,proto3" json:"postIDs,omitempty"`
+	// errors 表示创建失败的文章及其错误
+	Errors        []*BatchItemError `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreatePostsResponse) Reset() {
+	*x = BatchCreatePostsResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreatePostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreatePostsResponse) ProtoMessage() {}
+
+func (x *BatchCreatePostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreatePostsResponse.ProtoReflect.Descriptor instead.
+func (*BatchCreatePostsResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchCreatePostsResponse) GetPostIDs() []string {
+	if x != nil {
+		return x.PostIDs
+	}
+	return nil
+}
+
+func (x *BatchCreatePostsResponse) GetErrors() []*BatchItemError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
 // ListPostRequest 表示获取文章列表请求
 type ListPostRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -507,14 +751,17 @@ type ListPostRequest struct {
 	// @gotags: form:"limit"
 	Limit int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty" form:"limit"`
 	// title 表示可选的标题过滤
-	Title         *string `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Title *string `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	// read_mask 表示要返回的文章字段，为空时返回所有字段，对应查询参数 fields
+	// @gotags: form:"-"
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=read_mask,json=fields,proto3" json:"read_mask,omitempty" form:"-"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListPostRequest) Reset() {
 	*x = ListPostRequest{}
-	mi := &file_apiserver_v1_post_proto_msgTypes[9]
+	mi := &file_apiserver_v1_post_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -526,7 +773,7 @@ func (x *ListPostRequest) String() string {
 func (*ListPostRequest) ProtoMessage() {}
 
 func (x *ListPostRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_post_proto_msgTypes[9]
+	mi := &file_apiserver_v1_post_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -539,7 +786,7 @@ func (x *ListPostRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPostRequest.ProtoReflect.Descriptor instead.
 func (*ListPostRequest) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{9}
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListPostRequest) GetOffset() int64 {
@@ -563,6 +810,13 @@ func (x *ListPostRequest) GetTitle() string {
 	return ""
 }
 
+func (x *ListPostRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
 // ListPostResponse 表示获取文章列表响应
 type ListPostResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -576,7 +830,7 @@ type ListPostResponse struct {
 
 func (x *ListPostResponse) Reset() {
 	*x = ListPostResponse{}
-	mi := &file_apiserver_v1_post_proto_msgTypes[10]
+	mi := &file_apiserver_v1_post_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -588,7 +842,7 @@ func (x *ListPostResponse) String() string {
 func (*ListPostResponse) ProtoMessage() {}
 
 func (x *ListPostResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_apiserver_v1_post_proto_msgTypes[10]
+	mi := &file_apiserver_v1_post_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -601,7 +855,7 @@ func (x *ListPostResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListPostResponse.ProtoReflect.Descriptor instead.
 func (*ListPostResponse) Descriptor() ([]byte, []int) {
-	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{10}
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ListPostResponse) GetTotalCount() int64 {
@@ -618,47 +872,268 @@ func (x *ListPostResponse) GetPosts() []*Post {
 	return nil
 }
 
+// SharePostRequest 表示分享文章请求
+type SharePostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示要分享的文章 ID
+	// @gotags: uri:"postID"
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty" uri:"postID"`
+	// userID 表示被分享的用户 ID
+	UserID string `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	// role 表示被分享用户的角色，可选值为 viewer（只能查看）和 editor（可以查看和修改）
+	Role          string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SharePostRequest) Reset() {
+	*x = SharePostRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharePostRequest) ProtoMessage() {}
+
+func (x *SharePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharePostRequest.ProtoReflect.Descriptor instead.
+func (*SharePostRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SharePostRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *SharePostRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *SharePostRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// SharePostResponse 表示分享文章响应
+type SharePostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SharePostResponse) Reset() {
+	*x = SharePostResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharePostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharePostResponse) ProtoMessage() {}
+
+func (x *SharePostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharePostResponse.ProtoReflect.Descriptor instead.
+func (*SharePostResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{16}
+}
+
+// UnsharePostRequest 表示取消分享文章请求
+type UnsharePostRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// postID 表示要取消分享的文章 ID
+	// @gotags: uri:"postID"
+	PostID string `protobuf:"bytes,1,opt,name=postID,proto3" json:"postID,omitempty" uri:"postID"`
+	// userID 表示要取消分享的用户 ID
+	// @gotags: uri:"userID"
+	UserID        string `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty" uri:"userID"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsharePostRequest) Reset() {
+	*x = UnsharePostRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsharePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsharePostRequest) ProtoMessage() {}
+
+func (x *UnsharePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsharePostRequest.ProtoReflect.Descriptor instead.
+func (*UnsharePostRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UnsharePostRequest) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *UnsharePostRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+// UnsharePostResponse 表示取消分享文章响应
+type UnsharePostResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsharePostResponse) Reset() {
+	*x = UnsharePostResponse{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsharePostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsharePostResponse) ProtoMessage() {}
+
+func (x *UnsharePostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsharePostResponse.ProtoReflect.Descriptor instead.
+func (*UnsharePostResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{18}
+}
+
 var File_apiserver_v1_post_proto protoreflect.FileDescriptor
 
 const file_apiserver_v1_post_proto_rawDesc = "" +
 	"\n" +
-	"\x17apiserver/v1/post.proto\x12\vminiblog.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xda\x01\n" +
+	"\x17apiserver/v1/post.proto\x12\vminiblog.v1\x1a\x18apiserver/v1/batch.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf6\x01\n" +
 	"\x04Post\x12\x16\n" +
 	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x16\n" +
 	"\x06userID\x18\x02 \x01(\tR\x06userID\x12\x14\n" +
 	"\x05title\x18\x03 \x01(\tR\x05title\x12\x18\n" +
 	"\acontent\x18\x04 \x01(\tR\acontent\x128\n" +
 	"\tcreatedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
-	"\tupdatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"C\n" +
+	"\tupdatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1a\n" +
+	"\btenantID\x18\a \x01(\tR\btenantID\"C\n" +
 	"\x11CreatePostRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\",\n" +
 	"\x12CreatePostResponse\x12\x16\n" +
-	"\x06postID\x18\x01 \x01(\tR\x06postID\"{\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\"\xb8\x01\n" +
 	"\x11UpdatePostRequest\x12\x16\n" +
 	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x19\n" +
 	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12\x1d\n" +
-	"\acontent\x18\x03 \x01(\tH\x01R\acontent\x88\x01\x01B\b\n" +
+	"\acontent\x18\x03 \x01(\tH\x01R\acontent\x88\x01\x01\x12;\n" +
+	"\vupdate_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMaskB\b\n" +
 	"\x06_titleB\n" +
 	"\n" +
 	"\b_content\"\x14\n" +
 	"\x12UpdatePostResponse\"-\n" +
 	"\x11DeletePostRequest\x12\x18\n" +
 	"\apostIDs\x18\x01 \x03(\tR\apostIDs\"\x14\n" +
-	"\x12DeletePostResponse\"(\n" +
+	"\x12DeletePostResponse\"_\n" +
 	"\x0eGetPostRequest\x12\x16\n" +
-	"\x06postID\x18\x01 \x01(\tR\x06postID\"8\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x125\n" +
+	"\tread_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\x06fields\"8\n" +
 	"\x0fGetPostResponse\x12%\n" +
-	"\x04post\x18\x01 \x01(\v2\x11.miniblog.v1.PostR\x04post\"d\n" +
+	"\x04post\x18\x01 \x01(\v2\x11.miniblog.v1.PostR\x04post\"0\n" +
+	"\x14BatchGetPostsRequest\x12\x18\n" +
+	"\apostIDs\x18\x01 \x03(\tR\apostIDs\"h\n" +
+	"\x15BatchGetPostsResponse\x12'\n" +
+	"\x05posts\x18\x01 \x03(\v2\x11.miniblog.v1.PostR\x05posts\x12&\n" +
+	"\x0emissingPostIDs\x18\x02 \x03(\tR\x0emissingPostIDs\"i\n" +
+	"\x17BatchCreatePostsRequest\x124\n" +
+	"\x05posts\x18\x01 \x03(\v2\x1e.miniblog.v1.CreatePostRequestR\x05posts\x12\x18\n" +
+	"\apartial\x18\x02 \x01(\bR\apartial\"i\n" +
+	"\x18BatchCreatePostsResponse\x12\x18\n" +
+	"\apostIDs\x18\x01 \x03(\tR\apostIDs\x123\n" +
+	"\x06errors\x18\x02 \x03(\v2\x1b.miniblog.v1.BatchItemErrorR\x06errors\"\x9b\x01\n" +
 	"\x0fListPostRequest\x12\x16\n" +
 	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x19\n" +
-	"\x05title\x18\x03 \x01(\tH\x00R\x05title\x88\x01\x01B\b\n" +
+	"\x05title\x18\x03 \x01(\tH\x00R\x05title\x88\x01\x01\x125\n" +
+	"\tread_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\x06fieldsB\b\n" +
 	"\x06_title\"\\\n" +
 	"\x10ListPostResponse\x12\x1f\n" +
 	"\vtotal_count\x18\x01 \x01(\x03R\n" +
 	"totalCount\x12'\n" +
-	"\x05posts\x18\x02 \x03(\v2\x11.miniblog.v1.PostR\x05postsB8Z6github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1;v1b\x06proto3"
+	"\x05posts\x18\x02 \x03(\v2\x11.miniblog.v1.PostR\x05posts\"V\n" +
+	"\x10SharePostRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x16\n" +
+	"\x06userID\x18\x02 \x01(\tR\x06userID\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"\x13\n" +
+	"\x11SharePostResponse\"D\n" +
+	"\x12UnsharePostRequest\x12\x16\n" +
+	"\x06postID\x18\x01 \x01(\tR\x06postID\x12\x16\n" +
+	"\x06userID\x18\x02 \x01(\tR\x06userID\"\x15\n" +
+	"\x13UnsharePostResponseB8Z6github.com/TobyIcetea/miniblog/pkg/api/apiserver/v1;v1b\x06proto3"
 
 var (
 	file_apiserver_v1_post_proto_rawDescOnce sync.Once
@@ -672,31 +1147,47 @@ func file_apiserver_v1_post_proto_rawDescGZIP() []byte {
 	return file_apiserver_v1_post_proto_rawDescData
 }
 
-var file_apiserver_v1_post_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_apiserver_v1_post_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_apiserver_v1_post_proto_goTypes = []any{
-	(*Post)(nil),                  // 0: miniblog.v1.Post
-	(*CreatePostRequest)(nil),     // 1: miniblog.v1.CreatePostRequest
-	(*CreatePostResponse)(nil),    // 2: miniblog.v1.CreatePostResponse
-	(*UpdatePostRequest)(nil),     // 3: miniblog.v1.UpdatePostRequest
-	(*UpdatePostResponse)(nil),    // 4: miniblog.v1.UpdatePostResponse
-	(*DeletePostRequest)(nil),     // 5: miniblog.v1.DeletePostRequest
-	(*DeletePostResponse)(nil),    // 6: miniblog.v1.DeletePostResponse
-	(*GetPostRequest)(nil),        // 7: miniblog.v1.GetPostRequest
-	(*GetPostResponse)(nil),       // 8: miniblog.v1.GetPostResponse
-	(*ListPostRequest)(nil),       // 9: miniblog.v1.ListPostRequest
-	(*ListPostResponse)(nil),      // 10: miniblog.v1.ListPostResponse
-	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(*Post)(nil),                     // 0: miniblog.v1.Post
+	(*CreatePostRequest)(nil),        // 1: miniblog.v1.CreatePostRequest
+	(*CreatePostResponse)(nil),       // 2: miniblog.v1.CreatePostResponse
+	(*UpdatePostRequest)(nil),        // 3: miniblog.v1.UpdatePostRequest
+	(*UpdatePostResponse)(nil),       // 4: miniblog.v1.UpdatePostResponse
+	(*DeletePostRequest)(nil),        // 5: miniblog.v1.DeletePostRequest
+	(*DeletePostResponse)(nil),       // 6: miniblog.v1.DeletePostResponse
+	(*GetPostRequest)(nil),           // 7: miniblog.v1.GetPostRequest
+	(*GetPostResponse)(nil),          // 8: miniblog.v1.GetPostResponse
+	(*BatchGetPostsRequest)(nil),     // 9: miniblog.v1.BatchGetPostsRequest
+	(*BatchGetPostsResponse)(nil),    // 10: miniblog.v1.BatchGetPostsResponse
+	(*BatchCreatePostsRequest)(nil),  // 11: miniblog.v1.BatchCreatePostsRequest
+	(*BatchCreatePostsResponse)(nil), // 12: miniblog.v1.BatchCreatePostsResponse
+	(*ListPostRequest)(nil),          // 13: miniblog.v1.ListPostRequest
+	(*ListPostResponse)(nil),         // 14: miniblog.v1.ListPostResponse
+	(*SharePostRequest)(nil),         // 15: miniblog.v1.SharePostRequest
+	(*SharePostResponse)(nil),        // 16: miniblog.v1.SharePostResponse
+	(*UnsharePostRequest)(nil),       // 17: miniblog.v1.UnsharePostRequest
+	(*UnsharePostResponse)(nil),      // 18: miniblog.v1.UnsharePostResponse
+	(*timestamppb.Timestamp)(nil),    // 19: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),    // 20: google.protobuf.FieldMask
+	(*BatchItemError)(nil),           // 21: miniblog.v1.BatchItemError
 }
 var file_apiserver_v1_post_proto_depIdxs = []int32{
-	11, // 0: miniblog.v1.Post.createdAt:type_name -> google.protobuf.Timestamp
-	11, // 1: miniblog.v1.Post.updatedAt:type_name -> google.protobuf.Timestamp
-	0,  // 2: miniblog.v1.GetPostResponse.post:type_name -> miniblog.v1.Post
-	0,  // 3: miniblog.v1.ListPostResponse.posts:type_name -> miniblog.v1.Post
-	4,  // [4:4] is the sub-list for method output_type
-	4,  // [4:4] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	19, // 0: miniblog.v1.Post.createdAt:type_name -> google.protobuf.Timestamp
+	19, // 1: miniblog.v1.Post.updatedAt:type_name -> google.protobuf.Timestamp
+	20, // 2: miniblog.v1.UpdatePostRequest.update_mask:type_name -> google.protobuf.FieldMask
+	20, // 3: miniblog.v1.GetPostRequest.read_mask:type_name -> google.protobuf.FieldMask
+	0,  // 4: miniblog.v1.GetPostResponse.post:type_name -> miniblog.v1.Post
+	0,  // 5: miniblog.v1.BatchGetPostsResponse.posts:type_name -> miniblog.v1.Post
+	1,  // 6: miniblog.v1.BatchCreatePostsRequest.posts:type_name -> miniblog.v1.CreatePostRequest
+	21, // 7: miniblog.v1.BatchCreatePostsResponse.errors:type_name -> miniblog.v1.BatchItemError
+	20, // 8: miniblog.v1.ListPostRequest.read_mask:type_name -> google.protobuf.FieldMask
+	0,  // 9: miniblog.v1.ListPostResponse.posts:type_name -> miniblog.v1.Post
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_apiserver_v1_post_proto_init() }
@@ -704,15 +1195,16 @@ func file_apiserver_v1_post_proto_init() {
 	if File_apiserver_v1_post_proto != nil {
 		return
 	}
+	file_apiserver_v1_batch_proto_init()
 	file_apiserver_v1_post_proto_msgTypes[3].OneofWrappers = []any{}
-	file_apiserver_v1_post_proto_msgTypes[9].OneofWrappers = []any{}
+	file_apiserver_v1_post_proto_msgTypes[13].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_post_proto_rawDesc), len(file_apiserver_v1_post_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
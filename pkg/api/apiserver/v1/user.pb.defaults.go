@@ -23,12 +23,75 @@ var (
 func (x *User) Default() {
 }
 
+func (x *Captcha) Default() {
+}
+
+func (x *CreateCaptchaRequest) Default() {
+}
+
+func (x *CreateCaptchaResponse) Default() {
+}
+
 func (x *LoginRequest) Default() {
 }
 
 func (x *LoginResponse) Default() {
 }
 
+func (x *LoginVerifyRequest) Default() {
+}
+
+func (x *LoginVerifyResponse) Default() {
+}
+
+func (x *EnrollTOTPRequest) Default() {
+}
+
+func (x *EnrollTOTPResponse) Default() {
+}
+
+func (x *EnableTOTPRequest) Default() {
+}
+
+func (x *EnableTOTPResponse) Default() {
+}
+
+func (x *RequestPasswordResetRequest) Default() {
+}
+
+func (x *RequestPasswordResetResponse) Default() {
+}
+
+func (x *ResetPasswordRequest) Default() {
+}
+
+func (x *ResetPasswordResponse) Default() {
+}
+
+func (x *SendVerificationEmailRequest) Default() {
+}
+
+func (x *SendVerificationEmailResponse) Default() {
+}
+
+func (x *VerifyEmailRequest) Default() {
+}
+
+func (x *VerifyEmailResponse) Default() {
+}
+
+func (x *OIDCAuthorizeRequest) Default() {
+}
+
+func (x *OIDCAuthorizeResponse) Default() {
+}
+
+func (x *OIDCCallbackRequest) Default() {
+}
+
+func (x *OIDCCallbackResponse) Default() {
+}
+
 func (x *RefreshTokenRequest) Default() {
 }
 
@@ -63,6 +126,12 @@ func (x *DeleteUserRequest) Default() {
 func (x *DeleteUserResponse) Default() {
 }
 
+func (x *BatchDeleteUsersRequest) Default() {
+}
+
+func (x *BatchDeleteUsersResponse) Default() {
+}
+
 func (x *GetUserRequest) Default() {
 }
 
@@ -74,3 +143,15 @@ func (x *ListUserRequest) Default() {
 
 func (x *ListUserResponse) Default() {
 }
+
+func (x *UnlockUserRequest) Default() {
+}
+
+func (x *UnlockUserResponse) Default() {
+}
+
+func (x *ImpersonateRequest) Default() {
+}
+
+func (x *ImpersonateResponse) Default() {
+}
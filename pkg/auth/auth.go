@@ -6,15 +6,186 @@
 
 package auth
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 
-// Encrypt 使用 bcrypt 加密纯文本.
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// HashAlgorithmBcrypt 表示使用 bcrypt 算法加密密码.
+	HashAlgorithmBcrypt = "bcrypt"
+	// HashAlgorithmArgon2id 表示使用 argon2id 算法加密密码.
+	HashAlgorithmArgon2id = "argon2id"
+
+	// argon2SaltLength 是 argon2id 随机盐的字节数.
+	argon2SaltLength = 16
+	// argon2KeyLength 是 argon2id 输出摘要的字节数.
+	argon2KeyLength = 32
+)
+
+// ErrMismatchedHashAndPassword 表示密文和明文不匹配.
+var ErrMismatchedHashAndPassword = bcrypt.ErrMismatchedHashAndPassword
+
+// HashOptions 包含密码加密算法及其参数.
+type HashOptions struct {
+	// Algorithm 是加密新密码时使用的算法，可选值为 bcrypt 和 argon2id
+	Algorithm string
+	// BcryptCost 是 bcrypt 的计算成本
+	BcryptCost int
+	// Argon2Memory 是 argon2id 使用的内存大小，单位为 KiB
+	Argon2Memory uint32
+	// Argon2Iterations 是 argon2id 的迭代次数
+	Argon2Iterations uint32
+	// Argon2Parallelism 是 argon2id 的并行度
+	Argon2Parallelism uint8
+}
+
+// DefaultHashOptions 返回默认的密码加密参数，默认使用 bcrypt 以兼容已有的密码.
+func DefaultHashOptions() HashOptions {
+	return HashOptions{
+		Algorithm:         HashAlgorithmBcrypt,
+		BcryptCost:        bcrypt.DefaultCost,
+		Argon2Memory:      64 * 1024,
+		Argon2Iterations:  3,
+		Argon2Parallelism: 2,
+	}
+}
+
+var (
+	mu          sync.RWMutex
+	hashOptions = DefaultHashOptions()
+)
+
+// SetHashOptions 设置加密新密码时使用的算法和参数，需要在服务启动时调用.
+// 已有的密文仍然可以通过 Compare 校验，可以通过 NeedsRehash 判断是否需要使用新参数重新加密.
+func SetHashOptions(opts HashOptions) {
+	mu.Lock()
+	defer mu.Unlock()
+	hashOptions = opts
+}
+
+// currentHashOptions 返回当前的密码加密参数.
+func currentHashOptions() HashOptions {
+	mu.RLock()
+	defer mu.RUnlock()
+	return hashOptions
+}
+
+// Encrypt 使用当前配置的算法加密纯文本.
 func Encrypt(source string) (string, error) {
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(source), bcrypt.DefaultCost)
+	opts := currentHashOptions()
+	if opts.Algorithm == HashAlgorithmArgon2id {
+		return encryptArgon2id(source, opts)
+	}
+
+	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(source), opts.BcryptCost)
 	return string(hashedBytes), err
 }
 
-// Compare 比较密文和明文是否相同.
+// Compare 比较密文和明文是否相同，根据密文的格式自动选择对应的算法.
 func Compare(hashedPassword, password string) error {
+	if strings.HasPrefix(hashedPassword, "$"+HashAlgorithmArgon2id+"$") {
+		return compareArgon2id(hashedPassword, password)
+	}
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 }
+
+// NeedsRehash 判断密文使用的算法或参数是否与当前配置不同，不同时需要使用当前配置重新加密.
+func NeedsRehash(hashedPassword string) bool {
+	opts := currentHashOptions()
+
+	if opts.Algorithm == HashAlgorithmArgon2id {
+		params, _, _, err := decodeArgon2id(hashedPassword)
+		if err != nil {
+			return true
+		}
+		return params.memory != opts.Argon2Memory || params.iterations != opts.Argon2Iterations || params.parallelism != opts.Argon2Parallelism
+	}
+
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	return err != nil || cost != opts.BcryptCost
+}
+
+// argon2Params 是编码在 argon2id 密文中的参数.
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+// encryptArgon2id 使用 argon2id 加密纯文本，返回 PHC 字符串格式的密文，例如：
+// $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>.
+func encryptArgon2id(source string, opts HashOptions) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(source), salt, opts.Argon2Iterations, opts.Argon2Memory, opts.Argon2Parallelism, argon2KeyLength)
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		HashAlgorithmArgon2id,
+		argon2.Version,
+		opts.Argon2Memory,
+		opts.Argon2Iterations,
+		opts.Argon2Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// compareArgon2id 使用密文中编码的参数重新计算摘要，并与密文中的摘要比较.
+func compareArgon2id(hashedPassword, password string) error {
+	params, salt, key, err := decodeArgon2id(hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return ErrMismatchedHashAndPassword
+	}
+	return nil
+}
+
+// decodeArgon2id 解析 argon2id 密文，返回参数、盐和摘要.
+func decodeArgon2id(hashedPassword string) (*argon2Params, []byte, []byte, error) {
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 || parts[1] != HashAlgorithmArgon2id {
+		return nil, nil, nil, errors.New("invalid argon2id hash format")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return nil, nil, nil, err
+	}
+	if version != argon2.Version {
+		return nil, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	var params argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return nil, nil, nil, err
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(key) == 0 {
+		return nil, nil, nil, errors.New("invalid argon2id hash format")
+	}
+
+	return &params, salt, key, nil
+}
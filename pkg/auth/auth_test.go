@@ -0,0 +1,60 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestEncryptAndRehash 测试切换加密算法后，旧密文仍然可以校验，并且会被标记为需要重新加密.
+func TestEncryptAndRehash(t *testing.T) {
+	defer SetHashOptions(DefaultHashOptions())
+
+	opts := DefaultHashOptions()
+	opts.BcryptCost = bcrypt.MinCost
+	SetHashOptions(opts)
+
+	bcryptHash, err := Encrypt("miniblog1234")
+	require.NoError(t, err)
+	assert.NoError(t, Compare(bcryptHash, "miniblog1234"))
+	assert.Error(t, Compare(bcryptHash, "miniblog12345"))
+	assert.False(t, NeedsRehash(bcryptHash))
+
+	// 提高 bcrypt 成本后需要重新加密
+	opts.BcryptCost = bcrypt.MinCost + 1
+	SetHashOptions(opts)
+	assert.True(t, NeedsRehash(bcryptHash))
+
+	// 切换到 argon2id 后，旧的 bcrypt 密文仍然可以校验
+	opts.Algorithm = HashAlgorithmArgon2id
+	opts.Argon2Memory = 1024
+	opts.Argon2Iterations = 1
+	opts.Argon2Parallelism = 1
+	SetHashOptions(opts)
+	assert.NoError(t, Compare(bcryptHash, "miniblog1234"))
+	assert.True(t, NeedsRehash(bcryptHash))
+
+	argon2Hash, err := Encrypt("miniblog1234")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(argon2Hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+	assert.NoError(t, Compare(argon2Hash, "miniblog1234"))
+	assert.ErrorIs(t, Compare(argon2Hash, "miniblog12345"), ErrMismatchedHashAndPassword)
+	assert.False(t, NeedsRehash(argon2Hash))
+
+	// 修改 argon2id 参数后需要重新加密
+	opts.Argon2Iterations = 2
+	SetHashOptions(opts)
+	assert.True(t, NeedsRehash(argon2Hash))
+	assert.NoError(t, Compare(argon2Hash, "miniblog1234"))
+
+	assert.Error(t, Compare("$argon2id$v=19$m=1024$bad", "miniblog1234"))
+}
@@ -7,10 +7,12 @@
 package auth
 
 import (
+	"strings"
 	"time"
 
 	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/util"
 	adapter "github.com/casbin/gorm-adapter/v3"
 	"github.com/google/wire"
 	"gorm.io/gorm"
@@ -18,20 +20,39 @@ import (
 
 const (
 	// 默认的 Casbin 访问控制模型.
+	// r/p/g/e/m 用于接口级别的访问控制：策略默认允许，命中 deny 策略时拒绝.
+	// e3 与 r/p/m 组合使用，用于服务主体等默认拒绝的主体：只有命中 allow 策略并且没有命中 deny 策略时才允许.
+	// r2/p2/g2/e2/m2 用于资源级别的访问控制：策略默认拒绝，只有命中 allow 策略时才允许.
+	// dom 表示租户（组织），g = 用户, 角色, 租户，表示用户在某个租户内拥有的角色，租户为 * 时表示在所有租户内都拥有该角色.
+	// g2 = 用户, 资源角色, 资源，表示用户在某个资源上拥有的角色，例如 user-xxx, role::post-editor, post:post-xxx.
 	defaultAclModel = `[request_definition]
-r = sub, obj, act
+r = sub, dom, obj, act
+r2 = sub, dom, obj, act
 
 [policy_definition]
-p = sub, obj, act, eft
+p = sub, dom, obj, act, eft
+p2 = sub, obj, act
 
 [role_definition]
-g = _, _
+g = _, _, _
+g2 = _, _, _
 
 [policy_effect]
 e = !some(where (p.eft == deny))
+e2 = some(where (p.eft == allow))
+e3 = some(where (p.eft == allow)) && !some(where (p.eft == deny))
 
 [matchers]
-m = g(r.sub, p.sub) && keyMatch(r.obj, p.obj) && r.act == p.act`
+m = g(r.sub, p.sub, r.dom) && keyMatch(r.dom, p.dom) && keyMatch(r.obj, p.obj) && r.act == p.act
+m2 = (g2(r2.sub, p2.sub, r2.obj) || g(r2.sub, p2.sub, r2.dom)) && keyMatch(r2.obj, p2.obj) && r2.act == p2.act`
+
+	// DomainAll 表示所有租户，角色分配和策略的租户为 DomainAll 时在所有租户内生效.
+	DomainAll = "*"
+
+	// roleGroupingType 是租户内角色使用的角色定义类型.
+	roleGroupingType = "g"
+	// resourceGroupingType 是资源级别访问控制使用的角色定义类型.
+	resourceGroupingType = "g2"
 )
 
 // Authz 定义了一个授权器，提供授权功能.
@@ -111,6 +132,9 @@ func NewAuthz(db *gorm.DB, opts ...Option) (*Authz, error) {
 		return nil, err // 返回错误
 	}
 
+	// 角色分配的租户支持通配符，租户为 * 的角色分配在所有租户内生效
+	enforcer.AddNamedDomainMatchingFunc(roleGroupingType, "keyMatch", util.KeyMatch)
+
 	// 从数据库加载策略
 	if err := enforcer.LoadPolicy(); err != nil {
 		return nil, err // 返回错误
@@ -123,8 +147,130 @@ func NewAuthz(db *gorm.DB, opts ...Option) (*Authz, error) {
 	return &Authz{enforcer}, nil
 }
 
-// Authorize 用于进行授权.
-func (a *Authz) Authorize(sub, obj, act string) (bool, error) {
+// Authorize 用于进行授权，dom 为请求所在的租户.
+func (a *Authz) Authorize(sub, dom, obj, act string) (bool, error) {
 	// 调用 Enforce 方法进行授权检查
-	return a.Enforce(sub, obj, act)
+	return a.Enforce(sub, dom, obj, act)
+}
+
+// explicitEnforceContext 选择默认拒绝的接口级别访问控制使用的 r/p/e3/m 定义.
+var explicitEnforceContext = casbin.EnforceContext{RType: "r", PType: "p", EType: "e3", MType: "m"}
+
+// AuthorizeExplicit 与 Authorize 类似，但是默认拒绝：主体本身或者其角色必须命中 allow 策略，并且没有命中 deny 策略.
+// 用于服务主体等不应该默认拥有所有接口权限的主体.
+func (a *Authz) AuthorizeExplicit(sub, dom, obj, act string) (bool, error) {
+	return a.Enforce(explicitEnforceContext, sub, dom, obj, act)
+}
+
+// AuthorizeExplicitEx 与 AuthorizeExplicit 相同，同时返回决定结果的策略.
+func (a *Authz) AuthorizeExplicitEx(sub, dom, obj, act string) (bool, []string, error) {
+	return a.EnforceEx(explicitEnforceContext, sub, dom, obj, act)
+}
+
+// resourceEnforceContext 选择资源级别访问控制使用的 r2/p2/e2/m2 定义.
+var resourceEnforceContext = casbin.NewEnforceContext("2")
+
+// AuthorizeResource 检查主体是否可以对资源执行操作，例如 sub=user-xxx, obj=post:post-xxx, act=write.
+// dom 为资源所属的租户，主体在该租户内拥有的角色同样会参与授权.
+// 与 Authorize 不同，资源级别的访问控制默认拒绝，只有主体在资源上拥有对应的角色时才允许.
+func (a *Authz) AuthorizeResource(sub, dom, obj, act string) (bool, error) {
+	return a.Enforce(resourceEnforceContext, sub, dom, obj, act)
+}
+
+// GrantResourceRole 授予主体在资源上的角色，返回值表示是否新增了授权.
+// 同一主体在同一资源上只保留一个角色，已有的其他角色会被替换.
+func (a *Authz) GrantResourceRole(sub, role, obj string) (bool, error) {
+	return a.replaceGrouping(resourceGroupingType, sub, role, obj)
+}
+
+// RevokeResourceRoles 撤销主体在资源上的所有角色，返回值表示是否有授权被撤销.
+func (a *Authz) RevokeResourceRoles(sub, obj string) (bool, error) {
+	return a.RemoveFilteredNamedGroupingPolicy(resourceGroupingType, 0, sub, "", obj)
+}
+
+// SubjectResources 返回主体拥有资源角色的所有资源名称，只返回以 prefix 开头的资源，prefix 为空时返回所有资源.
+func (a *Authz) SubjectResources(sub, prefix string) ([]string, error) {
+	grants, err := a.GetFilteredNamedGroupingPolicy(resourceGroupingType, 0, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	objs := make([]string, 0, len(grants))
+	for _, grant := range grants {
+		if strings.HasPrefix(grant[2], prefix) {
+			objs = append(objs, grant[2])
+		}
+	}
+	return objs, nil
+}
+
+// RemoveResource 删除资源上的所有授权，通常在资源被删除时调用.
+func (a *Authz) RemoveResource(obj string) error {
+	_, err := a.RemoveFilteredNamedGroupingPolicy(resourceGroupingType, 2, obj)
+	return err
+}
+
+// RemoveResourceSubject 删除主体在所有资源上的授权，通常在主体被删除时调用.
+func (a *Authz) RemoveResourceSubject(sub string) error {
+	_, err := a.RemoveFilteredNamedGroupingPolicy(resourceGroupingType, 0, sub)
+	return err
+}
+
+// SetTenantRole 设置主体在租户内的角色，返回值表示是否修改了角色.
+// 同一主体在同一租户内只保留一个角色，已有的其他角色会被替换.
+func (a *Authz) SetTenantRole(sub, role, dom string) (bool, error) {
+	return a.replaceGrouping(roleGroupingType, sub, role, dom)
+}
+
+// TenantRoles 返回主体在租户内直接拥有的角色，不包括租户为 * 的角色.
+func (a *Authz) TenantRoles(sub, dom string) ([]string, error) {
+	grants, err := a.GetFilteredGroupingPolicy(0, sub, "", dom)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]string, 0, len(grants))
+	for _, grant := range grants {
+		roles = append(roles, grant[1])
+	}
+	return roles, nil
+}
+
+// TenantMembers 返回租户内的所有角色分配，每一项为 [主体, 角色, 租户].
+func (a *Authz) TenantMembers(dom string) ([][]string, error) {
+	return a.GetFilteredGroupingPolicy(2, dom)
+}
+
+// RemoveTenantMember 撤销主体在租户内的所有角色，返回值表示是否有角色被撤销.
+func (a *Authz) RemoveTenantMember(sub, dom string) (bool, error) {
+	return a.RemoveFilteredGroupingPolicy(0, sub, "", dom)
+}
+
+// RemoveTenant 删除租户内的所有角色分配和策略，通常在租户被删除时调用.
+func (a *Authz) RemoveTenant(dom string) error {
+	if _, err := a.RemoveFilteredGroupingPolicy(2, dom); err != nil {
+		return err
+	}
+	_, err := a.RemoveFilteredPolicy(1, dom)
+	return err
+}
+
+// replaceGrouping 将主体在 target 上的角色替换为 role，返回值表示是否修改了角色.
+func (a *Authz) replaceGrouping(ptype, sub, role, target string) (bool, error) {
+	grants, err := a.GetFilteredNamedGroupingPolicy(ptype, 0, sub, "", target)
+	if err != nil {
+		return false, err
+	}
+	for _, grant := range grants {
+		if grant[1] == role {
+			return false, nil
+		}
+	}
+	if len(grants) > 0 {
+		if _, err := a.RemoveNamedGroupingPolicies(ptype, grants); err != nil {
+			return false, err
+		}
+	}
+
+	return a.AddNamedGroupingPolicy(ptype, sub, role, target)
 }
@@ -0,0 +1,246 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package auth
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newTestAuthz(t *testing.T) *Authz {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+
+	authz, err := NewAuthz(db, WithAutoLoadPolicyTime(time.Hour))
+	if err != nil {
+		t.Fatalf("NewAuthz: %v", err)
+	}
+	t.Cleanup(authz.StopAutoLoadPolicy)
+
+	rules := [][]string{
+		{"role::post-viewer", "post:*", "read"},
+		{"role::post-editor", "post:*", "read"},
+		{"role::post-editor", "post:*", "write"},
+		{"role::admin", "post:*", "write"},
+		{"role::tenant-admin", "post:*", "write"},
+	}
+	if _, err := authz.AddNamedPolicies("p2", rules); err != nil {
+		t.Fatalf("AddNamedPolicies: %v", err)
+	}
+	if _, err := authz.AddGroupingPolicy("admin", "role::admin", DomainAll); err != nil {
+		t.Fatalf("AddGroupingPolicy: %v", err)
+	}
+
+	return authz
+}
+
+func TestAuthorizeResource(t *testing.T) {
+	authz := newTestAuthz(t)
+
+	if _, err := authz.GrantResourceRole("alice", "role::post-viewer", "post:1"); err != nil {
+		t.Fatalf("GrantResourceRole: %v", err)
+	}
+	if _, err := authz.GrantResourceRole("bob", "role::post-editor", "post:1"); err != nil {
+		t.Fatalf("GrantResourceRole: %v", err)
+	}
+
+	tests := []struct {
+		sub, obj, act string
+		want          bool
+	}{
+		{"alice", "post:1", "read", true},
+		{"alice", "post:1", "write", false},
+		{"alice", "post:2", "read", false},
+		{"bob", "post:1", "write", true},
+		{"bob", "post:2", "write", false},
+		{"carol", "post:1", "read", false},
+		{"admin", "post:2", "write", true},
+	}
+	for _, tt := range tests {
+		got, err := authz.AuthorizeResource(tt.sub, "default", tt.obj, tt.act)
+		if err != nil {
+			t.Fatalf("AuthorizeResource(%s, %s, %s): %v", tt.sub, tt.obj, tt.act, err)
+		}
+		if got != tt.want {
+			t.Errorf("AuthorizeResource(%s, %s, %s) = %v, want %v", tt.sub, tt.obj, tt.act, got, tt.want)
+		}
+	}
+
+	// 接口级别的访问控制默认允许，不受资源级别策略的影响
+	if ok, _ := authz.Authorize("carol", "default", "/v1/posts", "GET"); !ok {
+		t.Errorf("Authorize should allow requests without deny policies")
+	}
+}
+
+func TestGrantResourceRoleReplacesRole(t *testing.T) {
+	authz := newTestAuthz(t)
+
+	if ok, _ := authz.GrantResourceRole("alice", "role::post-editor", "post:1"); !ok {
+		t.Fatalf("first grant should add a policy")
+	}
+	if ok, _ := authz.GrantResourceRole("alice", "role::post-editor", "post:1"); ok {
+		t.Fatalf("granting the same role again should be a no-op")
+	}
+	if ok, _ := authz.GrantResourceRole("alice", "role::post-viewer", "post:1"); !ok {
+		t.Fatalf("granting a different role should replace the old one")
+	}
+	if ok, _ := authz.AuthorizeResource("alice", "default", "post:1", "write"); ok {
+		t.Errorf("alice should no longer be able to write after downgrade")
+	}
+
+	if ok, _ := authz.RevokeResourceRoles("alice", "post:1"); !ok {
+		t.Fatalf("RevokeResourceRoles should remove the grant")
+	}
+	if ok, _ := authz.AuthorizeResource("alice", "default", "post:1", "read"); ok {
+		t.Errorf("alice should not be able to read after revoke")
+	}
+}
+
+func TestRemoveResource(t *testing.T) {
+	authz := newTestAuthz(t)
+
+	_, _ = authz.GrantResourceRole("alice", "role::post-viewer", "post:1")
+	_, _ = authz.GrantResourceRole("bob", "role::post-viewer", "post:1")
+	_, _ = authz.GrantResourceRole("bob", "role::post-viewer", "post:2")
+
+	if err := authz.RemoveResource("post:1"); err != nil {
+		t.Fatalf("RemoveResource: %v", err)
+	}
+	if ok, _ := authz.AuthorizeResource("alice", "default", "post:1", "read"); ok {
+		t.Errorf("grants on post:1 should be removed")
+	}
+	if ok, _ := authz.AuthorizeResource("bob", "default", "post:2", "read"); !ok {
+		t.Errorf("grants on post:2 should be kept")
+	}
+
+	if err := authz.RemoveResourceSubject("bob"); err != nil {
+		t.Fatalf("RemoveResourceSubject: %v", err)
+	}
+	if ok, _ := authz.AuthorizeResource("bob", "default", "post:2", "read"); ok {
+		t.Errorf("grants of bob should be removed")
+	}
+}
+
+func TestSubjectResources(t *testing.T) {
+	authz := newTestAuthz(t)
+
+	_, _ = authz.GrantResourceRole("alice", "role::post-viewer", "post:1")
+	_, _ = authz.GrantResourceRole("alice", "role::post-editor", "post:2")
+	_, _ = authz.GrantResourceRole("alice", "role::post-viewer", "doc:1")
+	_, _ = authz.GrantResourceRole("bob", "role::post-viewer", "post:3")
+
+	objs, err := authz.SubjectResources("alice", "post:")
+	if err != nil {
+		t.Fatalf("SubjectResources: %v", err)
+	}
+	if !slices.Equal(slices.Sorted(slices.Values(objs)), []string{"post:1", "post:2"}) {
+		t.Errorf("SubjectResources(alice, post:) = %v, want [post:1 post:2]", objs)
+	}
+
+	if objs, _ := authz.SubjectResources("carol", ""); len(objs) != 0 {
+		t.Errorf("SubjectResources(carol) = %v, want none", objs)
+	}
+}
+
+func TestTenantRoles(t *testing.T) {
+	authz := newTestAuthz(t)
+
+	if _, err := authz.AddPolicy("role::tenant-member", DomainAll, "organizations.delete", "CALL", "deny"); err != nil {
+		t.Fatalf("AddPolicy: %v", err)
+	}
+	if ok, _ := authz.SetTenantRole("alice", "role::tenant-member", "org-1"); !ok {
+		t.Fatalf("SetTenantRole should add a role")
+	}
+
+	// 租户内的角色只在该租户内生效
+	if ok, _ := authz.Authorize("alice", "org-1", "organizations.delete", "CALL"); ok {
+		t.Errorf("tenant members should not delete the organization")
+	}
+	if ok, _ := authz.Authorize("alice", "org-2", "organizations.delete", "CALL"); !ok {
+		t.Errorf("roles in org-1 should not apply to org-2")
+	}
+	if ok, _ := authz.AuthorizeResource("alice", "org-1", "post:1", "write"); ok {
+		t.Errorf("tenant members should not write posts of others")
+	}
+
+	// 替换角色后，新角色生效
+	if ok, _ := authz.SetTenantRole("alice", "role::tenant-admin", "org-1"); !ok {
+		t.Fatalf("SetTenantRole should replace the role")
+	}
+	if roles, _ := authz.TenantRoles("alice", "org-1"); len(roles) != 1 || roles[0] != "role::tenant-admin" {
+		t.Errorf("TenantRoles = %v, want [role::tenant-admin]", roles)
+	}
+	if ok, _ := authz.AuthorizeResource("alice", "org-1", "post:1", "write"); !ok {
+		t.Errorf("tenant admins should write posts in their tenant")
+	}
+	if ok, _ := authz.AuthorizeResource("alice", "org-2", "post:2", "write"); ok {
+		t.Errorf("tenant admins should not write posts in other tenants")
+	}
+
+	// 租户为 * 的角色在所有租户内生效
+	if ok, _ := authz.AuthorizeResource("admin", "org-1", "post:1", "write"); !ok {
+		t.Errorf("global administrators should write posts in any tenant")
+	}
+
+	if err := authz.RemoveTenant("org-1"); err != nil {
+		t.Fatalf("RemoveTenant: %v", err)
+	}
+	if roles, _ := authz.TenantRoles("alice", "org-1"); len(roles) != 0 {
+		t.Errorf("roles in org-1 should be removed, got %v", roles)
+	}
+}
+
+func TestAuthorizeExplicit(t *testing.T) {
+	authz := newTestAuthz(t)
+
+	rules := [][]string{
+		{"service::reporter", DomainAll, "posts.list", "CALL", "allow"},
+		{"role::reader", DomainAll, "posts.*", "CALL", "allow"},
+		{"role::reader", DomainAll, "posts.delete", "CALL", "deny"},
+	}
+	if _, err := authz.AddPolicies(rules); err != nil {
+		t.Fatalf("AddPolicies: %v", err)
+	}
+	if _, err := authz.AddGroupingPolicy("service::indexer", "role::reader", DomainAll); err != nil {
+		t.Fatalf("AddGroupingPolicy: %v", err)
+	}
+
+	tests := []struct {
+		sub, obj string
+		want     bool
+	}{
+		{"service::reporter", "posts.list", true},
+		{"service::reporter", "posts.get", false},
+		{"service::reporter", "users.batch-delete", false},
+		{"service::indexer", "posts.get", true},
+		{"service::indexer", "posts.delete", false},
+		{"service::unknown", "posts.list", false},
+	}
+	for _, tt := range tests {
+		got, err := authz.AuthorizeExplicit(tt.sub, "default", tt.obj, "CALL")
+		if err != nil {
+			t.Fatalf("AuthorizeExplicit(%s, %s): %v", tt.sub, tt.obj, err)
+		}
+		if got != tt.want {
+			t.Errorf("AuthorizeExplicit(%s, %s) = %v, want %v", tt.sub, tt.obj, got, tt.want)
+		}
+	}
+
+	// 默认允许的授权方式对未授权的服务主体同样放行，这正是服务主体需要使用 AuthorizeExplicit 的原因
+	if got, _ := authz.Authorize("service::unknown", "default", "users.batch-delete", "CALL"); !got {
+		t.Errorf("Authorize(service::unknown, users.batch-delete) = false, want true")
+	}
+}
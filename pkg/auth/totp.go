@@ -0,0 +1,163 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// TOTPPeriod 是 TOTP 的时间步长（RFC 6238 推荐值）.
+	TOTPPeriod = 30 * time.Second
+	// TOTPDigits 是 TOTP 动态码的位数.
+	TOTPDigits = 6
+	// TOTPSkew 是校验动态码时允许的前后时间步偏移量，用于容忍客户端与服务端的时钟误差.
+	TOTPSkew = 1
+
+	// totpSecretSize 是 TOTP 密钥的字节数（160 位，与 HMAC-SHA1 的输出长度一致）.
+	totpSecretSize = 20
+	// recoveryCodeSize 是单个恢复码的随机字节数.
+	recoveryCodeSize = 5
+)
+
+// base32NoPadding 是 TOTP 密钥使用的编码方式，大多数身份验证器 App 只接受无填充的 Base32.
+var base32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret 生成一个随机的 Base32 编码 TOTP 密钥.
+func GenerateTOTPSecret() (string, error) {
+	secret := make([]byte, totpSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return base32NoPadding.EncodeToString(secret), nil
+}
+
+// TOTPURI 生成 otpauth:// 格式的 URI，客户端可以将其渲染为二维码供身份验证器 App 扫描.
+// 格式参考：https://github.com/google/google-authenticator/wiki/Key-Uri-Format.
+func TOTPURI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(TOTPDigits))
+	query.Set("period", fmt.Sprint(int(TOTPPeriod.Seconds())))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// TOTPStep 返回指定时间所在的时间步.
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / int64(TOTPPeriod.Seconds())
+}
+
+// GenerateTOTPCode 根据密钥和时间生成 TOTP 动态码.
+func GenerateTOTPCode(secret string, t time.Time) (string, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return hotp(key, uint64(TOTPStep(t)), TOTPDigits), nil
+}
+
+// ValidateTOTP 校验动态码是否有效，允许前后 TOTPSkew 个时间步的偏移.
+// 校验成功时返回动态码所匹配的时间步，调用方需要持久化该值，并在下次校验时通过 lastStep 传入，
+// 所有不大于 lastStep 的时间步都会被拒绝，从而防止同一个动态码被重放.
+func ValidateTOTP(secret, code string, t time.Time, lastStep int64) (int64, bool) {
+	if len(code) != TOTPDigits {
+		return 0, false
+	}
+
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return 0, false
+	}
+
+	current := TOTPStep(t)
+	for offset := int64(-TOTPSkew); offset <= TOTPSkew; offset++ {
+		step := current + offset
+		if step <= lastStep {
+			continue
+		}
+
+		expected := hotp(key, uint64(step), TOTPDigits)
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// GenerateRecoveryCodes 生成 n 个一次性恢复码，格式为 xxxxx-xxxxx.
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		raw := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+
+		code := hex.EncodeToString(raw)
+		codes = append(codes, code[:5]+"-"+code[5:])
+	}
+
+	return codes, nil
+}
+
+// HashRecoveryCode 计算恢复码的摘要，数据库中只保存摘要.
+// 恢复码本身是高熵的随机串，所以这里使用 SHA-256 即可，不需要使用 bcrypt 这类慢哈希.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// decodeTOTPSecret 将 Base32 编码的密钥解码为原始字节，兼容小写和带填充的输入.
+func decodeTOTPSecret(secret string) ([]byte, error) {
+	normalized := strings.TrimRight(strings.ToUpper(strings.ReplaceAll(secret, " ", "")), "=")
+	return base32NoPadding.DecodeString(normalized)
+}
+
+// hotp 按照 RFC 4226 计算 HOTP 值.
+func hotp(key []byte, counter uint64, digits int) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// 动态截断
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 TobyIcetea <x2406862525@163.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/onexstack/miniblog. The professional
+// version of this repository is https://github.com/onexstack/onex.
+
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHOTP_RFC6238 使用 RFC 6238 附录 B 中的 SHA1 测试向量校验算法实现.
+func TestHOTP_RFC6238(t *testing.T) {
+	key := []byte("12345678901234567890")
+	cases := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, c := range cases {
+		step := TOTPStep(time.Unix(c.unix, 0))
+		assert.Equal(t, c.want, hotp(key, uint64(step), 8), "unix=%d", c.unix)
+	}
+}
+
+// TestValidateTOTP 测试动态码的时间偏移容忍和防重放.
+func TestValidateTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	assert.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	code, err := GenerateTOTPCode(secret, now)
+	assert.NoError(t, err)
+
+	// 当前时间步
+	step, ok := ValidateTOTP(secret, code, now, 0)
+	assert.True(t, ok)
+	assert.Equal(t, TOTPStep(now), step)
+
+	// 允许一个时间步的时钟偏移
+	_, ok = ValidateTOTP(secret, code, now.Add(TOTPPeriod), 0)
+	assert.True(t, ok)
+	_, ok = ValidateTOTP(secret, code, now.Add(-TOTPPeriod), 0)
+	assert.True(t, ok)
+
+	// 超出偏移范围
+	_, ok = ValidateTOTP(secret, code, now.Add(3*TOTPPeriod), 0)
+	assert.False(t, ok)
+
+	// 已经使用过的时间步不能再次使用
+	_, ok = ValidateTOTP(secret, code, now, step)
+	assert.False(t, ok)
+
+	// 错误的动态码
+	_, ok = ValidateTOTP(secret, "000000x", now, 0)
+	assert.False(t, ok)
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("miniblog", "colin", "JBSWY3DPEHPK3PXP")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/miniblog:colin?"))
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=miniblog")
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	assert.NoError(t, err)
+	assert.Len(t, codes, 10)
+
+	for _, code := range codes {
+		assert.Len(t, code, 11)
+		// 摘要计算忽略大小写和分隔符
+		assert.Equal(t, HashRecoveryCode(code), HashRecoveryCode(strings.ToUpper(strings.ReplaceAll(code, "-", ""))))
+	}
+}
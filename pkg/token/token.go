@@ -52,6 +52,13 @@ func Init(key string, identityKey string, expiration time.Duration) {
 
 // Parse 使用指定的密钥 key 解析 token，解析成功返回 token 上下文，否则报错.
 func Parse(tokenString string, key string) (string, error) {
+	identityKey, _, err := ParseWithActor(tokenString, key)
+	return identityKey, err
+}
+
+// ParseWithActor 使用指定的密钥 key 解析 token，解析成功返回 token 中的用户身份和实际操作者.
+// 只有模拟登录令牌才携带实际操作者，普通令牌返回的 actor 为空字符串.
+func ParseWithActor(tokenString string, key string) (string, string, error) {
 	// 解析 token
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
 		// 确保 token 加密算法是预期的加密算法
@@ -63,10 +70,10 @@ func Parse(tokenString string, key string) (string, error) {
 	})
 	// 解析失败
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	var identityKey string
+	var identityKey, actor string
 	// 如果解析成功，从 token 中取出 token 的主题
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		if key, exists := claims[config.identityKey]; exists {
@@ -74,16 +81,33 @@ func Parse(tokenString string, key string) (string, error) {
 				identityKey = identity
 			}
 		}
+		// 参考 RFC 8693，实际操作者存放在 act claim 的 sub 字段中
+		if act, exists := claims["act"]; exists {
+			actClaims, valid := act.(map[string]any)
+			if !valid {
+				return "", "", jwt.ErrSignatureInvalid
+			}
+			actor, _ = actClaims["sub"].(string)
+			if actor == "" {
+				return "", "", jwt.ErrSignatureInvalid
+			}
+		}
 	}
 	if identityKey == "" {
-		return "", jwt.ErrSignatureInvalid
+		return "", "", jwt.ErrSignatureInvalid
 	}
 
-	return identityKey, nil
+	return identityKey, actor, nil
 }
 
 // ParseRequest 从请求头中获取令牌，并将其传递给 Parse 函数以解析令牌.
 func ParseRequest(ctx context.Context) (string, error) {
+	identityKey, _, err := ParseRequestWithActor(ctx)
+	return identityKey, err
+}
+
+// ParseRequestWithActor 从请求头中获取令牌并解析，返回令牌中的用户身份和实际操作者.
+func ParseRequestWithActor(ctx context.Context) (string, string, error) {
 	var (
 		token string
 		err   error
@@ -94,7 +118,7 @@ func ParseRequest(ctx context.Context) (string, error) {
 	case *gin.Context:
 		header := typed.Request.Header.Get("Authorization")
 		if len(header) == 0 {
-			return "", errors.New("the Authorization header is empty")
+			return "", "", errors.New("the Authorization header is empty")
 		}
 		// 从请求头中取出 token
 		_, _ = fmt.Sscanf(header, "Bearer %s", &token)
@@ -103,11 +127,11 @@ func ParseRequest(ctx context.Context) (string, error) {
 	default:
 		token, err = auth.AuthFromMD(typed, "Bearer")
 		if err != nil {
-			return "", status.Errorf(codes.Unauthenticated, "unauthorized: %v", err)
+			return "", "", status.Errorf(codes.Unauthenticated, "unauthorized: %v", err)
 		}
 	}
 
-	return Parse(token, config.key) // 解析 token
+	return ParseWithActor(token, config.key) // 解析 token
 }
 
 // Sign 使用 jwtSecret 签发 token，token 的 claims 中会存放传入的 subject.
@@ -131,3 +155,120 @@ func Sign(identityKey string) (string, time.Time, error) {
 
 	return tokenString, expireAt, nil
 }
+
+// SignImpersonation 签发一个模拟登录令牌，令牌以 subject 的身份访问接口，同时记录实际操作者 actor.
+// 模拟登录令牌的有效期由 expiration 指定，通常远短于普通访问令牌.
+func SignImpersonation(subject string, actor string, expiration time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expireAt := now.Add(expiration)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		config.identityKey: subject,                         // 被模拟的用户身份
+		"act":              map[string]string{"sub": actor}, // 实际操作者
+		"nbf":              now.Unix(),                      // 生效时间
+		"iat":              now.Unix(),                      // 签发时间
+		"exp":              expireAt.Unix(),                 // 过期时间
+	})
+
+	tokenString, err := token.SignedString([]byte(config.key))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expireAt, nil
+}
+
+// SignScoped 签发一个仅用于特定用途（scope）的短期 token，例如二次验证挑战、密码重置等.
+// 该类 token 中不包含 identityKey，所以无法通过 Parse 校验，也就不能被当作访问令牌使用.
+func SignScoped(scope string, subject string, expiration time.Duration) (string, time.Time, error) {
+	return SignStateBound(scope, subject, "", expiration)
+}
+
+// SignStateBound 与 SignScoped 类似，但会在 token 中额外绑定一个状态指纹 state.
+// 使用方在校验时比对 state 与当前状态是否一致，状态一旦发生变化（例如密码已被修改），
+// 之前签发的 token 就会失效，从而实现无需额外存储的一次性 token.
+func SignStateBound(scope string, subject string, state string, expiration time.Duration) (string, time.Time, error) {
+	claims := map[string]string{"sub": subject}
+	if state != "" {
+		claims["state"] = state // 签发时的状态指纹
+	}
+
+	return SignScopedClaims(scope, claims, expiration)
+}
+
+// SignScopedClaims 签发一个携带自定义字符串 claims 的用途 token.
+// 注意：token 只做签名不做加密，claims 中不能存放需要对持有者保密的数据.
+func SignScopedClaims(scope string, claims map[string]string, expiration time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expireAt := now.Add(expiration)
+
+	mapClaims := jwt.MapClaims{}
+	for k, v := range claims {
+		mapClaims[k] = v
+	}
+	mapClaims["scope"] = scope         // token 的用途
+	mapClaims["nbf"] = now.Unix()      // 生效时间
+	mapClaims["iat"] = now.Unix()      // 签发时间
+	mapClaims["exp"] = expireAt.Unix() // 过期时间
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, mapClaims)
+	tokenString, err := token.SignedString([]byte(config.key))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expireAt, nil
+}
+
+// ParseScoped 解析由 SignScoped 签发的 token，并校验其用途是否为 scope，成功时返回 token 的主体.
+func ParseScoped(tokenString string, scope string) (string, error) {
+	subject, _, err := ParseStateBound(tokenString, scope)
+	return subject, err
+}
+
+// ParseStateBound 解析由 SignStateBound 签发的 token，成功时返回 token 的主体和状态指纹.
+func ParseStateBound(tokenString string, scope string) (string, string, error) {
+	claims, err := ParseScopedClaims(tokenString, scope)
+	if err != nil {
+		return "", "", err
+	}
+
+	if claims["sub"] == "" {
+		return "", "", jwt.ErrSignatureInvalid
+	}
+
+	return claims["sub"], claims["state"], nil
+}
+
+// ParseScopedClaims 解析由 SignScopedClaims 签发的 token，并校验其用途是否为 scope，
+// 成功时返回 token 中所有字符串类型的 claims.
+func ParseScopedClaims(tokenString string, scope string) (map[string]string, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+
+		return []byte(config.key), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mapClaims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, jwt.ErrSignatureInvalid
+	}
+
+	if s, _ := mapClaims["scope"].(string); s != scope {
+		return nil, fmt.Errorf("unexpected token scope: %q", s)
+	}
+
+	claims := make(map[string]string, len(mapClaims))
+	for k, v := range mapClaims {
+		if str, ok := v.(string); ok {
+			claims[k] = str
+		}
+	}
+
+	return claims, nil
+}
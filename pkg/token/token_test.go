@@ -112,3 +112,55 @@ func TestParseRequestWithGRPC(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "testUser", identityKey)
 }
+
+// TestSignScoped 测试带用途的 token 不能被当作访问令牌使用
+func TestSignScoped(t *testing.T) {
+	tokenString, _, err := SignScoped("mfa", "user-000001", time.Minute)
+	assert.NoError(t, err)
+
+	subject, err := ParseScoped(tokenString, "mfa")
+	assert.NoError(t, err)
+	assert.Equal(t, "user-000001", subject)
+
+	// 用途不匹配
+	_, err = ParseScoped(tokenString, "password-reset")
+	assert.Error(t, err)
+
+	// 不能作为访问令牌解析
+	_, err = Parse(tokenString, config.key)
+	assert.Error(t, err)
+}
+
+// TestSignStateBound 测试绑定状态指纹的 token
+func TestSignStateBound(t *testing.T) {
+	tokenString, _, err := SignStateBound("password-reset", "user-000001", "fp-1", time.Minute)
+	assert.NoError(t, err)
+
+	subject, state, err := ParseStateBound(tokenString, "password-reset")
+	assert.NoError(t, err)
+	assert.Equal(t, "user-000001", subject)
+	assert.Equal(t, "fp-1", state)
+
+	// 用途不匹配
+	_, _, err = ParseStateBound(tokenString, "email-verify")
+	assert.Error(t, err)
+}
+
+// TestSignImpersonation 测试模拟登录令牌同时携带用户身份和实际操作者
+func TestSignImpersonation(t *testing.T) {
+	tokenString, expireAt, err := SignImpersonation("user-000001", "user-000000", time.Minute)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), expireAt, time.Second)
+
+	subject, actor, err := ParseWithActor(tokenString, config.key)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-000001", subject)
+	assert.Equal(t, "user-000000", actor)
+
+	// 普通令牌不携带实际操作者
+	normalToken, _, _ := Sign("user-000001")
+	subject, actor, err = ParseWithActor(normalToken, config.key)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-000001", subject)
+	assert.Empty(t, actor)
+}